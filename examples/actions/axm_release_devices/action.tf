@@ -0,0 +1,9 @@
+action "axm_release_devices" "decommission" {
+  config {
+    # Released devices can only return to the organization by being added again
+    device_ids = [
+      "C02XL0GXJGH5",
+      "DMPXK2JNJF8J",
+    ]
+  }
+}
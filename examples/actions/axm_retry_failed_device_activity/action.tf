@@ -0,0 +1,10 @@
+action "axm_retry_failed_device_activity" "retry_migration" {
+  config {
+    # Activity ID reported by a partially failed assignment
+    activity_id = "b1481656-b267-480d-b284-a809eed8b041"
+
+    # Required only when the activity is not recorded in the provider's audit_log_path
+    activity_type = "ASSIGN_DEVICES"
+    server_id     = "1F97349736CF4614A94F624E705841AD"
+  }
+}
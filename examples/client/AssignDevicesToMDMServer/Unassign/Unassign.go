@@ -33,8 +33,16 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error unassigning devices: %v", err)
 	}
+	fmt.Printf("Activity %s submitted, waiting for it to finish...\n", activity.ID)
 
-	fmt.Printf("Unassignment completed successfully:\n"+
+	activity, err = c.WaitForActivity(context.Background(), activity.ID, client.DefaultActivityPollInterval, func(a *client.OrgDeviceActivity) {
+		fmt.Printf("  Status: %s\n", a.Attributes.Status)
+	})
+	if err != nil {
+		log.Fatalf("Error waiting for activity: %v", err)
+	}
+
+	fmt.Printf("Unassignment finished:\n"+
 		"  ID: %s\n"+
 		"  Status: %s\n"+
 		"  SubStatus: %s\n"+
@@ -0,0 +1,14 @@
+check "mdm_assignments" {
+  data "axm_assignment_compliance" "this" {
+    expected = {
+      "12345678ABCD9012EFGH5678IJKL9012" = ["FAKE000ABC123", "FAKE111DEF456"]
+      "98765432ZYXW1098VUTS5432RQPO1098" = ["FAKE222GHI789"]
+    }
+    exclusive = true
+  }
+
+  assert {
+    condition     = data.axm_assignment_compliance.this.compliant
+    error_message = "Devices out of compliance: missing ${jsonencode(data.axm_assignment_compliance.this.missing_serial_numbers)}, unexpected ${jsonencode(data.axm_assignment_compliance.this.unexpected_serial_numbers)}."
+  }
+}
@@ -0,0 +1,17 @@
+data "axm_organization_devices" "all" {}
+
+data "axm_client_stats" "current" {
+  depends_on = [data.axm_organization_devices.all]
+}
+
+output "axm_api_requests" {
+  value = data.axm_client_stats.current.requests
+}
+
+output "axm_rate_limit_wait_seconds" {
+  value = data.axm_client_stats.current.rate_limit_wait_seconds
+}
+
+output "axm_last_error_request_ids" {
+  value = data.axm_client_stats.current.last_error_response_headers
+}
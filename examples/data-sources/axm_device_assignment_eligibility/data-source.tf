@@ -0,0 +1,26 @@
+data "axm_device_management_services" "all" {}
+
+locals {
+  jamf_pro_id = one([for s in data.axm_device_management_services.all.device_management_services : s.id if s.name == "Jamf Pro - Production"])
+
+  # Serial numbers handed over by the procurement pipeline.
+  candidate_serials = ["C02XXXXXXXXX", "C02YYYYYYYYY", "C02ZZZZZZZZZ"]
+}
+
+data "axm_device_assignment_eligibility" "lab_macs" {
+  server_id             = local.jamf_pro_id
+  serial_numbers        = local.candidate_serials
+  purchase_source_types = ["APPLE", "RESELLER"]
+}
+
+# Only assign the devices that can be assigned, instead of failing the apply.
+resource "axm_device_assignment" "lab_macs" {
+  for_each = toset(data.axm_device_assignment_eligibility.lab_macs.eligible_serial_numbers)
+
+  device_id = each.value
+  server_id = local.jamf_pro_id
+}
+
+output "ineligible_devices" {
+  value = { for device in data.axm_device_assignment_eligibility.lab_macs.devices : device.serial_number => device.detail if !device.eligible }
+}
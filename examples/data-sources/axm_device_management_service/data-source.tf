@@ -5,3 +5,14 @@ data "axm_device_management_service" "example" {
 output "mdm_server" {
   value = data.axm_device_management_service.example
 }
+
+# Look a server up by its name instead, and read the serial numbers of the
+# devices assigned to it.
+data "axm_device_management_service" "jamf_pro" {
+  server_name            = "Jamf Pro - Production"
+  include_serial_numbers = true
+}
+
+output "jamf_pro_serial_numbers" {
+  value = data.axm_device_management_service.jamf_pro.serial_numbers
+}
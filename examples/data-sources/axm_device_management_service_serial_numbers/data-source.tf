@@ -5,3 +5,8 @@ data "axm_device_management_service_serial_numbers" "example" {
 output "device_serial_numbers" {
   value = data.axm_device_management_service_serial_numbers.example
 }
+
+data "axm_device_management_service_serial_numbers" "macs" {
+  server_id      = "12345678ABCD9012EFGH5678IJKL9012"
+  product_family = "Mac"
+}
@@ -3,3 +3,13 @@ data "axm_device_management_services" "all" {}
 output "all_mdm_servers" {
   value = data.axm_device_management_services.all
 }
+
+# Read the servers of a second organization, such as one acquired in a merger,
+# with a profile from the shared credentials file.
+data "axm_device_management_services" "acquired" {
+  credentials_profile = "acquired"
+}
+
+output "acquired_mdm_servers" {
+  value = data.axm_device_management_services.acquired
+}
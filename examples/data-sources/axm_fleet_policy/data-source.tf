@@ -0,0 +1,22 @@
+check "fleet_policy" {
+  data "axm_fleet_policy" "this" {
+    rules = [
+      {
+        name    = "iphones-assigned"
+        where   = { product_family = "iPhone" }
+        require = { status = "ASSIGNED" }
+      },
+      {
+        name   = "no-devices-from-old-reseller"
+        where  = { purchase_source_type = "RESELLER", purchase_source_id = "1234567" }
+        forbid = true
+      },
+    ]
+    max_violations = 20
+  }
+
+  assert {
+    condition     = data.axm_fleet_policy.this.compliant
+    error_message = "Fleet policy violations: ${jsonencode({ for r in data.axm_fleet_policy.this.results : r.name => r.violations[*].serial_number if !r.compliant })}."
+  }
+}
@@ -5,3 +5,12 @@ data "axm_organization_device" "example" {
 output "example_device" {
   value = data.axm_organization_device.example
 }
+
+data "axm_organization_device" "status_only" {
+  id     = "GX7N12345XYZ"
+  fields = ["status"]
+}
+
+output "example_device_status" {
+  value = data.axm_organization_device.status_only.status
+}
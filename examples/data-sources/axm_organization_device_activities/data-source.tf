@@ -0,0 +1,7 @@
+data "axm_organization_device_activities" "example" {
+  id = "FAKESERIAL12345"
+}
+
+output "last_activity" {
+  value = try(data.axm_organization_device_activities.example.activities[0], null)
+}
@@ -0,0 +1,25 @@
+data "axm_organization_device_activity" "migration" {
+  id                  = "b1481656-b267-480d-b284-a809eed8b041"
+  wait_for_completion = true
+  poll_interval       = "15s"
+
+  timeouts = {
+    read = "30m"
+  }
+}
+
+output "migration_status" {
+  value = "${data.axm_organization_device_activity.migration.status}/${data.axm_organization_device_activity.migration.sub_status}"
+}
+
+data "axm_organization_device_activity" "audit" {
+  id          = "b1481656-b267-480d-b284-a809eed8b041"
+  include_log = true
+}
+
+output "failed_serial_numbers" {
+  value = [
+    for entry in data.axm_organization_device_activity.audit.log : entry.serial_number
+    if entry.operation_status != "SUCCESS"
+  ]
+}
@@ -0,0 +1,10 @@
+data "axm_organization_device_activity_log" "migration" {
+  id = "b1481656-b267-480d-b284-a809eed8b041"
+}
+
+output "failed_devices" {
+  value = {
+    for row in data.axm_organization_device_activity_log.migration.rows : row.serial_number => row.sub_status
+    if row.status != "SUCCESS"
+  }
+}
@@ -3,3 +3,31 @@ data "axm_organization_devices" "all" {}
 output "all" {
   value = data.axm_organization_devices.all
 }
+
+data "axm_organization_devices" "reseller" {
+  purchase_source_type = "RESELLER"
+}
+
+output "reseller_device_count" {
+  value = length(data.axm_organization_devices.reseller.devices)
+}
+
+data "axm_organization_devices" "unassigned_macs" {
+  filter = {
+    product_family = "Mac"
+    status         = "UNASSIGNED"
+    added_after    = "2025-01-01"
+  }
+}
+
+output "unassigned_mac_serials" {
+  value = data.axm_organization_devices.unassigned_macs.devices[*].serial_number
+}
+
+data "axm_organization_devices" "serials" {
+  fields = ["serial_number", "status"]
+}
+
+output "assigned_serials" {
+  value = [for device in data.axm_organization_devices.serials.devices : device.serial_number if device.status == "ASSIGNED"]
+}
@@ -0,0 +1,16 @@
+locals {
+  cmdb_serials = ["C02XL0GSJGH5", "DMPWK8Q3JF8J", "F9FZ1234ABCD"]
+}
+
+data "axm_organization_devices_by_serial" "cmdb" {
+  serial_numbers = local.cmdb_serials
+  fields         = ["serial_number", "status", "product_family"]
+}
+
+output "cmdb_device_status" {
+  value = { for device in data.axm_organization_devices_by_serial.cmdb.devices : device.serial_number => device.status }
+}
+
+output "cmdb_serials_not_in_organization" {
+  value = data.axm_organization_devices_by_serial.cmdb.not_found
+}
@@ -0,0 +1,5 @@
+data "axm_provider_info" "current" {}
+
+output "axm_token_expires_at" {
+  value = data.axm_provider_info.current.token_expires_at
+}
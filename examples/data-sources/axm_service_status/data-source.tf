@@ -0,0 +1,11 @@
+# Fail the run before any resources are touched when the organization cannot use the API.
+data "axm_service_status" "current" {
+  fail_on_problem = true
+}
+
+# Or inspect the outcome and react to specific problems.
+data "axm_service_status" "probe" {}
+
+output "axm_terms_outstanding" {
+  value = data.axm_service_status.probe.status == "TERMS_NOT_ACCEPTED"
+}
@@ -0,0 +1,7 @@
+data "axm_stale_organization_devices" "example" {
+  older_than = "4320h"
+}
+
+output "stale_serial_numbers" {
+  value = data.axm_stale_organization_devices.example.devices[*].serial_number
+}
@@ -0,0 +1,13 @@
+ephemeral "axm_access_token" "this" {}
+
+# Calls an endpoint the provider does not cover. The token is never stored in state.
+resource "terraform_data" "first_device" {
+  provisioner "local-exec" {
+    command = "curl -sf -H \"Authorization: $AXM_TOKEN_TYPE $AXM_TOKEN\" \"$AXM_BASE_URL/v1/orgDevices?limit=1\""
+    environment = {
+      AXM_TOKEN      = ephemeral.axm_access_token.this.access_token
+      AXM_TOKEN_TYPE = ephemeral.axm_access_token.this.token_type
+      AXM_BASE_URL   = ephemeral.axm_access_token.this.base_url
+    }
+  }
+}
@@ -0,0 +1,12 @@
+data "axm_device_management_service_serial_numbers" "legacy" {
+  id = "1F97349736CF4614A94F624E705841AD"
+}
+
+locals {
+  # Batches of at most 500 serial numbers, stable across runs
+  batches = provider::axm::chunk_serials(toset(data.axm_device_management_service_serial_numbers.legacy.serial_numbers), 500)
+}
+
+output "batch_count" {
+  value = length(local.batches)
+}
@@ -0,0 +1,13 @@
+variable "serials" {
+  description = "Serial numbers supplied by the requesting team, in any case and spacing"
+  type        = list(string)
+}
+
+locals {
+  # Fails the plan naming any entry that is not a valid serial number
+  serials = toset([for serial in var.serials : provider::axm::parse_device_serial(serial)])
+}
+
+data "axm_organization_devices_by_serial" "requested" {
+  serial_numbers = local.serials
+}
@@ -0,0 +1,17 @@
+data "axm_organization_devices" "all" {}
+
+resource "local_file" "inventory" {
+  filename = "${path.module}/inventory.md"
+  content = join("\n", [
+    "# Device inventory",
+    "",
+    provider::axm::render_inventory_markdown(data.axm_organization_devices.all.devices),
+  ])
+}
+
+output "mac_report" {
+  value = provider::axm::render_inventory_markdown(
+    [for d in data.axm_organization_devices.all.devices : d if d.product_family == "Mac"],
+    "serial_number", "device_model", "color", "added_to_org_date_time",
+  )
+}
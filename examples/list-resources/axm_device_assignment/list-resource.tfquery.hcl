@@ -0,0 +1,13 @@
+list "axm_device_assignment" "jamf_pro_devices" {
+  provider = axm
+
+  # Return the full resource state so that
+  # `terraform query -generate-config-out=generated.tf` writes import blocks
+  # and resource configuration for every assigned device.
+  include_resource = true
+
+  config {
+    # Only return devices assigned to this MDM server
+    server_id = "1F97349736CF4614A94F624E705841AD"
+  }
+}
@@ -1,6 +1,11 @@
 list "axm_device_management_service" "london_mdm_servers" {
   provider = axm
 
+  # Return the full resource state so that
+  # `terraform query -generate-config-out=generated.tf` writes import blocks
+  # and resource configuration for every matching server.
+  include_resource = true
+
   config {
     # Only return device management services whose name includes "London"
     name_contains = "London"
@@ -0,0 +1,6 @@
+import {
+  to = axm_device_assignment.example
+  identity = {
+    device_id = "C02XXXXXXXXX"
+  }
+}
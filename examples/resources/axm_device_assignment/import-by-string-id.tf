@@ -0,0 +1,4 @@
+import {
+  to = axm_device_assignment.example
+  id = "C02XXXXXXXXX"
+}
@@ -0,0 +1 @@
+terraform import axm_device_assignment.example C02XXXXXXXXX
@@ -0,0 +1,17 @@
+data "axm_device_management_services" "all" {}
+
+locals {
+  jamf_pro_id = one([for s in data.axm_device_management_services.all.device_management_services : s.id if s.name == "Jamf Pro - Production"])
+
+  # Serial numbers of the Macs to enrol with Jamf Pro.
+  lab_macs = toset(["C02XXXXXXXXX", "C02YYYYYYYYY", "C02ZZZZZZZZZ"])
+}
+
+# Manage each assignment on its own, so that adding or removing a serial
+# number only assigns or unassigns that device.
+resource "axm_device_assignment" "lab_macs" {
+  for_each = local.lab_macs
+
+  device_id = each.value
+  server_id = local.jamf_pro_id
+}
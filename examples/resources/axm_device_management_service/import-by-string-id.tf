@@ -0,0 +1,4 @@
+import {
+  to = axm_device_management_service.example
+  id = "1F97349736CF4614A94F624E705841AD"
+}
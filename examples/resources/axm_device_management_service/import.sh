@@ -0,0 +1,4 @@
+terraform import axm_device_management_service.example 1F97349736CF4614A94F624E705841AD
+
+# The server can also be imported by its name, when no other server has the same name.
+terraform import axm_device_management_service.example 'serverName=Jamf Pro'
@@ -14,3 +14,18 @@ resource "axm_device_management_service" "example" {
     "FAKE222GHI789",
   ]
 }
+
+# Keep every Mac in the organization assigned to this server, including Macs
+# added later, instead of listing serial numbers.
+resource "axm_device_management_service" "macs" {
+  name = "Jamf Pro - Macs"
+
+  server_certificate = {
+    name = "PublicKey.pem"
+    data = filebase64("${path.module}/PublicKey.pem")
+  }
+
+  device_filter = {
+    product_family = "Mac"
+  }
+}
@@ -0,0 +1,11 @@
+resource "axm_inventory_snapshot" "macs" {
+  path             = "${path.module}/inventory/macs.csv"
+  format           = "csv"
+  product_families = ["Mac"]
+  status           = "ASSIGNED"
+  track_changes    = true
+}
+
+output "macs_added_since_last_snapshot" {
+  value = axm_inventory_snapshot.macs.added_devices
+}
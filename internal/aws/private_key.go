@@ -0,0 +1,131 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package aws fetches the provider's signing key from AWS Secrets Manager or Systems Manager
+// Parameter Store. Credentials and the region are resolved by the AWS SDK's default chain, so
+// environment variables, shared configuration profiles, SSO, web identity, ECS container
+// credentials and EC2 instance metadata are all supported.
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// KeyReference identifies where the private key is stored and how to reach it.
+type KeyReference struct {
+	// ARN is a Secrets Manager secret ARN or an SSM parameter ARN.
+	ARN string
+	// Region overrides the region taken from the ARN.
+	Region string
+	// RoleARN, when set, is assumed before the key is fetched.
+	RoleARN string
+}
+
+// FetchPrivateKey returns the PEM-encoded private key stored at ref.
+func FetchPrivateKey(ctx context.Context, ref KeyReference) (string, error) {
+	return fetchPrivateKey(ctx, ref)
+}
+
+// fetchPrivateKey fetches the key at ref with an AWS configuration loaded with optFns, which
+// tests use to point the SDK at a fake endpoint.
+func fetchPrivateKey(ctx context.Context, ref KeyReference, optFns ...func(*config.LoadOptions) error) (string, error) {
+	parsed, err := arn.Parse(ref.ARN)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid ARN", ref.ARN)
+	}
+
+	region := ref.Region
+	if region == "" {
+		region = parsed.Region
+	}
+	if region != "" {
+		optFns = append(optFns, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return "", fmt.Errorf("could not determine the AWS region for %s", ref.ARN)
+	}
+
+	var fetch func(context.Context, awssdk.Config, string) (string, error)
+	switch {
+	case parsed.Service == "secretsmanager" && strings.HasPrefix(parsed.Resource, "secret:"):
+		fetch = secretValue
+	case parsed.Service == "ssm" && strings.HasPrefix(parsed.Resource, "parameter/"):
+		fetch = parameterValue
+	default:
+		return "", fmt.Errorf("%s is neither a Secrets Manager secret ARN nor an SSM parameter ARN", ref.ARN)
+	}
+
+	if ref.RoleARN != "" {
+		cfg.Credentials = awssdk.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), ref.RoleARN))
+	}
+
+	value, err := fetch(ctx, cfg, ref.ARN)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch private key from %s: %w", ref.ARN, err)
+	}
+	return extractPrivateKey(value)
+}
+
+// secretValue returns the value of the Secrets Manager secret secretARN, preferring its string
+// value over its binary value.
+func secretValue(ctx context.Context, cfg awssdk.Config, secretARN string) (string, error) {
+	out, err := secretsmanager.NewFromConfig(cfg).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: awssdk.String(secretARN),
+	})
+	if err != nil {
+		return "", err
+	}
+	if value := awssdk.ToString(out.SecretString); value != "" {
+		return value, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+// parameterValue returns the decrypted value of the SSM parameter parameterARN.
+func parameterValue(ctx context.Context, cfg awssdk.Config, parameterARN string) (string, error) {
+	out, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           awssdk.String(parameterARN),
+		WithDecryption: awssdk.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	if out.Parameter == nil {
+		return "", fmt.Errorf("parameter %s has no value", parameterARN)
+	}
+	return awssdk.ToString(out.Parameter.Value), nil
+}
+
+// extractPrivateKey returns the PEM key from a stored value, which is either the PEM itself
+// or a JSON object with a private_key field.
+func extractPrivateKey(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") {
+		var doc struct {
+			PrivateKey string `json:"private_key"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &doc); err != nil || doc.PrivateKey == "" {
+			return "", fmt.Errorf("stored value is a JSON object without a private_key field")
+		}
+		return doc.PrivateKey, nil
+	}
+	if trimmed == "" {
+		return "", fmt.Errorf("stored value is empty")
+	}
+	return value, nil
+}
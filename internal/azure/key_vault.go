@@ -0,0 +1,209 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package azure fetches the provider's signing key from an Azure Key Vault secret, or signs
+// client assertions with an Azure Key Vault key so the key material never leaves the vault.
+// Credentials are resolved by the Azure SDK's DefaultAzureCredential, so environment
+// variables, workload identity, managed identity and the Azure CLI are all supported.
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// signTimeout bounds each Key Vault sign request, since the signer interface carries no
+// context.
+const signTimeout = 30 * time.Second
+
+// vaultID identifies a Key Vault secret or key.
+type vaultID struct {
+	// VaultURL is the vault's base URL, such as https://example.vault.azure.net.
+	VaultURL string
+	// Collection is either "secrets" or "keys".
+	Collection string
+	Name       string
+	Version    string
+}
+
+func parseVaultID(value string) (vaultID, error) {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return vaultID{}, fmt.Errorf("%q is not a Key Vault secret or key identifier", value)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || (parts[0] != "secrets" && parts[0] != "keys") || parts[1] == "" {
+		return vaultID{}, fmt.Errorf("%q is not a Key Vault secret or key identifier", value)
+	}
+	id := vaultID{VaultURL: "https://" + u.Host, Collection: parts[0], Name: parts[1]}
+	if len(parts) == 3 {
+		id.Version = parts[2]
+	}
+	return id, nil
+}
+
+// IsKeyID reports whether id identifies a Key Vault key, which signs client assertions in the
+// vault, rather than a secret holding the PEM-encoded private key.
+func IsKeyID(id string) bool {
+	parsed, err := parseVaultID(id)
+	return err == nil && parsed.Collection == "keys"
+}
+
+// keyVaultClient holds the credential and client options used to build Key Vault clients.
+// Tests disable challenge resource verification so a vault can be served from a local host.
+type keyVaultClient struct {
+	credential                           azcore.TokenCredential
+	options                              azcore.ClientOptions
+	disableChallengeResourceVerification bool
+}
+
+func newKeyVaultClient() (*keyVaultClient, error) {
+	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Azure credentials: %w", err)
+	}
+	return &keyVaultClient{credential: credential}, nil
+}
+
+// FetchPrivateKey returns the PEM-encoded private key stored in the Key Vault secret id.
+func FetchPrivateKey(ctx context.Context, id string) (string, error) {
+	c, err := newKeyVaultClient()
+	if err != nil {
+		return "", err
+	}
+	return c.fetchPrivateKey(ctx, id)
+}
+
+func (c *keyVaultClient) fetchPrivateKey(ctx context.Context, id string) (string, error) {
+	parsed, err := parseVaultID(id)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Collection != "secrets" {
+		return "", fmt.Errorf("%s is not a Key Vault secret identifier", id)
+	}
+
+	secrets, err := azsecrets.NewClient(parsed.VaultURL, c.credential, &azsecrets.ClientOptions{
+		ClientOptions:                        c.options,
+		DisableChallengeResourceVerification: c.disableChallengeResourceVerification,
+	})
+	if err != nil {
+		return "", err
+	}
+	resp, err := secrets.GetSecret(ctx, parsed.Name, parsed.Version, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch private key from %s: %w", id, err)
+	}
+	if resp.Value == nil {
+		return "", fmt.Errorf("secret %s has no value", id)
+	}
+	return extractPrivateKey(*resp.Value)
+}
+
+// extractPrivateKey returns the PEM key from a stored value, which is either the PEM itself
+// or a JSON object with a private_key field.
+func extractPrivateKey(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") {
+		var doc struct {
+			PrivateKey string `json:"private_key"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &doc); err != nil || doc.PrivateKey == "" {
+			return "", fmt.Errorf("stored value is a JSON object without a private_key field")
+		}
+		return doc.PrivateKey, nil
+	}
+	if trimmed == "" {
+		return "", fmt.Errorf("stored value is empty")
+	}
+	return value, nil
+}
+
+// Signer signs client assertions with an EC P-256 Key Vault key. It satisfies
+// client.AssertionSigner.
+type Signer struct {
+	keys    *azkeys.Client
+	name    string
+	version string
+}
+
+// NewSigner returns a Signer for the Key Vault key id after confirming the key exists and is
+// an EC P-256 key permitted to sign. An unversioned id is pinned to the key's current version.
+func NewSigner(ctx context.Context, id string) (*Signer, error) {
+	c, err := newKeyVaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.newSigner(ctx, id)
+}
+
+func (c *keyVaultClient) newSigner(ctx context.Context, id string) (*Signer, error) {
+	parsed, err := parseVaultID(id)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Collection != "keys" {
+		return nil, fmt.Errorf("%s is not a Key Vault key identifier", id)
+	}
+
+	keys, err := azkeys.NewClient(parsed.VaultURL, c.credential, &azkeys.ClientOptions{
+		ClientOptions:                        c.options,
+		DisableChallengeResourceVerification: c.disableChallengeResourceVerification,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := keys.GetKey(ctx, parsed.Name, parsed.Version, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %s: %w", id, err)
+	}
+	key := resp.Key
+	if key == nil || key.Kty == nil || key.Crv == nil ||
+		(*key.Kty != azkeys.KeyTypeEC && *key.Kty != azkeys.KeyTypeECHSM) || *key.Crv != azkeys.CurveNameP256 {
+		return nil, fmt.Errorf("key %s must be an EC P-256 key", id)
+	}
+	if len(key.KeyOps) > 0 && !slices.ContainsFunc(key.KeyOps, func(op *azkeys.KeyOperation) bool {
+		return op != nil && *op == azkeys.KeyOperationSign
+	}) {
+		return nil, fmt.Errorf("key %s does not permit the sign operation", id)
+	}
+
+	s := &Signer{keys: keys, name: parsed.Name, version: parsed.Version}
+	if s.version == "" {
+		if key.KID == nil || key.KID.Version() == "" {
+			return nil, fmt.Errorf("key %s returned no key version", id)
+		}
+		s.version = key.KID.Version()
+	}
+	return s, nil
+}
+
+// SignES256 signs digest, a SHA-256 hash, with the Key Vault key and returns the 64-byte R||S
+// signature.
+func (s *Signer) SignES256(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+
+	algorithm := azkeys.SignatureAlgorithmES256
+	resp, err := s.keys.Sign(ctx, s.name, s.version, azkeys.SignParameters{
+		Algorithm: &algorithm,
+		Value:     digest,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("key vault sign request failed: %w", err)
+	}
+	if len(resp.Result) != 64 {
+		return nil, fmt.Errorf("key vault returned a %d-byte signature, expected 64", len(resp.Result))
+	}
+	return resp.Result, nil
+}
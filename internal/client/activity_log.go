@@ -0,0 +1,140 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DownloadActivityLog downloads an organization device activity log CSV from a pre-signed URL.
+// This is a standalone function (not a client method) because the URL is pre-signed and doesn't
+// require authentication - it's a utility operation, not an API call.
+func DownloadActivityLog(ctx context.Context, downloadURL string) ([]byte, error) {
+	if downloadURL == "" {
+		return nil, fmt.Errorf("no download URL provided")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download activity log: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download activity log: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity log: %w", err)
+	}
+
+	return data, nil
+}
+
+// ParseActivityLogRows parses the per-device rows of an activity log CSV. Each row maps the
+// trimmed column headers, such as serial_number and operation_status, to the row's trimmed
+// values. Lines before the header row, which begins with serial_number, and blank lines are
+// skipped.
+func ParseActivityLogRows(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	var headers []string
+	var rows []map[string]string
+	for _, record := range records {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if headers == nil {
+			if strings.Contains(strings.ToLower(record[0]), "serial_number") {
+				headers = record
+			}
+			continue
+		}
+
+		row := make(map[string]string, len(headers))
+		for i, header := range headers {
+			if i < len(record) {
+				row[strings.TrimSpace(header)] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ActivityLogRow is the outcome of an activity for one device, as recorded in its activity log.
+type ActivityLogRow struct {
+	SerialNumber string
+	Status       string
+	SubStatus    string
+}
+
+// ParseActivityLog parses an activity log CSV into one ActivityLogRow per device, in log order.
+func ParseActivityLog(data []byte) ([]ActivityLogRow, error) {
+	rows, err := ParseActivityLogRows(data)
+	if err != nil {
+		return nil, err
+	}
+
+	logRows := make([]ActivityLogRow, 0, len(rows))
+	for _, row := range rows {
+		logRows = append(logRows, ActivityLogRow{
+			SerialNumber: row["serial_number"],
+			Status:       row["operation_status"],
+			SubStatus:    row["operation_substatus"],
+		})
+	}
+	return logRows, nil
+}
+
+// GetOrgDeviceActivityLog retrieves an organization device activity and downloads and parses
+// its activity log. It fails when the activity has no log to download yet, which is the case
+// until the activity has finished.
+func (c *Client) GetOrgDeviceActivityLog(ctx context.Context, activityID string) (*OrgDeviceActivity, []ActivityLogRow, error) {
+	activity, err := c.GetOrgDeviceActivity(ctx, activityID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if activity.Attributes.DownloadURL == "" {
+		return activity, nil, fmt.Errorf("activity %s has no activity log to download (status %s)", activityID, activity.Attributes.Status)
+	}
+
+	data, err := DownloadActivityLog(ctx, activity.Attributes.DownloadURL)
+	if err != nil {
+		return activity, nil, err
+	}
+	rows, err := ParseActivityLog(data)
+	if err != nil {
+		return activity, nil, err
+	}
+	return activity, rows, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseActivityLogRows(t *testing.T) {
+	data := "Activity,ASSIGN_DEVICES\n\n serial_number ,operation_status,operation_substatus\nSN001,SUCCESS,\n,,\nSN002,FAILED,DEVICE_NOT_FOUND\nSN003\n"
+
+	rows, err := ParseActivityLogRows([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d: %v", len(rows), rows)
+	}
+	if rows[1]["serial_number"] != "SN002" || rows[1]["operation_substatus"] != "DEVICE_NOT_FOUND" {
+		t.Errorf("unexpected row %v", rows[1])
+	}
+	if _, ok := rows[2]["operation_status"]; ok {
+		t.Errorf("expected short row to omit missing columns, got %v", rows[2])
+	}
+
+	if _, err := ParseActivityLogRows([]byte("serial_number\n\"SN001")); err == nil {
+		t.Error("expected error for malformed CSV")
+	}
+}
+
+func TestDownloadActivityLog(t *testing.T) {
+	if _, err := DownloadActivityLog(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "no download URL provided") {
+		t.Errorf("expected missing URL error, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("serial_number\nSN001\n"))
+	}))
+	defer server.Close()
+
+	data, err := DownloadActivityLog(context.Background(), server.URL)
+	if err != nil || string(data) != "serial_number\nSN001\n" {
+		t.Errorf("unexpected result %q, %v", data, err)
+	}
+	if _, err := DownloadActivityLog(context.Background(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Errorf("expected HTTP 403 error, got %v", err)
+	}
+}
+
+func TestParseActivityLog(t *testing.T) {
+	data := "serial_number,operation_status,operation_substatus\nSN001,SUCCESS,\nSN002,FAILED,DEVICE_NOT_FOUND\n"
+
+	rows, err := ParseActivityLog([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []ActivityLogRow{
+		{SerialNumber: "SN001", Status: "SUCCESS"},
+		{SerialNumber: "SN002", Status: "FAILED", SubStatus: "DEVICE_NOT_FOUND"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %v", len(want), len(rows), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], rows[i])
+		}
+	}
+}
+
+func TestGetOrgDeviceActivityLog(t *testing.T) {
+	var downloadURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/results.csv" {
+			_, _ = w.Write([]byte("serial_number,operation_status,operation_substatus\nSN001,FAILED,DEVICE_NOT_FOUND\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{
+			Data: OrgDeviceActivity{
+				Type: "orgDeviceActivities",
+				ID:   strings.TrimPrefix(r.URL.Path, "/v1/orgDeviceActivities/"),
+				Attributes: OrgDeviceActivityAttributes{
+					Status:      "COMPLETED",
+					DownloadURL: downloadURL,
+				},
+			},
+		}))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	downloadURL = server.URL + "/results.csv"
+	activity, rows, err := c.GetOrgDeviceActivityLog(context.Background(), "activity-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if activity.ID != "activity-1" {
+		t.Errorf("expected activity-1, got %s", activity.ID)
+	}
+	if len(rows) != 1 || rows[0] != (ActivityLogRow{SerialNumber: "SN001", Status: "FAILED", SubStatus: "DEVICE_NOT_FOUND"}) {
+		t.Errorf("unexpected rows %v", rows)
+	}
+
+	downloadURL = ""
+	if _, _, err := c.GetOrgDeviceActivityLog(context.Background(), "activity-2"); err == nil || !strings.Contains(err.Error(), "no activity log") {
+		t.Errorf("expected missing log error, got %v", err)
+	}
+}
@@ -5,12 +5,9 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // SupportedOS represents an operating system supported by an app.
@@ -60,58 +57,7 @@ type AppAttributes struct {
 
 // GetApps retrieves all apps in the organization.
 func (c *Client) GetApps(ctx context.Context, queryParams url.Values) ([]App, error) {
-	var allApps []App
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/apps", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response AppsResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allApps = append(allApps, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allApps, nil
+	return collectPages[App](ctx, c, "/v1/apps", queryParams, defaultPageLimit)
 }
 
 // GetApp retrieves a single app by ID.
@@ -139,7 +85,7 @@ func (c *Client) GetApp(ctx context.Context, id string, queryParams url.Values)
 	}
 
 	var response AppResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -0,0 +1,164 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// auditActorEnvVars lists environment variables recorded in audit records to identify who or what performed a change.
+var auditActorEnvVars = []string{
+	"USER",
+	"LOGNAME",
+	"TFC_RUN_ID",
+	"TFC_WORKSPACE_NAME",
+	"TFC_CONFIGURATION_VERSION_GIT_COMMIT_SHA",
+	"GITHUB_ACTOR",
+	"GITHUB_REPOSITORY",
+	"GITHUB_RUN_ID",
+	"GITLAB_USER_LOGIN",
+	"CI_PROJECT_PATH",
+	"CI_PIPELINE_ID",
+}
+
+// AuditActivityIssueClientAssertion is the activity type of audit records describing a newly
+// signed client assertion.
+const AuditActivityIssueClientAssertion = "ISSUE_CLIENT_ASSERTION"
+
+// AuditRecord describes a single mutating device activity, or an issued client assertion,
+// written to the audit log.
+type AuditRecord struct {
+	Timestamp    time.Time         `json:"timestamp"`
+	Actor        map[string]string `json:"actor,omitempty"`
+	ServerID     string            `json:"server_id,omitempty"`
+	ActivityType string            `json:"activity_type"`
+	DeviceCount  int               `json:"device_count,omitempty"`
+	ActivityID   string            `json:"activity_id,omitempty"`
+	AssertionID  string            `json:"assertion_jti,omitempty"`
+	IssuedAt     time.Time         `json:"issued_at,omitzero"`
+	ExpiresAt    time.Time         `json:"expires_at,omitzero"`
+	Result       string            `json:"result"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// auditLog appends JSON Lines audit records to a local file.
+type auditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// SetAuditLogPath enables the audit log, appending one JSON record per line to path. An empty path disables it.
+func (c *Client) SetAuditLogPath(path string) {
+	if path == "" {
+		c.audit = nil
+	} else {
+		c.audit = &auditLog{path: path}
+	}
+	if c.tokenSource != nil {
+		c.tokenSource.audit.Store(c.audit)
+	}
+}
+
+// WriteAuditRecord appends record to the audit log, filling in the timestamp and actor when unset.
+// It is a no-op when no audit log is configured.
+func (c *Client) WriteAuditRecord(record AuditRecord) error {
+	if c.audit == nil {
+		return nil
+	}
+	return c.audit.write(record)
+}
+
+// AuditedActivities returns the audit records of submitted device activities, those with an
+// activity ID, in the order they were written. It returns no records when no audit log is
+// configured or the log does not exist yet. Lines that cannot be parsed are skipped.
+func (c *Client) AuditedActivities() ([]AuditRecord, error) {
+	if c.audit == nil {
+		return nil, nil
+	}
+	return c.audit.activities()
+}
+
+// activities reads the activity records from the log file.
+func (l *auditLog) activities() ([]AuditRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record AuditRecord
+		if json.Unmarshal(scanner.Bytes(), &record) != nil || record.ActivityID == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
+
+// write fills in the timestamp and actor of record when unset and appends it to the log.
+func (l *auditLog) write(record AuditRecord) error {
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+	if record.Actor == nil {
+		record.Actor = auditActor()
+	}
+	return l.append(record)
+}
+
+// append serializes record as a single line and appends it to the log file.
+func (l *auditLog) append(record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// auditActor returns the set of actor-identifying environment variables present in the environment.
+func auditActor() map[string]string {
+	actor := make(map[string]string)
+	for _, key := range auditActorEnvVars {
+		if v, ok := os.LookupEnv(key); ok && v != "" {
+			actor[key] = v
+		}
+	}
+	return actor
+}
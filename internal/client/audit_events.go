@@ -6,11 +6,7 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"maps"
-	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // AuditEventsResponse represents a response that contains a list of audit event resources.
@@ -104,64 +100,8 @@ func (a *AuditEventAttributes) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// GetAuditEvents retrieves audit events based on the provided query parameters.
+// GetAuditEvents retrieves audit events based on the provided query parameters. A "limit"
+// parameter sets the page size.
 func (c *Client) GetAuditEvents(ctx context.Context, queryParams url.Values) ([]AuditEvent, error) {
-	var allEvents []AuditEvent
-	nextCursor := ""
-	limit := 1000
-
-	if queryParams.Has("limit") {
-		if parsed, err := strconv.Atoi(queryParams.Get("limit")); err == nil {
-			limit = parsed
-		}
-	}
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/auditEvents", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response AuditEventsResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allEvents = append(allEvents, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allEvents, nil
+	return collectPages[AuditEvent](ctx, c, "/v1/auditEvents", queryParams, defaultPageLimit)
 }
@@ -0,0 +1,170 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestWriteAuditRecord_AppendsJSONLines(t *testing.T) {
+	t.Setenv("GITHUB_ACTOR", "octocat")
+	path := filepath.Join(t.TempDir(), "audit", "axm.jsonl")
+
+	c := &Client{}
+	c.SetAuditLogPath(path)
+
+	records := []AuditRecord{
+		{ServerID: "srv-1", ActivityType: ActivityTypeAssignDevices, DeviceCount: 3, ActivityID: "act-1", Result: "COMPLETED_WITH_SUCCESS"},
+		{ServerID: "srv-1", ActivityType: ActivityTypeUnassignDevices, DeviceCount: 1, Result: "SUBMIT_FAILED", Error: "boom"},
+	}
+	for _, record := range records {
+		if err := c.WriteAuditRecord(record); err != nil {
+			t.Fatalf("WriteAuditRecord returned error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var got []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("failed to parse audit line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, record)
+	}
+
+	if len(got) != len(records) {
+		t.Fatalf("expected %d audit records, got %d", len(records), len(got))
+	}
+	for i, record := range got {
+		if record.Timestamp.IsZero() {
+			t.Errorf("record %d: expected timestamp to be set", i)
+		}
+		if record.Actor["GITHUB_ACTOR"] != "octocat" {
+			t.Errorf("record %d: expected actor GITHUB_ACTOR=octocat, got %v", i, record.Actor)
+		}
+		if record.ActivityType != records[i].ActivityType || record.Result != records[i].Result || record.DeviceCount != records[i].DeviceCount {
+			t.Errorf("record %d: got %+v, want %+v", i, record, records[i])
+		}
+	}
+	if got[1].Error != "boom" {
+		t.Errorf("expected error %q, got %q", "boom", got[1].Error)
+	}
+}
+
+func TestWriteAuditRecord_DisabledIsNoOp(t *testing.T) {
+	c := &Client{}
+	if err := c.WriteAuditRecord(AuditRecord{ServerID: "srv-1"}); err != nil {
+		t.Errorf("expected no error when audit log is disabled, got %v", err)
+	}
+}
+
+func TestCreateOrGetAssertion_AuditsIssuedAssertion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "axm.jsonl")
+	c := &Client{tokenSource: &appleTokenSource{
+		cacheDir: t.TempDir(),
+		config: &ClientConfig{
+			TeamID:     "TEAM123",
+			ClientID:   "CLIENT456",
+			KeyID:      "KEY789",
+			PrivateKey: generateTestP8Key(t),
+			Scope:      "business.api",
+		},
+	}}
+	c.SetAuditLogPath(path)
+
+	assertion, err := c.tokenSource.createOrGetAssertion()
+	if err != nil {
+		t.Fatalf("createOrGetAssertion returned error: %v", err)
+	}
+	if _, err := c.tokenSource.createOrGetAssertion(); err != nil {
+		t.Fatalf("createOrGetAssertion returned error: %v", err)
+	}
+
+	token, _, err := jwt.NewParser().ParseUnverified(assertion, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("failed to parse JWT: %v", err)
+	}
+	claims := token.Claims.(*jwt.RegisteredClaims)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one audit record for a reused assertion, got %d", len(lines))
+	}
+	if strings.Contains(lines[0], "server_id") || strings.Contains(lines[0], "device_count") {
+		t.Errorf("expected device activity fields to be omitted, got %s", lines[0])
+	}
+
+	var record AuditRecord
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("failed to parse audit line: %v", err)
+	}
+	if record.ActivityType != AuditActivityIssueClientAssertion || record.Result != "ISSUED" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if record.AssertionID == "" || record.AssertionID != claims.ID {
+		t.Errorf("expected jti %q, got %q", claims.ID, record.AssertionID)
+	}
+	if !record.IssuedAt.Equal(claims.IssuedAt.Time) || !record.ExpiresAt.Equal(claims.ExpiresAt.Time) {
+		t.Errorf("expected issued_at %v and expires_at %v, got %v and %v", claims.IssuedAt, claims.ExpiresAt, record.IssuedAt, record.ExpiresAt)
+	}
+	if c.tokenSource.assertionID != claims.ID {
+		t.Errorf("expected token source to remember jti %q, got %q", claims.ID, c.tokenSource.assertionID)
+	}
+}
+
+func TestAuditedActivities(t *testing.T) {
+	c := &Client{}
+	if records, err := c.AuditedActivities(); err != nil || records != nil {
+		t.Fatalf("expected no records without an audit log, got %v, %v", records, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "axm.jsonl")
+	c.SetAuditLogPath(path)
+	if records, err := c.AuditedActivities(); err != nil || records != nil {
+		t.Fatalf("expected no records before the log exists, got %v, %v", records, err)
+	}
+
+	for _, record := range []AuditRecord{
+		{ServerID: "srv-1", ActivityType: ActivityTypeAssignDevices, DeviceCount: 2, ActivityID: "act-1", Result: "COMPLETED"},
+		{ActivityType: AuditActivityIssueClientAssertion, AssertionID: "jti-1", Result: "ISSUED"},
+		{ServerID: "srv-1", ActivityType: ActivityTypeUnassignDevices, Result: "SUBMIT_FAILED"},
+		{ServerID: "srv-2", ActivityType: ActivityTypeUnassignDevices, DeviceCount: 1, ActivityID: "act-2", Result: "COMPLETED"},
+	} {
+		if err := c.WriteAuditRecord(record); err != nil {
+			t.Fatalf("WriteAuditRecord returned error: %v", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+
+	records, err := c.AuditedActivities()
+	if err != nil {
+		t.Fatalf("AuditedActivities returned error: %v", err)
+	}
+	if len(records) != 2 || records[0].ActivityID != "act-1" || records[1].ActivityID != "act-2" || records[1].ServerID != "srv-2" {
+		t.Errorf("unexpected activity records %+v", records)
+	}
+}
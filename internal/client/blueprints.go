@@ -8,10 +8,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // BlueprintResponse represents a response that contains a single Blueprint resource.
@@ -136,58 +134,7 @@ type BlueprintRelationshipRequest struct {
 
 // GetBlueprints retrieves all Blueprints in the organization.
 func (c *Client) GetBlueprints(ctx context.Context, queryParams url.Values) ([]Blueprint, error) {
-	var allBlueprints []Blueprint
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/blueprints", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response BlueprintsResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allBlueprints = append(allBlueprints, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allBlueprints, nil
+	return collectPages[Blueprint](ctx, c, "/v1/blueprints", queryParams, defaultPageLimit)
 }
 
 // GetBlueprint retrieves a single Blueprint by ID.
@@ -215,7 +162,7 @@ func (c *Client) GetBlueprint(ctx context.Context, id string, queryParams url.Va
 	}
 
 	var response BlueprintResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -249,7 +196,7 @@ func (c *Client) CreateBlueprint(ctx context.Context, request BlueprintCreateReq
 	}
 
 	var response BlueprintResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -283,7 +230,7 @@ func (c *Client) UpdateBlueprint(ctx context.Context, request BlueprintUpdateReq
 	}
 
 	var response BlueprintResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -315,60 +262,7 @@ func (c *Client) DeleteBlueprint(ctx context.Context, id string) error {
 
 // GetBlueprintRelationshipIDs retrieves related resource IDs for a Blueprint relationship.
 func (c *Client) GetBlueprintRelationshipIDs(ctx context.Context, blueprintID, relationship string) ([]string, error) {
-	var allIDs []string
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/blueprints/%s/relationships/%s", c.baseURL, blueprintID, relationship), nil)
-		if err != nil {
-			return nil, err
-		}
-
-		q := req.URL.Query()
-		q.Add("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			q.Add("cursor", nextCursor)
-		}
-		req.URL.RawQuery = q.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response BlueprintLinkagesResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			for _, entry := range response.Data {
-				allIDs = append(allIDs, entry.ID)
-			}
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allIDs, nil
+	return collectLinkageIDs(ctx, c, fmt.Sprintf("/v1/blueprints/%s/relationships/%s", blueprintID, relationship), "")
 }
 
 // UpdateBlueprintRelationship updates related resources for a Blueprint relationship.
@@ -10,11 +10,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -24,21 +31,170 @@ const (
 	maxBackoff            = 30 * time.Second
 )
 
-// Logger is an interface for logging HTTP requests, responses, and authentication events
+// RetryPolicy controls how requests are retried after rate-limit (429) and transient server
+// error (502, 503, 504) responses. Zero-valued fields inherit the client's policy.
+type RetryPolicy struct {
+	MaxRetries            int
+	MaxRetryAfterDuration time.Duration
+	InitialBackoff        time.Duration
+	MaxBackoff            time.Duration
+	// RetryableErrorCodes lists API error codes that are retried with exponential backoff
+	// whatever the HTTP status. A code also matches its dot-separated sub-codes, so
+	// "SERVICE_UNAVAILABLE" matches "SERVICE_UNAVAILABLE.MAINTENANCE".
+	RetryableErrorCodes []string
+	// RetryServerErrors also retries 500 and every other 5xx response with exponential
+	// backoff, not only 502, 503 and 504.
+	RetryServerErrors bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when no overrides are configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:            maxRetries,
+		MaxRetryAfterDuration: maxRetryAfterDuration,
+		InitialBackoff:        initialBackoff,
+		MaxBackoff:            maxBackoff,
+	}
+}
+
+// merge returns a copy of p with any non-zero fields of override applied.
+func (p RetryPolicy) merge(override RetryPolicy) RetryPolicy {
+	if override.MaxRetries > 0 {
+		p.MaxRetries = override.MaxRetries
+	}
+	if override.MaxRetryAfterDuration > 0 {
+		p.MaxRetryAfterDuration = override.MaxRetryAfterDuration
+	}
+	if override.InitialBackoff > 0 {
+		p.InitialBackoff = override.InitialBackoff
+	}
+	if override.MaxBackoff > 0 {
+		p.MaxBackoff = override.MaxBackoff
+	}
+	if len(override.RetryableErrorCodes) > 0 {
+		p.RetryableErrorCodes = override.RetryableErrorCodes
+	}
+	if override.RetryServerErrors {
+		p.RetryServerErrors = true
+	}
+	return p
+}
+
+// retryableStatus reports whether a response with the HTTP status code is retried under p.
+func (p RetryPolicy) retryableStatus(code int) bool {
+	return isRetryableStatus(code) || (p.RetryServerErrors && code >= 500 && code <= 599)
+}
+
+// backoff returns the delay before the given retry attempt, counted from 1: InitialBackoff
+// doubled on each attempt and capped at MaxBackoff, with up to half of it replaced by random
+// jitter so that concurrent requests failing together do not retry in lockstep.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := min(p.InitialBackoff*(1<<(attempt-1)), p.MaxBackoff)
+	if half := delay / 2; half > 0 {
+		delay = half + rand.N(delay-half+1)
+	}
+	return delay
+}
+
+// retryableErrorCode returns the first error code in an API error response body matched by
+// p.RetryableErrorCodes, or an empty string when none match.
+func (p RetryPolicy) retryableErrorCode(body []byte) string {
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return ""
+	}
+	for _, e := range errResp.Errors {
+		for _, code := range p.RetryableErrorCodes {
+			if e.Code == code || strings.HasPrefix(e.Code, code+".") {
+				return e.Code
+			}
+		}
+	}
+	return ""
+}
+
+type retryPolicyContextKey struct{}
+
+// WithRetryPolicy returns a context that overrides the client's retry policy for every
+// request made with it. Zero-valued fields of policy inherit the client's policy.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, policy)
+}
+
+// retryPolicyFor resolves the effective retry policy for a request context.
+func (c *Client) retryPolicyFor(ctx context.Context) RetryPolicy {
+	policy := DefaultRetryPolicy().merge(c.retryPolicy)
+	if override, ok := ctx.Value(retryPolicyContextKey{}).(RetryPolicy); ok {
+		policy = policy.merge(override)
+	}
+	return policy
+}
+
+// Logger is an interface for logging HTTP requests, responses, pagination progress and authentication events
 type Logger interface {
 	LogRequest(ctx context.Context, method, url string, body []byte)
 	LogResponse(ctx context.Context, statusCode int, headers http.Header, body []byte)
+	LogPage(ctx context.Context, page PageFetch)
 	LogAuth(ctx context.Context, message string, fields map[string]any)
+	LogWarning(ctx context.Context, message string, fields map[string]any)
+}
+
+// PageFetch describes one page fetched from a paginated collection endpoint.
+type PageFetch struct {
+	// Endpoint is the collection path, without query parameters.
+	Endpoint string
+	// Cursor is the cursor the page was requested with; empty for the first page.
+	Cursor string
+	// PageSize is the requested page size limit.
+	PageSize int
+	// Page is the 1-based number of the page.
+	Page int
+	// Items is the number of items the page returned.
+	Items int
+	// Total is the number of items returned by all pages so far.
+	Total int
+	// Elapsed is the time since the first page was requested.
+	Elapsed time.Duration
 }
 
 // Client represents the Apple Device Management API client.
 type Client struct {
-	httpClient  *http.Client
-	tokenSource *appleTokenSource
-	oauthTS     oauth2.TokenSource
-	baseURL     string
-	scope       string
-	logger      Logger
+	httpClient             *http.Client
+	tokenSource            *appleTokenSource
+	oauthTS                oauth2.TokenSource
+	baseURL                string
+	scope                  string
+	version                string
+	logger                 Logger
+	audit                  *auditLog
+	maxConcurrency         int
+	clock                  Clock
+	retryPolicy            RetryPolicy
+	acceptLanguage         string
+	skipUndecodable        bool
+	stats                  requestStats
+	apiTimeBudget          time.Duration
+	maxInFlight            int
+	slots                  chan struct{}
+	slotsOnce              sync.Once
+	rateLimitedUntil       atomic.Int64
+	requestsPerMinute      int
+	limiter                *requestLimiter
+	tuning                 rateLimitTuning
+	reads                  singleflight.Group
+	serverLists            serverLists
+	deviceWarningThreshold *int
+	activityChunkSize      int
+	serialLocks            SerialLockBackend
+	serialLockOwner        string
+	readOnly               bool
+	pageConcurrency        int
+	offlineFallback        bool
+	redactSerials          bool
+	features               *Features
+	responseCache          *responseCache
+	pageCheckpoints        pageCheckpoints
+	profiles               *profileClients
 }
 
 // ErrorResponse represents the error details that an API returns in the response body whenever the API request isn’t successful.
@@ -127,7 +283,24 @@ func NewClient(baseURL, teamID, clientID, keyID, scope, p8Key string) (*Client,
 		Scope:      scope,
 		PrivateKey: []byte(p8Key),
 	}
+	return newClient(baseURL, config)
+}
+
+// NewClientWithSigner creates a new Client whose client assertions are signed by signer
+// rather than by a private key held in memory.
+func NewClientWithSigner(baseURL, teamID, clientID, keyID, scope string, signer AssertionSigner) (*Client, error) {
+	config := &ClientConfig{
+		TeamID:   teamID,
+		ClientID: clientID,
+		KeyID:    keyID,
+		Scope:    scope,
+		Signer:   signer,
+	}
+	return newClient(baseURL, config)
+}
 
+// newClient validates config and creates a Client that authenticates with it.
+func newClient(baseURL string, config *ClientConfig) (*Client, error) {
 	if err := validateConfig(config); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
@@ -143,10 +316,46 @@ func NewClient(baseURL, teamID, clientID, keyID, scope, p8Key string) (*Client,
 		tokenSource: ts,
 		oauthTS:     reusableTS,
 		baseURL:     baseURL,
-		scope:       scope,
+		scope:       config.Scope,
+	}, nil
+}
+
+// NewClientWithAccessToken creates a new Client that authenticates every request with a
+// pre-issued OAuth access token instead of signing its own client assertions. Requests fail
+// once the token expires; a zero expiry means the token is used until the API rejects it.
+func NewClientWithAccessToken(baseURL, scope, accessToken string, expiry time.Time) (*Client, error) {
+	if accessToken == "" {
+		return nil, errors.New("access token is required")
+	}
+
+	ts := &preIssuedTokenSource{token: &oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}}
+
+	hc := oauth2.NewClient(context.Background(), ts)
+	hc.Timeout = 30 * time.Second
+	return &Client{
+		httpClient: hc,
+		oauthTS:    ts,
+		baseURL:    baseURL,
+		scope:      scope,
 	}, nil
 }
 
+// preIssuedTokenSource serves a single access token obtained outside the client.
+type preIssuedTokenSource struct {
+	token *oauth2.Token
+}
+
+func (s *preIssuedTokenSource) Token() (*oauth2.Token, error) {
+	if !s.token.Expiry.IsZero() && !s.token.Expiry.After(time.Now()) {
+		return nil, fmt.Errorf("pre-issued access token expired at %s", s.token.Expiry.UTC().Format(time.RFC3339))
+	}
+	return s.token, nil
+}
+
 // SetLogger sets the logger for the client.
 func (c *Client) SetLogger(logger Logger) {
 	c.logger = logger
@@ -160,6 +369,53 @@ func (c *Client) Scope() string {
 	return c.scope
 }
 
+// BaseURL returns the resolved API base URL for the client.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetProviderVersion records the provider version for diagnostic reporting.
+func (c *Client) SetProviderVersion(version string) {
+	c.version = version
+}
+
+// ProviderVersion returns the provider version recorded with SetProviderVersion.
+func (c *Client) ProviderVersion() string {
+	return c.version
+}
+
+// CacheDir returns the directory used to persist client assertions and access tokens.
+func (c *Client) CacheDir() string {
+	if c.tokenSource == nil {
+		return ""
+	}
+	return c.tokenSource.cacheDirectory()
+}
+
+// TokenExpiry returns the expiry of the current OAuth access token, obtaining one if necessary.
+func (c *Client) TokenExpiry() (time.Time, error) {
+	token, err := c.AccessToken()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return token.Expiry, nil
+}
+
+// AccessToken returns a copy of the OAuth access token the client authenticates with,
+// obtaining a new one when the current token is missing or about to expire. Its expiry is the
+// time Apple stops accepting it, without the refresh margin the client keeps for itself.
+func (c *Client) AccessToken() (*oauth2.Token, error) {
+	token, err := c.oauthTS.Token()
+	if err != nil {
+		return nil, err
+	}
+	copied := *token
+	if c.tokenSource != nil {
+		copied.Expiry = copied.Expiry.Add(tokenRefreshBuffer)
+	}
+	return &copied, nil
+}
+
 // IsBusinessScope reports whether the client is configured for the business API scope.
 func (c *Client) IsBusinessScope() bool {
 	return c.scope == "business.api"
@@ -167,6 +423,9 @@ func (c *Client) IsBusinessScope() bool {
 
 // TestAuth forces authentication and returns the JWT client assertion, its expiry, and the OAuth token.
 func (c *Client) TestAuth() (assertion string, assertionExpiry time.Time, token *oauth2.Token, err error) {
+	if c.tokenSource == nil {
+		return "", time.Time{}, nil, errors.New("client does not sign client assertions")
+	}
 	assertion, err = c.tokenSource.createOrGetAssertion()
 	if err != nil {
 		return "", time.Time{}, nil, fmt.Errorf("assertion failed: %w", err)
@@ -179,7 +438,7 @@ func (c *Client) TestAuth() (assertion string, assertionExpiry time.Time, token
 	return assertion, assertionExpiry, token, nil
 }
 
-// handleErrorResponse processes error responses from the API.
+// handleErrorResponse returns an *APIError describing an unsuccessful API response.
 func (c *Client) handleErrorResponse(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -193,16 +452,10 @@ func (c *Client) handleErrorResponse(resp *http.Response) error {
 		if len(snippet) > 200 {
 			snippet = snippet[:200] + "..."
 		}
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, snippet)
-	}
-
-	if len(errResp.Errors) > 0 {
-		e := errResp.Errors[0]
-		return fmt.Errorf("%s: %s (code: %s, status: %s, id: %s)",
-			e.Title, e.Detail, e.Code, e.Status, e.ID)
+		return &APIError{StatusCode: resp.StatusCode, Detail: snippet}
 	}
 
-	return fmt.Errorf("unknown error occurred with status %d", resp.StatusCode)
+	return newAPIError(resp.StatusCode, errResp)
 }
 
 // isRetryableStatus reports whether the HTTP status code is eligible for retry.
@@ -213,9 +466,85 @@ func isRetryableStatus(code int) bool {
 		code == http.StatusGatewayTimeout
 }
 
-// doRequest performs an authenticated HTTP request with automatic retry for
-// rate-limit (429) and server error (502, 503, 504) responses.
+// SetAcceptLanguage sets the Accept-Language header sent with every API request so that
+// localized error details are returned in the preferred language. An empty value omits it.
+func (c *Client) SetAcceptLanguage(value string) {
+	c.acceptLanguage = value
+}
+
+// SetMaxRetries sets how many times a retryable response is retried after the first attempt.
+// The max_retries provider attribute requires at least one; values below one restore the
+// default of 5.
+func (c *Client) SetMaxRetries(n int) {
+	c.retryPolicy.MaxRetries = max(n, 0)
+}
+
+// SetMaxRetryWait sets the longest Retry-After delay honoured on a rate-limit (429) response
+// before the request fails. Values below one restore the default of 60 seconds.
+func (c *Client) SetMaxRetryWait(d time.Duration) {
+	c.retryPolicy.MaxRetryAfterDuration = max(d, 0)
+}
+
+// SetRetryServerErrors configures whether 500 and every other 5xx response are retried with
+// exponential backoff in addition to 502, 503 and 504 responses.
+func (c *Client) SetRetryServerErrors(retry bool) {
+	c.retryPolicy.RetryServerErrors = retry
+}
+
+// SetRetryableErrorCodes configures API error codes whose responses are retried with
+// exponential backoff in addition to rate-limit and transient server error responses. A code
+// also matches its dot-separated sub-codes.
+func (c *Client) SetRetryableErrorCodes(codes []string) {
+	c.retryPolicy.RetryableErrorCodes = codes
+}
+
+// ErrReadOnly is returned for mutating requests made while the client is read-only.
+var ErrReadOnly = errors.New("provider is read-only")
+
+// SetReadOnly makes the client refuse every request other than GET and HEAD, and every serial
+// lock claim or release, with an error wrapping ErrReadOnly.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
+// ReadOnly reports whether the client refuses mutating requests.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly returns an error wrapping ErrReadOnly when the client is read-only and the
+// described operation would change something.
+func (c *Client) checkReadOnly(method, target string) error {
+	if !c.readOnly || method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s was not sent because read_only is set in the provider configuration", ErrReadOnly, method, target)
+}
+
+// doRequest performs an authenticated HTTP request with automatic retry for rate-limit (429)
+// and server error (502, 503, 504, or any 5xx when the policy retries server errors) responses,
+// and for error responses carrying one of the policy's retryable error codes. Concurrent
+// identical GET requests share a single API call. Mutating requests fail with ErrReadOnly on a
+// read-only client, and empty the response cache and the remembered MDM server lists.
 func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.checkReadOnly(req.Method, req.URL.Path); err != nil {
+		return nil, err
+	}
+	if req.Method == http.MethodGet && req.Body == nil {
+		return c.doSharedRead(ctx, req)
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		c.responseCache.clear()
+		c.serverLists.clear()
+		defer c.responseCache.clear()
+		defer c.serverLists.clear()
+	}
+	return c.sendWithRetry(ctx, req)
+}
+
+// sendWithRetry sends req, retrying as described for doRequest. Every attempt waits for a
+// client-wide request slot and for any rate-limit pause started by another request.
+func (c *Client) sendWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
 	var requestBody []byte
 	if req.Body != nil {
 		var err error
@@ -227,6 +556,19 @@ func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Respon
 		req.Body = io.NopCloser(bytes.NewBuffer(requestBody))
 	}
 
+	if c.acceptLanguage != "" && req.Header.Get("Accept-Language") == "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+
+	if err := c.checkAPITimeBudget(req.Method, 0); err != nil {
+		return nil, err
+	}
+	start := c.Clock().Now()
+	defer func() {
+		c.stats.apiTime.Add(int64(c.Clock().Now().Sub(start)))
+	}()
+
+	policy := c.retryPolicyFor(ctx)
 	attempts := 0
 
 	for {
@@ -243,12 +585,54 @@ func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Respon
 			c.logger.LogRequest(ctx, req.Method, req.URL.String(), requestBody)
 		}
 
+		if pause := c.rateLimitPause(); pause > 0 {
+			if err := c.checkAPITimeBudget(req.Method, c.Clock().Now().Sub(start)+pause); err != nil {
+				return nil, err
+			}
+			if err := c.waitWithContext(ctx, pause); err != nil {
+				return nil, err
+			}
+			c.stats.rateLimitWait.Add(int64(pause))
+		}
+
+		if wait := c.requestLimiterWait(); wait > 0 {
+			if err := c.checkAPITimeBudget(req.Method, c.Clock().Now().Sub(start)+wait); err != nil {
+				return nil, err
+			}
+			if err := c.waitWithContext(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		release, err := c.acquireRequestSlot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.stats.requests.Add(1)
 		resp, err := c.httpClient.Do(req)
+		release()
 		if err != nil {
 			return nil, err
 		}
+		c.recordResponseIDs(resp)
+
+		retryable := policy.retryableStatus(resp.StatusCode)
+		var errorCode string
+		if !retryable && resp.StatusCode >= 400 && len(policy.RetryableErrorCodes) > 0 && resp.Body != nil {
+			responseBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewBuffer(responseBody))
+			errorCode = policy.retryableErrorCode(responseBody)
+			retryable = errorCode != ""
+		}
 
-		if !isRetryableStatus(resp.StatusCode) {
+		if !retryable {
+			if resp.StatusCode < 400 {
+				c.recordSucceeded(ctx)
+			}
 			if c.logger != nil && resp.Body != nil {
 				responseBody, err := io.ReadAll(resp.Body)
 				if err != nil {
@@ -272,8 +656,8 @@ func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Respon
 		}
 
 		attempts++
-		if attempts >= maxRetries {
-			return nil, fmt.Errorf("received HTTP %d after %d retries", resp.StatusCode, attempts)
+		if attempts > policy.MaxRetries {
+			return nil, &retriesExhaustedError{statusCode: resp.StatusCode, errorCode: errorCode, retries: policy.MaxRetries}
 		}
 
 		var delay time.Duration
@@ -282,24 +666,35 @@ func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Respon
 			if parseErr != nil {
 				return nil, fmt.Errorf("received 429 Too Many Requests: %w", parseErr)
 			}
-			if retryAfter > maxRetryAfterDuration {
+			if retryAfter > policy.MaxRetryAfterDuration {
 				return nil, fmt.Errorf("received 429 Too Many Requests with Retry-After of %v", retryAfter)
 			}
 			delay = retryAfter
+			c.pauseForRateLimit(delay)
+			c.recordRateLimited(ctx)
 		} else {
-			delay = min(initialBackoff*(1<<(attempts-1)), maxBackoff)
+			delay = policy.backoff(attempts)
+		}
+
+		if err := c.checkAPITimeBudget(req.Method, c.Clock().Now().Sub(start)+delay); err != nil {
+			return nil, fmt.Errorf("received HTTP %d: %w", resp.StatusCode, err)
 		}
 
 		if c.logger != nil {
 			c.logger.LogAuth(ctx, "Retrying after transient error", map[string]any{
 				"status_code": resp.StatusCode,
+				"error_code":  errorCode,
 				"delay_secs":  delay.Seconds(),
 				"attempt":     attempts,
 			})
 		}
-		if err := waitWithContext(ctx, delay); err != nil {
+		if err := c.waitWithContext(ctx, delay); err != nil {
 			return nil, err
 		}
+		c.stats.retries.Add(1)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.stats.rateLimitWait.Add(int64(delay))
+		}
 	}
 }
 
@@ -323,18 +718,170 @@ func parseRetryAfter(header string) (time.Duration, error) {
 	return 0, fmt.Errorf("invalid Retry-After header: %s", header)
 }
 
-func waitWithContext(ctx context.Context, d time.Duration) error {
+// waitWithContext waits d on the client's clock, returning early with the context error when
+// ctx ends first.
+func (c *Client) waitWithContext(ctx context.Context, d time.Duration) error {
 	if d <= 0 {
 		return nil
 	}
 
-	timer := time.NewTimer(d)
-	defer timer.Stop()
-
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-timer.C:
+	case <-c.Clock().After(d):
 		return nil
 	}
 }
+
+// pageLogger reports the progress of one paginated collection request to the client's logger.
+type pageLogger struct {
+	c        *Client
+	endpoint string
+	pageSize int
+	start    time.Time
+	page     int
+	total    int
+}
+
+func (c *Client) newPageLogger(endpoint string, pageSize int) *pageLogger {
+	return &pageLogger{c: c, endpoint: endpoint, pageSize: pageSize, start: c.Clock().Now()}
+}
+
+// logPage records that the page requested with cursor returned items items.
+func (p *pageLogger) logPage(ctx context.Context, cursor string, items int) {
+	p.page++
+	p.total += items
+	if p.c.logger == nil {
+		return
+	}
+	p.c.logger.LogPage(ctx, PageFetch{
+		Endpoint: p.endpoint,
+		Cursor:   cursor,
+		PageSize: p.pageSize,
+		Page:     p.page,
+		Items:    items,
+		Total:    p.total,
+		Elapsed:  p.c.Clock().Now().Sub(p.start),
+	})
+}
+
+// SkippedRecord describes a record in a collection page that could not be decoded and was
+// skipped.
+type SkippedRecord struct {
+	Endpoint string
+	// ID is the record's resource ID, when it could be read.
+	ID  string
+	Err error
+}
+
+// SkippedRecords collects the records skipped while reading collections with a context
+// returned by WithSkippedRecords. It is safe for concurrent use.
+type SkippedRecords struct {
+	mu      sync.Mutex
+	records []SkippedRecord
+}
+
+// Add records a skipped record.
+func (s *SkippedRecords) Add(record SkippedRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+}
+
+// Records returns the skipped records collected so far.
+func (s *SkippedRecords) Records() []SkippedRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.records)
+}
+
+type skippedRecordsContextKey struct{}
+
+// WithSkippedRecords returns a context whose collection reads add any records they skip to
+// skipped. Records are only skipped when SetSkipUndecodableRecords is enabled.
+func WithSkippedRecords(ctx context.Context, skipped *SkippedRecords) context.Context {
+	return context.WithValue(ctx, skippedRecordsContextKey{}, skipped)
+}
+
+// SetSkipUndecodableRecords controls what happens when a page of a collection read cannot be
+// decoded. When disabled, the default, the read fails. When enabled, each record of the page is
+// decoded separately, and records that cannot be decoded are skipped and reported as warnings
+// so that one malformed record does not block the whole read. A page whose envelope cannot be
+// decoded still fails the read because its next cursor is unknown.
+func (c *Client) SetSkipUndecodableRecords(skip bool) {
+	c.skipUndecodable = skip
+}
+
+// decodePage decodes a collection page from body into data and meta, skipping undecodable
+// records when the client is configured to. pages supplies the client and endpoint.
+func decodePage[T any](ctx context.Context, pages *pageLogger, body io.Reader, data *[]T, meta *Meta) error {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	page := struct {
+		Data  *[]T               `json:"data"`
+		Links PagedDocumentLinks `json:"links"`
+		Meta  *Meta              `json:"meta"`
+	}{Data: data, Meta: meta}
+	strictErr := pages.c.decodeJSON(bytes.NewReader(raw), &page)
+	if strictErr == nil || !pages.c.skipUndecodable {
+		return strictErr
+	}
+
+	var lenient struct {
+		Data []json.RawMessage `json:"data"`
+		Meta Meta              `json:"meta"`
+	}
+	if err := json.Unmarshal(raw, &lenient); err != nil {
+		return strictErr
+	}
+	*meta = lenient.Meta
+	*data = make([]T, 0, len(lenient.Data))
+	for _, item := range lenient.Data {
+		var record T
+		if err := pages.c.decodeJSON(bytes.NewReader(item), &record); err != nil {
+			pages.c.reportSkippedRecord(ctx, pages.endpoint, item, err)
+			continue
+		}
+		*data = append(*data, record)
+	}
+	return nil
+}
+
+// reportSkippedRecord logs a skipped record and adds it to the context's SkippedRecords.
+func (c *Client) reportSkippedRecord(ctx context.Context, endpoint string, item json.RawMessage, err error) {
+	var resource struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(item, &resource)
+	record := SkippedRecord{Endpoint: endpoint, ID: resource.ID, Err: err}
+
+	if c.logger != nil {
+		c.logger.LogWarning(ctx, "Skipped undecodable record", map[string]any{
+			"endpoint": endpoint,
+			"id":       record.ID,
+			"error":    err.Error(),
+		})
+	}
+	if skipped, ok := ctx.Value(skippedRecordsContextKey{}).(*SkippedRecords); ok && skipped != nil {
+		skipped.Add(record)
+	}
+}
+
+// pageURL builds the URL for one page of a paginated collection request. It is the single
+// place page URLs are derived, so every page carries the caller's query parameters unchanged:
+// they are deep-copied rather than modified, limit replaces any caller-supplied value, and
+// cursor is added only after the first page.
+func (c *Client) pageURL(path string, queryParams url.Values, limit int, cursor string) string {
+	params := make(url.Values, len(queryParams)+2)
+	for key, values := range queryParams {
+		params[key] = slices.Clone(values)
+	}
+	params.Set("limit", strconv.Itoa(limit))
+	params.Del("cursor")
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	return c.baseURL + path + "?" + params.Encode()
+}
@@ -5,6 +5,7 @@ package client
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -18,6 +19,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -30,15 +32,17 @@ const (
 	assertionMaxLifetime = 180 * 24 * time.Hour
 	tokenRefreshBuffer   = 5 * time.Minute
 	assertionCacheDir    = ".axm/cache"
+	fallbackCacheDir     = "terraform-provider-axm"
 )
 
 // ClientConfig holds the credentials and settings required to authenticate with the Apple API.
 type ClientConfig struct {
-	ClientID   string `json:"client_id"`
-	TeamID     string `json:"team_id"`
-	KeyID      string `json:"key_id"`
-	PrivateKey []byte `json:"private_key"`
-	Scope      string `json:"scope"`
+	ClientID   string          `json:"client_id"`
+	TeamID     string          `json:"team_id"`
+	KeyID      string          `json:"key_id"`
+	PrivateKey []byte          `json:"-"`
+	Signer     AssertionSigner `json:"-"`
+	Scope      string          `json:"scope"`
 }
 
 // TokenResponse represents the JSON response from Apple's OAuth token endpoint.
@@ -59,6 +63,7 @@ type AuthErrorResponse struct {
 // CachedAssertion represents a JWT client assertion persisted to disk for reuse across provider runs.
 type CachedAssertion struct {
 	Assertion string    `json:"assertion"`
+	JTI       string    `json:"jti,omitempty"`
 	ExpiresAt time.Time `json:"expires_at"`
 	ClientID  string    `json:"client_id"`
 	TeamID    string    `json:"team_id"`
@@ -78,19 +83,52 @@ type CachedToken struct {
 
 // appleTokenSource implements oauth2.TokenSource by creating JWT client assertions
 // and exchanging them for access tokens at Apple's OAuth endpoint.
+//
+// Concurrency: mu guards the mutable auth state (assertion, assertionID, assertionExpiry,
+// signingKey and config.PrivateKey) and is held for the whole check-create-save sequence in
+// createOrGetAssertion, so concurrent callers share one assertion rather than racing to
+// create several. createClientAssertion, loadCachedAssertion and saveCachedAssertion access
+// that state and must be called with mu held. The logger and audit log are swapped atomically
+// so that they can be read without mu, and the cache directory is resolved once. Access token reuse and
+// refresh are serialized by the oauth2.ReuseTokenSource wrapping this source.
 type appleTokenSource struct {
 	config          *ClientConfig
 	tokenClient     *http.Client
 	assertion       string
+	assertionID     string
 	assertionExpiry time.Time
-	logger          Logger
+	logger          atomic.Pointer[loggerHolder]
+	audit           atomic.Pointer[auditLog]
+	cacheDir        string
+	cacheFallback   bool
+	cacheOnce       sync.Once
+	signingKey      *ecdsa.PrivateKey
+	clock           Clock
 	mu              sync.Mutex
 }
 
+// loggerHolder wraps a Logger so it can be stored in an atomic.Pointer.
+type loggerHolder struct {
+	Logger
+}
+
 func (s *appleTokenSource) setLogger(logger Logger) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.logger = logger
+	if logger == nil {
+		s.logger.Store(nil)
+		return
+	}
+	s.logger.Store(&loggerHolder{Logger: logger})
+	s.logAuth("Using credential cache directory", map[string]any{
+		"cache_dir": s.cacheDirectory(),
+		"fallback":  s.cacheFallback,
+	})
+}
+
+// logAuth forwards an authentication event to the configured logger, if any.
+func (s *appleTokenSource) logAuth(message string, fields map[string]any) {
+	if holder := s.logger.Load(); holder != nil {
+		holder.LogAuth(context.Background(), message, fields)
+	}
 }
 
 // Token creates a new access token by generating or reusing a JWT client assertion
@@ -101,11 +139,9 @@ func (s *appleTokenSource) Token() (*oauth2.Token, error) {
 		return nil, fmt.Errorf("failed to get valid assertion: %w", err)
 	}
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Requesting new access token", map[string]any{
-			"reason": "token expired or missing",
-		})
-	}
+	s.logAuth("Requesting new access token", map[string]any{
+		"reason": "token expired or missing",
+	})
 
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
@@ -142,16 +178,14 @@ func (s *appleTokenSource) Token() (*oauth2.Token, error) {
 	token := &oauth2.Token{
 		AccessToken: tokenResp.AccessToken,
 		TokenType:   tokenResp.TokenType,
-		Expiry:      time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second).Add(-tokenRefreshBuffer),
+		Expiry:      s.now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second).Add(-tokenRefreshBuffer),
 	}
 
 	_ = s.saveCachedToken(token)
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Successfully obtained new access token", map[string]any{
-			"expires_at": token.Expiry.Add(tokenRefreshBuffer),
-		})
-	}
+	s.logAuth("Successfully obtained new access token", map[string]any{
+		"expires_at": token.Expiry.Add(tokenRefreshBuffer),
+	})
 
 	return token, nil
 }
@@ -159,46 +193,68 @@ func (s *appleTokenSource) Token() (*oauth2.Token, error) {
 // createOrGetAssertion returns a valid JWT assertion, creating a new one if necessary.
 func (s *appleTokenSource) createOrGetAssertion() (string, error) {
 	s.mu.Lock()
-	if s.assertion != "" && time.Now().Before(s.assertionExpiry.Add(-tokenRefreshBuffer)) {
-		assertion := s.assertion
-		s.mu.Unlock()
-		if s.logger != nil {
-			s.logger.LogAuth(context.Background(), "Using cached client assertion", map[string]any{
-				"expires_at": s.assertionExpiry,
-			})
-		}
-		return assertion, nil
-	}
-	s.mu.Unlock()
+	defer s.mu.Unlock()
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Creating new client assertion", map[string]any{
-			"reason": "assertion expired or missing",
+	if s.assertion != "" && s.now().Before(s.assertionExpiry.Add(-tokenRefreshBuffer)) {
+		s.logAuth("Using cached client assertion", map[string]any{
+			"jti":        s.assertionID,
+			"expires_at": s.assertionExpiry,
 		})
+		return s.assertion, nil
 	}
 
-	newAssertion, err := s.createClientAssertion()
+	s.logAuth("Creating new client assertion", map[string]any{
+		"reason": "assertion expired or missing",
+	})
+
+	newAssertion, claims, err := s.createClientAssertion()
 	if err != nil {
 		return "", fmt.Errorf("failed to create client assertion: %w", err)
 	}
 
 	s.assertion = newAssertion
-	s.assertionExpiry = time.Now().Add(assertionMaxLifetime)
+	s.assertionID = claims.ID
+	s.assertionExpiry = s.now().Add(assertionMaxLifetime)
 
 	_ = s.saveCachedAssertion()
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Successfully created new client assertion", map[string]any{
-			"expires_at": s.assertionExpiry,
-		})
-	}
+	s.logAuth("Successfully created new client assertion", map[string]any{
+		"jti":        claims.ID,
+		"issued_at":  claims.IssuedAt.Time,
+		"expires_at": claims.ExpiresAt.Time,
+	})
+	s.auditAssertion(claims)
 
 	return s.assertion, nil
 }
 
-// createClientAssertion generates a signed JWT client assertion for Apple's OAuth endpoint.
-func (s *appleTokenSource) createClientAssertion() (string, error) {
-	now := time.Now()
+// auditAssertion records an issued client assertion in the audit log, if one is configured, so
+// that Apple-side authentication events can be correlated with the run that caused them.
+// Failures are logged rather than returned because they must not block authentication.
+func (s *appleTokenSource) auditAssertion(claims jwt.RegisteredClaims) {
+	audit := s.audit.Load()
+	if audit == nil {
+		return
+	}
+	err := audit.write(AuditRecord{
+		ActivityType: AuditActivityIssueClientAssertion,
+		AssertionID:  claims.ID,
+		IssuedAt:     claims.IssuedAt.UTC(),
+		ExpiresAt:    claims.ExpiresAt.UTC(),
+		Result:       "ISSUED",
+	})
+	if err != nil {
+		s.logAuth("Failed to write client assertion audit record", map[string]any{
+			"jti":   claims.ID,
+			"error": err.Error(),
+		})
+	}
+}
+
+// createClientAssertion generates a signed JWT client assertion for Apple's OAuth endpoint and
+// returns it with the claims it carries.
+func (s *appleTokenSource) createClientAssertion() (string, jwt.RegisteredClaims, error) {
+	now := s.now()
 
 	claims := jwt.RegisteredClaims{
 		Issuer:    s.config.TeamID,
@@ -212,17 +268,26 @@ func (s *appleTokenSource) createClientAssertion() (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
 	token.Header["kid"] = s.config.KeyID
 
-	key, err := jwt.ParseECPrivateKeyFromPEM(s.config.PrivateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse private key: %w", err)
+	if s.config.Signer != nil {
+		signedToken, err := signWithAssertionSigner(token, s.config.Signer)
+		return signedToken, claims, err
+	}
+
+	key := s.signingKey
+	if key == nil {
+		parsed, err := jwt.ParseECPrivateKeyFromPEM(s.config.PrivateKey)
+		if err != nil {
+			return "", claims, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		key = parsed
 	}
 
 	signedToken, err := token.SignedString(key)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+		return "", claims, fmt.Errorf("failed to sign token: %w", err)
 	}
 
-	return signedToken, nil
+	return signedToken, claims, nil
 }
 
 // newUUIDv4 generates a random UUID v4 string using crypto/rand.
@@ -245,7 +310,7 @@ func validateConfig(config *ClientConfig) error {
 	if config.KeyID == "" {
 		return errors.New("key_id is required")
 	}
-	if len(config.PrivateKey) == 0 {
+	if len(config.PrivateKey) == 0 && config.Signer == nil {
 		return errors.New("private_key is required")
 	}
 	if config.Scope == "" {
@@ -254,13 +319,24 @@ func validateConfig(config *ClientConfig) error {
 	return nil
 }
 
+// now returns the current time according to the token source's clock.
+func (s *appleTokenSource) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
+
 // newTokenSource creates and initializes an appleTokenSource with disk-cached assertion.
 func newTokenSource(config *ClientConfig) *appleTokenSource {
 	ts := &appleTokenSource{
 		config:      config,
 		tokenClient: &http.Client{Timeout: 30 * time.Second},
 	}
+	ts.cacheDirectory()
+	ts.mu.Lock()
 	_ = ts.loadCachedAssertion()
+	ts.mu.Unlock()
 	return ts
 }
 
@@ -275,11 +351,9 @@ func (s *appleTokenSource) loadCachedOAuthToken() *oauth2.Token {
 	data, err := os.ReadFile(cacheFile)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			if s.logger != nil {
-				s.logger.LogAuth(context.Background(), "No cached token found on disk", map[string]any{
-					"cache_file": cacheFile,
-				})
-			}
+			s.logAuth("No cached token found on disk", map[string]any{
+				"cache_file": cacheFile,
+			})
 		}
 		return nil
 	}
@@ -292,32 +366,26 @@ func (s *appleTokenSource) loadCachedOAuthToken() *oauth2.Token {
 	if cached.ClientID != s.config.ClientID ||
 		cached.TeamID != s.config.TeamID ||
 		cached.KeyID != s.config.KeyID {
-		if s.logger != nil {
-			s.logger.LogAuth(context.Background(), "Cached token config mismatch", map[string]any{
-				"cache_file": cacheFile,
-			})
-		}
-		return nil
-	}
-
-	if !time.Now().Before(cached.ExpiresAt.Add(-tokenRefreshBuffer)) {
-		if s.logger != nil {
-			s.logger.LogAuth(context.Background(), "Cached token expired, removing", map[string]any{
-				"cache_file": cacheFile,
-				"expires_at": cached.ExpiresAt,
-			})
-		}
-		_ = os.Remove(cacheFile)
+		s.logAuth("Cached token config mismatch", map[string]any{
+			"cache_file": cacheFile,
+		})
 		return nil
 	}
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Loaded valid cached token from disk", map[string]any{
+	if !s.now().Before(cached.ExpiresAt.Add(-tokenRefreshBuffer)) {
+		s.logAuth("Cached token expired, removing", map[string]any{
 			"cache_file": cacheFile,
 			"expires_at": cached.ExpiresAt,
 		})
+		_ = os.Remove(cacheFile)
+		return nil
 	}
 
+	s.logAuth("Loaded valid cached token from disk", map[string]any{
+		"cache_file": cacheFile,
+		"expires_at": cached.ExpiresAt,
+	})
+
 	return &oauth2.Token{
 		AccessToken: cached.AccessToken,
 		TokenType:   cached.TokenType,
@@ -332,18 +400,59 @@ func (s *appleTokenSource) getConfigHash() string {
 	return hex.EncodeToString(hash[:])[:16]
 }
 
+// resolveCacheDir returns the directory used to cache assertions and tokens. The home
+// directory is preferred; when it cannot be determined or written, as on some ephemeral
+// CI runners, a per-user directory under the system temp directory is used instead and
+// fallback is reported as true.
+func resolveCacheDir() (dir string, fallback bool) {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		dir = filepath.Join(home, assertionCacheDir)
+		if isDirWritable(dir) {
+			return dir, false
+		}
+	}
+	name := fallbackCacheDir
+	if uid := os.Getuid(); uid >= 0 {
+		name = fmt.Sprintf("%s-%d", fallbackCacheDir, uid)
+	}
+	return filepath.Join(os.TempDir(), name, "cache"), true
+}
+
+// isDirWritable reports whether dir exists or can be created, and accepts new files.
+func isDirWritable(dir string) bool {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return false
+	}
+	f, err := os.CreateTemp(dir, ".write-probe-*")
+	if err != nil {
+		return false
+	}
+	name := f.Name()
+	_ = f.Close()
+	_ = os.Remove(name)
+	return true
+}
+
+// cacheDirectory returns the resolved cache directory, resolving it on first use.
+func (s *appleTokenSource) cacheDirectory() string {
+	s.cacheOnce.Do(func() {
+		if s.cacheDir == "" {
+			s.cacheDir, s.cacheFallback = resolveCacheDir()
+		}
+	})
+	return s.cacheDir
+}
+
 // getCacheFilePath returns the path to the assertion cache file.
 func (s *appleTokenSource) getCacheFilePath() (string, error) {
-	cacheDir := filepath.Join(os.TempDir(), assertionCacheDir)
 	configHash := s.getConfigHash()
-	return filepath.Join(cacheDir, fmt.Sprintf("assertion_%s.json", configHash)), nil
+	return filepath.Join(s.cacheDirectory(), fmt.Sprintf("assertion_%s.json", configHash)), nil
 }
 
 // getTokenCacheFilePath returns the path to the token cache file.
 func (s *appleTokenSource) getTokenCacheFilePath() (string, error) {
-	cacheDir := filepath.Join(os.TempDir(), assertionCacheDir)
 	configHash := s.getConfigHash()
-	return filepath.Join(cacheDir, fmt.Sprintf("token_%s.json", configHash)), nil
+	return filepath.Join(s.cacheDirectory(), fmt.Sprintf("token_%s.json", configHash)), nil
 }
 
 // loadCachedAssertion loads a cached assertion from disk if valid.
@@ -356,11 +465,9 @@ func (s *appleTokenSource) loadCachedAssertion() error {
 	data, err := os.ReadFile(cacheFile)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			if s.logger != nil {
-				s.logger.LogAuth(context.Background(), "No cached assertion found on disk", map[string]any{
-					"cache_file": cacheFile,
-				})
-			}
+			s.logAuth("No cached assertion found on disk", map[string]any{
+				"cache_file": cacheFile,
+			})
 			return nil
 		}
 		return fmt.Errorf("failed to read cache file: %w", err)
@@ -374,32 +481,28 @@ func (s *appleTokenSource) loadCachedAssertion() error {
 	if cached.ClientID != s.config.ClientID ||
 		cached.TeamID != s.config.TeamID ||
 		cached.KeyID != s.config.KeyID {
-		if s.logger != nil {
-			s.logger.LogAuth(context.Background(), "Cached assertion config mismatch", map[string]any{
-				"cache_file": cacheFile,
-			})
-		}
+		s.logAuth("Cached assertion config mismatch", map[string]any{
+			"cache_file": cacheFile,
+		})
 		return errors.New("cached assertion config mismatch")
 	}
 
-	if time.Now().Before(cached.ExpiresAt.Add(-tokenRefreshBuffer)) {
+	if s.now().Before(cached.ExpiresAt.Add(-tokenRefreshBuffer)) {
 		s.assertion = cached.Assertion
+		s.assertionID = cached.JTI
 		s.assertionExpiry = cached.ExpiresAt
-		if s.logger != nil {
-			s.logger.LogAuth(context.Background(), "Loaded valid cached assertion from disk", map[string]any{
-				"cache_file": cacheFile,
-				"expires_at": cached.ExpiresAt,
-			})
-		}
-		return nil
-	}
-
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Cached assertion expired, removing", map[string]any{
+		s.logAuth("Loaded valid cached assertion from disk", map[string]any{
 			"cache_file": cacheFile,
+			"jti":        cached.JTI,
 			"expires_at": cached.ExpiresAt,
 		})
+		return nil
 	}
+
+	s.logAuth("Cached assertion expired, removing", map[string]any{
+		"cache_file": cacheFile,
+		"expires_at": cached.ExpiresAt,
+	})
 	_ = os.Remove(cacheFile)
 	return nil
 }
@@ -422,6 +525,7 @@ func (s *appleTokenSource) saveCachedAssertion() error {
 
 	cached := CachedAssertion{
 		Assertion: s.assertion,
+		JTI:       s.assertionID,
 		ExpiresAt: s.assertionExpiry,
 		ClientID:  s.config.ClientID,
 		TeamID:    s.config.TeamID,
@@ -437,12 +541,10 @@ func (s *appleTokenSource) saveCachedAssertion() error {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Saved assertion to disk cache", map[string]any{
-			"cache_file": cacheFile,
-			"expires_at": s.assertionExpiry,
-		})
-	}
+	s.logAuth("Saved assertion to disk cache", map[string]any{
+		"cache_file": cacheFile,
+		"expires_at": s.assertionExpiry,
+	})
 
 	return nil
 }
@@ -478,12 +580,10 @@ func (s *appleTokenSource) saveCachedToken(token *oauth2.Token) error {
 		return fmt.Errorf("failed to write token cache file: %w", err)
 	}
 
-	if s.logger != nil {
-		s.logger.LogAuth(context.Background(), "Saved token to disk cache", map[string]any{
-			"cache_file": cacheFile,
-			"expires_at": cached.ExpiresAt,
-		})
-	}
+	s.logAuth("Saved token to disk cache", map[string]any{
+		"cache_file": cacheFile,
+		"expires_at": cached.ExpiresAt,
+	})
 
 	return nil
 }
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -11,12 +12,17 @@ import (
 	"encoding/pem"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
 )
 
 func generateTestP8Key(t *testing.T) []byte {
@@ -35,6 +41,7 @@ func generateTestP8Key(t *testing.T) []byte {
 func TestCreateClientAssertion_ValidKey(t *testing.T) {
 	pemKey := generateTestP8Key(t)
 	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
 		config: &ClientConfig{
 			TeamID:     "TEAM123",
 			ClientID:   "CLIENT456",
@@ -44,7 +51,7 @@ func TestCreateClientAssertion_ValidKey(t *testing.T) {
 		},
 	}
 
-	assertion, err := ts.createClientAssertion()
+	assertion, _, err := ts.createClientAssertion()
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -86,6 +93,7 @@ func TestCreateClientAssertion_ValidKey(t *testing.T) {
 
 func TestCreateClientAssertion_InvalidKey(t *testing.T) {
 	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
 		config: &ClientConfig{
 			TeamID:     "TEAM123",
 			ClientID:   "CLIENT456",
@@ -95,7 +103,7 @@ func TestCreateClientAssertion_InvalidKey(t *testing.T) {
 		},
 	}
 
-	_, err := ts.createClientAssertion()
+	_, _, err := ts.createClientAssertion()
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -107,6 +115,7 @@ func TestCreateClientAssertion_InvalidKey(t *testing.T) {
 func TestCreateOrGetAssertion_CachesAssertion(t *testing.T) {
 	pemKey := generateTestP8Key(t)
 	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
 		config: &ClientConfig{
 			TeamID:     "TEAM123",
 			ClientID:   "CLIENT456",
@@ -149,6 +158,7 @@ func TestTokenSource_Token_Success(t *testing.T) {
 	defer tokenServer.Close()
 
 	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
 		config: &ClientConfig{
 			TeamID:     "TEAM123",
 			ClientID:   "CLIENT456",
@@ -213,6 +223,7 @@ func TestTokenSource_Token_AuthError(t *testing.T) {
 	defer tokenServer.Close()
 
 	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
 		config: &ClientConfig{
 			TeamID:     "TEAM123",
 			ClientID:   "CLIENT456",
@@ -252,3 +263,177 @@ func TestNewUUIDv4(t *testing.T) {
 		seen[u] = true
 	}
 }
+
+func TestResolveCacheDir_Home(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir, fallback := resolveCacheDir()
+	if fallback {
+		t.Error("expected home directory cache, got fallback")
+	}
+	if want := filepath.Join(home, assertionCacheDir); dir != want {
+		t.Errorf("expected cache dir %q, got %q", want, dir)
+	}
+}
+
+func TestResolveCacheDir_UnwritableHomeFallsBack(t *testing.T) {
+	base := t.TempDir()
+	home := filepath.Join(base, "home")
+	if err := os.WriteFile(home, []byte("not a directory"), 0600); err != nil {
+		t.Fatalf("failed to create home placeholder: %v", err)
+	}
+	tmp := filepath.Join(base, "tmp")
+	t.Setenv("HOME", home)
+	t.Setenv("TMPDIR", tmp)
+
+	dir, fallback := resolveCacheDir()
+	if !fallback {
+		t.Error("expected fallback cache directory")
+	}
+	if !strings.HasPrefix(dir, filepath.Join(tmp, fallbackCacheDir)) {
+		t.Errorf("expected cache dir under %q, got %q", tmp, dir)
+	}
+}
+
+// recordingLogger is a Logger that records authentication events, fetched pages and warnings.
+type recordingLogger struct {
+	mu       sync.Mutex
+	events   []string
+	pages    []PageFetch
+	warnings []string
+}
+
+func (l *recordingLogger) LogRequest(ctx context.Context, method, url string, body []byte) {}
+
+func (l *recordingLogger) LogResponse(ctx context.Context, statusCode int, headers http.Header, body []byte) {
+}
+
+func (l *recordingLogger) LogPage(ctx context.Context, page PageFetch) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pages = append(l.pages, page)
+}
+
+func (l *recordingLogger) LogWarning(ctx context.Context, message string, fields map[string]any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, message)
+}
+
+func (l *recordingLogger) LogAuth(ctx context.Context, message string, fields map[string]any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, message)
+}
+
+// newConcurrencyTestTokenSource returns a token source backed by a token server that records
+// every client assertion it receives.
+func newConcurrencyTestTokenSource(t *testing.T) (*appleTokenSource, *sync.Map, *atomic.Int32) {
+	t.Helper()
+	var assertions sync.Map
+	var requests atomic.Int32
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse token request: %v", err)
+		}
+		assertions.Store(r.PostForm.Get("client_assertion"), struct{}{})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test-access-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
+		config: &ClientConfig{
+			TeamID:     "TEAM123",
+			ClientID:   "CLIENT456",
+			KeyID:      "KEY789",
+			PrivateKey: generateTestP8Key(t),
+			Scope:      "business.api",
+		},
+		tokenClient: &http.Client{
+			Transport: &rewriteTransport{base: http.DefaultTransport, rewrite: tokenServer.URL},
+		},
+	}
+	return ts, &assertions, &requests
+}
+
+func TestTokenSource_ConcurrentTokenSharesAssertion(t *testing.T) {
+	ts, assertions, requests := newConcurrencyTestTokenSource(t)
+	ts.setLogger(&recordingLogger{})
+
+	const workers = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for range workers {
+		wg.Go(func() {
+			if _, err := ts.Token(); err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if requests.Load() != workers {
+		t.Errorf("expected %d token requests, got %d", workers, requests.Load())
+	}
+	distinct := 0
+	assertions.Range(func(_, _ any) bool {
+		distinct++
+		return true
+	})
+	if distinct != 1 {
+		t.Errorf("expected all requests to share one client assertion, got %d", distinct)
+	}
+}
+
+func TestTokenSource_ConcurrentReuseRequestsOnce(t *testing.T) {
+	ts, _, requests := newConcurrencyTestTokenSource(t)
+	reusable := oauth2.ReuseTokenSource(nil, ts)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Go(func() {
+			if _, err := reusable.Token(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if requests.Load() != 1 {
+		t.Errorf("expected a single token request, got %d", requests.Load())
+	}
+}
+
+func TestTokenSource_ConcurrentLoggerAndCacheAccess(t *testing.T) {
+	ts, _, _ := newConcurrencyTestTokenSource(t)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			ts.setLogger(&recordingLogger{})
+			_ = ts.cacheDirectory()
+			if _, err := ts.createOrGetAssertion(); err != nil {
+				t.Errorf("worker %d: unexpected error: %v", i, err)
+			}
+		})
+	}
+	wg.Go(func() {
+		if err := ts.enableStrictKeyHygiene(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	wg.Wait()
+
+	if _, err := ts.createOrGetAssertion(); err != nil {
+		t.Fatalf("unexpected error after concurrent access: %v", err)
+	}
+}
@@ -6,9 +6,11 @@ package client
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -282,6 +284,44 @@ func TestDoRequest_RateLimitExceedsMaxRetries(t *testing.T) {
 	}
 }
 
+func TestDoRequest_RetryPolicyOverride(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{MaxRetries: 2})
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	_, err := c.doRequest(ctx, req)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "after 2 retries") {
+		t.Fatalf("expected overridden max retries error, got %q", err.Error())
+	}
+	if got := requestCount.Load(); got != 3 {
+		t.Fatalf("expected the first attempt and 2 retries, got %d requests", got)
+	}
+}
+
+func TestRetryPolicyMerge(t *testing.T) {
+	merged := DefaultRetryPolicy().merge(RetryPolicy{InitialBackoff: time.Second})
+	if merged.InitialBackoff != time.Second {
+		t.Errorf("expected overridden InitialBackoff, got %v", merged.InitialBackoff)
+	}
+	if merged.MaxRetries != maxRetries {
+		t.Errorf("expected inherited MaxRetries %d, got %d", maxRetries, merged.MaxRetries)
+	}
+	if merged.MaxBackoff != maxBackoff {
+		t.Errorf("expected inherited MaxBackoff %v, got %v", maxBackoff, merged.MaxBackoff)
+	}
+}
+
 func TestDoRequest_RateLimitExceedsMaxDuration(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Retry-After", "120")
@@ -392,7 +432,7 @@ func TestWaitWithContext(t *testing.T) {
 				ctx, cancelFn = context.WithCancel(ctx)
 				cancelFn()
 			}
-			err := waitWithContext(ctx, tt.dur)
+			err := (&Client{}).waitWithContext(ctx, tt.dur)
 			if tt.wantErr && err == nil {
 				t.Fatal("expected error, got nil")
 			}
@@ -402,3 +442,312 @@ func TestWaitWithContext(t *testing.T) {
 		})
 	}
 }
+
+func TestPageURL(t *testing.T) {
+	c := &Client{baseURL: "https://api.example.com"}
+	caller := url.Values{
+		"filter[status]": {"ASSIGNED", "UNASSIGNED"},
+		"limit":          {"5"},
+		"cursor":         {"stale"},
+	}
+
+	first, err := url.Parse(c.pageURL("/v1/orgDevices", caller, 1000, ""))
+	if err != nil {
+		t.Fatalf("failed to parse first page URL: %v", err)
+	}
+	if first.Path != "/v1/orgDevices" {
+		t.Errorf("expected path /v1/orgDevices, got %s", first.Path)
+	}
+	q := first.Query()
+	if got := q["filter[status]"]; len(got) != 2 || got[0] != "ASSIGNED" || got[1] != "UNASSIGNED" {
+		t.Errorf("expected repeated filter values to be preserved, got %v", got)
+	}
+	if q.Get("limit") != "1000" {
+		t.Errorf("expected limit=1000, got %v", q["limit"])
+	}
+	if q.Has("cursor") {
+		t.Errorf("expected no cursor on the first page, got %v", q["cursor"])
+	}
+
+	second, err := url.Parse(c.pageURL("/v1/orgDevices", caller, 1000, "page2"))
+	if err != nil {
+		t.Fatalf("failed to parse second page URL: %v", err)
+	}
+	q = second.Query()
+	if got := q["cursor"]; len(got) != 1 || got[0] != "page2" {
+		t.Errorf("expected a single cursor=page2, got %v", got)
+	}
+	if got := q["filter[status]"]; len(got) != 2 {
+		t.Errorf("expected filter to persist on page 2, got %v", got)
+	}
+
+	if caller.Get("limit") != "5" || caller.Get("cursor") != "stale" || len(caller["filter[status]"]) != 2 {
+		t.Errorf("expected caller query parameters to be left unmodified, got %v", caller)
+	}
+}
+
+func TestNewClientWithAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer pre-issued" {
+			t.Errorf("expected pre-issued bearer token, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[],"meta":{"paging":{}}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithAccessToken(server.URL, "business.api", "pre-issued", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.GetOrgDevices(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.CacheDir() != "" {
+		t.Errorf("expected no cache directory, got %q", c.CacheDir())
+	}
+	if _, _, _, err := c.TestAuth(); err == nil {
+		t.Error("expected TestAuth to fail without signing credentials")
+	}
+
+	token, err := c.AccessToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "pre-issued" || token.TokenType != "Bearer" {
+		t.Errorf("expected the pre-issued bearer token, got %q %q", token.TokenType, token.AccessToken)
+	}
+}
+
+func TestNewClientWithAccessToken_Expired(t *testing.T) {
+	if _, err := NewClientWithAccessToken("https://api.example.com", "business.api", "", time.Time{}); err == nil {
+		t.Fatal("expected an error for an empty access token")
+	}
+
+	c, err := NewClientWithAccessToken("https://api.example.com", "business.api", "stale", time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.TokenExpiry(); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected an expired token error, got %v", err)
+	}
+}
+
+func TestDoRequest_RetryableErrorCode(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errors":[{"status":"500","code":"UNEXPECTED_ERROR.BACKEND"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetRetryableErrorCodes([]string{"UNEXPECTED_ERROR"})
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{InitialBackoff: time.Millisecond})
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := requestCount.Load(); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestDoRequest_UnlistedErrorCodeNotRetried(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"400","code":"PARAMETER_ERROR.INVALID","title":"Invalid"}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetRetryableErrorCodes([]string{"PARAMETER_ERROR.MISSING", "PARAMETER"})
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if got := requestCount.Load(); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+	if err := c.handleErrorResponse(resp); err == nil || !strings.Contains(err.Error(), "PARAMETER_ERROR.INVALID") {
+		t.Errorf("expected the response body to remain readable, got %v", err)
+	}
+}
+
+func TestDoRequest_RetryableErrorCodeExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"409","code":"STATE_ERROR"}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetRetryableErrorCodes([]string{"STATE_ERROR"})
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond})
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	_, err := c.doRequest(ctx, req)
+	if err == nil || !strings.Contains(err.Error(), "error code STATE_ERROR after 2 retries") {
+		t.Fatalf("expected retry exhaustion error, got %v", err)
+	}
+}
+
+func TestDoRequest_AcceptLanguage(t *testing.T) {
+	var got []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Accept-Language"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetAcceptLanguage("fr-FR, en;q=0.8")
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	req.Header.Set("Accept-Language", "de")
+	resp, err = c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(got) != 2 || got[0] != "fr-FR, en;q=0.8" || got[1] != "de" {
+		t.Errorf("expected configured then explicit Accept-Language, got %q", got)
+	}
+}
+
+func TestDoRequest_ReadOnly(t *testing.T) {
+	var methods []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetReadOnly(true)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/orgDevices", nil)
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodPost, server.URL+"/v1/orgDeviceActivities", strings.NewReader("{}"))
+	_, err = c.doRequest(context.Background(), req)
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "POST /v1/orgDeviceActivities") {
+		t.Errorf("expected the refused request in the error, got %v", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("expected only the GET to be sent, got %v", methods)
+	}
+}
+
+func TestDoRequest_RetryServerErrors(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{InitialBackoff: time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500 to be returned without retrying by default, got %d", resp.StatusCode)
+	}
+
+	c.SetRetryServerErrors(true)
+	requestCount.Store(0)
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	resp, err = c.doRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || requestCount.Load() != 2 {
+		t.Errorf("expected 500 to be retried, got status %d after %d requests", resp.StatusCode, requestCount.Load())
+	}
+}
+
+func TestClientRetrySettings(t *testing.T) {
+	c := &Client{}
+	c.SetMaxRetries(8)
+	c.SetMaxRetryWait(5 * time.Minute)
+
+	policy := c.retryPolicyFor(context.Background())
+	if policy.MaxRetries != 8 || policy.MaxRetryAfterDuration != 5*time.Minute {
+		t.Errorf("expected configured retry settings, got %+v", policy)
+	}
+
+	c.SetMaxRetries(0)
+	c.SetMaxRetryWait(-time.Second)
+	policy = c.retryPolicyFor(context.Background())
+	if policy.MaxRetries != maxRetries || policy.MaxRetryAfterDuration != maxRetryAfterDuration {
+		t.Errorf("expected defaults to be restored, got %+v", policy)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 2 * time.Second, MaxBackoff: 30 * time.Second}
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{4, 16 * time.Second},
+		{6, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 50 {
+			delay := policy.backoff(tt.attempt)
+			if delay < tt.ceiling/2 || delay > tt.ceiling {
+				t.Fatalf("attempt %d: expected a delay between %v and %v, got %v", tt.attempt, tt.ceiling/2, tt.ceiling, delay)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import "time"
+
+// Clock abstracts the passage of time for token expiry and activity polling so that
+// time-dependent behaviour can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock returns the Clock backed by the time package.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// SetClock replaces the clock used by the client and its token source. A nil clock
+// restores the system clock. It must be called before the client is used concurrently.
+func (c *Client) SetClock(clock Clock) {
+	c.clock = clock
+	if c.tokenSource != nil {
+		c.tokenSource.clock = clock
+	}
+}
+
+// Clock returns the clock used by the client.
+func (c *Client) Clock() Clock {
+	if c.clock == nil {
+		return systemClock{}
+	}
+	return c.clock
+}
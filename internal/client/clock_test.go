@@ -0,0 +1,217 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// fakeClock is a manually advanced Clock. Channels returned by After fire as soon as the
+// clock is advanced to or past their deadline.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	deadline := c.now.Add(d)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: deadline, ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.deadline.After(c.now) {
+			w.ch <- c.now
+			continue
+		}
+		pending = append(pending, w)
+	}
+	c.waiters = pending
+}
+
+// awaitWaiter blocks until a caller is waiting on After, failing the test after a second.
+func (c *fakeClock) awaitWaiter(t *testing.T) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		waiting := len(c.waiters) > 0
+		c.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for a caller to wait on the clock")
+}
+
+func TestFakeClock_After(t *testing.T) {
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := clock.After(time.Minute)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("expected timer not to fire before its deadline")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+	default:
+		t.Fatal("expected timer to fire at its deadline")
+	}
+}
+
+func TestClient_ClockDefaultsToSystemClock(t *testing.T) {
+	c := &Client{}
+	if _, ok := c.Clock().(systemClock); !ok {
+		t.Errorf("expected system clock, got %T", c.Clock())
+	}
+
+	clock := newFakeClock(time.Now())
+	ts := &appleTokenSource{}
+	c = &Client{tokenSource: ts}
+	c.SetClock(clock)
+	if c.Clock() != clock || ts.clock != clock {
+		t.Error("expected SetClock to update the client and token source")
+	}
+}
+
+func TestCreateOrGetAssertion_ExpiresWithClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
+		clock:    clock,
+		config: &ClientConfig{
+			TeamID:     "TEAM123",
+			ClientID:   "CLIENT456",
+			KeyID:      "KEY789",
+			PrivateKey: generateTestP8Key(t),
+			Scope:      "business.api",
+		},
+	}
+
+	first, err := ts.createOrGetAssertion()
+	if err != nil {
+		t.Fatalf("first call error: %v", err)
+	}
+	if !ts.assertionExpiry.Equal(start.Add(assertionMaxLifetime)) {
+		t.Errorf("expected assertion expiry %v, got %v", start.Add(assertionMaxLifetime), ts.assertionExpiry)
+	}
+
+	clock.Advance(assertionMaxLifetime - tokenRefreshBuffer - time.Second)
+	second, err := ts.createOrGetAssertion()
+	if err != nil {
+		t.Fatalf("second call error: %v", err)
+	}
+	if first != second {
+		t.Error("expected assertion to be reused before the refresh buffer")
+	}
+
+	clock.Advance(time.Second)
+	third, err := ts.createOrGetAssertion()
+	if err != nil {
+		t.Fatalf("third call error: %v", err)
+	}
+	if third == first {
+		t.Error("expected a new assertion once the refresh buffer is reached")
+	}
+}
+
+func TestTokenSource_TokenExpiryUsesClock(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test-access-token","token_type":"Bearer","expires_in":3600,"scope":"business.api"}`))
+	}))
+	defer tokenServer.Close()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
+		clock:    newFakeClock(start),
+		config: &ClientConfig{
+			TeamID:     "TEAM123",
+			ClientID:   "CLIENT456",
+			KeyID:      "KEY789",
+			PrivateKey: generateTestP8Key(t),
+			Scope:      "business.api",
+		},
+		tokenClient: &http.Client{
+			Transport: &rewriteTransport{base: http.DefaultTransport, rewrite: tokenServer.URL},
+		},
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := start.Add(time.Hour).Add(-tokenRefreshBuffer)
+	if !token.Expiry.Equal(want) {
+		t.Errorf("expected expiry %v, got %v", want, token.Expiry)
+	}
+}
+
+func TestLoadCachedOAuthToken_ExpiredByClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
+		clock:    clock,
+		config: &ClientConfig{
+			TeamID:   "TEAM123",
+			ClientID: "CLIENT456",
+			KeyID:    "KEY789",
+			Scope:    "business.api",
+		},
+	}
+
+	if err := ts.saveCachedToken(&oauth2.Token{AccessToken: "cached", TokenType: "Bearer", Expiry: start.Add(time.Hour)}); err != nil {
+		t.Fatalf("failed to save token: %v", err)
+	}
+	if ts.loadCachedOAuthToken() == nil {
+		t.Fatal("expected cached token to be valid")
+	}
+
+	clock.Advance(time.Hour)
+	if ts.loadCachedOAuthToken() != nil {
+		t.Error("expected cached token to be treated as expired")
+	}
+}
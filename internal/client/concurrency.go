@@ -0,0 +1,82 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultMaxConcurrency is the number of concurrent per-resource requests used when no
+// limit has been configured with SetMaxConcurrency.
+const DefaultMaxConcurrency = 4
+
+// SetMaxConcurrency sets the maximum number of per-resource requests the client issues in
+// parallel when enriching a list of resources. Values below one restore the default.
+func (c *Client) SetMaxConcurrency(n int) {
+	c.maxConcurrency = n
+}
+
+// MaxConcurrency returns the effective per-resource request concurrency, halved for each
+// TuningLevel.
+func (c *Client) MaxConcurrency() int {
+	if c.maxConcurrency < 1 {
+		return c.tuning.scale(DefaultMaxConcurrency, 1)
+	}
+	return c.tuning.scale(c.maxConcurrency, 1)
+}
+
+// ForEachConcurrent calls fn for each index in [0, n) using at most limit concurrent workers.
+// The context passed to fn is cancelled as soon as any call fails, and the first error is
+// returned once all running calls have finished. Callers write results into
+// index-addressed slices so that output order matches input order.
+func ForEachConcurrent(ctx context.Context, limit, n int, fn func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return nil
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	limit = min(limit, n)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	indexes := make(chan int)
+
+	for range limit {
+		wg.Go(func() {
+			for i := range indexes {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		})
+	}
+
+	for i := range n {
+		if ctx.Err() != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,92 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrent_RespectsLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	results := make([]int, 20)
+
+	err := ForEachConcurrent(context.Background(), 3, len(results), func(ctx context.Context, i int) error {
+		current := running.Add(1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		results[i] = i * 2
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", peak.Load())
+	}
+	for i, v := range results {
+		if v != i*2 {
+			t.Fatalf("expected results[%d]=%d, got %d", i, i*2, v)
+		}
+	}
+}
+
+func TestForEachConcurrent_StopsOnFirstError(t *testing.T) {
+	wantErr := errors.New("boom")
+	var calls atomic.Int32
+
+	err := ForEachConcurrent(context.Background(), 2, 100, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		if i == 1 {
+			return wantErr
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(10 * time.Millisecond):
+		}
+		return nil
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if calls.Load() >= 100 {
+		t.Errorf("expected remaining work to be skipped after the first error, got %d calls", calls.Load())
+	}
+}
+
+func TestForEachConcurrent_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ForEachConcurrent(ctx, 4, 10, func(ctx context.Context, i int) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMaxConcurrency_Default(t *testing.T) {
+	c := &Client{}
+	if c.MaxConcurrency() != DefaultMaxConcurrency {
+		t.Errorf("expected default %d, got %d", DefaultMaxConcurrency, c.MaxConcurrency())
+	}
+	c.SetMaxConcurrency(16)
+	if c.MaxConcurrency() != 16 {
+		t.Errorf("expected 16, got %d", c.MaxConcurrency())
+	}
+	c.SetMaxConcurrency(0)
+	if c.MaxConcurrency() != DefaultMaxConcurrency {
+		t.Errorf("expected default after reset, got %d", c.MaxConcurrency())
+	}
+}
@@ -8,10 +8,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // ConfigurationType represents the type of a Configuration.
@@ -130,58 +128,7 @@ type ConfigurationUpdateRequestAttributes struct {
 
 // GetConfigurations retrieves all Configurations in the organization.
 func (c *Client) GetConfigurations(ctx context.Context, queryParams url.Values) ([]Configuration, error) {
-	var allConfigs []Configuration
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/configurations", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response ConfigurationsResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allConfigs = append(allConfigs, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allConfigs, nil
+	return collectPages[Configuration](ctx, c, "/v1/configurations", queryParams, defaultPageLimit)
 }
 
 // GetConfiguration retrieves a single Configuration by ID.
@@ -209,7 +156,7 @@ func (c *Client) GetConfiguration(ctx context.Context, id string, queryParams ur
 	}
 
 	var response ConfigurationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -243,7 +190,7 @@ func (c *Client) CreateConfiguration(ctx context.Context, request ConfigurationC
 	}
 
 	var response ConfigurationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -277,7 +224,7 @@ func (c *Client) UpdateConfiguration(ctx context.Context, request ConfigurationU
 	}
 
 	var response ConfigurationResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -0,0 +1,179 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRequestsInFlight is the number of API requests the client sends at once, across
+// all resources and data sources, when no limit has been configured with
+// SetMaxRequestsInFlight.
+const DefaultMaxRequestsInFlight = 8
+
+// SetMaxRequestsInFlight sets the maximum number of API requests the client sends at once,
+// shared by every resource and data source using it. Values below one restore the default.
+// It must be called before the client is used concurrently.
+func (c *Client) SetMaxRequestsInFlight(n int) {
+	c.maxInFlight = n
+}
+
+// MaxRequestsInFlight returns the effective client-wide limit on requests in flight.
+func (c *Client) MaxRequestsInFlight() int {
+	if c.maxInFlight < 1 {
+		return DefaultMaxRequestsInFlight
+	}
+	return c.maxInFlight
+}
+
+// acquireRequestSlot blocks until fewer than MaxRequestsInFlight requests are in flight, and
+// returns a function that releases the slot.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	c.slotsOnce.Do(func() {
+		c.slots = make(chan struct{}, c.MaxRequestsInFlight())
+	})
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// pauseForRateLimit holds back every request sent by the client until delay has elapsed, so
+// that a 429 response seen by one caller pauses all of them instead of each discovering the
+// rate limit separately.
+func (c *Client) pauseForRateLimit(delay time.Duration) {
+	until := c.Clock().Now().Add(delay).UnixNano()
+	for {
+		current := c.rateLimitedUntil.Load()
+		if current >= until || c.rateLimitedUntil.CompareAndSwap(current, until) {
+			return
+		}
+	}
+}
+
+// rateLimitPause returns how long remains, on the client's clock, of a pause started by
+// pauseForRateLimit, or zero when no pause was started.
+func (c *Client) rateLimitPause() time.Duration {
+	until := c.rateLimitedUntil.Load()
+	if until == 0 {
+		return 0
+	}
+	return time.Unix(0, until).Sub(c.Clock().Now())
+}
+
+// requestLimiter spaces requests evenly so that no more than a configured number are sent
+// per minute.
+type requestLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+// reserve claims the next free send time and returns how long after now the caller must wait
+// for it.
+func (l *requestLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// SetRequestsPerMinute limits the client to sending n API requests per minute, spaced evenly
+// and shared by every resource and data source using it, so that large runs stay under
+// Apple's rate limits instead of relying on 429 responses. Retries count towards the limit.
+// Values below one remove the limit. It must be called before the client is used
+// concurrently.
+func (c *Client) SetRequestsPerMinute(n int) {
+	c.requestsPerMinute = n
+	if n < 1 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = &requestLimiter{interval: time.Minute / time.Duration(n)}
+}
+
+// RequestsPerMinute returns the configured request rate limit, or zero when there is none.
+func (c *Client) RequestsPerMinute() int {
+	if c.limiter == nil {
+		return 0
+	}
+	return c.requestsPerMinute
+}
+
+// requestLimiterWait reserves a send time with the request rate limiter and returns how long
+// the caller must wait for it.
+func (c *Client) requestLimiterWait() time.Duration {
+	if c.limiter == nil {
+		return 0
+	}
+	return c.limiter.reserve(c.Clock().Now())
+}
+
+// sharedResponse is a fully read response that can be handed to every caller of a shared read.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// sendShared sends req and reads its response fully so that it can be shared.
+func (c *Client) sendShared(ctx context.Context, req *http.Request) (*sharedResponse, error) {
+	resp, err := c.sendWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	if resp.Body != nil {
+		body, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+	}
+	return &sharedResponse{resp: resp, body: body}, nil
+}
+
+// doSharedRead sends a GET request, sharing the response with any identical GET requests
+// already in flight so that concurrent reads of the same resource reach the API once. Each
+// caller receives its own copy of the response. The retry policy of the caller whose request
+// is sent applies to all of them. Reads the response cache may keep go through it.
+func (c *Client) doSharedRead(ctx context.Context, req *http.Request) (*http.Response, error) {
+	language := req.Header.Get("Accept-Language")
+	if language == "" {
+		language = c.acceptLanguage
+	}
+	key := req.URL.String() + "\x00" + language
+
+	result, err, _ := c.reads.Do(key, func() (any, error) {
+		if c.cacheable(req.URL) {
+			return c.sendCached(ctx, req, key)
+		}
+		return c.sendShared(ctx, req)
+	})
+	if err != nil {
+		if ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			return c.sendWithRetry(ctx, req)
+		}
+		return nil, err
+	}
+
+	shared := result.(*sharedResponse)
+	resp := *shared.resp
+	resp.Header = shared.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(shared.body))
+	resp.Request = req
+	return &resp, nil
+}
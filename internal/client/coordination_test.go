@@ -0,0 +1,346 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRequest_SharesConcurrentIdenticalReads(t *testing.T) {
+	var requestCount atomic.Int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"DEVICE1"}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetLogger(&recordingLogger{})
+
+	const readers = 10
+	bodies := make([]string, readers)
+	var wg sync.WaitGroup
+	for i := range readers {
+		wg.Go(func() {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/orgDevices/DEVICE1", nil)
+			resp, err := c.doRequest(context.Background(), req)
+			if err != nil {
+				t.Errorf("reader %d: unexpected error: %v", i, err)
+				return
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(body)
+		})
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("expected concurrent identical reads to share 1 request, got %d", got)
+	}
+	for i, body := range bodies {
+		if body != `{"data":{"id":"DEVICE1"}}` {
+			t.Errorf("reader %d: unexpected body %q", i, body)
+		}
+	}
+}
+
+func TestDoRequest_DoesNotShareWrites(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/orgDeviceActivities", nil)
+			resp, err := c.doRequest(context.Background(), req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		})
+	}
+	wg.Wait()
+
+	if got := requestCount.Load(); got != 3 {
+		t.Errorf("expected every write to be sent, got %d requests", got)
+	}
+}
+
+func TestDoRequest_LimitsRequestsInFlight(t *testing.T) {
+	var running, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := running.Add(1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetMaxRequestsInFlight(2)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Go(func() {
+			req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/orgDevices/DEVICE%d", server.URL, i), nil)
+			resp, err := c.doRequest(context.Background(), req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		})
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", got)
+	}
+	if got := c.Stats().Requests; got != 10 {
+		t.Errorf("expected 10 requests, got %d", got)
+	}
+}
+
+func TestMaxRequestsInFlight_Default(t *testing.T) {
+	c := &Client{}
+	if got := c.MaxRequestsInFlight(); got != DefaultMaxRequestsInFlight {
+		t.Errorf("expected default %d, got %d", DefaultMaxRequestsInFlight, got)
+	}
+	c.SetMaxRequestsInFlight(0)
+	if got := c.MaxRequestsInFlight(); got != DefaultMaxRequestsInFlight {
+		t.Errorf("expected values below one to restore the default, got %d", got)
+	}
+}
+
+func TestRequestLimiter_SpacesRequests(t *testing.T) {
+	l := &requestLimiter{interval: time.Second}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, want := range []time.Duration{0, time.Second, 2 * time.Second} {
+		if got := l.reserve(now); got != want {
+			t.Errorf("reservation %d: expected wait %v, got %v", i, want, got)
+		}
+	}
+	if got := l.reserve(now.Add(time.Minute)); got != 0 {
+		t.Errorf("expected no wait once the limiter is idle, got %v", got)
+	}
+}
+
+func TestDoRequest_RequestsPerMinute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetRequestsPerMinute(1200)
+	if got := c.RequestsPerMinute(); got != 1200 {
+		t.Fatalf("expected 1200 requests per minute, got %d", got)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/orgDeviceActivities/%d", server.URL, i), nil)
+			resp, err := c.doRequest(context.Background(), req)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		})
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected 4 requests at 1200 per minute to take at least 150ms, took %v", elapsed)
+	}
+
+	c.SetRequestsPerMinute(0)
+	if got := c.RequestsPerMinute(); got != 0 {
+		t.Errorf("expected values below one to remove the limit, got %d", got)
+	}
+}
+
+func TestDoRequest_RateLimitPausesOtherRequests(t *testing.T) {
+	var limited atomic.Bool
+	rateLimited := make(chan struct{})
+	var otherSentAt atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/orgDevices/DEVICE1" && limited.CompareAndSwap(false, true) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			close(rateLimited)
+			return
+		}
+		if r.URL.Path == "/v1/orgDevices/DEVICE2" {
+			otherSentAt.Store(time.Now().UnixNano())
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/orgDevices/DEVICE1", nil)
+		resp, err := c.doRequest(context.Background(), req)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		_ = resp.Body.Close()
+	})
+
+	<-rateLimited
+	time.Sleep(20 * time.Millisecond)
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/orgDevices/DEVICE2", nil)
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	wg.Wait()
+
+	if waited := time.Unix(0, otherSentAt.Load()).Sub(start); waited < 500*time.Millisecond {
+		t.Errorf("expected the other request to wait for the rate-limit pause, sent after %v", waited)
+	}
+	if got := c.Stats().Requests; got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestDoRequest_RateLimitPauseFollowsClientClock(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.SetClock(clock)
+
+	done := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/orgDevices/DEVICE1", nil)
+		resp, err := c.doRequest(context.Background(), req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		done <- err
+	}()
+
+	clock.awaitWaiter(t)
+	if got := c.rateLimitPause(); got != 30*time.Second {
+		t.Errorf("expected a 30s pause on the client clock, got %v", got)
+	}
+	clock.Advance(10 * time.Second)
+	if got := c.rateLimitPause(); got != 20*time.Second {
+		t.Errorf("expected 20s of the pause to remain, got %v", got)
+	}
+	clock.Advance(20 * time.Second)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the retry to follow the client clock")
+	}
+	if got := c.rateLimitPause(); got > 0 {
+		t.Errorf("expected the pause to have ended, %v remains", got)
+	}
+	if got := requestCount.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestDoRequest_SharedReadRetriedWhenSenderCancels(t *testing.T) {
+	var requestCount atomic.Int32
+	firstArrived := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			close(firstArrived)
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/orgDevices/DEVICE1", nil)
+		if _, err := c.doRequest(ctx, req); err == nil {
+			t.Error("expected the cancelled read to fail")
+		}
+	})
+
+	<-firstArrived
+	result := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/orgDevices/DEVICE1", nil)
+		resp, err := c.doRequest(context.Background(), req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		result <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	if err := <-result; err != nil {
+		t.Fatalf("expected the waiting read to be sent again, got %v", err)
+	}
+	if got := requestCount.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
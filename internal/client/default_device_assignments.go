@@ -0,0 +1,116 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// DefaultDeviceAssignments maps each product family to the ID of the MDM server that new
+// devices of that family are assigned to by default. A family mapped to the empty string, or
+// absent, has no default server.
+type DefaultDeviceAssignments map[MdmServerProductFamily]string
+
+// MdmServerProductFamilyValues returns every product family a default server can be designated for.
+func MdmServerProductFamilyValues() []MdmServerProductFamily {
+	return []MdmServerProductFamily{
+		MdmServerProductFamilyAppleTV,
+		MdmServerProductFamilyVision,
+		MdmServerProductFamilyIPad,
+		MdmServerProductFamilyIPhone,
+		MdmServerProductFamilyIPod,
+		MdmServerProductFamilyMac,
+		MdmServerProductFamilyWatch,
+	}
+}
+
+// GetDefaultDeviceAssignments returns the default server designations held by the given MDM
+// servers. The API has no dedicated endpoint for default device assignment settings, so the
+// designations are derived from the defaultProductFamilies attribute of each server; families
+// whose default server is not among serverIDs are omitted.
+func (c *Client) GetDefaultDeviceAssignments(ctx context.Context, serverIDs []string) (DefaultDeviceAssignments, error) {
+	servers := make([]*MdmServer, len(serverIDs))
+	err := ForEachConcurrent(ctx, c.MaxConcurrency(), len(serverIDs), func(ctx context.Context, i int) error {
+		srv, err := c.GetDeviceManagementService(ctx, serverIDs[i], url.Values{})
+		if err != nil {
+			return fmt.Errorf("failed to read MDM server %s: %w", serverIDs[i], err)
+		}
+		servers[i] = srv
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make(DefaultDeviceAssignments)
+	for i, srv := range servers {
+		for _, family := range srv.Attributes.DefaultProductFamilies {
+			assignments[family] = serverIDs[i]
+		}
+	}
+	return assignments, nil
+}
+
+// ApplyDefaultDeviceAssignments moves the default server designations from current to desired.
+// A family can only be held by one server at a time, so servers losing families are reduced or
+// cleared first and servers gaining families are updated afterwards, with one PATCH per server.
+func (c *Client) ApplyDefaultDeviceAssignments(ctx context.Context, current, desired DefaultDeviceAssignments) error {
+	losing := make(map[string]bool)
+	gaining := make(map[string]bool)
+	for _, family := range MdmServerProductFamilyValues() {
+		oldServer, newServer := current[family], desired[family]
+		if oldServer != "" && oldServer != newServer {
+			losing[oldServer] = true
+		}
+		if newServer != "" && newServer != oldServer {
+			gaining[newServer] = true
+		}
+	}
+
+	for serverID := range losing {
+		if err := c.setDefaultProductFamilies(ctx, serverID, desired.familiesFor(serverID)); err != nil {
+			return fmt.Errorf("failed to remove default product families from MDM server %s: %w", serverID, err)
+		}
+	}
+
+	for serverID := range gaining {
+		if err := c.setDefaultProductFamilies(ctx, serverID, desired.familiesFor(serverID)); err != nil {
+			return fmt.Errorf("failed to add default product families to MDM server %s: %w", serverID, err)
+		}
+	}
+
+	return nil
+}
+
+// familiesFor returns the product families designated to serverID, in canonical order.
+func (a DefaultDeviceAssignments) familiesFor(serverID string) []MdmServerProductFamily {
+	var families []MdmServerProductFamily
+	for _, family := range MdmServerProductFamilyValues() {
+		if a[family] == serverID {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
+// setDefaultProductFamilies replaces the default product families of an MDM server with a
+// single PATCH. An empty slice clears all of them.
+func (c *Client) setDefaultProductFamilies(ctx context.Context, serverID string, families []MdmServerProductFamily) error {
+	if len(families) == 0 {
+		_, err := c.ClearDeviceManagementServiceDefaultFamilies(ctx, serverID)
+		return err
+	}
+	_, err := c.UpdateDeviceManagementService(ctx, MdmServerUpdateRequest{
+		Data: MdmServerUpdateRequestData{
+			Type: "mdmServers",
+			ID:   serverID,
+			Attributes: MdmServerUpdateAttributes{
+				DefaultProductFamilies: families,
+			},
+		},
+	})
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetDefaultDeviceAssignments(t *testing.T) {
+	families := map[string][]MdmServerProductFamily{
+		"srv-1": {"IPHONE", "IPAD"},
+		"srv-2": {"MAC"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/mdmServers/")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, MdmServerResponse{
+			Data: MdmServer{
+				Type:       "mdmServers",
+				ID:         id,
+				Attributes: MdmServerAttribute{DefaultProductFamilies: families[id]},
+			},
+		}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	got, err := c.GetDefaultDeviceAssignments(context.Background(), []string{"srv-1", "srv-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := DefaultDeviceAssignments{"IPHONE": "srv-1", "IPAD": "srv-1", "MAC": "srv-2"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d assignments, got %v", len(want), got)
+	}
+	for family, serverID := range want {
+		if got[family] != serverID {
+			t.Errorf("expected %s -> %s, got %q", family, serverID, got[family])
+		}
+	}
+}
+
+func TestApplyDefaultDeviceAssignments_ReducesBeforeGaining(t *testing.T) {
+	type patch struct {
+		id       string
+		families []MdmServerProductFamily
+	}
+	var mu sync.Mutex
+	var patches []patch
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		var body MdmServerUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		mu.Lock()
+		patches = append(patches, patch{id: body.Data.ID, families: body.Data.Attributes.DefaultProductFamilies})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, MdmServerResponse{Data: MdmServer{Type: "mdmServers", ID: body.Data.ID}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	current := DefaultDeviceAssignments{"IPHONE": "srv-1", "MAC": "srv-1"}
+	desired := DefaultDeviceAssignments{"IPHONE": "srv-2", "MAC": "srv-1", "IPAD": ""}
+	if err := c.ApplyDefaultDeviceAssignments(context.Background(), current, desired); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(patches) != 2 {
+		t.Fatalf("expected 2 PATCH requests, got %d", len(patches))
+	}
+	if patches[0].id != "srv-1" || !slices.Equal(patches[0].families, []MdmServerProductFamily{"MAC"}) {
+		t.Errorf("expected srv-1 reduced to [MAC] first, got %s %v", patches[0].id, patches[0].families)
+	}
+	if patches[1].id != "srv-2" || !slices.Equal(patches[1].families, []MdmServerProductFamily{"IPHONE"}) {
+		t.Errorf("expected srv-2 set to [IPHONE] second, got %s %v", patches[1].id, patches[1].families)
+	}
+}
+
+func TestApplyDefaultDeviceAssignments_ClearsServer(t *testing.T) {
+	var families []MdmServerProductFamily
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body MdmServerUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		families = body.Data.Attributes.DefaultProductFamilies
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, MdmServerResponse{Data: MdmServer{Type: "mdmServers", ID: body.Data.ID}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	current := DefaultDeviceAssignments{"WATCH": "srv-1"}
+	desired := DefaultDeviceAssignments{"WATCH": ""}
+	if err := c.ApplyDefaultDeviceAssignments(context.Background(), current, desired); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 PATCH request, got %d", requests)
+	}
+	if len(families) != 0 {
+		t.Errorf("expected no default product families, got %v", families)
+	}
+}
+
+func TestApplyDefaultDeviceAssignments_NoChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	assignments := DefaultDeviceAssignments{"IPAD": "srv-1"}
+	if err := c.ApplyDefaultDeviceAssignments(context.Background(), assignments, assignments); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
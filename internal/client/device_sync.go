@@ -0,0 +1,227 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// deviceCheckpointVersion is bumped whenever the checkpoint layout changes so that
+	// checkpoints written by older provider versions trigger a full sync.
+	deviceCheckpointVersion = 1
+
+	// incrementalSyncMaxChanges is the number of changed devices above which a full
+	// listing is cheaper than fetching each changed device individually.
+	incrementalSyncMaxChanges = 100
+)
+
+// Device sync modes reported in DeviceSyncResult. DeviceSyncModeOffline is reported by data
+// sources that read the cached inventory because the API could not be reached.
+const (
+	DeviceSyncModeFull        = "full"
+	DeviceSyncModeIncremental = "incremental"
+	DeviceSyncModeOffline     = "offline"
+)
+
+// DeviceSyncResult describes how SyncOrgDevices obtained the device inventory.
+type DeviceSyncResult struct {
+	Mode           string
+	FallbackReason string
+	ChangedCount   int
+	RemovedCount   int
+	Checkpoint     string
+}
+
+// deviceCheckpoint is the on-disk record of the last successful device sync.
+type deviceCheckpoint struct {
+	Version  int         `json:"version"`
+	BaseURL  string      `json:"base_url"`
+	SyncedAt time.Time   `json:"synced_at"`
+	Devices  []OrgDevice `json:"devices"`
+}
+
+// SyncOrgDevices returns all organization devices, reusing the checkpoint written by the
+// previous successful sync where possible. The API offers no change feed, so an
+// incremental sync lists only device IDs and update timestamps and then fetches full
+// records for new or updated devices. A full sync is performed whenever the checkpoint is
+// missing or unusable, the listing omits update timestamps, or too many devices changed.
+func (c *Client) SyncOrgDevices(ctx context.Context) ([]OrgDevice, DeviceSyncResult, error) {
+	return c.syncOrgDevices(ctx, c.deviceCheckpointPath())
+}
+
+// deviceCheckpointPath returns the checkpoint file path for the client's credentials and
+// API base URL, or "" when no cache directory is available.
+func (c *Client) deviceCheckpointPath() string {
+	if c.tokenSource == nil {
+		return ""
+	}
+	urlHash := sha256.Sum256([]byte(c.baseURL))
+	name := fmt.Sprintf("devices_%s_%s.json", c.tokenSource.getConfigHash(), hex.EncodeToString(urlHash[:])[:8])
+	return filepath.Join(c.tokenSource.cacheDirectory(), name)
+}
+
+// syncOrgDevices implements SyncOrgDevices against an explicit checkpoint path.
+func (c *Client) syncOrgDevices(ctx context.Context, checkpointPath string) ([]OrgDevice, DeviceSyncResult, error) {
+	result := DeviceSyncResult{Mode: DeviceSyncModeFull, Checkpoint: checkpointPath}
+	if checkpointPath == "" {
+		result.FallbackReason = "no cache directory is available"
+		devices, err := c.listOrgDevices(ctx, nil)
+		return devices, result, err
+	}
+
+	checkpoint, reason := c.loadDeviceCheckpoint(checkpointPath)
+	if checkpoint != nil {
+		devices, changed, removed, incrementalReason, err := c.incrementalOrgDevices(ctx, checkpoint)
+		if err != nil {
+			return nil, result, err
+		}
+		if incrementalReason == "" {
+			result.Mode = DeviceSyncModeIncremental
+			result.ChangedCount = changed
+			result.RemovedCount = removed
+			if err := saveDeviceCheckpoint(checkpointPath, c.baseURL, devices); err != nil {
+				result.FallbackReason = fmt.Sprintf("checkpoint not saved: %s", err)
+			}
+			return devices, result, nil
+		}
+		reason = incrementalReason
+	}
+
+	result.FallbackReason = reason
+	devices, err := c.listOrgDevices(ctx, nil)
+	if err != nil {
+		return nil, result, err
+	}
+	result.ChangedCount = len(devices)
+	if err := saveDeviceCheckpoint(checkpointPath, c.baseURL, devices); err != nil {
+		result.FallbackReason = fmt.Sprintf("%s; checkpoint not saved: %s", reason, err)
+	}
+	return devices, result, nil
+}
+
+// loadDeviceCheckpoint reads a checkpoint, returning nil and the reason when it cannot be used.
+func (c *Client) loadDeviceCheckpoint(path string) (*deviceCheckpoint, string) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "no previous checkpoint"
+	}
+	if err != nil {
+		return nil, fmt.Sprintf("checkpoint unreadable: %s", err)
+	}
+
+	var checkpoint deviceCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Sprintf("checkpoint corrupt: %s", err)
+	}
+	if checkpoint.Version != deviceCheckpointVersion {
+		return nil, "checkpoint written by a different provider version"
+	}
+	if checkpoint.BaseURL != c.baseURL {
+		return nil, "checkpoint belongs to a different API scope"
+	}
+	return &checkpoint, ""
+}
+
+// incrementalOrgDevices lists device update timestamps and refetches only devices that are
+// new or changed since the checkpoint. A non-empty reason means a full sync is required.
+func (c *Client) incrementalOrgDevices(ctx context.Context, checkpoint *deviceCheckpoint) (devices []OrgDevice, changed, removed int, reason string, err error) {
+	index, err := c.GetOrgDevices(ctx, url.Values{"fields[orgDevices]": {"updatedDateTime"}})
+	if err != nil {
+		return nil, 0, 0, "", err
+	}
+
+	known := make(map[string]OrgDevice, len(checkpoint.Devices))
+	for _, device := range checkpoint.Devices {
+		known[device.ID] = device
+	}
+
+	var changedIDs []string
+	for _, entry := range index {
+		if entry.Attributes.UpdatedDateTime == "" {
+			return nil, 0, 0, "device listing did not include update timestamps", nil
+		}
+		previous, ok := known[entry.ID]
+		if !ok || previous.Attributes.UpdatedDateTime != entry.Attributes.UpdatedDateTime {
+			changedIDs = append(changedIDs, entry.ID)
+		}
+	}
+	if len(changedIDs) > incrementalSyncMaxChanges {
+		return nil, 0, 0, fmt.Sprintf("%d devices changed, more than the incremental limit of %d", len(changedIDs), incrementalSyncMaxChanges), nil
+	}
+
+	refreshed := make([]OrgDevice, len(changedIDs))
+	err = ForEachConcurrent(ctx, c.MaxConcurrency(), len(changedIDs), func(ctx context.Context, i int) error {
+		device, err := c.GetOrgDevice(ctx, changedIDs[i], nil)
+		if err != nil {
+			return fmt.Errorf("failed to read changed device %s: %w", changedIDs[i], err)
+		}
+		refreshed[i] = *device
+		return nil
+	})
+	if err != nil {
+		return nil, 0, 0, "", err
+	}
+	for _, device := range refreshed {
+		known[device.ID] = device
+	}
+
+	devices = make([]OrgDevice, 0, len(index))
+	listed := make(map[string]struct{}, len(index))
+	for _, entry := range index {
+		devices = append(devices, known[entry.ID])
+		listed[entry.ID] = struct{}{}
+	}
+	for _, device := range checkpoint.Devices {
+		if _, ok := listed[device.ID]; !ok {
+			removed++
+		}
+	}
+	return devices, len(changedIDs), removed, "", nil
+}
+
+// saveDeviceCheckpoint atomically writes the device checkpoint.
+func saveDeviceCheckpoint(path, baseURL string, devices []OrgDevice) error {
+	data, err := json.Marshal(deviceCheckpoint{
+		Version:  deviceCheckpointVersion,
+		BaseURL:  baseURL,
+		SyncedAt: time.Now().UTC(),
+		Devices:  devices,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal device checkpoint: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".devices-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create device checkpoint: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write device checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write device checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write device checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeInventory serves /v1/orgDevices listings and single-device reads from a mutable map.
+type fakeInventory struct {
+	mu          sync.Mutex
+	devices     []OrgDevice
+	fullLists   int
+	indexLists  int
+	deviceReads []string
+	omitUpdated bool
+}
+
+func (f *fakeInventory) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		if id, ok := strings.CutPrefix(r.URL.Path, "/v1/orgDevices/"); ok {
+			f.deviceReads = append(f.deviceReads, id)
+			for _, device := range f.devices {
+				if device.ID == id {
+					_, _ = w.Write(mustMarshalJSON(t, OrgDeviceResponse{Data: device}))
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var data []OrgDevice
+		if r.URL.Query().Get("fields[orgDevices]") == "updatedDateTime" {
+			f.indexLists++
+			for _, device := range f.devices {
+				entry := OrgDevice{Type: device.Type, ID: device.ID}
+				if !f.omitUpdated {
+					entry.Attributes.UpdatedDateTime = device.Attributes.UpdatedDateTime
+				}
+				data = append(data, entry)
+			}
+		} else {
+			f.fullLists++
+			data = f.devices
+		}
+		_, _ = w.Write(mustMarshalJSON(t, OrgDevicesResponse{Data: data}))
+	}
+}
+
+func syncTestDevice(id, updated, color string) OrgDevice {
+	return OrgDevice{
+		Type: "orgDevices",
+		ID:   id,
+		Attributes: DeviceAttribute{
+			SerialNumber:    id,
+			UpdatedDateTime: updated,
+			Color:           color,
+		},
+	}
+}
+
+func TestSyncOrgDevices_FullThenIncremental(t *testing.T) {
+	inventory := &fakeInventory{devices: []OrgDevice{
+		syncTestDevice("DEV1", "2024-01-01T00:00:00Z", "Silver"),
+		syncTestDevice("DEV2", "2024-01-01T00:00:00Z", "Silver"),
+		syncTestDevice("DEV3", "2024-01-01T00:00:00Z", "Silver"),
+	}}
+	server := httptest.NewServer(inventory.handler(t))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	checkpoint := filepath.Join(t.TempDir(), "devices.json")
+
+	devices, result, err := c.syncOrgDevices(context.Background(), checkpoint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Mode != DeviceSyncModeFull || result.FallbackReason != "no previous checkpoint" {
+		t.Fatalf("expected initial full sync, got %+v", result)
+	}
+	if len(devices) != 3 {
+		t.Fatalf("expected 3 devices, got %d", len(devices))
+	}
+
+	inventory.mu.Lock()
+	inventory.devices = []OrgDevice{
+		syncTestDevice("DEV1", "2024-01-01T00:00:00Z", "Silver"),
+		syncTestDevice("DEV2", "2024-02-01T00:00:00Z", "Gold"),
+		syncTestDevice("DEV4", "2024-02-01T00:00:00Z", "Blue"),
+	}
+	inventory.mu.Unlock()
+
+	devices, result, err = c.syncOrgDevices(context.Background(), checkpoint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Mode != DeviceSyncModeIncremental {
+		t.Fatalf("expected incremental sync, got %+v", result)
+	}
+	if result.ChangedCount != 2 || result.RemovedCount != 1 {
+		t.Errorf("expected 2 changed and 1 removed, got %+v", result)
+	}
+	if inventory.fullLists != 1 {
+		t.Errorf("expected a single full listing, got %d", inventory.fullLists)
+	}
+	if len(inventory.deviceReads) != 2 {
+		t.Errorf("expected 2 single-device reads, got %v", inventory.deviceReads)
+	}
+
+	if len(devices) != 3 {
+		t.Fatalf("expected 3 devices, got %d", len(devices))
+	}
+	if devices[0].ID != "DEV1" || devices[0].Attributes.Color != "Silver" {
+		t.Errorf("expected unchanged DEV1 from checkpoint, got %+v", devices[0])
+	}
+	if devices[1].ID != "DEV2" || devices[1].Attributes.Color != "Gold" {
+		t.Errorf("expected refreshed DEV2, got %+v", devices[1])
+	}
+	if devices[2].ID != "DEV4" || devices[2].Attributes.Color != "Blue" {
+		t.Errorf("expected new DEV4, got %+v", devices[2])
+	}
+}
+
+func TestSyncOrgDevices_FallsBackWithoutTimestamps(t *testing.T) {
+	inventory := &fakeInventory{devices: []OrgDevice{
+		syncTestDevice("DEV1", "2024-01-01T00:00:00Z", "Silver"),
+	}}
+	server := httptest.NewServer(inventory.handler(t))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	checkpoint := filepath.Join(t.TempDir(), "devices.json")
+	if _, _, err := c.syncOrgDevices(context.Background(), checkpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inventory.mu.Lock()
+	inventory.omitUpdated = true
+	inventory.mu.Unlock()
+	_, result, err := c.syncOrgDevices(context.Background(), checkpoint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Mode != DeviceSyncModeFull || !strings.Contains(result.FallbackReason, "update timestamps") {
+		t.Errorf("expected full sync fallback for missing timestamps, got %+v", result)
+	}
+}
+
+func TestSyncOrgDevices_FallsBackWhenTooManyChanged(t *testing.T) {
+	inventory := &fakeInventory{}
+	for i := range incrementalSyncMaxChanges + 1 {
+		inventory.devices = append(inventory.devices, syncTestDevice(strings.Repeat("D", i+1), "2024-01-01T00:00:00Z", "Silver"))
+	}
+	server := httptest.NewServer(inventory.handler(t))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	checkpoint := filepath.Join(t.TempDir(), "devices.json")
+	if err := saveDeviceCheckpoint(checkpoint, c.baseURL, nil); err != nil {
+		t.Fatalf("failed to seed checkpoint: %v", err)
+	}
+
+	_, result, err := c.syncOrgDevices(context.Background(), checkpoint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Mode != DeviceSyncModeFull || !strings.Contains(result.FallbackReason, "incremental limit") {
+		t.Errorf("expected full sync fallback for too many changes, got %+v", result)
+	}
+	if len(inventory.deviceReads) != 0 {
+		t.Errorf("expected no single-device reads, got %d", len(inventory.deviceReads))
+	}
+}
+
+func TestLoadDeviceCheckpoint_Unusable(t *testing.T) {
+	c := &Client{baseURL: "https://api-business.apple.com"}
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		setup   func(path string)
+		wantMsg string
+	}{
+		{
+			name:    "missing",
+			setup:   func(path string) {},
+			wantMsg: "no previous checkpoint",
+		},
+		{
+			name: "corrupt",
+			setup: func(path string) {
+				_ = os.WriteFile(path, []byte("{"), 0600)
+			},
+			wantMsg: "checkpoint corrupt",
+		},
+		{
+			name: "other_scope",
+			setup: func(path string) {
+				_ = saveDeviceCheckpoint(path, "https://api-school.apple.com", nil)
+			},
+			wantMsg: "different API scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".json")
+			tt.setup(path)
+			checkpoint, reason := c.loadDeviceCheckpoint(path)
+			if checkpoint != nil {
+				t.Fatal("expected checkpoint to be rejected")
+			}
+			if !strings.Contains(reason, tt.wantMsg) {
+				t.Errorf("expected reason containing %q, got %q", tt.wantMsg, reason)
+			}
+		})
+	}
+}
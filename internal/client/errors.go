@@ -0,0 +1,108 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ErrorCodeNotFound is the API error code for a resource that does not exist.
+	ErrorCodeNotFound = "NOT_FOUND"
+	// ErrorCodeParameterError is the API error code for an invalid query parameter.
+	ErrorCodeParameterError = "PARAMETER_ERROR"
+)
+
+// APIError is an unsuccessful API response. When the response body holds a JSON error
+// document, the fields describe its first error; otherwise Detail holds the start of the body.
+type APIError struct {
+	StatusCode      int
+	ID              string
+	Code            string
+	Title           string
+	Detail          string
+	SourcePointer   string
+	SourceParameter string
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Code != "" || e.Title != "":
+		return fmt.Sprintf("%s: %s (code: %s, status: %d, id: %s)", e.Title, e.Detail, e.Code, e.StatusCode, e.ID)
+	case e.Detail != "":
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Detail)
+	default:
+		return fmt.Sprintf("unknown error occurred with status %d", e.StatusCode)
+	}
+}
+
+// Is reports whether e matches target, an *APIError whose non-zero StatusCode and Code must
+// both match, so that errors.Is(err, &APIError{Code: ErrorCodeNotFound}) matches every not-found
+// response. A code also matches its dot-separated sub-codes.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	if t.StatusCode != 0 && t.StatusCode != e.StatusCode {
+		return false
+	}
+	return t.Code == "" || e.HasCode(t.Code)
+}
+
+// HasCode reports whether the error code is code or one of its dot-separated sub-codes, so that
+// "PARAMETER_ERROR" matches "PARAMETER_ERROR.INVALID".
+func (e *APIError) HasCode(code string) bool {
+	return e.Code == code || strings.HasPrefix(e.Code, code+".")
+}
+
+// newAPIError builds the APIError for an unsuccessful response with the given status and body.
+func newAPIError(statusCode int, errResp ErrorResponse) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+	if len(errResp.Errors) == 0 {
+		return apiErr
+	}
+	e := errResp.Errors[0]
+	apiErr.ID = e.ID
+	apiErr.Code = e.Code
+	apiErr.Title = e.Title
+	apiErr.Detail = e.Detail
+	if e.Source != nil {
+		apiErr.SourcePointer = e.Source.Pointer
+		apiErr.SourceParameter = e.Source.Parameter
+	}
+	return apiErr
+}
+
+// AsAPIError returns the APIError in err's chain, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// HasErrorCode reports whether err is an APIError with code or one of its dot-separated
+// sub-codes.
+func HasErrorCode(err error, code string) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.HasCode(code)
+}
+
+// IsNotFound reports whether err is an API response saying the requested resource does not
+// exist, either with the NOT_FOUND error code or with an HTTP 404 status.
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.HasCode(ErrorCodeNotFound) || apiErr.StatusCode == http.StatusNotFound)
+}
+
+// IsParameterError reports whether err is an API response rejecting a query parameter, such as
+// an unsupported filter.
+func IsParameterError(err error) bool {
+	return HasErrorCode(err, ErrorCodeParameterError)
+}
@@ -0,0 +1,101 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleErrorResponse_APIError(t *testing.T) {
+	body := `{"errors":[{"id":"err-1","status":"400","code":"PARAMETER_ERROR.INVALID","title":"Invalid Parameter","detail":"bad filter","source":{"parameter":"filter[serialNumber]"}}]}`
+	err := (&Client{}).handleErrorResponse(&http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	})
+
+	apiErr, ok := AsAPIError(fmt.Errorf("failed to read devices: %w", err))
+	if !ok {
+		t.Fatalf("expected an APIError, got %T", err)
+	}
+	want := APIError{
+		StatusCode:      http.StatusBadRequest,
+		ID:              "err-1",
+		Code:            "PARAMETER_ERROR.INVALID",
+		Title:           "Invalid Parameter",
+		Detail:          "bad filter",
+		SourceParameter: "filter[serialNumber]",
+	}
+	if *apiErr != want {
+		t.Errorf("expected %+v, got %+v", want, *apiErr)
+	}
+	if !IsParameterError(err) || IsNotFound(err) {
+		t.Errorf("expected only a parameter error, got %v", err)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"})
+
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{"code", &APIError{Code: ErrorCodeNotFound}, true},
+		{"status", &APIError{StatusCode: http.StatusNotFound}, true},
+		{"code and status", &APIError{StatusCode: http.StatusNotFound, Code: ErrorCodeNotFound}, true},
+		{"other code", &APIError{Code: ErrorCodeParameterError}, false},
+		{"other status", &APIError{StatusCode: http.StatusConflict, Code: ErrorCodeNotFound}, false},
+		{"code prefix", &APIError{Code: "NOT"}, false},
+		{"other error", ErrReadOnly, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("expected errors.Is to be %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"not found code", &APIError{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}, true},
+		{"html 404", &APIError{StatusCode: http.StatusNotFound, Detail: "<html></html>"}, true},
+		{"other code", &APIError{StatusCode: http.StatusForbidden, Code: "FORBIDDEN"}, false},
+		{"device not found in message", errors.New("DEVICE_NOT_FOUND"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetOrgDevice_NotFoundIsTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"id":"e1","status":"404","code":"NOT_FOUND","title":"Not Found","detail":"Resource not found"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server).GetOrgDevice(context.Background(), "SN001", nil)
+	if !IsNotFound(err) {
+		t.Errorf("expected a not-found APIError, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Features holds the behavior changes gated by the provider's features block, so that
+// improvements that change behavior can ship without changing what existing configurations do.
+type Features struct {
+	DeleteUnassignsDevices   bool
+	StrictDecoding           bool
+	AuthoritativeAssignments bool
+}
+
+// DefaultFeatures returns the features in effect when the features block does not set them,
+// which preserve the provider's established behavior: deleting a device management service
+// unassigns its devices first, responses may contain fields the provider does not know, and a
+// device management service's device_ids is authoritative.
+func DefaultFeatures() Features {
+	return Features{
+		DeleteUnassignsDevices:   true,
+		AuthoritativeAssignments: true,
+	}
+}
+
+// SetFeatures sets the features in effect for the client.
+func (c *Client) SetFeatures(features Features) {
+	c.features = &features
+}
+
+// Features returns the features in effect for the client, or DefaultFeatures when none have
+// been set.
+func (c *Client) Features() Features {
+	if c == nil || c.features == nil {
+		return DefaultFeatures()
+	}
+	return *c.features
+}
+
+// decodeJSON decodes the JSON document in body into v. With strict decoding enabled, a field
+// that v does not declare fails the decoding, so that API changes are reported instead of
+// silently ignored.
+func (c *Client) decodeJSON(body io.Reader, v any) error {
+	decoder := json.NewDecoder(body)
+	if c.Features().StrictDecoding {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder.Decode(v)
+}
@@ -0,0 +1,48 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFeatures_Defaults(t *testing.T) {
+	var unset *Client
+	if got := unset.Features(); got != DefaultFeatures() {
+		t.Errorf("expected a nil client to use the default features, got %+v", got)
+	}
+
+	c := &Client{}
+	if got := c.Features(); !got.DeleteUnassignsDevices || !got.AuthoritativeAssignments || got.StrictDecoding {
+		t.Errorf("expected the established behavior by default, got %+v", got)
+	}
+
+	c.SetFeatures(Features{StrictDecoding: true})
+	if got := c.Features(); got.DeleteUnassignsDevices || !got.StrictDecoding {
+		t.Errorf("expected the features that were set, got %+v", got)
+	}
+}
+
+func TestFeatures_StrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"type":"orgDevices","id":"SN001","attributes":{"serialNumber":"SN001","newField":"x"}},"links":{"self":"x"}}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	if _, err := c.GetOrgDevice(context.Background(), "SN001", nil); err != nil {
+		t.Fatalf("expected unknown fields to be ignored by default, got %v", err)
+	}
+
+	c.SetFeatures(Features{StrictDecoding: true})
+	_, err := c.GetOrgDevice(context.Background(), "SN001", nil)
+	if err == nil || !strings.Contains(err.Error(), "newField") {
+		t.Errorf("expected strict decoding to reject the unknown field, got %v", err)
+	}
+}
@@ -5,12 +5,9 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // MdmDeviceResponse represents a response that contains a list of Apple devices
@@ -80,59 +77,7 @@ type MdmDeviceDetailAttribute struct {
 // GetMdmDevices retrieves all Apple devices enrolled in a device management
 // service.
 func (c *Client) GetMdmDevices(ctx context.Context, queryParams url.Values) ([]MdmDevice, error) {
-	var allDevices []MdmDevice
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/mdmDevices", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response MdmDeviceResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allDevices = append(allDevices, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allDevices, nil
+	return collectPages[MdmDevice](ctx, c, "/v1/mdmDevices", queryParams, defaultPageLimit)
 }
 
 // GetMdmDeviceDetail retrieves detailed information about a specific Apple
@@ -161,7 +106,7 @@ func (c *Client) GetMdmDeviceDetail(ctx context.Context, id string, queryParams
 	}
 
 	var response MdmDeviceDetailResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -8,10 +8,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
+	"slices"
 )
 
 // MdmServerStatus represents the status of an MDM server.
@@ -23,6 +22,18 @@ const (
 	MdmServerStatusInactive MdmServerStatus = "INACTIVE"
 )
 
+// Device management service types reported in the serverType attribute.
+const (
+	MdmServerTypeMdm               = "MDM"
+	MdmServerTypeAppleConfigurator = "APPLE_CONFIGURATOR"
+	MdmServerTypeAppleMdm          = "APPLE_MDM"
+)
+
+// MdmServerTypeValues returns every documented device management service type.
+func MdmServerTypeValues() []string {
+	return []string{MdmServerTypeMdm, MdmServerTypeAppleConfigurator, MdmServerTypeAppleMdm}
+}
+
 // MdmServerProductFamily represents a product family for an MDM server.
 type MdmServerProductFamily string
 
@@ -62,6 +73,7 @@ type MdmServer struct {
 	ID            string                 `json:"id"`
 	Attributes    MdmServerAttribute     `json:"attributes"`
 	Relationships MdmServerRelationships `json:"relationships"`
+	Links         ResourceLinks          `json:"links"`
 }
 
 // MdmServerAttribute represents attributes that describe a device management service resource
@@ -142,138 +154,44 @@ type MdmServerUpdateAttributes struct {
 }
 
 // GetDeviceManagementServices retrieves all MDM servers configured in the organization.
+// Concurrent calls with the same query share one read, and its result is reused for a few
+// seconds so that resources refreshing together do not each list every server. Any request
+// that changes data discards remembered results.
 func (c *Client) GetDeviceManagementServices(ctx context.Context, queryParams url.Values) ([]MdmServer, error) {
-	var allServers []MdmServer
-	nextCursor := ""
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(1000))
-		params.Set("fields[mdmServers]", mdmServersFields)
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/mdmServers", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		req.URL.RawQuery = params.Encode()
-
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response MdmServersResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allServers = append(allServers, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allServers, nil
+	return c.listDeviceManagementServices(ctx, queryParams)
 }
 
 // GetDeviceManagementServiceSerialNumbers retrieves all device serial numbers assigned to a specific MDM server identified by serverID.
 func (c *Client) GetDeviceManagementServiceSerialNumbers(ctx context.Context, serverID string) ([]string, error) {
-	var allSerialNumbers []string
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/mdmServers/%s/relationships/devices", c.baseURL, serverID), nil)
-		if err != nil {
-			return nil, err
-		}
-
-		q := req.URL.Query()
-		q.Add("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			q.Add("cursor", nextCursor)
-		}
-		req.URL.RawQuery = q.Encode()
-
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response MdmServerDevicesLinkagesResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			for _, device := range response.Data {
-				if device.Type == "orgDevices" {
-					allSerialNumbers = append(allSerialNumbers, device.ID)
-				}
-			}
-
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allSerialNumbers, nil
+	return collectLinkageIDs(ctx, c, fmt.Sprintf("/v1/mdmServers/%s/relationships/devices", serverID), "orgDevices")
 }
 
-// GetDeviceManagementService retrieves a single MDM server by ID.
+// GetDeviceManagementService retrieves a single MDM server by ID. When the API refuses to read
+// the server on its own, with 403 Forbidden or 405 Method Not Allowed, the server is looked up
+// in the list of every server instead. Like GetDeviceManagementServices, the result is reused
+// for a few seconds and discarded by any request that changes data.
 func (c *Client) GetDeviceManagementService(ctx context.Context, id string, queryParams url.Values) (*MdmServer, error) {
-	if queryParams == nil {
-		queryParams = url.Values{}
+	queryParams = withMdmServersFields(queryParams)
+	key := id + "?" + queryParams.Encode()
+	if servers, ok := c.serverLists.lookup(key, c.Clock().Now()); ok {
+		srv := servers[0]
+		return &srv, nil
 	}
-	queryParams.Set("fields[mdmServers]", mdmServersFields)
 
-	baseURL := fmt.Sprintf("%s/v1/mdmServers/%s", c.baseURL, id)
-	if len(queryParams) > 0 {
-		baseURL += "?" + queryParams.Encode()
+	srv, err := c.getDeviceManagementService(ctx, id, queryParams)
+	if apiErr, ok := AsAPIError(err); ok && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusMethodNotAllowed) {
+		srv, err = c.findDeviceManagementService(ctx, id, queryParams)
 	}
+	if err != nil {
+		return nil, err
+	}
+	c.serverLists.store(key, []MdmServer{*srv}, c.Clock().Now())
+	return srv, nil
+}
+
+// getDeviceManagementService reads a single MDM server from its own endpoint.
+func (c *Client) getDeviceManagementService(ctx context.Context, id string, queryParams url.Values) (*MdmServer, error) {
+	baseURL := fmt.Sprintf("%s/v1/mdmServers/%s?%s", c.baseURL, id, queryParams.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
 	if err != nil {
@@ -292,13 +210,33 @@ func (c *Client) GetDeviceManagementService(ctx context.Context, id string, quer
 	}
 
 	var response MdmServerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
 	return &response.Data, nil
 }
 
+// findDeviceManagementService looks up a single MDM server in the list of every server, and
+// reports a server that is not listed as not found.
+func (c *Client) findDeviceManagementService(ctx context.Context, id string, queryParams url.Values) (*MdmServer, error) {
+	servers, err := c.listDeviceManagementServices(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		if servers[i].ID == id {
+			return &servers[i], nil
+		}
+	}
+	return nil, &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       ErrorCodeNotFound,
+		Title:      "The specified resource does not exist",
+		Detail:     fmt.Sprintf("There is no MDM server with ID %s.", id),
+	}
+}
+
 // CreateDeviceManagementService creates a new device management service.
 func (c *Client) CreateDeviceManagementService(ctx context.Context, request MdmServerCreateRequest) (*MdmServer, error) {
 	jsonData, err := json.Marshal(request)
@@ -325,7 +263,7 @@ func (c *Client) CreateDeviceManagementService(ctx context.Context, request MdmS
 	}
 
 	var response MdmServerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -358,7 +296,7 @@ func (c *Client) UpdateDeviceManagementService(ctx context.Context, request MdmS
 	}
 
 	var response MdmServerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -402,7 +340,7 @@ func (c *Client) ClearDeviceManagementServiceDefaultFamilies(ctx context.Context
 	}
 
 	var response MdmServerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -430,3 +368,13 @@ func (c *Client) DeleteDeviceManagementService(ctx context.Context, id string) e
 
 	return nil
 }
+
+// withMdmServersFields returns a copy of queryParams requesting the full MDM server field set.
+func withMdmServersFields(queryParams url.Values) url.Values {
+	params := make(url.Values, len(queryParams)+1)
+	for key, values := range queryParams {
+		params[key] = slices.Clone(values)
+	}
+	params.Set("fields[mdmServers]", mdmServersFields)
+	return params
+}
@@ -5,8 +5,10 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -337,6 +339,73 @@ func TestGetDeviceManagementService_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetDeviceManagementService_FallsBackToList(t *testing.T) {
+	for _, status := range []int{http.StatusForbidden, http.StatusMethodNotAllowed} {
+		t.Run(fmt.Sprint(status), func(t *testing.T) {
+			var listed atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path != "/v1/mdmServers" {
+					w.WriteHeader(status)
+					_, _ = w.Write([]byte(`{"errors":[{"status":"` + fmt.Sprint(status) + `","code":"FORBIDDEN","title":"Refused"}]}`))
+					return
+				}
+				listed.Add(1)
+				_, _ = w.Write(mustMarshalJSON(t, MdmServersResponse{
+					Data: []MdmServer{
+						{Type: "mdmServers", ID: "srv-1", Attributes: MdmServerAttribute{ServerName: "Jamf Pro"}},
+						{Type: "mdmServers", ID: "srv-2", Attributes: MdmServerAttribute{ServerName: "Intune"}},
+					},
+					Meta: Meta{Paging: Paging{Limit: 100}},
+				}))
+			}))
+			defer server.Close()
+
+			c := newTestClient(t, server)
+			srv, err := c.GetDeviceManagementService(context.Background(), "srv-2", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if srv.Attributes.ServerName != "Intune" {
+				t.Errorf("expected 'Intune', got %s", srv.Attributes.ServerName)
+			}
+
+			_, err = c.GetDeviceManagementService(context.Background(), "missing", nil)
+			if !IsNotFound(err) {
+				t.Errorf("expected a server missing from the list to be not found, got %v", err)
+			}
+			if got := listed.Load(); got != 1 {
+				t.Errorf("expected the list to be read once, got %d", got)
+			}
+		})
+	}
+}
+
+func TestGetDeviceManagementService_ReusesRecentRead(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, MdmServerResponse{Data: MdmServer{Type: "mdmServers", ID: "srv-1"}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	for range 3 {
+		srv, err := c.GetDeviceManagementService(context.Background(), "srv-1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if srv.ID != "srv-1" {
+			t.Fatalf("expected callers not to share the remembered server, got ID %q", srv.ID)
+		}
+		srv.ID = "changed by caller"
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected a recent read to be reused, got %d requests", got)
+	}
+}
+
 func TestCreateDeviceManagementService_Success(t *testing.T) {
 	disown := true
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -532,3 +601,67 @@ func TestDeleteDeviceManagementService_NotFound(t *testing.T) {
 		t.Errorf("expected 'Not Found' in error, got %q", err.Error())
 	}
 }
+
+func TestGetDeviceManagementServices_QueryParamsPersistAcrossPages(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := requestCount.Add(1)
+		q := r.URL.Query()
+
+		if got := q["filter[serverType]"]; len(got) != 1 || got[0] != "MDM" {
+			t.Errorf("page %d: expected filter[serverType]=MDM, got %v", count, got)
+		}
+		if q.Get("fields[mdmServers]") != mdmServersFields {
+			t.Errorf("page %d: expected full field set, got %q", count, q.Get("fields[mdmServers]"))
+		}
+		if got := q["limit"]; len(got) != 1 {
+			t.Errorf("page %d: expected a single limit parameter, got %v", count, got)
+		}
+
+		resp := MdmServersResponse{
+			Data: []MdmServer{{Type: "mdmServers", ID: fmt.Sprintf("server-%d", count)}},
+		}
+		if count == 1 {
+			resp.Meta.Paging.NextCursor = "page2"
+		} else if q.Get("cursor") != "page2" {
+			t.Errorf("expected cursor=page2 on page 2, got %q", q.Get("cursor"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	params := url.Values{"filter[serverType]": {"MDM"}}
+	servers, err := c.GetDeviceManagementServices(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("expected 2 servers, got %d", len(servers))
+	}
+	if len(params) != 1 {
+		t.Errorf("expected caller query parameters to be left unmodified, got %v", params)
+	}
+}
+
+func TestGetDeviceManagementService_DoesNotModifyQueryParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fields[mdmServers]") != mdmServersFields {
+			t.Errorf("expected full field set, got %q", r.URL.Query().Get("fields[mdmServers]"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, MdmServerResponse{Data: MdmServer{Type: "mdmServers", ID: "server-1"}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	params := url.Values{}
+	if _, err := c.GetDeviceManagementService(context.Background(), "server-1", params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(params) != 0 {
+		t.Errorf("expected caller query parameters to be left unmodified, got %v", params)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// retriesExhaustedError reports a transient response that was still returned after the last
+// retry.
+type retriesExhaustedError struct {
+	statusCode int
+	errorCode  string
+	retries    int
+}
+
+func (e *retriesExhaustedError) Error() string {
+	if e.errorCode != "" {
+		return fmt.Sprintf("received HTTP %d with error code %s after %d retries", e.statusCode, e.errorCode, e.retries)
+	}
+	return fmt.Sprintf("received HTTP %d after %d retries", e.statusCode, e.retries)
+}
+
+// IsAPIUnreachable reports whether err shows that the API could not be reached: the
+// connection or token request failed or timed out, or the API kept responding with server
+// errors through every retry. Errors the API returned for the request itself are not.
+func IsAPIUnreachable(err error) bool {
+	var exhausted *retriesExhaustedError
+	if errors.As(err, &exhausted) {
+		return exhausted.statusCode >= 500
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr) || errors.Is(urlErr.Err, io.EOF) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF)
+}
+
+// SetOfflineFallback enables keeping the device inventory cached on disk after each full read,
+// so that data sources can fall back to it when the API cannot be reached.
+func (c *Client) SetOfflineFallback(enabled bool) {
+	c.offlineFallback = enabled
+}
+
+// OfflineFallback reports whether data sources may fall back to the cached device inventory.
+func (c *Client) OfflineFallback() bool {
+	return c.offlineFallback
+}
+
+// CachedInventory is the device inventory cached by the last successful full read.
+type CachedInventory struct {
+	Devices  []OrgDevice
+	CachedAt time.Time
+	Path     string
+}
+
+// CachedOrgDevices returns the device inventory cached by the last successful full read or
+// incremental sync for the client's credentials and API base URL.
+func (c *Client) CachedOrgDevices() (*CachedInventory, error) {
+	path := c.deviceCheckpointPath()
+	if path == "" {
+		return nil, errors.New("no cache directory is available")
+	}
+	checkpoint, reason := c.loadDeviceCheckpoint(path)
+	if checkpoint == nil {
+		return nil, fmt.Errorf("no usable cached device inventory at %s: %s", path, reason)
+	}
+	return &CachedInventory{Devices: checkpoint.Devices, CachedAt: checkpoint.SyncedAt, Path: path}, nil
+}
+
+// cacheOrgDevices replaces the cached device inventory, logging a warning when it cannot be
+// written.
+func (c *Client) cacheOrgDevices(ctx context.Context, devices []OrgDevice) {
+	path := c.deviceCheckpointPath()
+	if path == "" {
+		return
+	}
+	if err := saveDeviceCheckpoint(path, c.baseURL, devices); err != nil && c.logger != nil {
+		c.logger.LogWarning(ctx, "Failed to cache the device inventory for offline fallback", map[string]any{
+			"error": err.Error(),
+			"path":  path,
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestIsAPIUnreachable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection refused", &url.Error{Op: "Get", URL: "https://api", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{"token request network failure", &url.Error{Op: "Get", URL: "https://api", Err: fmt.Errorf("token request failed: %w", &url.Error{Err: &net.DNSError{Err: "no such host"}})}, true},
+		{"token request rejected", &url.Error{Op: "Get", URL: "https://api", Err: errors.New("token request failed: invalid_client - bad assertion")}, false},
+		{"server errors through retries", fmt.Errorf("failed to read page: %w", &retriesExhaustedError{statusCode: 503, retries: 5}), true},
+		{"rate limited through retries", &retriesExhaustedError{statusCode: 429, retries: 5}, false},
+		{"API error", errors.New("Not Found: Device not found (code: NOT_FOUND, status: 404, id: e1)"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAPIUnreachable(tt.err); got != tt.want {
+				t.Errorf("IsAPIUnreachable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetriesExhaustedError_Message(t *testing.T) {
+	if got, want := (&retriesExhaustedError{statusCode: 503, retries: 3}).Error(), "received HTTP 503 after 3 retries"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got, want := (&retriesExhaustedError{statusCode: 400, errorCode: "UNEXPECTED_ERROR", retries: 2}).Error(), "received HTTP 400 with error code UNEXPECTED_ERROR after 2 retries"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestGetOrgDevices_CachesInventoryForOfflineFallback(t *testing.T) {
+	inventory := &fakeInventory{devices: []OrgDevice{
+		syncTestDevice("DEV1", "2024-01-01T00:00:00Z", "Silver"),
+		syncTestDevice("DEV2", "2024-01-01T00:00:00Z", "Gold"),
+	}}
+	server := httptest.NewServer(inventory.handler(t))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.tokenSource = &appleTokenSource{cacheDir: t.TempDir(), config: &ClientConfig{ClientID: "CLIENT"}}
+	ctx := context.Background()
+
+	if _, err := c.GetOrgDevices(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.CachedOrgDevices(); err == nil {
+		t.Fatal("expected no cached inventory while offline fallback is disabled")
+	}
+
+	c.SetOfflineFallback(true)
+	if _, err := c.GetOrgDevices(ctx, url.Values{"filter[status]": {"ASSIGNED"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.CachedOrgDevices(); err == nil {
+		t.Fatal("expected a filtered read not to be cached")
+	}
+
+	if _, err := c.GetOrgDevices(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cached, err := c.CachedOrgDevices()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cached.Devices) != 2 || cached.Devices[1].Attributes.Color != "Gold" || cached.CachedAt.IsZero() {
+		t.Errorf("unexpected cached inventory: %+v", cached)
+	}
+}
+
+func TestSendWithRetry_ServerErrorsAreUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetMaxRetries(1)
+	_, err := c.GetOrgDevices(context.Background(), nil)
+	if !IsAPIUnreachable(err) {
+		t.Errorf("expected an unreachable error, got %v", err)
+	}
+
+	server.Close()
+	_, err = c.GetOrgDevices(context.Background(), nil)
+	if !IsAPIUnreachable(err) {
+		t.Errorf("expected an unreachable error for a closed server, got %v", err)
+	}
+}
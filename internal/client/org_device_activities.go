@@ -6,10 +6,32 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Activity types accepted when creating an organization device activity.
+const (
+	ActivityTypeAssignDevices   = "ASSIGN_DEVICES"
+	ActivityTypeUnassignDevices = "UNASSIGN_DEVICES"
+	ActivityTypeReleaseDevices  = "RELEASE_DEVICES"
+)
+
+// Statuses reported for an organization device activity. Every status other than
+// ActivityStatusInProgress is terminal.
+const (
+	ActivityStatusInProgress = "IN_PROGRESS"
+	ActivityStatusCompleted  = "COMPLETED"
+	ActivityStatusFailed     = "FAILED"
+	ActivityStatusStopped    = "STOPPED"
 )
 
 // OrgDeviceActivity represents the data structure that represents an organization device activity resource.
@@ -53,9 +75,10 @@ type OrgDeviceActivityCreateRequestAttributes struct {
 }
 
 // OrgDeviceActivityCreateRequestRelationships represents the relationships you include in the request, and those that you can operate on.
+// MdmServer is omitted for activities, such as releases, that do not target a device management service.
 type OrgDeviceActivityCreateRequestRelationships struct {
-	MdmServer OrgDeviceActivityCreateRequestDataRelationshipsMdmServer `json:"mdmServer"`
-	Devices   OrgDeviceActivityCreateRequestDataRelationships          `json:"devices"`
+	MdmServer *OrgDeviceActivityCreateRequestDataRelationshipsMdmServer `json:"mdmServer,omitempty"`
+	Devices   OrgDeviceActivityCreateRequestDataRelationships           `json:"devices"`
 }
 
 // OrgDeviceActivityCreateRequestDataRelationshipsMdmServer represents the data that describe the relationship between the resources.
@@ -68,14 +91,131 @@ type OrgDeviceActivityCreateRequestDataRelationships struct {
 	Data []Data `json:"data"`
 }
 
+// DefaultActivityChunkSize is the largest number of devices AssignDevicesToMDMServerInChunks
+// submits in a single activity when no size has been configured with SetActivityChunkSize.
+const DefaultActivityChunkSize = 500
+
+// SetActivityChunkSize sets the largest number of devices submitted in a single assignment or
+// unassignment activity. Values below one restore the default.
+func (c *Client) SetActivityChunkSize(n int) {
+	c.activityChunkSize = n
+}
+
+// ActivityChunkSize returns the effective number of devices submitted per activity.
+func (c *Client) ActivityChunkSize() int {
+	if c.activityChunkSize < 1 {
+		return DefaultActivityChunkSize
+	}
+	return c.activityChunkSize
+}
+
+// DeviceActivityChunk describes one activity submitted by AssignDevicesToMDMServerInChunks.
+// Activity is nil when the submission failed, and Err holds the chunk's final error. Reused
+// reports that Activity is an earlier activity returned by the reuse function, and that no new
+// activity was submitted.
+type DeviceActivityChunk struct {
+	DeviceIDs []string
+	Activity  *OrgDeviceActivity
+	Reused    bool
+	Err       error
+}
+
+// ActivityIdempotencyKey returns a deterministic key identifying an assignment or unassignment
+// of deviceIDs to serverID: the hex-encoded SHA-256 hash of the activity type, the server ID and
+// the sorted device IDs. Identical activities share a key regardless of device order.
+func ActivityIdempotencyKey(serverID string, deviceIDs []string, assign bool) string {
+	activityType := ActivityTypeAssignDevices
+	if !assign {
+		activityType = ActivityTypeUnassignDevices
+	}
+	sorted := slices.Sorted(slices.Values(deviceIDs))
+	sum := sha256.Sum256([]byte(activityType + "\n" + serverID + "\n" + strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AssignDevicesToMDMServerInChunks assigns or unassigns deviceIDs in activities of at most
+// ActivityChunkSize devices, submitted one after another and, when delay is positive, at least
+// delay apart as measured by the client Clock. When reuse is not nil it is called
+// with each chunk's devices before submission; a non-nil activity it returns is used in place of
+// submitting a new one. When monitor is not nil it is called with each chunk after its
+// submission, including failed ones, and is expected to wait for the activity to finish; its
+// return value becomes the chunk's error. A failed chunk does not stop the remaining chunks from
+// being submitted, unless ctx is done. It returns every chunk attempted and the errors of the
+// failed chunks joined together.
+func (c *Client) AssignDevicesToMDMServerInChunks(ctx context.Context, serverID string, deviceIDs []string, assign bool, delay time.Duration, reuse func(context.Context, []string) *OrgDeviceActivity, monitor func(context.Context, DeviceActivityChunk) error) ([]DeviceActivityChunk, error) {
+	size := c.ActivityChunkSize()
+	chunks := make([]DeviceActivityChunk, 0, (len(deviceIDs)+size-1)/size)
+	var errs []error
+	submitted := false
+
+	for start := 0; start < len(deviceIDs); start += size {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		chunk := DeviceActivityChunk{DeviceIDs: deviceIDs[start:min(start+size, len(deviceIDs))]}
+		if reuse != nil {
+			chunk.Activity = reuse(ctx, chunk.DeviceIDs)
+			chunk.Reused = chunk.Activity != nil
+		}
+		if !chunk.Reused {
+			if submitted && delay > 0 {
+				select {
+				case <-ctx.Done():
+					errs = append(errs, ctx.Err())
+					return chunks, errors.Join(errs...)
+				case <-c.Clock().After(delay):
+				}
+			}
+			chunk.Activity, chunk.Err = c.AssignDevicesToMDMServer(ctx, serverID, chunk.DeviceIDs, assign)
+			submitted = true
+		}
+		if monitor != nil {
+			chunk.Err = monitor(ctx, chunk)
+		}
+		chunks = append(chunks, chunk)
+
+		switch {
+		case chunk.Err == nil:
+		case len(deviceIDs) > size:
+			errs = append(errs, fmt.Errorf("devices %d-%d of %d: %w", start+1, start+len(chunk.DeviceIDs), len(deviceIDs), chunk.Err))
+		default:
+			errs = append(errs, chunk.Err)
+		}
+	}
+
+	return chunks, errors.Join(errs...)
+}
+
 // AssignDevicesToMDMServer assigns or unassigns devices to/from an MDM server
-// Returns the created activity. Caller is responsible for polling activity status if needed.
+// Returns the created activity; use WaitForActivity to wait for it to finish.
 func (c *Client) AssignDevicesToMDMServer(ctx context.Context, serverID string, deviceIDs []string, assign bool) (*OrgDeviceActivity, error) {
-	activityType := "ASSIGN_DEVICES"
+	activityType := ActivityTypeAssignDevices
 	if !assign {
-		activityType = "UNASSIGN_DEVICES"
+		activityType = ActivityTypeUnassignDevices
 	}
 
+	request := newOrgDeviceActivityCreateRequest(activityType, deviceIDs)
+	request.Data.Relationships.MdmServer = &OrgDeviceActivityCreateRequestDataRelationshipsMdmServer{
+		Data: Data{
+			Type: "mdmServers",
+			ID:   serverID,
+		},
+	}
+	return c.createOrgDeviceActivity(ctx, request)
+}
+
+// ReleaseDevices releases devices from the organization. Released devices can no longer be
+// assigned to a device management service until they are added to the organization again.
+// Returns the created activity. Caller is responsible for polling activity status if needed.
+func (c *Client) ReleaseDevices(ctx context.Context, deviceIDs []string) (*OrgDeviceActivity, error) {
+	return c.createOrgDeviceActivity(ctx, newOrgDeviceActivityCreateRequest(ActivityTypeReleaseDevices, deviceIDs))
+}
+
+// newOrgDeviceActivityCreateRequest builds the request body for an activity of activityType
+// over deviceIDs.
+func newOrgDeviceActivityCreateRequest(activityType string, deviceIDs []string) OrgDeviceActivityCreateRequest {
 	devices := make([]Data, len(deviceIDs))
 	for i, id := range deviceIDs {
 		devices[i] = Data{
@@ -84,26 +224,23 @@ func (c *Client) AssignDevicesToMDMServer(ctx context.Context, serverID string,
 		}
 	}
 
-	request := OrgDeviceActivityCreateRequest{
+	return OrgDeviceActivityCreateRequest{
 		Data: OrgDeviceActivityCreateRequestData{
 			Type: "orgDeviceActivities",
 			Attributes: OrgDeviceActivityCreateRequestAttributes{
 				ActivityType: activityType,
 			},
 			Relationships: OrgDeviceActivityCreateRequestRelationships{
-				MdmServer: OrgDeviceActivityCreateRequestDataRelationshipsMdmServer{
-					Data: Data{
-						Type: "mdmServers",
-						ID:   serverID,
-					},
-				},
 				Devices: OrgDeviceActivityCreateRequestDataRelationships{
 					Data: devices,
 				},
 			},
 		},
 	}
+}
 
+// createOrgDeviceActivity submits request and returns the created activity.
+func (c *Client) createOrgDeviceActivity(ctx context.Context, request OrgDeviceActivityCreateRequest) (*OrgDeviceActivity, error) {
 	jsonData, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
@@ -129,7 +266,7 @@ func (c *Client) AssignDevicesToMDMServer(ctx context.Context, serverID string,
 	}
 
 	var response OrgDeviceActivityResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -161,9 +298,58 @@ func (c *Client) GetOrgDeviceActivity(ctx context.Context, activityID string, qu
 	}
 
 	var response OrgDeviceActivityResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
 	return &response.Data, nil
 }
+
+// DefaultActivityPollInterval is the interval between status checks used by WaitForActivity
+// when none is given.
+const DefaultActivityPollInterval = 5 * time.Second
+
+// ActivityProgressFunc is called by WaitForActivity with each activity status it observes,
+// including the final one.
+type ActivityProgressFunc func(activity *OrgDeviceActivity)
+
+// WaitForActivity checks the status of an activity every interval, or every
+// DefaultActivityPollInterval when interval is not positive, until it is no longer IN_PROGRESS,
+// and returns the last activity observed. progress, when not nil, is called after every check.
+// The number of checks is not limited: the wait ends only when ctx is done, in which case the
+// last activity observed, if any, is returned together with an error wrapping ctx.Err(). Callers
+// bound the wait with a context deadline sized for the activity, since large assignments can
+// take far longer than small ones.
+//
+// This is the only place activities are polled: methods that create an activity, such as
+// AssignDevicesToMDMServer, return it as soon as it is accepted.
+func (c *Client) WaitForActivity(ctx context.Context, activityID string, interval time.Duration, progress ActivityProgressFunc) (*OrgDeviceActivity, error) {
+	if interval <= 0 {
+		interval = DefaultActivityPollInterval
+	}
+
+	var activity *OrgDeviceActivity
+	for {
+		select {
+		case <-ctx.Done():
+			if activity == nil {
+				return nil, fmt.Errorf("stopped waiting for activity %s: %w", activityID, ctx.Err())
+			}
+			return activity, fmt.Errorf("stopped waiting for activity %s while it was %s: %w", activityID, activity.Attributes.Status, ctx.Err())
+		case <-c.Clock().After(interval):
+		}
+
+		next, err := c.GetOrgDeviceActivity(ctx, activityID, nil)
+		if err != nil {
+			return activity, fmt.Errorf("error checking activity status: %w", err)
+		}
+		activity = next
+		if progress != nil {
+			progress(activity)
+		}
+		if activity.Attributes.Status != ActivityStatusInProgress {
+			c.responseCache.clear()
+			return activity, nil
+		}
+	}
+}
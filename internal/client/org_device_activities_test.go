@@ -6,12 +6,18 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAssignDevicesToMDMServer_Assign(t *testing.T) {
@@ -169,6 +175,241 @@ func TestAssignDevicesToMDMServer_Error(t *testing.T) {
 	}
 }
 
+func TestReleaseDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "mdmServer") {
+			t.Errorf("expected no mdmServer relationship, got %s", body)
+		}
+		var req OrgDeviceActivityCreateRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to parse request body: %v", err)
+		}
+		if req.Data.Attributes.ActivityType != ActivityTypeReleaseDevices {
+			t.Errorf("expected RELEASE_DEVICES, got %s", req.Data.Attributes.ActivityType)
+		}
+		if len(req.Data.Relationships.Devices.Data) != 2 || req.Data.Relationships.Devices.Data[1].ID != "DEV002" {
+			t.Errorf("unexpected devices: %+v", req.Data.Relationships.Devices.Data)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1"}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	activity, err := c.ReleaseDevices(context.Background(), []string{"DEV001", "DEV002"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if activity.ID != "activity-1" {
+		t.Errorf("expected activity ID activity-1, got %s", activity.ID)
+	}
+}
+
+func TestAssignDevicesToMDMServerInChunks(t *testing.T) {
+	var submitted [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req OrgDeviceActivityCreateRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to parse request body: %v", err)
+		}
+		var ids []string
+		for _, device := range req.Data.Relationships.Devices.Data {
+			ids = append(ids, device.ID)
+		}
+		submitted = append(submitted, ids)
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(submitted) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"id":"e1","status":"400","code":"BAD_REQUEST","title":"Bad Request","detail":"Too many devices"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{
+			Data: OrgDeviceActivity{Type: "orgDeviceActivities", ID: fmt.Sprintf("activity-%d", len(submitted))},
+		}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetActivityChunkSize(2)
+
+	var monitored []string
+	chunks, err := c.AssignDevicesToMDMServerInChunks(context.Background(), "srv-1",
+		[]string{"DEV001", "DEV002", "DEV003", "DEV004", "DEV005"}, true, 0, nil,
+		func(ctx context.Context, chunk DeviceActivityChunk) error {
+			if chunk.Activity != nil {
+				monitored = append(monitored, chunk.Activity.ID)
+			}
+			return chunk.Err
+		})
+
+	if err == nil || !strings.Contains(err.Error(), "devices 3-4 of 5") || !strings.Contains(err.Error(), "Too many devices") {
+		t.Errorf("expected the failed chunk to be reported, got %v", err)
+	}
+	wantSubmitted := [][]string{{"DEV001", "DEV002"}, {"DEV003", "DEV004"}, {"DEV005"}}
+	if fmt.Sprint(submitted) != fmt.Sprint(wantSubmitted) {
+		t.Errorf("expected chunks %v, got %v", wantSubmitted, submitted)
+	}
+	if want := []string{"activity-1", "activity-3"}; fmt.Sprint(monitored) != fmt.Sprint(want) {
+		t.Errorf("expected monitored activities %v, got %v", want, monitored)
+	}
+	if len(chunks) != 3 || chunks[1].Activity != nil || chunks[1].Err == nil || chunks[2].Err != nil {
+		t.Errorf("unexpected chunk results: %+v", chunks)
+	}
+}
+
+func TestAssignDevicesToMDMServerInChunks_SingleChunkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1"}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	monitorErr := errors.New("activity failed")
+	chunks, err := c.AssignDevicesToMDMServerInChunks(context.Background(), "srv-1", []string{"DEV001"}, false, 0, nil,
+		func(ctx context.Context, chunk DeviceActivityChunk) error {
+			return monitorErr
+		})
+	if !errors.Is(err, monitorErr) || err.Error() != monitorErr.Error() {
+		t.Errorf("expected the monitor error unwrapped, got %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].Err != monitorErr {
+		t.Errorf("unexpected chunk results: %+v", chunks)
+	}
+}
+
+func TestAssignDevicesToMDMServerInChunks_Reuse(t *testing.T) {
+	var submitted int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submitted++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-new"}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetActivityChunkSize(1)
+	chunks, err := c.AssignDevicesToMDMServerInChunks(context.Background(), "srv-1", []string{"DEV001", "DEV002"}, true, 0,
+		func(ctx context.Context, deviceIDs []string) *OrgDeviceActivity {
+			if deviceIDs[0] == "DEV001" {
+				return &OrgDeviceActivity{ID: "activity-old"}
+			}
+			return nil
+		}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if submitted != 1 {
+		t.Errorf("expected only the second chunk to be submitted, got %d submissions", submitted)
+	}
+	if len(chunks) != 2 || !chunks[0].Reused || chunks[0].Activity.ID != "activity-old" || chunks[1].Reused || chunks[1].Activity.ID != "activity-new" {
+		t.Errorf("unexpected chunk results: %+v", chunks)
+	}
+}
+
+func TestAssignDevicesToMDMServerInChunks_Delay(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	var mu sync.Mutex
+	var submittedAt []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		submittedAt = append(submittedAt, clock.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1"}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetClock(clock)
+	c.SetActivityChunkSize(1)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.AssignDevicesToMDMServerInChunks(context.Background(), "srv-1", []string{"DEV001", "DEV002", "DEV003"}, true, 30*time.Second, nil, nil)
+		done <- err
+	}()
+	for range 2 {
+		clock.awaitWaiter(t)
+		clock.Advance(30 * time.Second)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []time.Time{start, start.Add(30 * time.Second), start.Add(time.Minute)}
+	if fmt.Sprint(submittedAt) != fmt.Sprint(want) {
+		t.Errorf("expected submissions at %v, got %v", want, submittedAt)
+	}
+}
+
+func TestAssignDevicesToMDMServerInChunks_DelayCancelled(t *testing.T) {
+	var submitted atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submitted.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1"}}))
+	}))
+	defer server.Close()
+
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := newTestClient(t, server)
+	c.SetClock(clock)
+	c.SetActivityChunkSize(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.AssignDevicesToMDMServerInChunks(ctx, "srv-1", []string{"DEV001", "DEV002"}, true, time.Minute, nil, nil)
+		done <- err
+	}()
+	clock.awaitWaiter(t)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancellation to be reported, got %v", err)
+	}
+	if got := submitted.Load(); got != 1 {
+		t.Errorf("expected only the first chunk to be submitted, got %d", got)
+	}
+}
+
+func TestActivityIdempotencyKey(t *testing.T) {
+	key := ActivityIdempotencyKey("srv-1", []string{"DEV002", "DEV001"}, true)
+	if len(key) != 64 {
+		t.Errorf("expected a hex-encoded SHA-256 hash, got %q", key)
+	}
+	if other := ActivityIdempotencyKey("srv-1", []string{"DEV001", "DEV002"}, true); other != key {
+		t.Errorf("expected device order not to change the key, got %q and %q", key, other)
+	}
+	for _, other := range []string{
+		ActivityIdempotencyKey("srv-1", []string{"DEV001", "DEV002"}, false),
+		ActivityIdempotencyKey("srv-2", []string{"DEV001", "DEV002"}, true),
+		ActivityIdempotencyKey("srv-1", []string{"DEV001"}, true),
+	} {
+		if other == key {
+			t.Errorf("expected a different key for a different activity, got %q", other)
+		}
+	}
+}
+
+func TestActivityChunkSize_Default(t *testing.T) {
+	c := &Client{}
+	if got := c.ActivityChunkSize(); got != DefaultActivityChunkSize {
+		t.Errorf("expected default %d, got %d", DefaultActivityChunkSize, got)
+	}
+	c.SetActivityChunkSize(0)
+	if got := c.ActivityChunkSize(); got != DefaultActivityChunkSize {
+		t.Errorf("expected values below one to restore the default, got %d", got)
+	}
+}
+
 func TestGetOrgDeviceActivity_Completed(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/v1/orgDeviceActivities/activity-1") {
@@ -259,3 +500,71 @@ func TestGetOrgDeviceActivity_WithQueryParams(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestWaitForActivity(t *testing.T) {
+	t.Run("polls_until_terminal_without_attempt_limit", func(t *testing.T) {
+		var polls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polls++
+			status := ActivityStatusInProgress
+			if polls == 40 {
+				status = ActivityStatusCompleted
+			}
+			w.Header().Set("Content-Type", "application/json")
+			resp := OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1", Attributes: OrgDeviceActivityAttributes{Status: status}}}
+			_, _ = w.Write(mustMarshalJSON(t, resp))
+		}))
+		defer server.Close()
+
+		activity, err := newTestClient(t, server).WaitForActivity(context.Background(), "activity-1", time.Millisecond, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if activity.Attributes.Status != ActivityStatusCompleted || polls != 40 {
+			t.Errorf("expected COMPLETED after 40 polls, got %s after %d", activity.Attributes.Status, polls)
+		}
+	})
+
+	t.Run("stops_when_context_ends", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			resp := OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1", Attributes: OrgDeviceActivityAttributes{Status: ActivityStatusInProgress}}}
+			_, _ = w.Write(mustMarshalJSON(t, resp))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		activity, err := newTestClient(t, server).WaitForActivity(ctx, "activity-1", time.Millisecond, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a deadline error, got %v", err)
+		}
+		if activity == nil || activity.Attributes.Status != ActivityStatusInProgress {
+			t.Errorf("expected the last IN_PROGRESS activity to be returned, got %+v", activity)
+		}
+	})
+
+	t.Run("reports_each_status_to_progress", func(t *testing.T) {
+		statuses := []string{ActivityStatusInProgress, ActivityStatusInProgress, ActivityStatusCompleted}
+		var polls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := statuses[polls]
+			polls++
+			w.Header().Set("Content-Type", "application/json")
+			resp := OrgDeviceActivityResponse{Data: OrgDeviceActivity{ID: "activity-1", Attributes: OrgDeviceActivityAttributes{Status: status}}}
+			_, _ = w.Write(mustMarshalJSON(t, resp))
+		}))
+		defer server.Close()
+
+		var observed []string
+		_, err := newTestClient(t, server).WaitForActivity(context.Background(), "activity-1", time.Millisecond, func(activity *OrgDeviceActivity) {
+			observed = append(observed, activity.Attributes.Status)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(observed, statuses) {
+			t.Errorf("expected progress for %v, got %v", statuses, observed)
+		}
+	})
+}
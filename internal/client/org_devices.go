@@ -5,14 +5,81 @@ package client
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
+	"slices"
+	"strings"
 )
 
+// OrgDeviceStatus represents the assignment status of an organization device.
+type OrgDeviceStatus string
+
+const (
+	OrgDeviceStatusAssigned   OrgDeviceStatus = "ASSIGNED"
+	OrgDeviceStatusUnassigned OrgDeviceStatus = "UNASSIGNED"
+)
+
+// OrgDeviceStatusValues returns every documented organization device status.
+func OrgDeviceStatusValues() []string {
+	return []string{string(OrgDeviceStatusAssigned), string(OrgDeviceStatusUnassigned)}
+}
+
+// OrgDeviceProductFamilyValues returns every product family reported in the productFamily
+// attribute of an organization device.
+func OrgDeviceProductFamilyValues() []string {
+	return []string{"iPhone", "iPad", "Mac", "AppleTV", "Watch", "Vision"}
+}
+
+// PurchaseSourceType represents how an organization device was acquired.
+type PurchaseSourceType string
+
+const (
+	PurchaseSourceTypeApple         PurchaseSourceType = "APPLE"
+	PurchaseSourceTypeReseller      PurchaseSourceType = "RESELLER"
+	PurchaseSourceTypeManuallyAdded PurchaseSourceType = "MANUALLY_ADDED"
+)
+
+// PurchaseSourceTypeValues returns every documented purchase source type.
+func PurchaseSourceTypeValues() []string {
+	return []string{string(PurchaseSourceTypeApple), string(PurchaseSourceTypeReseller), string(PurchaseSourceTypeManuallyAdded)}
+}
+
+// AppleCareCoverageStatus represents the status of an AppleCare coverage resource.
+type AppleCareCoverageStatus string
+
+const (
+	AppleCareCoverageStatusActive   AppleCareCoverageStatus = "ACTIVE"
+	AppleCareCoverageStatusInactive AppleCareCoverageStatus = "INACTIVE"
+)
+
+// AppleCareCoverageStatusValues returns every documented AppleCare coverage status.
+func AppleCareCoverageStatusValues() []string {
+	return []string{string(AppleCareCoverageStatusActive), string(AppleCareCoverageStatusInactive)}
+}
+
+// DefaultDeviceWarningThreshold is the number of devices above which a device list read warns
+// about the size of the state it writes, when no threshold has been configured with
+// SetDeviceWarningThreshold.
+const DefaultDeviceWarningThreshold = 5000
+
+// SetDeviceWarningThreshold sets the number of devices above which device list reads warn
+// about the size of the state they write. Zero disables the warning.
+func (c *Client) SetDeviceWarningThreshold(n int) {
+	c.deviceWarningThreshold = &n
+}
+
+// DeviceWarningThreshold returns the effective device warning threshold. Zero means the
+// warning is disabled.
+func (c *Client) DeviceWarningThreshold() int {
+	if c.deviceWarningThreshold == nil {
+		return DefaultDeviceWarningThreshold
+	}
+	return *c.deviceWarningThreshold
+}
+
 // OrgDevicesResponse represents a response that contains a list of organization device resources.
 type OrgDevicesResponse struct {
 	Data  []OrgDevice        `json:"data"`
@@ -37,29 +104,29 @@ type OrgDevice struct {
 
 // DeviceAttribute represents attributes that describe an organization device resource.
 type DeviceAttribute struct {
-	SerialNumber            string   `json:"serialNumber"`
-	AddedToOrgDateTime      string   `json:"addedToOrgDateTime"`
-	ReleasedFromOrgDateTime string   `json:"releasedFromOrgDateTime,omitempty"`
-	UpdatedDateTime         string   `json:"updatedDateTime"`
-	DeviceModel             string   `json:"deviceModel"`
-	ProductFamily           string   `json:"productFamily"`
-	ProductType             string   `json:"productType"`
-	DeviceCapacity          string   `json:"deviceCapacity"`
-	PartNumber              string   `json:"partNumber,omitempty"`
-	OrderNumber             string   `json:"orderNumber,omitempty"`
-	Color                   string   `json:"color"`
-	Status                  string   `json:"status"`
-	OrderDateTime           string   `json:"orderDateTime,omitempty"`
-	IMEI                    []string `json:"imei,omitempty"`
-	MEID                    []string `json:"meid,omitempty"`
-	EID                     string   `json:"eid,omitempty"`
-	PurchaseSourceID        string   `json:"purchaseSourceId"`
-	PurchaseSourceType      string   `json:"purchaseSourceType"`
-	WifiMacAddress          string   `json:"wifiMacAddress,omitempty"`
-	BluetoothMacAddress     string   `json:"bluetoothMacAddress,omitempty"`
-	EthernetMacAddress      []string `json:"ethernetMacAddress,omitempty"`
-	ReleaserEntityType      string   `json:"releaserEntityType,omitempty"`
-	ReleaserID              string   `json:"releaserId,omitempty"`
+	SerialNumber            string             `json:"serialNumber"`
+	AddedToOrgDateTime      string             `json:"addedToOrgDateTime"`
+	ReleasedFromOrgDateTime string             `json:"releasedFromOrgDateTime,omitempty"`
+	UpdatedDateTime         string             `json:"updatedDateTime"`
+	DeviceModel             string             `json:"deviceModel"`
+	ProductFamily           string             `json:"productFamily"`
+	ProductType             string             `json:"productType"`
+	DeviceCapacity          string             `json:"deviceCapacity"`
+	PartNumber              string             `json:"partNumber,omitempty"`
+	OrderNumber             string             `json:"orderNumber,omitempty"`
+	Color                   string             `json:"color"`
+	Status                  OrgDeviceStatus    `json:"status"`
+	OrderDateTime           string             `json:"orderDateTime,omitempty"`
+	IMEI                    []string           `json:"imei,omitempty"`
+	MEID                    []string           `json:"meid,omitempty"`
+	EID                     string             `json:"eid,omitempty"`
+	PurchaseSourceID        string             `json:"purchaseSourceId"`
+	PurchaseSourceType      PurchaseSourceType `json:"purchaseSourceType"`
+	WifiMacAddress          string             `json:"wifiMacAddress,omitempty"`
+	BluetoothMacAddress     string             `json:"bluetoothMacAddress,omitempty"`
+	EthernetMacAddress      []string           `json:"ethernetMacAddress,omitempty"`
+	ReleaserEntityType      string             `json:"releaserEntityType,omitempty"`
+	ReleaserID              string             `json:"releaserId,omitempty"`
 }
 
 // AppleCareCoverageResponse represents a response that contains AppleCare Coverage for an organization device.
@@ -78,15 +145,15 @@ type AppleCareCoverage struct {
 
 // AppleCareCoverageAttribute represents AppleCare Coverage resources for an organization device.
 type AppleCareCoverageAttribute struct {
-	Status                 string `json:"status"`
-	PaymentType            string `json:"paymentType"`
-	Description            string `json:"description"`
-	StartDateTime          string `json:"startDateTime"`
-	EndDateTime            string `json:"endDateTime"`
-	IsRenewable            bool   `json:"isRenewable"`
-	IsCanceled             bool   `json:"isCanceled"`
-	ContractCancelDateTime string `json:"contractCancelDateTime"`
-	AgreementNumber        string `json:"agreementNumber"`
+	Status                 AppleCareCoverageStatus `json:"status"`
+	PaymentType            string                  `json:"paymentType"`
+	Description            string                  `json:"description"`
+	StartDateTime          string                  `json:"startDateTime"`
+	EndDateTime            string                  `json:"endDateTime"`
+	IsRenewable            bool                    `json:"isRenewable"`
+	IsCanceled             bool                    `json:"isCanceled"`
+	ContractCancelDateTime string                  `json:"contractCancelDateTime"`
+	AgreementNumber        string                  `json:"agreementNumber"`
 }
 
 // OrgDeviceRelationships represents the relationships you include in the request, and those that you can operate on.
@@ -105,62 +172,390 @@ type OrgDeviceAssignedServerLinkageResponse struct {
 	Links DocumentLinks `json:"links"`
 }
 
-// GetOrgDevices retrieves all organization devices from the API.
+// orgDevicesPageLimit is the page size requested when reading organization devices.
+const orgDevicesPageLimit = 1000
+
+// GetOrgDevices retrieves all organization devices from the API. When page concurrency is
+// above one and the inventory spans more than one page, the devices of each product family
+// are paged through in parallel and returned grouped by product family; the inventory is read
+// page by page instead whenever the partitions cannot be shown to cover it. With offline
+// fallback enabled, a read without query parameters also replaces the cached inventory.
 func (c *Client) GetOrgDevices(ctx context.Context, queryParams url.Values) ([]OrgDevice, error) {
-	var allDevices []OrgDevice
-	nextCursor := ""
-	limit := 1000
+	devices, err := c.listOrgDevices(ctx, queryParams)
+	if err == nil && len(queryParams) == 0 && c.offlineFallback {
+		c.cacheOrgDevices(ctx, devices)
+	}
+	return devices, err
+}
 
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
+// listOrgDevices implements GetOrgDevices without caching the inventory.
+func (c *Client) listOrgDevices(ctx context.Context, queryParams url.Values) ([]OrgDevice, error) {
+	if queryParams.Get("filter[productFamily]") != "" {
+		return c.getOrgDevicesOfFamily(ctx, queryParams)
+	}
+	if c.PageConcurrency() > 1 {
+		devices, ok, err := c.getOrgDevicesByProductFamily(ctx, queryParams)
+		if err != nil || ok {
+			return devices, err
 		}
+	}
+	return c.getOrgDevicePages(ctx, queryParams)
+}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/orgDevices", c.baseURL), nil)
-		if err != nil {
-			return nil, err
+// getOrgDevicesOfFamily reads the devices matching queryParams, which filter them by product
+// family. When the API ignores the filter, the devices are listed without it and filtered by
+// product family locally.
+func (c *Client) getOrgDevicesOfFamily(ctx context.Context, queryParams url.Values) ([]OrgDevice, error) {
+	families := strings.Split(queryParams.Get("filter[productFamily]"), ",")
+	inFamily := func(device OrgDevice) bool {
+		return slices.ContainsFunc(families, func(family string) bool {
+			return strings.EqualFold(strings.TrimSpace(family), device.Attributes.ProductFamily)
+		})
+	}
+
+	params, added := withOrgDeviceField(queryParams, "productFamily")
+	devices, err := c.getFilteredOrgDevicePages(ctx, params, inFamily)
+	if errors.Is(err, errFilterIgnored) {
+		if c.logger != nil {
+			c.logger.LogWarning(ctx, "API ignored the product family filter; filtering organization devices locally", nil)
+		}
+		params.Del("filter[productFamily]")
+		var all []OrgDevice
+		all, err = c.getOrgDevicePages(ctx, params)
+		devices = slices.DeleteFunc(all, func(device OrgDevice) bool { return !inFamily(device) })
+	}
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		for i := range devices {
+			devices[i].Attributes.ProductFamily = ""
+		}
+	}
+	return devices, nil
+}
+
+// getOrgDevicesByProductFamily reads the devices of each product family in parallel. It
+// reports false, without an error, when the inventory fits in one page, when the API does not
+// report its size, when it rejects or ignores the product family filter, or when the
+// partitions do not add up to the reported size, such as for a device of a product family the
+// client does not know; the caller then reads the inventory page by page.
+func (c *Client) getOrgDevicesByProductFamily(ctx context.Context, queryParams url.Values) ([]OrgDevice, bool, error) {
+	total, err := c.countOrgDevices(ctx, queryParams)
+	if err != nil {
+		return nil, false, err
+	}
+	if total <= orgDevicesPageLimit {
+		return nil, false, nil
+	}
+
+	families := OrgDeviceProductFamilyValues()
+	params, added := withOrgDeviceField(queryParams, "productFamily")
+	devices, err := fetchPartitions(ctx, c.PageConcurrency(), len(families), func(ctx context.Context, i int) ([]OrgDevice, error) {
+		partition := maps.Clone(params)
+		partition.Set("filter[productFamily]", families[i])
+		return c.getFilteredOrgDevicePages(ctx, partition, func(device OrgDevice) bool {
+			return strings.EqualFold(device.Attributes.ProductFamily, families[i])
+		})
+	})
+	switch {
+	case errors.Is(err, errFilterIgnored):
+		if c.logger != nil {
+			c.logger.LogWarning(ctx, "API ignored the product family filter; reading organization devices page by page", nil)
+		}
+		return nil, false, nil
+	case err != nil && IsParameterError(err):
+		if c.logger != nil {
+			c.logger.LogWarning(ctx, "API rejected the product family filter; reading organization devices page by page", map[string]any{
+				"error": err.Error(),
+			})
 		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	case len(devices) != total:
+		if c.logger != nil {
+			c.logger.LogWarning(ctx, "Product family partitions did not cover the organization's devices; reading them page by page", map[string]any{
+				"expected": total,
+				"received": len(devices),
+			})
+		}
+		return nil, false, nil
+	}
+	if added {
+		for i := range devices {
+			devices[i].Attributes.ProductFamily = ""
 		}
-		req.URL.RawQuery = params.Encode()
+	}
+	return devices, true, nil
+}
 
-		req.Header.Set("Accept", "application/json")
+// countOrgDevices returns the number of devices matching queryParams reported by the API, or
+// zero when the API does not report it.
+func (c *Client) countOrgDevices(ctx context.Context, queryParams url.Values) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.pageURL("/v1/orgDevices", queryParams, 1, ""), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
 
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, c.handleErrorResponse(resp)
+	}
+
+	var response struct {
+		Meta Meta `json:"meta"`
+	}
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
+		return 0, fmt.Errorf("failed to decode response JSON: %w", err)
+	}
+	return response.Meta.Paging.Total, nil
+}
+
+// getOrgDevicePages reads every page of organization devices matching queryParams in turn.
+func (c *Client) getOrgDevicePages(ctx context.Context, queryParams url.Values) ([]OrgDevice, error) {
+	return collectPages[OrgDevice](ctx, c, "/v1/orgDevices", queryParams, orgDevicesPageLimit)
+}
+
+// errFilterIgnored reports a filtered read of organization devices that returned a device not
+// matching the filter, which means the API ignored the filter query parameter.
+var errFilterIgnored = errors.New("the API ignored the filter query parameter")
+
+// getFilteredOrgDevicePages reads every page of organization devices matching queryParams like
+// getOrgDevicePages, and checks each device returned with matches. The API may ignore a filter
+// it does not support instead of rejecting it, so the read stops with errFilterIgnored at the
+// first page holding a device that does not match, rather than paging through the whole
+// inventory.
+func (c *Client) getFilteredOrgDevicePages(ctx context.Context, queryParams url.Values, matches func(OrgDevice) bool) ([]OrgDevice, error) {
+	return collectCheckedPages(ctx, c, "/v1/orgDevices", queryParams, orgDevicesPageLimit, func(items []OrgDevice) error {
+		if !slices.ContainsFunc(items, func(device OrgDevice) bool { return !matches(device) }) {
+			return nil
 		}
+		return errFilterIgnored
+	})
+}
+
+// withOrgDeviceField returns a copy of queryParams whose fields[orgDevices] parameter, when it
+// is set, also requests field, so that a filter on field can be checked against the devices
+// returned. It also reports whether field had to be added.
+func withOrgDeviceField(queryParams url.Values, field string) (url.Values, bool) {
+	params := maps.Clone(queryParams)
+	if params == nil {
+		params = url.Values{}
+	}
+	fields := params.Get("fields[orgDevices]")
+	if fields == "" || slices.Contains(strings.Split(fields, ","), field) {
+		return params, false
+	}
+	params.Set("fields[orgDevices]", fields+","+field)
+	return params, true
+}
 
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
+// ResolveOrgDeviceIDs maps each identifier, which may be either an opaque orgDevice ID or a
+// serial number, to its canonical orgDevice ID. Identifiers are first looked up as serial numbers
+// with GetOrgDevicesBySerial; the rest are found with lookupOrgDevices. Identifiers that do not
+// match any device are omitted from the returned map.
+func (c *Client) ResolveOrgDeviceIDs(ctx context.Context, identifiers []string) (map[string]string, error) {
+	resolved := make(map[string]string, len(identifiers))
+	if len(identifiers) == 0 {
+		return resolved, nil
+	}
 
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
+	params := url.Values{"fields[orgDevices]": {"serialNumber"}}
+	bySerial, err := c.GetOrgDevicesBySerial(ctx, identifiers, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up organization devices: %w", err)
+	}
+
+	var unmatched []string
+	for _, identifier := range identifiers {
+		if device, ok := bySerial[strings.ToUpper(strings.TrimSpace(identifier))]; ok {
+			resolved[identifier] = device.ID
+			continue
+		}
+		if strings.TrimSpace(identifier) != "" && !slices.Contains(unmatched, identifier) {
+			unmatched = append(unmatched, identifier)
+		}
+	}
+
+	found, err := c.lookupOrgDevices(ctx, unmatched, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up organization devices: %w", err)
+	}
+	for identifier, device := range found {
+		resolved[identifier] = device.ID
+	}
+
+	return resolved, nil
+}
 
-			var response OrgDevicesResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
+// orgDeviceDirectReadLimit is the most identifiers lookupOrgDevices reads one request at a
+// time. Larger sets are matched against a single listing of the organization's devices.
+const orgDeviceDirectReadLimit = 25
+
+// lookupOrgDevices finds the devices whose ID or serial number is one of identifiers and
+// returns them keyed by identifier; identifiers with no device are absent. Up to
+// orgDeviceDirectReadLimit identifiers are each read directly as a device ID, with at most
+// MaxConcurrency requests in parallel, and those the API reports as not found are skipped.
+// More identifiers are matched against a single listing of the organization's devices.
+func (c *Client) lookupOrgDevices(ctx context.Context, identifiers []string, queryParams url.Values) (map[string]OrgDevice, error) {
+	if len(identifiers) > orgDeviceDirectReadLimit {
+		params, added := withOrgDeviceField(queryParams, "serialNumber")
+		devices, err := c.getOrgDevicePages(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		index := make(map[string]OrgDevice, 2*len(devices))
+		for _, device := range devices {
+			serial := strings.ToUpper(device.Attributes.SerialNumber)
+			if added {
+				device.Attributes.SerialNumber = ""
+			}
+			if serial != "" {
+				index[serial] = device
 			}
+			index[device.ID] = device
+		}
+		found := make(map[string]OrgDevice, len(identifiers))
+		for _, identifier := range identifiers {
+			if device, ok := index[identifier]; ok {
+				found[identifier] = device
+			} else if device, ok := index[strings.ToUpper(strings.TrimSpace(identifier))]; ok {
+				found[identifier] = device
+			}
+		}
+		return found, nil
+	}
 
-			allDevices = append(allDevices, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
+	devices := make([]*OrgDevice, len(identifiers))
+	err := ForEachConcurrent(ctx, c.MaxConcurrency(), len(identifiers), func(ctx context.Context, i int) error {
+		device, err := c.GetOrgDevice(ctx, identifiers[i], queryParams)
+		switch {
+		case err != nil && IsNotFound(err):
 			return nil
-		}(); err != nil {
+		case err != nil:
+			return fmt.Errorf("failed to read device %s: %w", identifiers[i], err)
+		}
+		devices[i] = device
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]OrgDevice, len(identifiers))
+	for i, device := range devices {
+		if device != nil {
+			found[identifiers[i]] = *device
+		}
+	}
+	return found, nil
+}
+
+// orgDeviceSerialBatchSize is the number of serial numbers sent in each filter[serialNumber]
+// query by GetOrgDevicesBySerial.
+const orgDeviceSerialBatchSize = 100
+
+// GetOrgDevicesBySerial looks up the devices with the given serial numbers and returns them
+// keyed by upper-cased serial number. Serials with no device in the organization are absent.
+// Serials are sent in batches of orgDeviceSerialBatchSize with the filter[serialNumber] query
+// parameter. When the API ignores the filter, returning devices with other serial numbers, the
+// serials are matched against a single listing of the organization's devices instead; when it
+// rejects the filter, they are found with lookupOrgDevices.
+func (c *Client) GetOrgDevicesBySerial(ctx context.Context, serials []string, queryParams url.Values) (map[string]OrgDevice, error) {
+	var normalized []string
+	for _, serial := range serials {
+		if serial = strings.ToUpper(strings.TrimSpace(serial)); serial != "" && !slices.Contains(normalized, serial) {
+			normalized = append(normalized, serial)
+		}
+	}
+	if len(normalized) == 0 {
+		return make(map[string]OrgDevice), nil
+	}
+
+	params, added := withOrgDeviceField(queryParams, "serialNumber")
+	batches := slices.Collect(slices.Chunk(normalized, orgDeviceSerialBatchSize))
+	devices, err := fetchPartitions(ctx, c.MaxConcurrency(), len(batches), func(ctx context.Context, i int) ([]OrgDevice, error) {
+		batch := maps.Clone(params)
+		batch.Set("filter[serialNumber]", strings.Join(batches[i], ","))
+		return c.getFilteredOrgDevicePages(ctx, batch, func(device OrgDevice) bool {
+			return slices.Contains(batches[i], strings.ToUpper(device.Attributes.SerialNumber))
+		})
+	})
+	switch {
+	case errors.Is(err, errFilterIgnored):
+		if c.logger != nil {
+			c.logger.LogWarning(ctx, "API ignored the serial number filter; matching serial numbers against a single listing of organization devices", nil)
+		}
+		devices, err = c.getOrgDevicePages(ctx, params)
+		if err != nil {
 			return nil, err
 		}
+	case err != nil && IsParameterError(err):
+		if c.logger != nil {
+			c.logger.LogWarning(ctx, "API rejected the serial number filter; looking up organization devices without it", map[string]any{
+				"error": err.Error(),
+			})
+		}
+		return c.lookupOrgDevices(ctx, normalized, queryParams)
+	case err != nil:
+		return nil, err
+	}
 
-		if nextCursor == "" {
-			break
+	found := make(map[string]OrgDevice, len(normalized))
+	for _, device := range devices {
+		serial := strings.ToUpper(device.Attributes.SerialNumber)
+		if !slices.Contains(normalized, serial) {
+			continue
+		}
+		if added {
+			device.Attributes.SerialNumber = ""
 		}
+		found[serial] = device
 	}
+	return found, nil
+}
 
-	return allDevices, nil
+// missingOrgDevicesBatchSize is the number of devices looked up per batch by MissingOrgDevices.
+const missingOrgDevicesBatchSize = 100
+
+// MissingOrgDevices reads each of ids as an organization device, requesting only its serial
+// number, and returns the ones the API reports as not found in sorted order. Devices are read in
+// batches of missingOrgDevicesBatchSize with at most MaxConcurrency requests in parallel, so that
+// a large check stops after the first batch with a failed request.
+func (c *Client) MissingOrgDevices(ctx context.Context, ids []string) ([]string, error) {
+	params := url.Values{"fields[orgDevices]": {"serialNumber"}}
+	var missing []string
+	for batch := range slices.Chunk(ids, missingOrgDevicesBatchSize) {
+		found := make([]bool, len(batch))
+		err := ForEachConcurrent(ctx, c.MaxConcurrency(), len(batch), func(ctx context.Context, i int) error {
+			_, err := c.GetOrgDevice(ctx, batch[i], params)
+			switch {
+			case err != nil && IsNotFound(err):
+				return nil
+			case err != nil:
+				return fmt.Errorf("failed to read device %s: %w", batch[i], err)
+			}
+			found[i] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i, ok := range found {
+			if !ok {
+				missing = append(missing, batch[i])
+			}
+		}
+	}
+	slices.Sort(missing)
+	return missing, nil
 }
 
 // GetOrgDevice retrieves a single organization device by its ID.
@@ -188,7 +583,7 @@ func (c *Client) GetOrgDevice(ctx context.Context, id string, queryParams url.Va
 	}
 
 	var response OrgDeviceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -216,13 +611,36 @@ func (c *Client) GetOrgDeviceAssignedServerID(ctx context.Context, deviceID stri
 	}
 
 	var response OrgDeviceAssignedServerLinkageResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
 	return &response.Data, nil
 }
 
+// GetOrgDeviceAssignedServerIDs retrieves the assigned MDM server ID for each of the given
+// devices, issuing at most MaxConcurrency requests in parallel. Unassigned devices map to "".
+func (c *Client) GetOrgDeviceAssignedServerIDs(ctx context.Context, deviceIDs []string) (map[string]string, error) {
+	serverIDs := make([]string, len(deviceIDs))
+	err := ForEachConcurrent(ctx, c.MaxConcurrency(), len(deviceIDs), func(ctx context.Context, i int) error {
+		data, err := c.GetOrgDeviceAssignedServerID(ctx, deviceIDs[i])
+		if err != nil {
+			return fmt.Errorf("failed to read assigned server for device %s: %w", deviceIDs[i], err)
+		}
+		serverIDs[i] = data.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make(map[string]string, len(deviceIDs))
+	for i, deviceID := range deviceIDs {
+		assignments[deviceID] = serverIDs[i]
+	}
+	return assignments, nil
+}
+
 // GetOrgDeviceAssignedServer retrieves the MDM server assigned to a specific device.
 func (c *Client) GetOrgDeviceAssignedServer(ctx context.Context, deviceID string, queryParams url.Values) (*MdmServer, error) {
 	baseURL := fmt.Sprintf("%s/v1/orgDevices/%s/assignedServer", c.baseURL, deviceID)
@@ -248,7 +666,7 @@ func (c *Client) GetOrgDeviceAssignedServer(ctx context.Context, deviceID string
 	}
 
 	var response MdmServerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -257,57 +675,28 @@ func (c *Client) GetOrgDeviceAssignedServer(ctx context.Context, deviceID string
 
 // GetOrgDeviceAppleCareCoverage retrieves the AppleCare coverage details for a specific device.
 func (c *Client) GetOrgDeviceAppleCareCoverage(ctx context.Context, deviceID string, queryParams url.Values) ([]AppleCareCoverage, error) {
-	var allCoverages []AppleCareCoverage
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/orgDevices/%s/appleCareCoverage", c.baseURL, deviceID), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-
-		req.Header.Set("Accept", "application/json")
+	return collectPages[AppleCareCoverage](ctx, c, fmt.Sprintf("/v1/orgDevices/%s/appleCareCoverage", deviceID), queryParams, defaultPageLimit)
+}
 
-		resp, err := c.doRequest(ctx, req)
+// GetOrgDevicesAppleCareCoverage retrieves the AppleCare coverage for each of the given
+// devices, issuing at most MaxConcurrency requests in parallel.
+func (c *Client) GetOrgDevicesAppleCareCoverage(ctx context.Context, deviceIDs []string, queryParams url.Values) (map[string][]AppleCareCoverage, error) {
+	coverages := make([][]AppleCareCoverage, len(deviceIDs))
+	err := ForEachConcurrent(ctx, c.MaxConcurrency(), len(deviceIDs), func(ctx context.Context, i int) error {
+		coverage, err := c.GetOrgDeviceAppleCareCoverage(ctx, deviceIDs[i], queryParams)
 		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response AppleCareCoverageResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allCoverages = append(allCoverages, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
+			return fmt.Errorf("failed to read AppleCare coverage for device %s: %w", deviceIDs[i], err)
 		}
+		coverages[i] = coverage
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return allCoverages, nil
+	report := make(map[string][]AppleCareCoverage, len(deviceIDs))
+	for i, deviceID := range deviceIDs {
+		report[deviceID] = coverages[i]
+	}
+	return report, nil
 }
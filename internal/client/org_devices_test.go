@@ -5,9 +5,11 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -96,6 +98,8 @@ func TestGetOrgDevices_MultiPage(t *testing.T) {
 	defer server.Close()
 
 	c := newTestClient(t, server)
+	logger := &recordingLogger{}
+	c.SetLogger(logger)
 	devices, err := c.GetOrgDevices(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -106,6 +110,20 @@ func TestGetOrgDevices_MultiPage(t *testing.T) {
 	if got := requestCount.Load(); got != 2 {
 		t.Fatalf("expected 2 requests, got %d", got)
 	}
+
+	if len(logger.pages) != 2 {
+		t.Fatalf("expected 2 logged pages, got %d", len(logger.pages))
+	}
+	first, second := logger.pages[0], logger.pages[1]
+	if first.Endpoint != "/v1/orgDevices" || first.PageSize != 1000 || first.Page != 1 || first.Cursor != "" || first.Items != 2 || first.Total != 2 {
+		t.Errorf("unexpected first page %+v", first)
+	}
+	if second.Page != 2 || second.Cursor != "page2cursor" || second.Items != 1 || second.Total != 3 {
+		t.Errorf("unexpected second page %+v", second)
+	}
+	if second.Elapsed < first.Elapsed {
+		t.Errorf("expected elapsed time to be cumulative, got %v then %v", first.Elapsed, second.Elapsed)
+	}
 }
 
 func TestGetOrgDevices_EmptyResponse(t *testing.T) {
@@ -166,6 +184,242 @@ func TestGetOrgDevices_WithQueryParams(t *testing.T) {
 	}
 }
 
+func TestResolveOrgDeviceIDs(t *testing.T) {
+	var listRequests, deviceRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields[orgDevices]"); got != "serialNumber" {
+			t.Errorf("expected fields[orgDevices]=serialNumber, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/orgDevices":
+			listRequests.Add(1)
+			if got := r.URL.Query().Get("filter[serialNumber]"); got != "DEV001,SN002,UNKNOWN" {
+				t.Errorf("expected filter[serialNumber]=DEV001,SN002,UNKNOWN, got %q", got)
+			}
+			resp := OrgDevicesResponse{
+				Data: []OrgDevice{
+					{Type: "orgDevices", ID: "DEV002", Attributes: DeviceAttribute{SerialNumber: "SN002"}},
+				},
+				Meta: Meta{Paging: Paging{Limit: 1000}},
+			}
+			_, _ = w.Write(mustMarshalJSON(t, resp))
+		case "/v1/orgDevices/DEV001":
+			deviceRequests.Add(1)
+			resp := OrgDeviceResponse{Data: OrgDevice{Type: "orgDevices", ID: "DEV001", Attributes: DeviceAttribute{SerialNumber: "SN001"}}}
+			_, _ = w.Write(mustMarshalJSON(t, resp))
+		default:
+			deviceRequests.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"id":"e1","status":"404","code":"NOT_FOUND","title":"Not Found","detail":"Device not found"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	resolved, err := c.ResolveOrgDeviceIDs(context.Background(), []string{"DEV001", "sn002", "UNKNOWN"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := listRequests.Load(); got != 1 {
+		t.Errorf("expected a single batched serial number lookup, got %d", got)
+	}
+	if got := deviceRequests.Load(); got != 2 {
+		t.Errorf("expected the unmatched identifiers to be read as device IDs, got %d reads", got)
+	}
+	if resolved["DEV001"] != "DEV001" {
+		t.Errorf("expected DEV001 -> DEV001, got %q", resolved["DEV001"])
+	}
+	if resolved["sn002"] != "DEV002" {
+		t.Errorf("expected sn002 -> DEV002, got %q", resolved["sn002"])
+	}
+	if _, ok := resolved["UNKNOWN"]; ok {
+		t.Error("expected UNKNOWN to be omitted")
+	}
+}
+
+func TestResolveOrgDeviceIDs_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request for empty identifiers")
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	resolved, err := c.ResolveOrgDeviceIDs(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resolved) != 0 {
+		t.Errorf("expected empty map, got %v", resolved)
+	}
+}
+
+func TestGetOrgDevicesBySerial(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		if got := r.URL.Query().Get("filter[serialNumber]"); got != "SN001,SN002,SN003" {
+			t.Errorf("expected filter[serialNumber]=SN001,SN002,SN003, got %q", got)
+		}
+		resp := OrgDevicesResponse{
+			Data: []OrgDevice{
+				{Type: "orgDevices", ID: "DEV001", Attributes: DeviceAttribute{SerialNumber: "SN001"}},
+				{Type: "orgDevices", ID: "DEV002", Attributes: DeviceAttribute{SerialNumber: "sn002"}},
+			},
+			Meta: Meta{Paging: Paging{Limit: 1000}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	found, err := c.GetOrgDevicesBySerial(context.Background(), []string{"sn001", " SN002 ", "SN003", "SN001", ""}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("expected a single batched request, got %d", got)
+	}
+	if len(found) != 2 || found["SN001"].ID != "DEV001" || found["SN002"].ID != "DEV002" {
+		t.Errorf("unexpected devices: %+v", found)
+	}
+}
+
+func TestGetOrgDevicesBySerial_FallsBackToEachDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/orgDevices":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"id":"e1","status":"400","code":"PARAMETER_ERROR","title":"Invalid Parameter","detail":"filter[serialNumber] is not supported"}]}`))
+		case "/v1/orgDevices/SN001":
+			resp := OrgDeviceResponse{Data: OrgDevice{Type: "orgDevices", ID: "SN001", Attributes: DeviceAttribute{SerialNumber: "SN001"}}}
+			_, _ = w.Write(mustMarshalJSON(t, resp))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"id":"e1","status":"404","code":"NOT_FOUND","title":"Not Found","detail":"Device not found"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	found, err := c.GetOrgDevicesBySerial(context.Background(), []string{"SN001", "SN404"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(found) != 1 || found["SN001"].ID != "SN001" {
+		t.Errorf("unexpected devices: %+v", found)
+	}
+}
+
+func TestGetOrgDevicesBySerial_IgnoredFilterFallsBackToListing(t *testing.T) {
+	var filtered, listed atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter[serialNumber]") != "" {
+			filtered.Add(1)
+		} else {
+			listed.Add(1)
+		}
+		if got := r.URL.Query().Get("fields[orgDevices]"); got != "status,serialNumber" {
+			t.Errorf("expected the serial number to be requested, got fields[orgDevices]=%q", got)
+		}
+		resp := OrgDevicesResponse{
+			Data: []OrgDevice{
+				{Type: "orgDevices", ID: "DEV001", Attributes: DeviceAttribute{SerialNumber: "SN001", Status: "ASSIGNED"}},
+				{Type: "orgDevices", ID: "DEV002", Attributes: DeviceAttribute{SerialNumber: "SN002", Status: "UNASSIGNED"}},
+				{Type: "orgDevices", ID: "DEV003", Attributes: DeviceAttribute{SerialNumber: "SN003", Status: "ASSIGNED"}},
+			},
+			Meta: Meta{Paging: Paging{Limit: 1000}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	found, err := c.GetOrgDevicesBySerial(context.Background(), []string{"SN001", "SN003", "SN404"}, url.Values{"fields[orgDevices]": {"status"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filtered.Load() != 1 || listed.Load() != 1 {
+		t.Errorf("expected one filtered request and one listing, got %d and %d", filtered.Load(), listed.Load())
+	}
+	if len(found) != 2 || found["SN001"].ID != "DEV001" || found["SN003"].ID != "DEV003" {
+		t.Errorf("unexpected devices: %+v", found)
+	}
+	if found["SN001"].Attributes.SerialNumber != "" || found["SN001"].Attributes.Status != "ASSIGNED" {
+		t.Errorf("expected only the requested fields to be returned, got %+v", found["SN001"].Attributes)
+	}
+}
+
+func TestResolveOrgDeviceIDs_ListsManyUnmatchedIdentifiers(t *testing.T) {
+	var listed, deviceReads atomic.Int32
+	ids := make([]string, orgDeviceDirectReadLimit+1)
+	var devices []OrgDevice
+	for i := range ids {
+		ids[i] = fmt.Sprintf("DEV%04d", i)
+		devices = append(devices, OrgDevice{Type: "orgDevices", ID: ids[i], Attributes: DeviceAttribute{SerialNumber: fmt.Sprintf("SN%04d", i)}})
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/orgDevices" {
+			deviceReads.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		resp := OrgDevicesResponse{Data: []OrgDevice{}, Meta: Meta{Paging: Paging{Limit: 1000}}}
+		if r.URL.Query().Get("filter[serialNumber]") == "" {
+			listed.Add(1)
+			resp.Data = devices
+		}
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	resolved, err := c.ResolveOrgDeviceIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listed.Load() != 1 || deviceReads.Load() != 0 {
+		t.Errorf("expected a single listing instead of device reads, got %d listings and %d reads", listed.Load(), deviceReads.Load())
+	}
+	if len(resolved) != len(ids) || resolved["DEV0000"] != "DEV0000" {
+		t.Errorf("expected every identifier to resolve, got %v", resolved)
+	}
+}
+
+func TestGetOrgDevicesBySerial_Batches(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		if got := len(strings.Split(r.URL.Query().Get("filter[serialNumber]"), ",")); got > orgDeviceSerialBatchSize {
+			t.Errorf("expected at most %d serials per request, got %d", orgDeviceSerialBatchSize, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, OrgDevicesResponse{Data: []OrgDevice{}, Meta: Meta{Paging: Paging{Limit: 1000}}}))
+	}))
+	defer server.Close()
+
+	serials := make([]string, orgDeviceSerialBatchSize+1)
+	for i := range serials {
+		serials[i] = fmt.Sprintf("SN%04d", i)
+	}
+
+	c := newTestClient(t, server)
+	found, err := c.GetOrgDevicesBySerial(context.Background(), serials, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := requestCount.Load(); got != 2 {
+		t.Errorf("expected 2 batched requests, got %d", got)
+	}
+	if len(found) != 0 {
+		t.Errorf("expected no devices, got %+v", found)
+	}
+}
+
 func TestGetOrgDevice_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/v1/orgDevices/DEV001") {
@@ -279,6 +533,65 @@ func TestGetOrgDeviceAssignedServerID_Success(t *testing.T) {
 	}
 }
 
+func TestGetOrgDeviceAssignedServerIDs(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+
+		deviceID := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/orgDevices/"), "/")[0]
+		w.Header().Set("Content-Type", "application/json")
+		resp := OrgDeviceAssignedServerLinkageResponse{}
+		if deviceID != "DEV003" {
+			resp.Data = Data{ID: "server-" + deviceID, Type: "mdmServers"}
+		}
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetMaxConcurrency(2)
+	assignments, err := c.GetOrgDeviceAssignedServerIDs(context.Background(), []string{"DEV001", "DEV002", "DEV003", "DEV004"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(assignments) != 4 {
+		t.Fatalf("expected 4 assignments, got %d", len(assignments))
+	}
+	if assignments["DEV002"] != "server-DEV002" {
+		t.Errorf("expected server-DEV002, got %q", assignments["DEV002"])
+	}
+	if assignments["DEV003"] != "" {
+		t.Errorf("expected unassigned device to map to empty string, got %q", assignments["DEV003"])
+	}
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", peak.Load())
+	}
+}
+
+func TestGetOrgDeviceAssignedServerIDs_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not Found","detail":"missing"}]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	_, err := c.GetOrgDeviceAssignedServerIDs(context.Background(), []string{"DEV001"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "DEV001") {
+		t.Errorf("expected error to name the device, got %v", err)
+	}
+}
+
 func TestGetOrgDeviceAssignedServer_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/v1/orgDevices/DEV001/assignedServer") {
@@ -439,3 +752,317 @@ func TestGetOrgDeviceAppleCareCoverage_Empty(t *testing.T) {
 		t.Fatalf("expected 0 coverages, got %d", len(coverages))
 	}
 }
+
+func TestGetOrgDevicesAppleCareCoverage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deviceID := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/orgDevices/"), "/")[0]
+		w.Header().Set("Content-Type", "application/json")
+		resp := AppleCareCoverageResponse{
+			Data: []AppleCareCoverage{
+				{ID: deviceID + "-coverage", Type: "appleCareCoverage", Attributes: AppleCareCoverageAttribute{Status: "ACTIVE"}},
+			},
+		}
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	report, err := c.GetOrgDevicesAppleCareCoverage(context.Background(), []string{"DEV001", "DEV002"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("expected coverage for 2 devices, got %d", len(report))
+	}
+	if len(report["DEV002"]) != 1 || report["DEV002"][0].ID != "DEV002-coverage" {
+		t.Errorf("unexpected coverage for DEV002: %+v", report["DEV002"])
+	}
+}
+
+func TestGetOrgDevices_UndecodableRecord(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": [
+			{"type": "orgDevices", "id": "DEV001", "attributes": {"serialNumber": "SN001"}},
+			{"type": "orgDevices", "id": "DEV002", "attributes": {"serialNumber": "SN002", "imei": "not-a-list"}},
+			{"type": "orgDevices", "id": "DEV003", "attributes": {"serialNumber": "SN003"}}
+		], "meta": {"paging": {"limit": 1000}}}`))
+	}))
+	defer server.Close()
+
+	t.Run("strict", func(t *testing.T) {
+		c := newTestClient(t, server)
+		if _, err := c.GetOrgDevices(context.Background(), nil); err == nil {
+			t.Fatal("expected decode error when skipping is disabled")
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		c := newTestClient(t, server)
+		c.SetSkipUndecodableRecords(true)
+		logger := &recordingLogger{}
+		c.SetLogger(logger)
+
+		var skipped SkippedRecords
+		devices, err := c.GetOrgDevices(WithSkippedRecords(context.Background(), &skipped), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(devices) != 2 || devices[0].ID != "DEV001" || devices[1].ID != "DEV003" {
+			t.Fatalf("expected DEV001 and DEV003, got %+v", devices)
+		}
+		records := skipped.Records()
+		if len(records) != 1 || records[0].ID != "DEV002" || records[0].Endpoint != "/v1/orgDevices" || records[0].Err == nil {
+			t.Errorf("expected DEV002 to be reported as skipped, got %+v", records)
+		}
+		if len(logger.warnings) != 1 {
+			t.Errorf("expected one logged warning, got %v", logger.warnings)
+		}
+	})
+}
+
+func TestGetOrgDevices_MalformedPageFailsWhenSkipping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": [`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetSkipUndecodableRecords(true)
+	if _, err := c.GetOrgDevices(context.Background(), nil); err == nil {
+		t.Fatal("expected error for a page whose envelope cannot be decoded")
+	}
+}
+
+func TestDeviceWarningThreshold(t *testing.T) {
+	c := &Client{}
+	if got := c.DeviceWarningThreshold(); got != DefaultDeviceWarningThreshold {
+		t.Errorf("expected default %d, got %d", DefaultDeviceWarningThreshold, got)
+	}
+	c.SetDeviceWarningThreshold(0)
+	if got := c.DeviceWarningThreshold(); got != 0 {
+		t.Errorf("expected zero to disable the warning, got %d", got)
+	}
+	c.SetDeviceWarningThreshold(200)
+	if got := c.DeviceWarningThreshold(); got != 200 {
+		t.Errorf("expected 200, got %d", got)
+	}
+}
+
+// newPartitionedInventoryServer serves an inventory of devices, honouring the
+// filter[productFamily] parameter and reporting total as the inventory size. Every page
+// holds up to limit devices.
+func newPartitionedInventoryServer(t *testing.T, devices []OrgDevice, total int, filtered *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		family := q.Get("filter[productFamily]")
+		if family != "" {
+			filtered.Add(1)
+		}
+
+		var matching []OrgDevice
+		for _, device := range devices {
+			if family == "" || device.Attributes.ProductFamily == family {
+				matching = append(matching, device)
+			}
+		}
+
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		start, _ := strconv.Atoi(q.Get("cursor"))
+		end := min(start+limit, len(matching))
+		resp := OrgDevicesResponse{Data: matching[start:end], Meta: Meta{Paging: Paging{Limit: limit}}}
+		if end < len(matching) {
+			resp.Meta.Paging.NextCursor = strconv.Itoa(end)
+		}
+		if family == "" {
+			resp.Meta.Paging.Total = total
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+}
+
+func partitionedInventory() []OrgDevice {
+	var devices []OrgDevice
+	for i := range 1500 {
+		family := "iPhone"
+		if i%3 == 0 {
+			family = "Mac"
+		}
+		devices = append(devices, OrgDevice{ID: fmt.Sprintf("DEV%04d", i), Attributes: DeviceAttribute{ProductFamily: family}})
+	}
+	return devices
+}
+
+func TestGetOrgDevices_ConcurrentPartitions(t *testing.T) {
+	var filtered atomic.Int32
+	devices := partitionedInventory()
+	server := newPartitionedInventoryServer(t, devices, len(devices), &filtered)
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetPageConcurrency(3)
+	got, err := c.GetOrgDevices(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(devices) {
+		t.Fatalf("expected %d devices, got %d", len(devices), len(got))
+	}
+	if filtered.Load() == 0 {
+		t.Error("expected the inventory to be read by product family")
+	}
+	if got[0].Attributes.ProductFamily != "iPhone" || got[len(got)-1].Attributes.ProductFamily != "Mac" {
+		t.Errorf("expected devices grouped in product family order, got %s first and %s last",
+			got[0].Attributes.ProductFamily, got[len(got)-1].Attributes.ProductFamily)
+	}
+}
+
+func TestGetOrgDevices_PartitionsFallBackWhenIncomplete(t *testing.T) {
+	var filtered atomic.Int32
+	devices := partitionedInventory()
+	devices = append(devices, OrgDevice{ID: "DEV-NEW", Attributes: DeviceAttribute{ProductFamily: "FutureDevice"}})
+	server := newPartitionedInventoryServer(t, devices, len(devices), &filtered)
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetPageConcurrency(3)
+	got, err := c.GetOrgDevices(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(devices) || got[len(got)-1].ID != "DEV-NEW" {
+		t.Errorf("expected the page-by-page inventory of %d devices, got %d", len(devices), len(got))
+	}
+}
+
+func TestGetOrgDevices_SequentialByDefault(t *testing.T) {
+	var filtered atomic.Int32
+	devices := partitionedInventory()
+	server := newPartitionedInventoryServer(t, devices, len(devices), &filtered)
+	defer server.Close()
+
+	got, err := newTestClient(t, server).GetOrgDevices(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(devices) || filtered.Load() != 0 {
+		t.Errorf("expected a page-by-page read of %d devices, got %d with %d filtered requests", len(devices), len(got), filtered.Load())
+	}
+}
+
+// newFilterIgnoringInventoryServer serves every device on every request, ignoring any filter,
+// in pages of up to limit devices, and counts the requests that carried a filter.
+func newFilterIgnoringInventoryServer(t *testing.T, devices []OrgDevice, filtered *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("filter[productFamily]") != "" {
+			filtered.Add(1)
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		start, _ := strconv.Atoi(q.Get("cursor"))
+		end := min(start+limit, len(devices))
+		resp := OrgDevicesResponse{Data: devices[start:end], Meta: Meta{Paging: Paging{Limit: limit, Total: len(devices)}}}
+		if end < len(devices) {
+			resp.Meta.Paging.NextCursor = strconv.Itoa(end)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, resp))
+	}))
+}
+
+func TestGetOrgDevices_IgnoredProductFamilyFilter(t *testing.T) {
+	devices := partitionedInventory()
+
+	t.Run("partitions", func(t *testing.T) {
+		var filtered atomic.Int32
+		server := newFilterIgnoringInventoryServer(t, devices, &filtered)
+		defer server.Close()
+
+		c := newTestClient(t, server)
+		c.SetPageConcurrency(1 + len(OrgDeviceProductFamilyValues()))
+		got, err := c.GetOrgDevices(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != len(devices) {
+			t.Errorf("expected the page-by-page inventory of %d devices, got %d", len(devices), len(got))
+		}
+		if n := int(filtered.Load()); n > len(OrgDeviceProductFamilyValues()) {
+			t.Errorf("expected each partition to stop after its first page, got %d filtered requests", n)
+		}
+	})
+
+	t.Run("explicit_filter", func(t *testing.T) {
+		var filtered atomic.Int32
+		server := newFilterIgnoringInventoryServer(t, devices, &filtered)
+		defer server.Close()
+
+		got, err := newTestClient(t, server).GetOrgDevices(context.Background(), url.Values{"filter[productFamily]": {"Mac"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if filtered.Load() != 1 {
+			t.Errorf("expected the filtered read to stop after its first page, got %d filtered requests", filtered.Load())
+		}
+		if len(got) != 500 {
+			t.Errorf("expected the 500 Mac devices, got %d", len(got))
+		}
+		for _, device := range got {
+			if device.Attributes.ProductFamily != "Mac" {
+				t.Fatalf("expected only Mac devices, got %s", device.Attributes.ProductFamily)
+			}
+		}
+	})
+}
+
+func TestMissingOrgDevices(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.URL.Query().Get("fields[orgDevices]"); got != "serialNumber" {
+			t.Errorf("expected fields[orgDevices]=serialNumber, got %q", got)
+		}
+		id := strings.TrimPrefix(r.URL.Path, "/v1/orgDevices/")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(id, "TYPO"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"id":"e1","status":"404","code":"NOT_FOUND","title":"Not Found","detail":"No device"}]}`))
+		case id == "BROKEN":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errors":[{"id":"e2","status":"500","code":"INTERNAL","title":"Internal Error","detail":"Something broke"}]}`))
+		default:
+			_, _ = w.Write(mustMarshalJSON(t, OrgDeviceResponse{Data: OrgDevice{ID: id}}))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	ids := []string{"TYPO2", "C02AAA"}
+	for i := range 150 {
+		ids = append(ids, fmt.Sprintf("DEV%03d", i))
+	}
+	ids = append(ids, "TYPO1")
+	missing, err := c.MissingOrgDevices(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(missing, ",") != "TYPO1,TYPO2" {
+		t.Errorf("expected TYPO1,TYPO2 to be missing, got %v", missing)
+	}
+	if int(requests.Load()) != len(ids) {
+		t.Errorf("expected %d requests, got %d", len(ids), requests.Load())
+	}
+
+	requests.Store(0)
+	ids = append([]string{"BROKEN"}, ids...)
+	if _, err := c.MissingOrgDevices(context.Background(), ids); err == nil || !strings.Contains(err.Error(), "BROKEN") {
+		t.Fatalf("expected an error naming BROKEN, got %v", err)
+	}
+	if int(requests.Load()) > missingOrgDevicesBatchSize {
+		t.Errorf("expected the check to stop after the first batch, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultPageLimit is the page size requested from collection endpoints unless a read
+// overrides it.
+const defaultPageLimit = 1000
+
+// errStopPaging can be returned by a paginate page callback to stop reading further pages
+// without failing the read.
+var errStopPaging = errors.New("stop paging")
+
+// DefaultPageConcurrency is the number of partitions of a large collection read in parallel
+// when no limit has been configured with SetPageConcurrency. One reads every collection
+// page by page.
+const DefaultPageConcurrency = 1
+
+// SetPageConcurrency sets how many partitions of a large collection, such as the devices of
+// each product family, are paged through in parallel. Cursors are opaque, so the pages of one
+// partition are always read in sequence. Values below one restore the default.
+func (c *Client) SetPageConcurrency(n int) {
+	c.pageConcurrency = n
+}
+
+// PageConcurrency returns the effective number of partitions read in parallel, halved for each
+// TuningLevel.
+func (c *Client) PageConcurrency() int {
+	if c.pageConcurrency < 1 {
+		return DefaultPageConcurrency
+	}
+	return c.tuning.scale(c.pageConcurrency, 1)
+}
+
+// fetchPartitions reads n independent partitions of a collection using at most limit
+// concurrent workers and returns their items concatenated in partition order, so that the
+// result does not depend on which partition finishes first. Each request still waits for a
+// client-wide request slot and honours rate-limit pauses.
+func fetchPartitions[T any](ctx context.Context, limit, n int, fetch func(ctx context.Context, i int) ([]T, error)) ([]T, error) {
+	partitions := make([][]T, n)
+	err := ForEachConcurrent(ctx, limit, n, func(ctx context.Context, i int) error {
+		var err error
+		partitions[i], err = fetch(ctx, i)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(partitions...), nil
+}
+
+// paginate reads the collection at endpoint page by page, following cursors until the last
+// page, and passes each page's items to onPage in order. Every page carries queryParams, and
+// the page size is the numeric "limit" query parameter when the caller set one, and limit
+// otherwise, reduced after repeated rate limits; values below one request defaultPageLimit.
+// Pages are decoded with decodePage and reported to the client's logger. When onPage returns
+// errStopPaging no further pages are requested and paginate returns nil; any other error is
+// returned as is.
+func paginate[T any](ctx context.Context, c *Client, endpoint string, queryParams url.Values, limit int, onPage func(items []T) error) error {
+	return paginateFrom(ctx, c, endpoint, queryParams, c.tunedPageLimit(limit), "", func(items []T, _ string) error {
+		return onPage(items)
+	})
+}
+
+// paginateFrom implements paginate starting from cursor, or from the first page when cursor is
+// empty, and also passes onPage the cursor of the page that follows, which is empty after the
+// last page.
+func paginateFrom[T any](ctx context.Context, c *Client, endpoint string, queryParams url.Values, limit int, cursor string, onPage func(items []T, nextCursor string) error) error {
+	limit = pageLimit(queryParams, limit)
+	pages := c.newPageLogger(endpoint, limit)
+	nextCursor := cursor
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			c.pageURL(endpoint, queryParams, limit, nextCursor), nil)
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.doRequest(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		var items []T
+		var meta Meta
+		if err := func() error {
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return c.handleErrorResponse(resp)
+			}
+			if err := decodePage(ctx, pages, resp.Body, &items, &meta); err != nil {
+				return fmt.Errorf("failed to decode response JSON: %w", err)
+			}
+			return nil
+		}(); err != nil {
+			return err
+		}
+
+		pages.logPage(ctx, nextCursor, len(items))
+		if err := onPage(items, meta.Paging.NextCursor); err != nil {
+			if errors.Is(err, errStopPaging) {
+				return nil
+			}
+			return err
+		}
+
+		nextCursor = meta.Paging.NextCursor
+		if nextCursor == "" {
+			return nil
+		}
+	}
+}
+
+// pageLimit returns the page size for a read of a collection with queryParams: the numeric
+// "limit" query parameter when there is one, and fallback otherwise, or defaultPageLimit when
+// the result is below one.
+func pageLimit(queryParams url.Values, fallback int) int {
+	limit := fallback
+	if parsed, err := strconv.Atoi(queryParams.Get("limit")); err == nil {
+		limit = parsed
+	}
+	if limit < 1 {
+		return defaultPageLimit
+	}
+	return limit
+}
+
+// collectPages returns the items of every page of the collection at endpoint, read with
+// paginate. After each page the items read so far and the next cursor are checkpointed, so
+// that when the read fails partway through, repeating it later in the same Terraform operation
+// resumes from the page that failed instead of the first page. A resumed read whose cursor the
+// API rejects starts again from the first page.
+func collectPages[T any](ctx context.Context, c *Client, endpoint string, queryParams url.Values, limit int) ([]T, error) {
+	return collectCheckedPages[T](ctx, c, endpoint, queryParams, limit, nil)
+}
+
+// collectCheckedPages implements collectPages, passing the items of each page to check, when
+// it is not nil, before they are kept. An error from check stops the read, discards its
+// checkpoint and is returned as is.
+func collectCheckedPages[T any](ctx context.Context, c *Client, endpoint string, queryParams url.Values, limit int, check func(items []T) error) ([]T, error) {
+	limit = pageLimit(queryParams, c.tunedPageLimit(limit))
+	key := pageCheckpointKey(endpoint, queryParams, limit)
+	var all []T
+	cursor, resumedPages := "", 0
+	if checkpoint, ok := c.pageCheckpoints.load(key, c.Clock().Now()); ok {
+		if items, ok := checkpoint.items.([]T); ok {
+			all, cursor, resumedPages = slices.Clip(items), checkpoint.cursor, checkpoint.pages
+			if c.logger != nil {
+				c.logger.LogWarning(ctx, "Resuming paginated read from the checkpoint of a failed attempt", map[string]any{
+					"endpoint":    endpoint,
+					"pages_read":  checkpoint.pages,
+					"items_read":  len(items),
+					"checkpoint":  checkpoint.savedAt.UTC().Format(time.RFC3339),
+					"next_cursor": cursor,
+				})
+			}
+		}
+	}
+
+	pages := resumedPages
+	var rejected bool
+	collect := func(items []T, nextCursor string) error {
+		if check != nil {
+			if err := check(items); err != nil {
+				rejected = true
+				return err
+			}
+		}
+		all = append(all, items...)
+		pages++
+		if nextCursor != "" {
+			c.pageCheckpoints.save(key, pageCheckpoint{cursor: nextCursor, items: all, pages: pages, savedAt: c.Clock().Now()})
+		}
+		return nil
+	}
+	err := paginateFrom(ctx, c, endpoint, queryParams, limit, cursor, collect)
+	if apiErr, ok := AsAPIError(err); ok && cursor != "" && pages == resumedPages && apiErr.StatusCode == http.StatusBadRequest {
+		c.pageCheckpoints.remove(key)
+		all, pages = nil, 0
+		err = paginateFrom(ctx, c, endpoint, queryParams, limit, "", collect)
+	}
+	if err != nil {
+		if rejected {
+			c.pageCheckpoints.remove(key)
+		}
+		return nil, err
+	}
+	c.pageCheckpoints.remove(key)
+	return all, nil
+}
+
+// collectLinkageIDs returns the IDs of the linkages of resourceType in every page of the
+// relationship at endpoint, read with collectPages. An empty resourceType keeps every linkage.
+func collectLinkageIDs(ctx context.Context, c *Client, endpoint, resourceType string) ([]string, error) {
+	items, err := collectPages[Data](ctx, c, endpoint, nil, defaultPageLimit)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, item := range items {
+		if resourceType == "" || item.Type == resourceType {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids, nil
+}
+
+// pageCheckpointTTL is how long after it was saved a checkpoint may be resumed. Older
+// checkpoints are discarded, because their cursors may have expired and the collection may
+// have changed.
+const pageCheckpointTTL = 15 * time.Minute
+
+// pageCheckpoint records the progress of a paginated read that has not finished: the items
+// read so far, as a []T, and the cursor of the next page.
+type pageCheckpoint struct {
+	cursor  string
+	items   any
+	pages   int
+	savedAt time.Time
+}
+
+// pageCheckpoints holds the checkpoints of the client's unfinished paginated reads, keyed by
+// pageCheckpointKey. They live in memory only, so a checkpoint is resumed only within the
+// Terraform operation that saved it.
+type pageCheckpoints struct {
+	mu      sync.Mutex
+	entries map[string]pageCheckpoint
+}
+
+// pageCheckpointKey identifies the paginated read of endpoint with queryParams and limit.
+func pageCheckpointKey(endpoint string, queryParams url.Values, limit int) string {
+	return fmt.Sprintf("%s?%s#%d", endpoint, queryParams.Encode(), limit)
+}
+
+// load returns the checkpoint saved for key, and false when there is none or it is older
+// than pageCheckpointTTL at now.
+func (p *pageCheckpoints) load(key string, now time.Time) (pageCheckpoint, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	checkpoint, ok := p.entries[key]
+	if !ok {
+		return pageCheckpoint{}, false
+	}
+	if now.Sub(checkpoint.savedAt) > pageCheckpointTTL {
+		delete(p.entries, key)
+		return pageCheckpoint{}, false
+	}
+	return checkpoint, true
+}
+
+// save records checkpoint for key, replacing any earlier one.
+func (p *pageCheckpoints) save(key string, checkpoint pageCheckpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.entries == nil {
+		p.entries = make(map[string]pageCheckpoint)
+	}
+	p.entries[key] = checkpoint
+}
+
+// remove discards the checkpoint for key.
+func (p *pageCheckpoints) remove(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, key)
+}
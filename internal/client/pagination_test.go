@@ -0,0 +1,279 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPageConcurrency(t *testing.T) {
+	c := &Client{}
+	if got := c.PageConcurrency(); got != DefaultPageConcurrency {
+		t.Errorf("expected default %d, got %d", DefaultPageConcurrency, got)
+	}
+	c.SetPageConcurrency(4)
+	if got := c.PageConcurrency(); got != 4 {
+		t.Errorf("expected 4, got %d", got)
+	}
+	c.SetPageConcurrency(0)
+	if got := c.PageConcurrency(); got != DefaultPageConcurrency {
+		t.Errorf("expected values below one to restore the default, got %d", got)
+	}
+}
+
+func TestFetchPartitions_PreservesPartitionOrder(t *testing.T) {
+	got, err := fetchPartitions(context.Background(), 3, 3, func(ctx context.Context, i int) ([]int, error) {
+		time.Sleep(time.Duration(3-i) * 5 * time.Millisecond)
+		return []int{i * 10, i*10 + 1}, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{0, 1, 10, 11, 20, 21}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFetchPartitions_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	_, err := fetchPartitions(context.Background(), 2, 4, func(ctx context.Context, i int) ([]int, error) {
+		if i == 2 {
+			return nil, wantErr
+		}
+		return []int{i}, nil
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func newPagedServer(t *testing.T, pages int, requests *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		page := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+		next := ""
+		if page+1 < pages {
+			next = strconv.Itoa(page + 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, map[string]any{
+			"data": []Data{{ID: fmt.Sprintf("item-%d", page), Type: "items"}},
+			"meta": Meta{Paging: Paging{NextCursor: next}},
+		}))
+	}))
+}
+
+func TestPaginate(t *testing.T) {
+	var requests []string
+	server := newPagedServer(t, 3, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	var pages [][]Data
+	err := paginate(context.Background(), c, "/v1/items", nil, 50, func(items []Data) error {
+		pages = append(pages, items)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != 3 || pages[2][0].ID != "item-2" {
+		t.Errorf("expected a callback for each of 3 pages in order, got %v", pages)
+	}
+	if want := []string{"limit=50", "cursor=1&limit=50", "cursor=2&limit=50"}; !slices.Equal(requests, want) {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestPaginate_DefaultLimit(t *testing.T) {
+	var requests []string
+	server := newPagedServer(t, 1, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	if _, err := collectPages[Data](context.Background(), c, "/v1/items", nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "limit=" + strconv.Itoa(defaultPageLimit); len(requests) != 1 || requests[0] != want {
+		t.Errorf("expected a single request with %q, got %v", want, requests)
+	}
+}
+
+func TestPaginate_QueryLimit(t *testing.T) {
+	var requests []string
+	server := newPagedServer(t, 2, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	if _, err := collectPages[Data](context.Background(), c, "/v1/items", url.Values{"limit": {"25"}}, defaultPageLimit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"limit=25", "cursor=1&limit=25"}; !slices.Equal(requests, want) {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestPaginate_StopPaging(t *testing.T) {
+	var requests []string
+	server := newPagedServer(t, 5, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	calls := 0
+	err := paginate(context.Background(), c, "/v1/items", nil, 1, func(items []Data) error {
+		calls++
+		if calls == 2 {
+			return errStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected stopping early to succeed, got %v", err)
+	}
+	if calls != 2 || len(requests) != 2 {
+		t.Errorf("expected 2 pages to be read, got %d callbacks and %d requests", calls, len(requests))
+	}
+}
+
+func TestPaginate_CallbackError(t *testing.T) {
+	var requests []string
+	server := newPagedServer(t, 3, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	wantErr := errors.New("boom")
+	err := paginate(context.Background(), c, "/v1/items", nil, 1, func(items []Data) error {
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected %v, got %v", wantErr, err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected no further pages after an error, got %d requests", len(requests))
+	}
+}
+
+func TestCollectLinkageIDs_FiltersType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(mustMarshalJSON(t, map[string]any{
+			"data": []Data{{ID: "a", Type: "users"}, {ID: "b", Type: "other"}, {ID: "c", Type: "users"}},
+			"meta": Meta{},
+		}))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	ids, err := collectLinkageIDs(context.Background(), c, "/v1/userGroups/g1/relationships/users", "users")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"a", "c"}; !slices.Equal(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+}
+
+func TestCollectPages_ResumesFromCheckpoint(t *testing.T) {
+	var requests []string
+	failCursor := "2"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		requests = append(requests, cursor)
+		if cursor == failCursor {
+			failCursor = ""
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		page, _ := strconv.Atoi(cursor)
+		next := ""
+		if page+1 < 4 {
+			next = strconv.Itoa(page + 1)
+		}
+		_, _ = w.Write(mustMarshalJSON(t, map[string]any{
+			"data": []Data{{ID: fmt.Sprintf("item-%d", page), Type: "items"}},
+			"meta": Meta{Paging: Paging{NextCursor: next}},
+		}))
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	ctx := context.Background()
+
+	if _, err := collectPages[Data](ctx, c, "/v1/items", nil, 1); err == nil {
+		t.Fatal("expected the first read to fail")
+	}
+	items, err := collectPages[Data](ctx, c, "/v1/items", nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	if want := []string{"item-0", "item-1", "item-2", "item-3"}; !slices.Equal(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if want := []string{"", "1", "2", "2", "3"}; !slices.Equal(requests, want) {
+		t.Errorf("expected the retry to resume at the failed page, got cursors %v", requests)
+	}
+	if _, ok := c.pageCheckpoints.load(pageCheckpointKey("/v1/items", nil, 1), c.Clock().Now()); ok {
+		t.Error("expected the checkpoint to be removed after the read completed")
+	}
+}
+
+func TestCollectPages_RestartsWhenCursorRejected(t *testing.T) {
+	var requests []string
+	server := newPagedServer(t, 2, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	key := pageCheckpointKey("/v1/items", nil, 1)
+	c.pageCheckpoints.save(key, pageCheckpoint{cursor: "expired", items: []Data{{ID: "stale"}}, pages: 1, savedAt: c.Clock().Now()})
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "expired" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"status":"400","code":"PARAMETER_ERROR.INVALID","title":"Invalid cursor"}]}`))
+			return
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer rejecting.Close()
+	c.baseURL = rejecting.URL
+
+	items, err := collectPages[Data](context.Background(), c, "/v1/items", nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != "item-0" {
+		t.Errorf("expected a full read from the first page, got %v", items)
+	}
+}
+
+func TestPageCheckpoints_Expire(t *testing.T) {
+	var checkpoints pageCheckpoints
+	savedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	checkpoints.save("key", pageCheckpoint{cursor: "5", savedAt: savedAt})
+
+	if _, ok := checkpoints.load("key", savedAt.Add(pageCheckpointTTL)); !ok {
+		t.Error("expected a checkpoint within the TTL to be loaded")
+	}
+	if _, ok := checkpoints.load("key", savedAt.Add(pageCheckpointTTL+time.Second)); ok {
+		t.Error("expected an expired checkpoint to be discarded")
+	}
+	if _, ok := checkpoints.load("key", savedAt); ok {
+		t.Error("expected an expired checkpoint to be removed")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ProfileClientFactory creates a client authenticated with the named credentials profile.
+type ProfileClientFactory func(profile string) (*Client, error)
+
+// profileClients holds the clients created for credentials profiles, keyed by profile name.
+type profileClients struct {
+	mu      sync.Mutex
+	factory ProfileClientFactory
+	clients map[string]*Client
+}
+
+// SetProfileClientFactory enables ForProfile, creating the client for each credentials profile
+// with factory the first time it is requested. A nil factory disables it.
+func (c *Client) SetProfileClientFactory(factory ProfileClientFactory) {
+	c.profiles = &profileClients{factory: factory, clients: make(map[string]*Client)}
+}
+
+// ForProfile returns a client authenticated with the named credentials profile, sharing the
+// client's retry, concurrency, audit, serial lock and read-only settings. An empty profile
+// returns the client itself. Clients are created once per profile and reused.
+func (c *Client) ForProfile(profile string) (*Client, error) {
+	if profile == "" {
+		return c, nil
+	}
+	if c.profiles == nil || c.profiles.factory == nil {
+		return nil, errors.New("credentials profiles are not available for this provider configuration")
+	}
+
+	c.profiles.mu.Lock()
+	defer c.profiles.mu.Unlock()
+	if existing, ok := c.profiles.clients[profile]; ok {
+		return existing, nil
+	}
+
+	created, err := c.profiles.factory(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure credentials profile %q: %w", profile, err)
+	}
+	created.inheritSettings(c)
+	c.profiles.clients[profile] = created
+	return created, nil
+}
+
+// inheritSettings copies the provider-level settings of parent that do not depend on the
+// credentials in use.
+func (c *Client) inheritSettings(parent *Client) {
+	c.version = parent.version
+	c.logger = parent.logger
+	c.audit = parent.audit
+	c.maxConcurrency = parent.maxConcurrency
+	c.clock = parent.clock
+	c.retryPolicy = parent.retryPolicy
+	c.acceptLanguage = parent.acceptLanguage
+	c.skipUndecodable = parent.skipUndecodable
+	c.apiTimeBudget = parent.apiTimeBudget
+	c.maxInFlight = parent.maxInFlight
+	c.SetRequestsPerMinute(parent.requestsPerMinute)
+	c.deviceWarningThreshold = parent.deviceWarningThreshold
+	c.activityChunkSize = parent.activityChunkSize
+	c.serialLocks = parent.serialLocks
+	c.serialLockOwner = parent.serialLockOwner
+	c.readOnly = parent.readOnly
+	c.pageConcurrency = parent.pageConcurrency
+	c.offlineFallback = parent.offlineFallback
+	c.redactSerials = parent.redactSerials
+	c.features = parent.features
+	if parent.responseCache != nil {
+		c.SetResponseCache(parent.responseCache.ttl, parent.responseCache.persist)
+	}
+}
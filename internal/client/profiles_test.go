@@ -0,0 +1,56 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestForProfile(t *testing.T) {
+	parent := &Client{baseURL: "https://api-business.apple.com"}
+	parent.SetReadOnly(true)
+	parent.SetActivityChunkSize(25)
+
+	var created []string
+	parent.SetProfileClientFactory(func(profile string) (*Client, error) {
+		created = append(created, profile)
+		if profile == "missing" {
+			return nil, errors.New("profile not found")
+		}
+		return &Client{baseURL: "https://api-school.apple.com"}, nil
+	})
+
+	if c, err := parent.ForProfile(""); err != nil || c != parent {
+		t.Errorf("expected an empty profile to return the client itself, got %p, %v", c, err)
+	}
+
+	first, err := parent.ForProfile("acquired")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.baseURL != "https://api-school.apple.com" {
+		t.Errorf("expected the profile's base URL, got %q", first.baseURL)
+	}
+	if !first.readOnly || first.ActivityChunkSize() != 25 {
+		t.Errorf("expected provider settings to be inherited, got readOnly=%v chunkSize=%d", first.readOnly, first.ActivityChunkSize())
+	}
+
+	second, _ := parent.ForProfile("acquired")
+	if second != first || len(created) != 1 {
+		t.Errorf("expected the profile client to be reused, created %v", created)
+	}
+
+	if _, err := parent.ForProfile("missing"); err == nil || !strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("expected the factory error naming the profile, got %v", err)
+	}
+}
+
+func TestForProfile_Disabled(t *testing.T) {
+	c := &Client{}
+	if _, err := c.ForProfile("acquired"); err == nil {
+		t.Error("expected an error when no profile factory is configured")
+	}
+}
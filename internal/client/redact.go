@@ -0,0 +1,127 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// serialPattern matches the tokens RedactText treats as serial numbers: 10 to 12 upper-case
+// letters and digits, standing alone.
+var serialPattern = regexp.MustCompile(`\b[A-Z0-9]{10,12}\b`)
+
+// redactedSerialPrefix starts every redacted serial number, so that redacted values are
+// recognizable in logs.
+const redactedSerialPrefix = "serial-"
+
+// SetRedactSerials enables or disables redacting device serial numbers in the client's logs and
+// in the text returned by RedactSerial, RedactSerials and RedactText. Enabling it wraps the
+// logger set by SetLogger, so it must be called after SetLogger.
+func (c *Client) SetRedactSerials(enabled bool) {
+	c.redactSerials = enabled
+	if !enabled || c.logger == nil {
+		return
+	}
+	if _, wrapped := c.logger.(*redactingLogger); !wrapped {
+		c.logger = &redactingLogger{next: c.logger, client: c}
+		if c.tokenSource != nil {
+			c.tokenSource.setLogger(c.logger)
+		}
+	}
+}
+
+// RedactsSerials reports whether serial number redaction is enabled.
+func (c *Client) RedactsSerials() bool {
+	return c != nil && c.redactSerials
+}
+
+// RedactSerial returns serial unchanged, or, when redaction is enabled, a stable token derived
+// from its SHA-256 hash, such as serial-3fa2c1d09b, so that log lines about the same device can
+// still be correlated.
+func (c *Client) RedactSerial(serial string) string {
+	if !c.RedactsSerials() || serial == "" {
+		return serial
+	}
+	sum := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(serial))))
+	return redactedSerialPrefix + hex.EncodeToString(sum[:])[:10]
+}
+
+// RedactSerials applies RedactSerial to each of serials, returning a new slice.
+func (c *Client) RedactSerials(serials []string) []string {
+	if !c.RedactsSerials() {
+		return serials
+	}
+	redacted := make([]string, len(serials))
+	for i, serial := range serials {
+		redacted[i] = c.RedactSerial(serial)
+	}
+	return redacted
+}
+
+// RedactText replaces every token in text that looks like a serial number, containing both
+// letters and digits, with its RedactSerial token when redaction is enabled.
+func (c *Client) RedactText(text string) string {
+	if !c.RedactsSerials() {
+		return text
+	}
+	return serialPattern.ReplaceAllStringFunc(text, func(token string) string {
+		if !strings.ContainsAny(token, "0123456789") || !strings.ContainsAny(token, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			return token
+		}
+		return c.RedactSerial(token)
+	})
+}
+
+// redactFields returns a copy of fields with serial numbers redacted from every string value.
+func (c *Client) redactFields(fields map[string]any) map[string]any {
+	redacted := make(map[string]any, len(fields))
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			redacted[key] = c.RedactText(v)
+		case []string:
+			values := make([]string, len(v))
+			for i, s := range v {
+				values[i] = c.RedactText(s)
+			}
+			redacted[key] = values
+		default:
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// redactingLogger redacts serial numbers from everything logged through it before passing it
+// on to next.
+type redactingLogger struct {
+	next   Logger
+	client *Client
+}
+
+func (l *redactingLogger) LogRequest(ctx context.Context, method, url string, body []byte) {
+	l.next.LogRequest(ctx, method, l.client.RedactText(url), []byte(l.client.RedactText(string(body))))
+}
+
+func (l *redactingLogger) LogResponse(ctx context.Context, statusCode int, headers http.Header, body []byte) {
+	l.next.LogResponse(ctx, statusCode, headers, []byte(l.client.RedactText(string(body))))
+}
+
+func (l *redactingLogger) LogPage(ctx context.Context, page PageFetch) {
+	page.Endpoint = l.client.RedactText(page.Endpoint)
+	l.next.LogPage(ctx, page)
+}
+
+func (l *redactingLogger) LogAuth(ctx context.Context, message string, fields map[string]any) {
+	l.next.LogAuth(ctx, l.client.RedactText(message), l.client.redactFields(fields))
+}
+
+func (l *redactingLogger) LogWarning(ctx context.Context, message string, fields map[string]any) {
+	l.next.LogWarning(ctx, l.client.RedactText(message), l.client.redactFields(fields))
+}
@@ -0,0 +1,116 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// captureLogger is a Logger that records everything logged through it.
+type captureLogger struct {
+	urls     []string
+	bodies   []string
+	messages []string
+	fields   []map[string]any
+}
+
+func (l *captureLogger) LogRequest(ctx context.Context, method, url string, body []byte) {
+	l.urls = append(l.urls, url)
+	l.bodies = append(l.bodies, string(body))
+}
+
+func (l *captureLogger) LogResponse(ctx context.Context, statusCode int, headers http.Header, body []byte) {
+	l.bodies = append(l.bodies, string(body))
+}
+
+func (l *captureLogger) LogPage(ctx context.Context, page PageFetch) {
+	l.urls = append(l.urls, page.Endpoint)
+}
+
+func (l *captureLogger) LogAuth(ctx context.Context, message string, fields map[string]any) {
+	l.messages = append(l.messages, message)
+	l.fields = append(l.fields, fields)
+}
+
+func (l *captureLogger) LogWarning(ctx context.Context, message string, fields map[string]any) {
+	l.messages = append(l.messages, message)
+	l.fields = append(l.fields, fields)
+}
+
+func TestRedactSerial(t *testing.T) {
+	c := &Client{}
+	if got := c.RedactSerial("C02XG0FDH7JY"); got != "C02XG0FDH7JY" {
+		t.Errorf("expected serials to be unchanged when disabled, got %q", got)
+	}
+
+	c.SetRedactSerials(true)
+	got := c.RedactSerial("C02XG0FDH7JY")
+	if !strings.HasPrefix(got, "serial-") || len(got) != len("serial-")+10 || strings.Contains(got, "C02XG0FDH7JY") {
+		t.Errorf("expected a hashed serial token, got %q", got)
+	}
+	if again := c.RedactSerial(" c02xg0fdh7jy "); again != got {
+		t.Errorf("expected the token to ignore case and whitespace, got %q and %q", got, again)
+	}
+	if other := c.RedactSerial("DMPXJ1ABCDEF"); other == got {
+		t.Errorf("expected different serials to produce different tokens, got %q", other)
+	}
+	if got := c.RedactSerials([]string{"C02XG0FDH7JY", ""}); got[0] != c.RedactSerial("C02XG0FDH7JY") || got[1] != "" {
+		t.Errorf("unexpected redacted serials: %v", got)
+	}
+
+	var nilClient *Client
+	if got := nilClient.RedactSerial("C02XG0FDH7JY"); got != "C02XG0FDH7JY" {
+		t.Errorf("expected a nil client not to redact, got %q", got)
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	c := &Client{}
+	c.SetRedactSerials(true)
+
+	text := "Device C02XG0FDH7JY failed: NOT_FOUND for 1234567890 in ABCDEFGHIJ"
+	got := c.RedactText(text)
+	want := "Device " + c.RedactSerial("C02XG0FDH7JY") + " failed: NOT_FOUND for 1234567890 in ABCDEFGHIJ"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSetRedactSerials_WrapsLogger(t *testing.T) {
+	recorder := &captureLogger{}
+	c := &Client{}
+	c.SetLogger(recorder)
+	c.SetRedactSerials(true)
+	c.SetRedactSerials(true)
+
+	if _, ok := c.logger.(*redactingLogger); !ok {
+		t.Fatalf("expected the logger to be wrapped, got %T", c.logger)
+	}
+	if inner := c.logger.(*redactingLogger).next; inner != recorder {
+		t.Fatalf("expected the logger to be wrapped once, got %T", inner)
+	}
+
+	ctx := context.Background()
+	token := c.RedactSerial("C02XG0FDH7JY")
+	c.logger.LogRequest(ctx, http.MethodGet, "https://api-business.apple.com/v1/orgDevices/C02XG0FDH7JY", []byte(`{"serial":"C02XG0FDH7JY"}`))
+	c.logger.LogResponse(ctx, http.StatusOK, nil, []byte(`{"data":{"id":"C02XG0FDH7JY"}}`))
+	c.logger.LogWarning(ctx, "Device C02XG0FDH7JY is stale", map[string]any{
+		"serial":  "C02XG0FDH7JY",
+		"serials": []string{"C02XG0FDH7JY"},
+		"count":   1,
+	})
+
+	for _, logged := range append(append(recorder.urls, recorder.bodies...), recorder.messages...) {
+		if strings.Contains(logged, "C02XG0FDH7JY") || !strings.Contains(logged, token) {
+			t.Errorf("expected the serial to be redacted, got %q", logged)
+		}
+	}
+	fields := recorder.fields[0]
+	if fields["serial"] != token || fields["serials"].([]string)[0] != token || fields["count"] != 1 {
+		t.Errorf("unexpected redacted fields: %v", fields)
+	}
+}
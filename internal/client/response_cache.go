@@ -0,0 +1,239 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheableCollections lists the top-level API collections whose GET responses the response
+// cache keeps. Activities are never cached, because their status is polled until it changes.
+// Only the collections and their records are cached, not their relationships: assignments
+// settle some time after an activity completes, and verification and drift reads must see
+// them do so.
+var cacheableCollections = []string{"mdmServers", "orgDevices"}
+
+// cachedResponse is a successful GET response kept by the response cache.
+type cachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+// validated reports whether the response carries an ETag or Last-Modified header with which
+// it can be revalidated.
+func (r *cachedResponse) validated() bool {
+	return r.Header.Get("ETag") != "" || r.Header.Get("Last-Modified") != ""
+}
+
+// responseCache keeps the successful GET responses of cacheableCollections, keyed by URL and
+// Accept-Language. Responses carrying an ETag or Last-Modified header are revalidated with a
+// conditional request on every read and reused when the API answers 304 Not Modified; other
+// responses are reused without a request until ttl has elapsed. When dir is set, responses
+// are also written there so that later Terraform operations can revalidate them.
+type responseCache struct {
+	ttl     time.Duration
+	persist bool
+	dir     string
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+// SetResponseCache enables caching the responses of reads of MDM servers and organization
+// devices for ttl, or disables it when ttl is not positive. When persist is true, cached
+// responses are also kept in the provider cache directory across Terraform operations. Any
+// request that changes data, and any activity reaching a final status, empties the cache,
+// because assignments change what these reads return.
+func (c *Client) SetResponseCache(ttl time.Duration, persist bool) {
+	if ttl <= 0 {
+		c.responseCache = nil
+		return
+	}
+	cache := &responseCache{ttl: ttl, persist: persist, entries: make(map[string]*cachedResponse)}
+	if persist && c.tokenSource != nil {
+		urlHash := sha256.Sum256([]byte(c.baseURL))
+		cache.dir = filepath.Join(c.tokenSource.cacheDirectory(),
+			fmt.Sprintf("responses_%s_%s", c.tokenSource.getConfigHash(), hex.EncodeToString(urlHash[:])[:8]))
+	}
+	c.responseCache = cache
+}
+
+// cacheable reports whether GET responses for target may be cached.
+func (c *Client) cacheable(target *url.URL) bool {
+	if c.responseCache == nil {
+		return false
+	}
+	segments := strings.Split(strings.TrimPrefix(target.Path, "/"), "/")
+	if len(segments) < 2 || len(segments) > 3 || segments[0] != "v1" {
+		return false
+	}
+	for _, collection := range cacheableCollections {
+		if segments[1] == collection {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the response cached for key, loading it from disk when it is not in memory,
+// and whether it is fresh enough to be used without a request at now. A response that is
+// neither fresh nor revalidatable is discarded.
+func (rc *responseCache) lookup(key string, now time.Time) (*cachedResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[key]
+	if !ok {
+		entry = rc.load(key)
+		if entry == nil {
+			return nil, false
+		}
+		rc.entries[key] = entry
+	}
+	if entry.validated() {
+		return entry, false
+	}
+	if now.Sub(entry.StoredAt) < rc.ttl {
+		return entry, true
+	}
+	rc.remove(key)
+	return nil, false
+}
+
+// store caches resp for key, replacing any earlier response.
+func (rc *responseCache) store(key string, resp *cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[key] = resp
+	if rc.dir == "" {
+		return
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(rc.dir, 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(rc.path(key), data, 0o600)
+}
+
+// clear empties the cache, including the responses kept on disk.
+func (rc *responseCache) clear() {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[string]*cachedResponse)
+	if rc.dir != "" {
+		_ = os.RemoveAll(rc.dir)
+	}
+}
+
+// load reads the response kept on disk for key, or returns nil when there is none.
+func (rc *responseCache) load(key string) *cachedResponse {
+	if rc.dir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(rc.path(key))
+	if err != nil {
+		return nil
+	}
+	var entry cachedResponse
+	if json.Unmarshal(data, &entry) != nil {
+		return nil
+	}
+	return &entry
+}
+
+// remove discards the response cached for key. rc.mu must be held.
+func (rc *responseCache) remove(key string) {
+	delete(rc.entries, key)
+	if rc.dir != "" {
+		_ = os.Remove(rc.path(key))
+	}
+}
+
+// path returns the file in which the response for key is kept on disk.
+func (rc *responseCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(rc.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// addValidators makes req conditional on entry still being current.
+func addValidators(req *http.Request, entry *cachedResponse) {
+	if etag := entry.Header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if modified := entry.Header.Get("Last-Modified"); modified != "" {
+		req.Header.Set("If-Modified-Since", modified)
+	}
+}
+
+// sendCached sends the shared read req for key through the response cache: a fresh cached
+// response is returned without a request, a revalidatable one is returned when the API answers
+// 304 Not Modified, and any other successful response replaces it.
+func (c *Client) sendCached(ctx context.Context, req *http.Request, key string) (*sharedResponse, error) {
+	rc := c.responseCache
+	now := c.Clock().Now()
+	entry, fresh := rc.lookup(key, now)
+	if fresh {
+		c.stats.cacheHits.Add(1)
+		return entry.shared(), nil
+	}
+	if entry != nil {
+		addValidators(req, entry)
+	}
+
+	shared, err := c.sendShared(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case shared.resp.StatusCode == http.StatusNotModified && entry != nil:
+		c.stats.cacheHits.Add(1)
+		renewed := *entry
+		renewed.StoredAt = now
+		rc.store(key, &renewed)
+		return renewed.shared(), nil
+	case shared.resp.StatusCode == http.StatusOK:
+		c.stats.cacheMisses.Add(1)
+		rc.store(key, &cachedResponse{
+			StatusCode: shared.resp.StatusCode,
+			Header:     shared.resp.Header.Clone(),
+			Body:       shared.body,
+			StoredAt:   now,
+		})
+	default:
+		c.stats.cacheMisses.Add(1)
+	}
+	return shared, nil
+}
+
+// shared returns the cached response as a sharedResponse.
+func (r *cachedResponse) shared() *sharedResponse {
+	return &sharedResponse{
+		resp: &http.Response{
+			Status:     fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+			StatusCode: r.StatusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     r.Header.Clone(),
+		},
+		body: r.Body,
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newCachingServer(t *testing.T, etag string, requests *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		*requests++
+		if etag != "" {
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, map[string]any{
+			"data": []Data{{ID: "server-1", Type: "mdmServers"}},
+			"meta": Meta{},
+		}))
+	}))
+}
+
+func TestResponseCache_TTL(t *testing.T) {
+	var requests int
+	server := newCachingServer(t, "", &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.SetResponseCache(time.Minute, false)
+
+	for range 2 {
+		items, err := collectPages[Data](context.Background(), c, "/v1/mdmServers", nil, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(items) != 1 || items[0].ID != "server-1" {
+			t.Fatalf("unexpected items: %v", items)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second read to be answered from the cache, got %d requests", requests)
+	}
+	if stats := c.Stats(); stats.CacheHits != 1 || stats.CacheMisses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %d and %d", stats.CacheHits, stats.CacheMisses)
+	}
+}
+
+func TestResponseCache_RevalidatesETag(t *testing.T) {
+	var requests int
+	server := newCachingServer(t, `"v1"`, &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.SetResponseCache(time.Minute, false)
+
+	for range 2 {
+		items, err := collectPages[Data](context.Background(), c, "/v1/mdmServers", nil, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(items) != 1 || items[0].ID != "server-1" {
+			t.Fatalf("unexpected items: %v", items)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected the second read to be revalidated with a request, got %d requests", requests)
+	}
+	if stats := c.Stats(); stats.CacheHits != 1 || stats.CacheMisses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %d and %d", stats.CacheHits, stats.CacheMisses)
+	}
+}
+
+func TestResponseCache_ClearedByMutation(t *testing.T) {
+	var requests int
+	server := newCachingServer(t, "", &requests)
+	defer server.Close()
+	c := newTestClient(t, server)
+	c.SetResponseCache(time.Minute, false)
+
+	if _, err := collectPages[Data](context.Background(), c, "/v1/mdmServers", nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/v1/orgDeviceActivities", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if _, err := collectPages[Data](context.Background(), c, "/v1/mdmServers", nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the read after a mutation to reach the API, got %d requests", requests)
+	}
+}
+
+func TestResponseCache_Cacheable(t *testing.T) {
+	c := &Client{}
+	c.SetResponseCache(time.Minute, false)
+	tests := map[string]bool{
+		"/v1/mdmServers":                                true,
+		"/v1/mdmServers/1":                              true,
+		"/v1/mdmServers/1/relationships/devices":        false,
+		"/v1/orgDevices":                                true,
+		"/v1/orgDevices/1/relationships/assignedServer": false,
+		"/v1/orgDevices/1/assignedServer":               false,
+		"/v1/orgDeviceActivities/1":                     false,
+		"/v1/users":                                     false,
+	}
+	for path, want := range tests {
+		if got := c.cacheable(&url.URL{Path: path}); got != want {
+			t.Errorf("cacheable(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	c.SetResponseCache(0, false)
+	if c.cacheable(&url.URL{Path: "/v1/mdmServers"}) {
+		t.Error("expected nothing to be cacheable when the cache is disabled")
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SerialLockBackend records which workspace owns which device serial numbers, so that two
+// workspaces cannot both assign the same devices.
+type SerialLockBackend interface {
+	// Owners returns the owner of each of serials that has one.
+	Owners(ctx context.Context, serials []string) (map[string]string, error)
+	// Claim records owner as the owner of serials. It fails with a *SerialLockConflictError,
+	// claiming none of them, when any is owned by another owner.
+	Claim(ctx context.Context, owner string, serials []string) error
+	// Release removes the claims owner holds on serials. Serials owned by others are left alone.
+	Release(ctx context.Context, owner string, serials []string) error
+}
+
+// SerialLockConflictError reports serial numbers owned by another workspace.
+type SerialLockConflictError struct {
+	// Owners maps each conflicting serial number to the owner holding it.
+	Owners map[string]string
+}
+
+func (e *SerialLockConflictError) Error() string {
+	return "devices are managed by another workspace: " + FormatSerialOwners(e.Owners)
+}
+
+// FormatSerialOwners formats serial number owners as a sorted, comma-separated list of
+// "serial (owner)" entries.
+func FormatSerialOwners(owners map[string]string) string {
+	entries := make([]string, 0, len(owners))
+	for _, serial := range slices.Sorted(maps.Keys(owners)) {
+		entries = append(entries, fmt.Sprintf("%s (%s)", serial, owners[serial]))
+	}
+	return strings.Join(entries, ", ")
+}
+
+// SetSerialLocks enables serial ownership locking, recording claims in backend under owner.
+// A nil backend disables it.
+func (c *Client) SetSerialLocks(backend SerialLockBackend, owner string) {
+	c.serialLocks = backend
+	c.serialLockOwner = owner
+}
+
+// SerialLockConflicts returns the serials owned by a workspace other than the client's, mapped
+// to their owners. It returns no conflicts when serial locking is disabled.
+func (c *Client) SerialLockConflicts(ctx context.Context, serials []string) (map[string]string, error) {
+	if c.serialLocks == nil || len(serials) == 0 {
+		return nil, nil
+	}
+	owners, err := c.serialLocks.Owners(ctx, serials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read serial locks: %w", err)
+	}
+	maps.DeleteFunc(owners, func(_, owner string) bool {
+		return owner == c.serialLockOwner
+	})
+	if len(owners) == 0 {
+		return nil, nil
+	}
+	return owners, nil
+}
+
+// ClaimSerials records the client's workspace as the owner of serials. It fails with a
+// *SerialLockConflictError when any is owned by another workspace, or with ErrReadOnly on a
+// read-only client, and does nothing when serial locking is disabled.
+func (c *Client) ClaimSerials(ctx context.Context, serials []string) error {
+	if c.serialLocks == nil || len(serials) == 0 {
+		return nil
+	}
+	if err := c.checkReadOnly("CLAIM", "serial locks"); err != nil {
+		return err
+	}
+	return c.serialLocks.Claim(ctx, c.serialLockOwner, serials)
+}
+
+// ReleaseSerials removes the client's workspace's claims on serials. It fails with ErrReadOnly
+// on a read-only client and does nothing when serial locking is disabled.
+func (c *Client) ReleaseSerials(ctx context.Context, serials []string) error {
+	if c.serialLocks == nil || len(serials) == 0 {
+		return nil
+	}
+	if err := c.checkReadOnly("RELEASE", "serial locks"); err != nil {
+		return err
+	}
+	return c.serialLocks.Release(ctx, c.serialLockOwner, serials)
+}
+
+// serialLockFile is the JSON document stored by a file serial lock backend.
+type serialLockFile struct {
+	Owners map[string]string `json:"owners"`
+}
+
+// fileSerialLocks stores serial ownership in a local JSON file shared by every workspace, such
+// as one on a network share. Updates hold an exclusive lock file next to it.
+type fileSerialLocks struct {
+	path string
+}
+
+// serialLockFileRetryInterval is how often a file backend retries taking a held lock file.
+const serialLockFileRetryInterval = 100 * time.Millisecond
+
+// NewFileSerialLocks returns a serial lock backend storing claims in the JSON file at path.
+func NewFileSerialLocks(path string) SerialLockBackend {
+	return &fileSerialLocks{path: path}
+}
+
+func (f *fileSerialLocks) Owners(ctx context.Context, serials []string) (map[string]string, error) {
+	var owners map[string]string
+	err := f.update(ctx, func(doc *serialLockFile) bool {
+		owners = ownersOf(doc.Owners, serials)
+		return false
+	})
+	return owners, err
+}
+
+func (f *fileSerialLocks) Claim(ctx context.Context, owner string, serials []string) error {
+	var conflict error
+	err := f.update(ctx, func(doc *serialLockFile) bool {
+		conflicts := ownersOf(doc.Owners, serials)
+		maps.DeleteFunc(conflicts, func(_, current string) bool { return current == owner })
+		if len(conflicts) > 0 {
+			conflict = &SerialLockConflictError{Owners: conflicts}
+			return false
+		}
+		for _, serial := range serials {
+			doc.Owners[serial] = owner
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return conflict
+}
+
+func (f *fileSerialLocks) Release(ctx context.Context, owner string, serials []string) error {
+	return f.update(ctx, func(doc *serialLockFile) bool {
+		changed := false
+		for _, serial := range serials {
+			if doc.Owners[serial] == owner {
+				delete(doc.Owners, serial)
+				changed = true
+			}
+		}
+		return changed
+	})
+}
+
+// update reads the lock file while holding its lock file, calls fn with its contents, and
+// writes them back when fn reports a change.
+func (f *fileSerialLocks) update(ctx context.Context, fn func(doc *serialLockFile) bool) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("failed to create serial lock directory: %w", err)
+	}
+
+	unlock, err := f.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	doc := serialLockFile{Owners: map[string]string{}}
+	data, err := os.ReadFile(f.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read serial lock file: %w", err)
+	default:
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse serial lock file %s: %w", f.path, err)
+		}
+		if doc.Owners == nil {
+			doc.Owners = map[string]string{}
+		}
+	}
+
+	if !fn(&doc) {
+		return nil
+	}
+
+	data, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal serial lock file: %w", err)
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write serial lock file: %w", err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to write serial lock file: %w", err)
+	}
+	return nil
+}
+
+// lock creates the lock file next to the lock document, waiting while another process holds
+// it, and returns a function that removes it.
+func (f *fileSerialLocks) lock(ctx context.Context) (func(), error) {
+	lockPath := f.path + ".lock"
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = lockFile.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock serial lock file: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for serial lock file %s, which another run holds; remove it if no run is in progress: %w", lockPath, ctx.Err())
+		case <-time.After(serialLockFileRetryInterval):
+		}
+	}
+}
+
+// ownersOf returns the owners recorded in all for each of serials that has one.
+func ownersOf(all map[string]string, serials []string) map[string]string {
+	owners := make(map[string]string)
+	for _, serial := range serials {
+		if owner, ok := all[serial]; ok {
+			owners[serial] = owner
+		}
+	}
+	return owners
+}
+
+// httpSerialLocks stores serial ownership through a user-supplied HTTP endpoint.
+type httpSerialLocks struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// serialLockRequest is the JSON body posted to an HTTP serial lock endpoint.
+type serialLockRequest struct {
+	Owner   string   `json:"owner,omitempty"`
+	Serials []string `json:"serials"`
+}
+
+// serialLockResponse is the JSON body returned by an HTTP serial lock endpoint. Owners holds
+// the owners found by an owners request, or the conflicting owners of a rejected claim.
+type serialLockResponse struct {
+	Owners map[string]string `json:"owners"`
+}
+
+// NewHTTPSerialLocks returns a serial lock backend that posts JSON requests to url. The
+// endpoint serves POST {url}/owners, {url}/claim and {url}/release, each with a body of
+// {"owner": "...", "serials": [...]}; owners returns {"owners": {"serial": "owner"}}, and a
+// rejected claim responds 409 Conflict with the conflicting owners in the same form. token,
+// when set, is sent as a bearer token.
+func NewHTTPSerialLocks(url, token string) SerialLockBackend {
+	return &httpSerialLocks{
+		url:        strings.TrimRight(url, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (h *httpSerialLocks) Owners(ctx context.Context, serials []string) (map[string]string, error) {
+	resp, err := h.post(ctx, "owners", serialLockRequest{Serials: serials})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Owners == nil {
+		resp.Owners = map[string]string{}
+	}
+	return resp.Owners, nil
+}
+
+func (h *httpSerialLocks) Claim(ctx context.Context, owner string, serials []string) error {
+	_, err := h.post(ctx, "claim", serialLockRequest{Owner: owner, Serials: serials})
+	return err
+}
+
+func (h *httpSerialLocks) Release(ctx context.Context, owner string, serials []string) error {
+	_, err := h.post(ctx, "release", serialLockRequest{Owner: owner, Serials: serials})
+	return err
+}
+
+// post sends body to the named operation of the endpoint and decodes its response. A 409
+// response is returned as a *SerialLockConflictError.
+func (h *httpSerialLocks) post(ctx context.Context, operation string, body serialLockRequest) (*serialLockResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal serial lock request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url+"/"+operation, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("serial lock %s request failed: %w", operation, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read serial lock %s response: %w", operation, err)
+	}
+
+	var decoded serialLockResponse
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &decoded); err != nil && resp.StatusCode < 300 {
+			return nil, fmt.Errorf("failed to decode serial lock %s response: %w", operation, err)
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusConflict:
+		return nil, &SerialLockConflictError{Owners: decoded.Owners}
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("serial lock %s request failed with status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return &decoded, nil
+}
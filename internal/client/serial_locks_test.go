@@ -0,0 +1,178 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileSerialLocks(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "locks", "serials.json")
+	locks := NewFileSerialLocks(path)
+
+	if err := locks.Claim(ctx, "workspace-a", []string{"SN001", "SN002"}); err != nil {
+		t.Fatalf("unexpected claim error: %v", err)
+	}
+	if err := locks.Claim(ctx, "workspace-a", []string{"SN002", "SN003"}); err != nil {
+		t.Fatalf("expected reclaiming owned serials to succeed, got %v", err)
+	}
+
+	err := locks.Claim(ctx, "workspace-b", []string{"SN003", "SN004"})
+	var conflict *SerialLockConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected a SerialLockConflictError, got %v", err)
+	}
+	if len(conflict.Owners) != 1 || conflict.Owners["SN003"] != "workspace-a" {
+		t.Errorf("unexpected conflicts: %v", conflict.Owners)
+	}
+
+	owners, err := locks.Owners(ctx, []string{"SN001", "SN004"})
+	if err != nil {
+		t.Fatalf("unexpected owners error: %v", err)
+	}
+	if len(owners) != 1 || owners["SN001"] != "workspace-a" {
+		t.Errorf("expected a rejected claim to claim nothing, got %v", owners)
+	}
+
+	if err := locks.Release(ctx, "workspace-b", []string{"SN001"}); err != nil {
+		t.Fatalf("unexpected release error: %v", err)
+	}
+	if err := locks.Release(ctx, "workspace-a", []string{"SN001", "SN002"}); err != nil {
+		t.Fatalf("unexpected release error: %v", err)
+	}
+	owners, _ = locks.Owners(ctx, []string{"SN001", "SN002", "SN003"})
+	if len(owners) != 1 || owners["SN003"] != "workspace-a" {
+		t.Errorf("expected only SN003 to remain claimed, got %v", owners)
+	}
+
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("expected the lock file to be removed, got %v", err)
+	}
+}
+
+func TestFileSerialLocks_WaitsForHeldLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "serials.json")
+	if err := os.WriteFile(path+".lock", nil, 0600); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	_, err := NewFileSerialLocks(path).Owners(ctx, []string{"SN001"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected to time out waiting for the held lock, got %v", err)
+	}
+}
+
+func TestHTTPSerialLocks(t *testing.T) {
+	var requests []serialLockRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+		var body serialLockRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+
+		switch r.URL.Path {
+		case "/locks/owners":
+			_, _ = w.Write([]byte(`{"owners":{"SN001":"workspace-b"}}`))
+		case "/locks/claim":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"owners":{"SN001":"workspace-b"}}`))
+		case "/locks/release":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{}
+	c.SetSerialLocks(NewHTTPSerialLocks(server.URL+"/locks/", "secret"), "workspace-a")
+	ctx := context.Background()
+
+	conflicts, err := c.SerialLockConflicts(ctx, []string{"SN001", "SN002"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts["SN001"] != "workspace-b" {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+
+	err = c.ClaimSerials(ctx, []string{"SN001"})
+	var conflict *SerialLockConflictError
+	if !errors.As(err, &conflict) || conflict.Owners["SN001"] != "workspace-b" {
+		t.Errorf("expected a SerialLockConflictError, got %v", err)
+	}
+	if err := c.ReleaseSerials(ctx, []string{"SN002"}); err != nil {
+		t.Errorf("unexpected release error: %v", err)
+	}
+
+	if len(requests) != 3 || requests[1].Owner != "workspace-a" || requests[2].Serials[0] != "SN002" {
+		t.Errorf("unexpected requests: %+v", requests)
+	}
+}
+
+func TestSerialLockConflicts_IgnoresOwnClaims(t *testing.T) {
+	ctx := context.Background()
+	locks := NewFileSerialLocks(filepath.Join(t.TempDir(), "serials.json"))
+	if err := locks.Claim(ctx, "workspace-a", []string{"SN001"}); err != nil {
+		t.Fatalf("unexpected claim error: %v", err)
+	}
+
+	c := &Client{}
+	c.SetSerialLocks(locks, "workspace-a")
+	conflicts, err := c.SerialLockConflicts(ctx, []string{"SN001"})
+	if err != nil || conflicts != nil {
+		t.Errorf("expected no conflicts for the client's own claims, got %v, %v", conflicts, err)
+	}
+}
+
+func TestSerialLocks_Disabled(t *testing.T) {
+	c := &Client{}
+	ctx := context.Background()
+	if conflicts, err := c.SerialLockConflicts(ctx, []string{"SN001"}); err != nil || conflicts != nil {
+		t.Errorf("expected no conflicts when disabled, got %v, %v", conflicts, err)
+	}
+	if err := c.ClaimSerials(ctx, []string{"SN001"}); err != nil {
+		t.Errorf("expected claims to be a no-op when disabled, got %v", err)
+	}
+	if err := c.ReleaseSerials(ctx, []string{"SN001"}); err != nil {
+		t.Errorf("expected releases to be a no-op when disabled, got %v", err)
+	}
+}
+
+func TestFormatSerialOwners(t *testing.T) {
+	got := FormatSerialOwners(map[string]string{"SN002": "b", "SN001": "a"})
+	if want := "SN001 (a), SN002 (b)"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSerialLocks_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+	c := &Client{}
+	c.SetSerialLocks(NewFileSerialLocks(filepath.Join(t.TempDir(), "serials.json")), "workspace-a")
+	c.SetReadOnly(true)
+
+	if err := c.ClaimSerials(ctx, []string{"SN001"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly for claims, got %v", err)
+	}
+	if err := c.ReleaseSerials(ctx, []string{"SN001"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly for releases, got %v", err)
+	}
+	if _, err := c.SerialLockConflicts(ctx, []string{"SN001"}); err != nil {
+		t.Errorf("expected conflict checks to be allowed, got %v", err)
+	}
+}
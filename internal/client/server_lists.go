@@ -0,0 +1,92 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// serverListTTL is how long MDM servers read by GetDeviceManagementServices and
+// GetDeviceManagementService are reused. It is long enough to cover the reads of one refresh,
+// in which many resources resolve their server at once, and short enough that a later
+// operation sees changes made in Apple Business or School Manager.
+const serverListTTL = 10 * time.Second
+
+// serverList is a list of MDM servers remembered by GetDeviceManagementServices, or the single
+// server remembered by GetDeviceManagementService.
+type serverList struct {
+	servers   []MdmServer
+	fetchedAt time.Time
+}
+
+// serverLists remembers the results of GetDeviceManagementServices, keyed by query, and of
+// GetDeviceManagementService, keyed by server ID and query, and collapses concurrent identical
+// list calls into one.
+type serverLists struct {
+	group   singleflight.Group
+	mu      sync.Mutex
+	entries map[string]serverList
+}
+
+// lookup returns the servers remembered for key, if they were fetched less than serverListTTL
+// before now.
+func (l *serverLists) lookup(key string, now time.Time) ([]MdmServer, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry, ok := l.entries[key]
+	if !ok || now.Sub(entry.fetchedAt) >= serverListTTL {
+		return nil, false
+	}
+	return entry.servers, true
+}
+
+// store remembers servers for key as fetched at now.
+func (l *serverLists) store(key string, servers []MdmServer, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.entries == nil {
+		l.entries = make(map[string]serverList)
+	}
+	l.entries[key] = serverList{servers: servers, fetchedAt: now}
+}
+
+// clear forgets every remembered list.
+func (l *serverLists) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = nil
+}
+
+// listDeviceManagementServices returns the servers matching queryParams, reusing a list
+// fetched within serverListTTL and sharing one request between concurrent callers.
+func (c *Client) listDeviceManagementServices(ctx context.Context, queryParams url.Values) ([]MdmServer, error) {
+	queryParams = withMdmServersFields(queryParams)
+	key := queryParams.Encode()
+	if servers, ok := c.serverLists.lookup(key, c.Clock().Now()); ok {
+		return slices.Clone(servers), nil
+	}
+
+	result, err, _ := c.serverLists.group.Do(key, func() (any, error) {
+		servers, err := collectPages[MdmServer](ctx, c, "/v1/mdmServers", queryParams, defaultPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		c.serverLists.store(key, servers, c.Clock().Now())
+		return servers, nil
+	})
+	if err != nil {
+		if ctx.Err() == nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			return collectPages[MdmServer](ctx, c, "/v1/mdmServers", queryParams, defaultPageLimit)
+		}
+		return nil, err
+	}
+	return slices.Clone(result.([]MdmServer)), nil
+}
@@ -0,0 +1,109 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newServerListServer(t *testing.T, requests *atomic.Int32, release <-chan struct{}) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		requests.Add(1)
+		if release != nil {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, MdmServersResponse{
+			Data: []MdmServer{{Type: "mdmServers", ID: "srv-1", Attributes: MdmServerAttribute{ServerName: "Jamf Pro"}}},
+			Meta: Meta{Paging: Paging{Limit: 100}},
+		}))
+	}))
+}
+
+func TestGetDeviceManagementServices_SharesConcurrentCalls(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := newServerListServer(t, &requests, release)
+	defer server.Close()
+	c := newTestClient(t, server)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			servers, err := c.GetDeviceManagementServices(context.Background(), nil)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if len(servers) != 1 || servers[0].ID != "srv-1" {
+				t.Errorf("unexpected servers: %v", servers)
+			}
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected concurrent calls to share 1 request, got %d", got)
+	}
+}
+
+func TestGetDeviceManagementServices_ReusesRecentList(t *testing.T) {
+	var requests atomic.Int32
+	server := newServerListServer(t, &requests, nil)
+	defer server.Close()
+	c := newTestClient(t, server)
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.SetClock(clock)
+
+	list := func() {
+		t.Helper()
+		servers, err := c.GetDeviceManagementServices(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if servers[0].ID != "srv-1" {
+			t.Fatalf("expected callers not to share the remembered slice, got ID %q", servers[0].ID)
+		}
+		servers[0].ID = "changed by caller"
+	}
+
+	list()
+	list()
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected a recent list to be reused, got %d requests", got)
+	}
+
+	clock.Advance(serverListTTL)
+	list()
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected the list to be fetched again after %v, got %d requests", serverListTTL, got)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, server.URL+"/v1/mdmServers/srv-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	list()
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected a change to discard the remembered list, got %d requests", got)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ServiceProbe is the response to a minimal API request made to check that the organization
+// can use the API.
+type ServiceProbe struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Errors holds the API errors of an unsuccessful response, if its body could be decoded.
+	Errors []Error
+}
+
+// ProbeService requests a single device management service, the cheapest read every API
+// account can make, and returns the response status and errors. Unsuccessful responses are
+// reported in the probe rather than as an error; an error means no response was received.
+func (c *Client) ProbeService(ctx context.Context) (*ServiceProbe, error) {
+	params := url.Values{"fields[mdmServers]": {"serverName"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.pageURL("/v1/mdmServers", params, 1, ""), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	probe := &ServiceProbe{StatusCode: resp.StatusCode}
+	if resp.StatusCode == http.StatusOK {
+		return probe, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP %d: failed to read error response body: %w", resp.StatusCode, err)
+	}
+	var errResp ErrorResponse
+	if json.Unmarshal(body, &errResp) == nil {
+		probe.Errors = errResp.Errors
+	}
+	return probe, nil
+}
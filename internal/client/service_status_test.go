@@ -0,0 +1,47 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/mdmServers" || r.URL.Query().Get("limit") != "1" {
+			t.Errorf("unexpected probe request: %s", r.URL)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	probe, err := newTestClient(t, server).ProbeService(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if probe.StatusCode != http.StatusOK || len(probe.Errors) != 0 {
+		t.Errorf("unexpected probe: %+v", probe)
+	}
+}
+
+func TestProbeService_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":[{"status":"403","code":"FORBIDDEN.REQUIRED_AGREEMENTS_MISSING_OR_EXPIRED","title":"Forbidden","detail":"Accept the latest terms."}]}`))
+	}))
+	defer server.Close()
+
+	probe, err := newTestClient(t, server).ProbeService(context.Background())
+	if err != nil {
+		t.Fatalf("expected the error response in the probe, got %v", err)
+	}
+	if probe.StatusCode != http.StatusForbidden || len(probe.Errors) != 1 ||
+		probe.Errors[0].Code != "FORBIDDEN.REQUIRED_AGREEMENTS_MISSING_OR_EXPIRED" {
+		t.Errorf("unexpected probe: %+v", probe)
+	}
+}
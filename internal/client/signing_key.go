@@ -0,0 +1,108 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AssertionSigner signs client assertions with a key held outside the provider, such as a
+// key in a cloud key management service.
+type AssertionSigner interface {
+	// SignES256 returns the 64-byte R||S ECDSA P-256 signature of digest, a SHA-256 hash.
+	SignES256(digest []byte) ([]byte, error)
+}
+
+// signWithAssertionSigner returns token signed by signer in compact JWS form.
+func signWithAssertionSigner(token *jwt.Token, signer AssertionSigner) (string, error) {
+	signingString, err := token.SigningString()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token: %w", err)
+	}
+	digest := sha256.Sum256([]byte(signingString))
+	sig, err := signer.SignES256(digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	if len(sig) != 64 {
+		return "", fmt.Errorf("failed to sign token: expected a 64-byte ES256 signature, got %d bytes", len(sig))
+	}
+	return signingString + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// signingKeySelfTestMessage is the payload signed and verified during the key self-test.
+const signingKeySelfTestMessage = "terraform-provider-axm signing key self-test"
+
+// EnableStrictKeyHygiene parses the configured private key once, proves it can produce ES256
+// signatures with a sign/verify round-trip, and then zeroes and discards the PEM bytes so that
+// only the parsed key remains in memory for the lifetime of the client.
+func (c *Client) EnableStrictKeyHygiene() error {
+	if c.tokenSource == nil {
+		return errors.New("client has no token source")
+	}
+	return c.tokenSource.enableStrictKeyHygiene()
+}
+
+// enableStrictKeyHygiene replaces the PEM-encoded key with a verified parsed key.
+func (s *appleTokenSource) enableStrictKeyHygiene() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.signingKey != nil {
+		return nil
+	}
+	if s.config.Signer != nil {
+		return errors.New("strict key hygiene requires a local private key; client assertions are signed by an external signer")
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM(s.config.PrivateKey)
+	zeroBytes(s.config.PrivateKey)
+	s.config.PrivateKey = nil
+	if err != nil {
+		return fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	if err := selfTestSigningKey(key); err != nil {
+		return err
+	}
+
+	s.signingKey = key
+	s.logAuth("Private key self-test passed; PEM key material discarded", nil)
+	return nil
+}
+
+// selfTestSigningKey confirms key is a P-256 key suitable for ES256 and that a signature it
+// produces verifies against its public key.
+func selfTestSigningKey(key *ecdsa.PrivateKey) error {
+	if key.Curve != elliptic.P256() {
+		return fmt.Errorf("private key uses curve %s; ES256 requires P-256", key.Curve.Params().Name)
+	}
+
+	digest := sha256.Sum256([]byte(signingKeySelfTestMessage))
+	defer zeroBytes(digest[:])
+
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		return fmt.Errorf("private key self-test signing failed: %w", err)
+	}
+	defer zeroBytes(sig)
+
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig) {
+		return errors.New("private key self-test failed: signature did not verify against the key's public key")
+	}
+	return nil
+}
+
+// zeroBytes overwrites b with zeros.
+func zeroBytes(b []byte) {
+	clear(b)
+}
@@ -0,0 +1,159 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestEnableStrictKeyHygiene_ValidKey(t *testing.T) {
+	pemKey := generateTestP8Key(t)
+	keyCopy := pemKey
+	ts := &appleTokenSource{
+		cacheDir: t.TempDir(),
+		config: &ClientConfig{
+			TeamID:     "TEAM123",
+			ClientID:   "CLIENT456",
+			KeyID:      "KEY789",
+			PrivateKey: pemKey,
+			Scope:      "business.api",
+		},
+	}
+
+	if err := ts.enableStrictKeyHygiene(); err != nil {
+		t.Fatalf("enableStrictKeyHygiene returned error: %v", err)
+	}
+	if ts.config.PrivateKey != nil {
+		t.Error("expected PEM key to be discarded")
+	}
+	for _, b := range keyCopy {
+		if b != 0 {
+			t.Fatal("expected PEM key bytes to be zeroed")
+		}
+	}
+	if ts.signingKey == nil {
+		t.Fatal("expected parsed signing key to be retained")
+	}
+
+	if _, _, err := ts.createClientAssertion(); err != nil {
+		t.Errorf("createClientAssertion after strict hygiene returned error: %v", err)
+	}
+}
+
+func TestEnableStrictKeyHygiene_WrongCurve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	ts := &appleTokenSource{
+		config: &ClientConfig{PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})},
+	}
+
+	err = ts.enableStrictKeyHygiene()
+	if err == nil || !strings.Contains(err.Error(), "P-256") {
+		t.Errorf("expected P-256 curve error, got %v", err)
+	}
+	if ts.signingKey != nil {
+		t.Error("expected no signing key to be retained on failure")
+	}
+}
+
+func TestEnableStrictKeyHygiene_InvalidKey(t *testing.T) {
+	ts := &appleTokenSource{
+		config: &ClientConfig{PrivateKey: []byte("not a key")},
+	}
+
+	if err := ts.enableStrictKeyHygiene(); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
+
+func TestClientConfig_PrivateKeyNotSerialized(t *testing.T) {
+	data, err := json.Marshal(ClientConfig{ClientID: "client", PrivateKey: []byte("secret-key")})
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if strings.Contains(string(data), "secret-key") || strings.Contains(string(data), "private_key") {
+		t.Errorf("expected private key to be omitted from JSON, got %s", data)
+	}
+}
+
+// testSigner is an AssertionSigner backed by an in-memory key.
+type testSigner struct {
+	key *ecdsa.PrivateKey
+	sig []byte
+}
+
+func (s *testSigner) SignES256(digest []byte) ([]byte, error) {
+	if s.sig != nil {
+		return s.sig, nil
+	}
+	r, sv, err := ecdsa.Sign(rand.Reader, s.key, digest)
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	sv.FillBytes(sig[32:])
+	return sig, nil
+}
+
+func TestCreateClientAssertion_Signer(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	c, err := NewClientWithSigner("https://api-business.apple.com", "TEAM123", "CLIENT456", "KEY789", "business.api", &testSigner{key: key})
+	if err != nil {
+		t.Fatalf("NewClientWithSigner returned error: %v", err)
+	}
+
+	assertion, _, err := c.tokenSource.createClientAssertion()
+	if err != nil {
+		t.Fatalf("createClientAssertion returned error: %v", err)
+	}
+	token, err := jwt.Parse(assertion, func(*jwt.Token) (any, error) { return &key.PublicKey, nil },
+		jwt.WithValidMethods([]string{"ES256"}))
+	if err != nil {
+		t.Fatalf("assertion did not verify against the signer's public key: %v", err)
+	}
+	if kid, _ := token.Header["kid"].(string); kid != "KEY789" {
+		t.Errorf("expected kid=KEY789, got %s", kid)
+	}
+}
+
+func TestCreateClientAssertion_SignerWrongLength(t *testing.T) {
+	ts := &appleTokenSource{
+		config: &ClientConfig{ClientID: "client", Signer: &testSigner{sig: []byte("short")}},
+	}
+
+	_, _, err := ts.createClientAssertion()
+	if err == nil || !strings.Contains(err.Error(), "64-byte") {
+		t.Errorf("expected signature length error, got %v", err)
+	}
+}
+
+func TestEnableStrictKeyHygiene_Signer(t *testing.T) {
+	ts := &appleTokenSource{
+		config: &ClientConfig{ClientID: "client", Signer: &testSigner{}},
+	}
+
+	err := ts.enableStrictKeyHygiene()
+	if err == nil || !strings.Contains(err.Error(), "external signer") {
+		t.Errorf("expected external signer error, got %v", err)
+	}
+}
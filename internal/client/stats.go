@@ -0,0 +1,116 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrAPITimeBudgetExceeded is returned for reads attempted after the client's API time budget
+// has been used up.
+var ErrAPITimeBudgetExceeded = errors.New("API time budget exceeded")
+
+// Stats summarises the API traffic the client has generated since it was created.
+type Stats struct {
+	// Requests is the number of HTTP requests sent to the API, including retries.
+	Requests int64
+	// Retries is the number of requests that were retried after a rate-limit, transient
+	// server error, or retryable error code response.
+	Retries int64
+	// RateLimitWait is the total time spent waiting on Retry-After headers of 429 responses.
+	RateLimitWait time.Duration
+	// APITime is the cumulative time spent in API requests, including retry waits. Concurrent
+	// requests each contribute their full duration.
+	APITime time.Duration
+	// CacheHits is the number of reads answered by the response cache, either without a
+	// request or after the API confirmed the cached response with 304 Not Modified.
+	CacheHits int64
+	// CacheMisses is the number of cacheable reads whose response was fetched from the API.
+	CacheMisses int64
+}
+
+// requestStats accumulates Stats across concurrent requests.
+type requestStats struct {
+	requests      atomic.Int64
+	retries       atomic.Int64
+	rateLimitWait atomic.Int64
+	apiTime       atomic.Int64
+	cacheHits     atomic.Int64
+	cacheMisses   atomic.Int64
+	lastResponse  atomic.Pointer[ResponseIDs]
+	lastError     atomic.Pointer[ResponseIDs]
+}
+
+// responseIDHeaders lists, in lower case, the response headers that identify a request to
+// Apple. Support cases can quote them so that Apple can find the request in its own logs.
+var responseIDHeaders = []string{"x-request-id", "x-apple-request-uuid", "x-apple-jingle-correlation-key"}
+
+// ResponseIDs holds the identifying headers of one API response.
+type ResponseIDs struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Headers maps each header in responseIDHeaders that the response carried, by its
+	// lower-case name, to its value.
+	Headers map[string]string
+}
+
+// recordResponseIDs keeps the identifying headers of resp as the most recent response, and
+// as the most recent error response when its status is 400 or above.
+func (c *Client) recordResponseIDs(resp *http.Response) {
+	ids := &ResponseIDs{StatusCode: resp.StatusCode, Headers: make(map[string]string)}
+	for _, name := range responseIDHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			ids.Headers[name] = value
+		}
+	}
+	c.stats.lastResponse.Store(ids)
+	if resp.StatusCode >= 400 {
+		c.stats.lastError.Store(ids)
+	}
+}
+
+// LastResponseIDs returns the identifying headers of the most recent API response and of the
+// most recent error response. Either is nil until such a response has been received.
+func (c *Client) LastResponseIDs() (last, lastError *ResponseIDs) {
+	return c.stats.lastResponse.Load(), c.stats.lastError.Load()
+}
+
+// Stats returns a snapshot of the API traffic the client has generated since it was created.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests:      c.stats.requests.Load(),
+		Retries:       c.stats.retries.Load(),
+		RateLimitWait: time.Duration(c.stats.rateLimitWait.Load()),
+		APITime:       time.Duration(c.stats.apiTime.Load()),
+		CacheHits:     c.stats.cacheHits.Load(),
+		CacheMisses:   c.stats.cacheMisses.Load(),
+	}
+}
+
+// SetAPITimeBudget limits the cumulative API time the client may spend before further reads
+// fail with ErrAPITimeBudgetExceeded. Requests that change data are never refused. Zero or a
+// negative budget disables the limit.
+func (c *Client) SetAPITimeBudget(budget time.Duration) {
+	c.apiTimeBudget = budget
+}
+
+// checkAPITimeBudget returns an error wrapping ErrAPITimeBudgetExceeded when a read would take
+// the cumulative API time past the budget. pending is time the caller has spent or is about to
+// spend that has not yet been recorded.
+func (c *Client) checkAPITimeBudget(method string, pending time.Duration) error {
+	if c.apiTimeBudget <= 0 || method != http.MethodGet {
+		return nil
+	}
+	used := time.Duration(c.stats.apiTime.Load()) + pending
+	if used < c.apiTimeBudget {
+		return nil
+	}
+	return fmt.Errorf("%w: %s of API time used of the %s allowed by max_api_time_per_operation; "+
+		"remaining reads are refused so that the operation ends instead of running unattended",
+		ErrAPITimeBudgetExceeded, used.Round(time.Second), c.apiTimeBudget)
+}
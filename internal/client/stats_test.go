@@ -0,0 +1,178 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStats_CountsRequestsRetriesAndRateLimitWait(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requestCount.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	if got := c.Stats(); got != (Stats{}) {
+		t.Fatalf("expected zero stats for a new client, got %+v", got)
+	}
+
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{InitialBackoff: time.Millisecond})
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	got := c.Stats()
+	if got.APITime < time.Second {
+		t.Errorf("expected API time to include the rate-limit wait, got %v", got.APITime)
+	}
+	got.APITime = 0
+	want := Stats{Requests: 3, Retries: 2, RateLimitWait: time.Second}
+	if got != want {
+		t.Errorf("expected stats %+v, got %+v", want, got)
+	}
+}
+
+func TestStats_NotCountedWhenWaitCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	if _, err := c.doRequest(ctx, req); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	got := c.Stats()
+	got.APITime = 0
+	want := Stats{Requests: 1}
+	if got != want {
+		t.Errorf("expected stats %+v, got %+v", want, got)
+	}
+}
+
+func TestAPITimeBudget_RefusesReadsOnceExhausted(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetAPITimeBudget(time.Minute)
+	c.stats.apiTime.Store(int64(time.Minute))
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	_, err := c.doRequest(context.Background(), req)
+	if !errors.Is(err, ErrAPITimeBudgetExceeded) {
+		t.Fatalf("expected ErrAPITimeBudgetExceeded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "max_api_time_per_operation") {
+		t.Errorf("expected error to name the provider setting, got %q", err.Error())
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, server.URL+"/test", nil)
+	resp, err := c.doRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected writes to proceed, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("expected only the write to reach the server, got %d requests", got)
+	}
+}
+
+func TestAPITimeBudget_FailsFastInsteadOfWaiting(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetAPITimeBudget(10 * time.Second)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+	start := time.Now()
+	_, err := c.doRequest(context.Background(), req)
+	if !errors.Is(err, ErrAPITimeBudgetExceeded) {
+		t.Fatalf("expected ErrAPITimeBudgetExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the read to fail without waiting, took %v", elapsed)
+	}
+	if got := requestCount.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestLastResponseIDs(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requestCount.Add(1)
+		w.Header().Set("X-Request-ID", "req-"+strconv.Itoa(int(n)))
+		w.Header().Set("X-Apple-Jingle-Correlation-Key", "corr")
+		w.Header().Set("Server", "ignored")
+		if n == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	if last, lastError := c.LastResponseIDs(); last != nil || lastError != nil {
+		t.Fatalf("expected no response IDs for a new client, got %+v, %+v", last, lastError)
+	}
+
+	for range 2 {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+		resp, err := c.doRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	last, lastError := c.LastResponseIDs()
+	want := map[string]string{"x-request-id": "req-2", "x-apple-jingle-correlation-key": "corr"}
+	if last == nil || last.StatusCode != http.StatusOK || !maps.Equal(last.Headers, want) {
+		t.Errorf("expected the last response IDs %v, got %+v", want, last)
+	}
+	if lastError == nil || lastError.StatusCode != http.StatusNotFound || lastError.Headers["x-request-id"] != "req-1" {
+		t.Errorf("expected the 404 response IDs, got %+v", lastError)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// DevicesAPI is the organization device area of the API, as exposed by Client.Devices. Code
+// that only reads devices can depend on it instead of Client so that tests can replace it.
+type DevicesAPI interface {
+	List(ctx context.Context, queryParams url.Values) ([]OrgDevice, error)
+	Get(ctx context.Context, id string, queryParams url.Values) (*OrgDevice, error)
+	GetBySerial(ctx context.Context, serials []string, queryParams url.Values) (map[string]OrgDevice, error)
+	ResolveIDs(ctx context.Context, identifiers []string) (map[string]string, error)
+	Missing(ctx context.Context, ids []string) ([]string, error)
+	Sync(ctx context.Context) ([]OrgDevice, DeviceSyncResult, error)
+	AssignedServerID(ctx context.Context, deviceID string) (*Data, error)
+	AssignedServerIDs(ctx context.Context, deviceIDs []string) (map[string]string, error)
+	AssignedServer(ctx context.Context, deviceID string, queryParams url.Values) (*MdmServer, error)
+	AppleCareCoverage(ctx context.Context, deviceID string, queryParams url.Values) ([]AppleCareCoverage, error)
+	AppleCareCoverages(ctx context.Context, deviceIDs []string, queryParams url.Values) (map[string][]AppleCareCoverage, error)
+}
+
+// ServersAPI is the device management service area of the API, as exposed by Client.Servers.
+type ServersAPI interface {
+	List(ctx context.Context, queryParams url.Values) ([]MdmServer, error)
+	Get(ctx context.Context, id string, queryParams url.Values) (*MdmServer, error)
+	SerialNumbers(ctx context.Context, serverID string) ([]string, error)
+	Create(ctx context.Context, request MdmServerCreateRequest) (*MdmServer, error)
+	Update(ctx context.Context, request MdmServerUpdateRequest) (*MdmServer, error)
+	ClearDefaultFamilies(ctx context.Context, id string) (*MdmServer, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ActivitiesAPI is the organization device activity area of the API, as exposed by
+// Client.Activities.
+type ActivitiesAPI interface {
+	Assign(ctx context.Context, serverID string, deviceIDs []string, assign bool) (*OrgDeviceActivity, error)
+	AssignInChunks(ctx context.Context, serverID string, deviceIDs []string, assign bool, delay time.Duration, reuse func(context.Context, []string) *OrgDeviceActivity, monitor func(context.Context, DeviceActivityChunk) error) ([]DeviceActivityChunk, error)
+	Release(ctx context.Context, deviceIDs []string) (*OrgDeviceActivity, error)
+	Get(ctx context.Context, activityID string, queryParams url.Values) (*OrgDeviceActivity, error)
+	Wait(ctx context.Context, activityID string, interval time.Duration, progress ActivityProgressFunc) (*OrgDeviceActivity, error)
+	Log(ctx context.Context, activityID string) (*OrgDeviceActivity, []ActivityLogRow, error)
+}
+
+var (
+	_ DevicesAPI    = &DevicesClient{}
+	_ ServersAPI    = &ServersClient{}
+	_ ActivitiesAPI = &ActivitiesClient{}
+)
+
+// DevicesClient groups the organization device endpoints of a Client. It shares the client's
+// credentials, request limits and caches.
+type DevicesClient struct {
+	c *Client
+}
+
+// Devices returns the organization device endpoints of the client.
+func (c *Client) Devices() *DevicesClient {
+	return &DevicesClient{c: c}
+}
+
+// List retrieves all organization devices; see Client.GetOrgDevices.
+func (d *DevicesClient) List(ctx context.Context, queryParams url.Values) ([]OrgDevice, error) {
+	return d.c.GetOrgDevices(ctx, queryParams)
+}
+
+// Get retrieves a single organization device; see Client.GetOrgDevice.
+func (d *DevicesClient) Get(ctx context.Context, id string, queryParams url.Values) (*OrgDevice, error) {
+	return d.c.GetOrgDevice(ctx, id, queryParams)
+}
+
+// GetBySerial retrieves organization devices by serial number; see Client.GetOrgDevicesBySerial.
+func (d *DevicesClient) GetBySerial(ctx context.Context, serials []string, queryParams url.Values) (map[string]OrgDevice, error) {
+	return d.c.GetOrgDevicesBySerial(ctx, serials, queryParams)
+}
+
+// ResolveIDs resolves device identifiers to device IDs; see Client.ResolveOrgDeviceIDs.
+func (d *DevicesClient) ResolveIDs(ctx context.Context, identifiers []string) (map[string]string, error) {
+	return d.c.ResolveOrgDeviceIDs(ctx, identifiers)
+}
+
+// Missing returns the device IDs that are not in the organization; see Client.MissingOrgDevices.
+func (d *DevicesClient) Missing(ctx context.Context, ids []string) ([]string, error) {
+	return d.c.MissingOrgDevices(ctx, ids)
+}
+
+// Sync returns all organization devices incrementally; see Client.SyncOrgDevices.
+func (d *DevicesClient) Sync(ctx context.Context) ([]OrgDevice, DeviceSyncResult, error) {
+	return d.c.SyncOrgDevices(ctx)
+}
+
+// AssignedServerID retrieves the linkage to a device's assigned server; see
+// Client.GetOrgDeviceAssignedServerID.
+func (d *DevicesClient) AssignedServerID(ctx context.Context, deviceID string) (*Data, error) {
+	return d.c.GetOrgDeviceAssignedServerID(ctx, deviceID)
+}
+
+// AssignedServerIDs retrieves the assigned server IDs of many devices; see
+// Client.GetOrgDeviceAssignedServerIDs.
+func (d *DevicesClient) AssignedServerIDs(ctx context.Context, deviceIDs []string) (map[string]string, error) {
+	return d.c.GetOrgDeviceAssignedServerIDs(ctx, deviceIDs)
+}
+
+// AssignedServer retrieves a device's assigned server; see Client.GetOrgDeviceAssignedServer.
+func (d *DevicesClient) AssignedServer(ctx context.Context, deviceID string, queryParams url.Values) (*MdmServer, error) {
+	return d.c.GetOrgDeviceAssignedServer(ctx, deviceID, queryParams)
+}
+
+// AppleCareCoverage retrieves a device's AppleCare coverage; see
+// Client.GetOrgDeviceAppleCareCoverage.
+func (d *DevicesClient) AppleCareCoverage(ctx context.Context, deviceID string, queryParams url.Values) ([]AppleCareCoverage, error) {
+	return d.c.GetOrgDeviceAppleCareCoverage(ctx, deviceID, queryParams)
+}
+
+// AppleCareCoverages retrieves the AppleCare coverage of many devices; see
+// Client.GetOrgDevicesAppleCareCoverage.
+func (d *DevicesClient) AppleCareCoverages(ctx context.Context, deviceIDs []string, queryParams url.Values) (map[string][]AppleCareCoverage, error) {
+	return d.c.GetOrgDevicesAppleCareCoverage(ctx, deviceIDs, queryParams)
+}
+
+// ServersClient groups the device management service endpoints of a Client. It shares the
+// client's credentials, request limits and caches.
+type ServersClient struct {
+	c *Client
+}
+
+// Servers returns the device management service endpoints of the client.
+func (c *Client) Servers() *ServersClient {
+	return &ServersClient{c: c}
+}
+
+// List retrieves all device management services; see Client.GetDeviceManagementServices.
+func (s *ServersClient) List(ctx context.Context, queryParams url.Values) ([]MdmServer, error) {
+	return s.c.GetDeviceManagementServices(ctx, queryParams)
+}
+
+// Get retrieves a single device management service; see Client.GetDeviceManagementService.
+func (s *ServersClient) Get(ctx context.Context, id string, queryParams url.Values) (*MdmServer, error) {
+	return s.c.GetDeviceManagementService(ctx, id, queryParams)
+}
+
+// SerialNumbers retrieves the serial numbers of the devices assigned to a service; see
+// Client.GetDeviceManagementServiceSerialNumbers.
+func (s *ServersClient) SerialNumbers(ctx context.Context, serverID string) ([]string, error) {
+	return s.c.GetDeviceManagementServiceSerialNumbers(ctx, serverID)
+}
+
+// Create creates a device management service; see Client.CreateDeviceManagementService.
+func (s *ServersClient) Create(ctx context.Context, request MdmServerCreateRequest) (*MdmServer, error) {
+	return s.c.CreateDeviceManagementService(ctx, request)
+}
+
+// Update updates a device management service; see Client.UpdateDeviceManagementService.
+func (s *ServersClient) Update(ctx context.Context, request MdmServerUpdateRequest) (*MdmServer, error) {
+	return s.c.UpdateDeviceManagementService(ctx, request)
+}
+
+// ClearDefaultFamilies clears a service's default product families; see
+// Client.ClearDeviceManagementServiceDefaultFamilies.
+func (s *ServersClient) ClearDefaultFamilies(ctx context.Context, id string) (*MdmServer, error) {
+	return s.c.ClearDeviceManagementServiceDefaultFamilies(ctx, id)
+}
+
+// Delete deletes a device management service; see Client.DeleteDeviceManagementService.
+func (s *ServersClient) Delete(ctx context.Context, id string) error {
+	return s.c.DeleteDeviceManagementService(ctx, id)
+}
+
+// ActivitiesClient groups the organization device activity endpoints of a Client. It shares
+// the client's credentials, request limits and caches.
+type ActivitiesClient struct {
+	c *Client
+}
+
+// Activities returns the organization device activity endpoints of the client.
+func (c *Client) Activities() *ActivitiesClient {
+	return &ActivitiesClient{c: c}
+}
+
+// Assign assigns or unassigns devices in one activity; see Client.AssignDevicesToMDMServer.
+func (a *ActivitiesClient) Assign(ctx context.Context, serverID string, deviceIDs []string, assign bool) (*OrgDeviceActivity, error) {
+	return a.c.AssignDevicesToMDMServer(ctx, serverID, deviceIDs, assign)
+}
+
+// AssignInChunks assigns or unassigns devices in activities of at most ActivityChunkSize
+// devices; see Client.AssignDevicesToMDMServerInChunks.
+func (a *ActivitiesClient) AssignInChunks(ctx context.Context, serverID string, deviceIDs []string, assign bool, delay time.Duration, reuse func(context.Context, []string) *OrgDeviceActivity, monitor func(context.Context, DeviceActivityChunk) error) ([]DeviceActivityChunk, error) {
+	return a.c.AssignDevicesToMDMServerInChunks(ctx, serverID, deviceIDs, assign, delay, reuse, monitor)
+}
+
+// Release releases devices from the organization; see Client.ReleaseDevices.
+func (a *ActivitiesClient) Release(ctx context.Context, deviceIDs []string) (*OrgDeviceActivity, error) {
+	return a.c.ReleaseDevices(ctx, deviceIDs)
+}
+
+// Get retrieves a single activity; see Client.GetOrgDeviceActivity.
+func (a *ActivitiesClient) Get(ctx context.Context, activityID string, queryParams url.Values) (*OrgDeviceActivity, error) {
+	return a.c.GetOrgDeviceActivity(ctx, activityID, queryParams)
+}
+
+// Wait polls an activity until it finishes; see Client.WaitForActivity.
+func (a *ActivitiesClient) Wait(ctx context.Context, activityID string, interval time.Duration, progress ActivityProgressFunc) (*OrgDeviceActivity, error) {
+	return a.c.WaitForActivity(ctx, activityID, interval, progress)
+}
+
+// Log retrieves an activity and the rows of its activity log; see Client.GetOrgDeviceActivityLog.
+func (a *ActivitiesClient) Log(ctx context.Context, activityID string) (*OrgDeviceActivity, []ActivityLogRow, error) {
+	return a.c.GetOrgDeviceActivityLog(ctx, activityID)
+}
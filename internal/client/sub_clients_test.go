@@ -0,0 +1,55 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubClients_UseAreaEndpoints(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/mdmServers":
+			_, _ = w.Write(mustMarshalJSON(t, MdmServersResponse{Data: []MdmServer{{Type: "mdmServers", ID: "srv-1"}}}))
+		case "/v1/orgDevices/DEVICE1":
+			_, _ = w.Write(mustMarshalJSON(t, OrgDeviceResponse{Data: OrgDevice{Type: "orgDevices", ID: "DEVICE1"}}))
+		case "/v1/orgDeviceActivities/activity-1":
+			_, _ = w.Write(mustMarshalJSON(t, OrgDeviceActivityResponse{Data: OrgDeviceActivity{Type: "orgDeviceActivities", ID: "activity-1"}}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(t, server)
+	ctx := context.Background()
+
+	var servers ServersAPI = c.Servers()
+	if got, err := servers.List(ctx, nil); err != nil || len(got) != 1 || got[0].ID != "srv-1" {
+		t.Errorf("Servers().List: got %v, %v", got, err)
+	}
+	var devices DevicesAPI = c.Devices()
+	if got, err := devices.Get(ctx, "DEVICE1", nil); err != nil || got.ID != "DEVICE1" {
+		t.Errorf("Devices().Get: got %v, %v", got, err)
+	}
+	var activities ActivitiesAPI = c.Activities()
+	if got, err := activities.Get(ctx, "activity-1", nil); err != nil || got.ID != "activity-1" {
+		t.Errorf("Activities().Get: got %v, %v", got, err)
+	}
+
+	want := []string{"/v1/mdmServers", "/v1/orgDevices/DEVICE1", "/v1/orgDeviceActivities/activity-1"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected requests to %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d: expected %s, got %s", i, want[i], paths[i])
+		}
+	}
+}
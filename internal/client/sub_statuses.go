@@ -0,0 +1,38 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import "fmt"
+
+// subStatusExplanations maps the sub-status codes reported for activities, and for each
+// device in an activity log, to explanations operators can act on without looking the code
+// up in Apple's documentation.
+var subStatusExplanations = map[string]string{
+	"COMPLETED_WITH_SUCCESS":  "every device in the activity was processed successfully",
+	"COMPLETED_WITH_ERRORS":   "some devices could not be processed; the activity log lists them with their own sub-status",
+	"DEVICE_NOT_FOUND":        "the serial number is not in the organization's inventory; check for typos or whether the device has been released",
+	"DEVICE_ALREADY_ASSIGNED": "the device is already assigned to the target server, so there was nothing to change",
+	"DEVICE_NOT_ASSIGNED":     "the device is not assigned to the server it was to be unassigned from",
+	"DEVICE_NOT_ELIGIBLE":     "the device cannot be managed through Apple Business or School Manager, for example because it was not purchased from Apple or an authorised reseller or has been released",
+	"DEVICE_RELEASED":         "the device has been released from the organization and can no longer be assigned",
+	"SERVER_NOT_FOUND":        "the device management service does not exist or is not visible to these API credentials",
+	"INVALID_DEVICE_ID":       "the device identifier is not a valid serial number",
+	"INTERNAL_ERROR":          "Apple could not process the device; retrying the activity later usually succeeds",
+}
+
+// ExplainSubStatus returns the explanation of a known activity or device sub-status code, and
+// false when the code is not known.
+func ExplainSubStatus(code string) (string, bool) {
+	explanation, ok := subStatusExplanations[code]
+	return explanation, ok
+}
+
+// DescribeSubStatus returns code followed by its explanation, such as
+// "DEVICE_NOT_FOUND: the serial number is not in ...", or code alone when it is not known.
+func DescribeSubStatus(code string) string {
+	if explanation, ok := ExplainSubStatus(code); ok {
+		return fmt.Sprintf("%s: %s", code, explanation)
+	}
+	return code
+}
@@ -0,0 +1,21 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribeSubStatus(t *testing.T) {
+	if got := DescribeSubStatus("DEVICE_NOT_FOUND"); !strings.HasPrefix(got, "DEVICE_NOT_FOUND: ") || !strings.Contains(got, "released") {
+		t.Errorf("expected DEVICE_NOT_FOUND with its explanation, got %q", got)
+	}
+	if got := DescribeSubStatus("SOMETHING_NEW"); got != "SOMETHING_NEW" {
+		t.Errorf("expected an unknown code unchanged, got %q", got)
+	}
+	if _, ok := ExplainSubStatus(""); ok {
+		t.Error("expected no explanation for an empty code")
+	}
+}
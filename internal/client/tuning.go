@@ -0,0 +1,121 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// tuningThreshold is the number of rate-limit (429) responses within tuningWindow after
+	// which the client reduces its page size and concurrency by one step.
+	tuningThreshold = 3
+	// tuningWindow is the period within which rate-limit responses are counted.
+	tuningWindow = time.Minute
+	// tuningMaxLevel is the number of steps by which page size and concurrency can be
+	// reduced. Each step halves them.
+	tuningMaxLevel = 3
+	// tuningRecovery is the number of consecutive successful responses after which one step
+	// of reduction is undone.
+	tuningRecovery = 50
+	// minTunedPageLimit is the smallest page size requested because of tuning.
+	minTunedPageLimit = 100
+)
+
+// rateLimitTuning tracks rate-limit responses within an operation and derives how far page
+// sizes and concurrency are reduced below their configured values, so that tenants with a
+// smaller request budget are not rate limited on every read.
+type rateLimitTuning struct {
+	mu        sync.Mutex
+	level     int
+	limited   []time.Time
+	successes int
+}
+
+// rateLimited records a rate-limit response at now, and reports whether it reduced page sizes
+// and concurrency by a further step.
+func (t *rateLimitTuning) rateLimited(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.successes = 0
+	recent := t.limited[:0]
+	for _, at := range t.limited {
+		if now.Sub(at) < tuningWindow {
+			recent = append(recent, at)
+		}
+	}
+	t.limited = append(recent, now)
+	if len(t.limited) < tuningThreshold || t.level >= tuningMaxLevel {
+		return false
+	}
+	t.level++
+	t.limited = t.limited[:0]
+	return true
+}
+
+// succeeded records a successful response, and reports whether it undid a step of reduction.
+func (t *rateLimitTuning) succeeded() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.level == 0 {
+		return false
+	}
+	t.successes++
+	if t.successes < tuningRecovery {
+		return false
+	}
+	t.level--
+	t.successes = 0
+	return true
+}
+
+// scale returns n reduced by the current level, but never below floor, or n itself when it is
+// already at or below floor.
+func (t *rateLimitTuning) scale(n, floor int) int {
+	t.mu.Lock()
+	level := t.level
+	t.mu.Unlock()
+	if n <= floor {
+		return n
+	}
+	return max(floor, n>>level)
+}
+
+// TuningLevel returns the number of steps by which the client has halved its page size and
+// concurrency after repeated rate-limit (429) responses; zero means the configured values are
+// in use.
+func (c *Client) TuningLevel() int {
+	c.tuning.mu.Lock()
+	defer c.tuning.mu.Unlock()
+	return c.tuning.level
+}
+
+// tunedPageLimit returns the page size to request for a read whose default is fallback, reduced
+// after repeated rate-limit responses. Values below one stand for defaultPageLimit.
+func (c *Client) tunedPageLimit(fallback int) int {
+	if fallback < 1 {
+		fallback = defaultPageLimit
+	}
+	return c.tuning.scale(fallback, minTunedPageLimit)
+}
+
+// recordRateLimited feeds a rate-limit response into the tuning and logs any reduction.
+func (c *Client) recordRateLimited(ctx context.Context) {
+	if c.tuning.rateLimited(c.Clock().Now()) && c.logger != nil {
+		c.logger.LogWarning(ctx, "Reducing page size and concurrency after repeated rate limits", map[string]any{
+			"tuning_level": c.TuningLevel(),
+		})
+	}
+}
+
+// recordSucceeded feeds a successful response into the tuning and logs any restoration.
+func (c *Client) recordSucceeded(ctx context.Context) {
+	if c.tuning.succeeded() && c.logger != nil {
+		c.logger.LogAuth(ctx, "Restoring page size and concurrency after successful requests", map[string]any{
+			"tuning_level": c.TuningLevel(),
+		})
+	}
+}
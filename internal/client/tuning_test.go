@@ -0,0 +1,102 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTuning_StepsDownAndRecovers(t *testing.T) {
+	var tuning rateLimitTuning
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := range tuningThreshold - 1 {
+		if tuning.rateLimited(now.Add(time.Duration(i) * time.Second)) {
+			t.Fatalf("expected no reduction after %d rate limits", i+1)
+		}
+	}
+	if !tuning.rateLimited(now.Add(time.Minute - time.Second)) {
+		t.Fatalf("expected a reduction after %d rate limits within %v", tuningThreshold, tuningWindow)
+	}
+	if got := tuning.scale(1000, minTunedPageLimit); got != 500 {
+		t.Errorf("expected the page size to be halved to 500, got %d", got)
+	}
+	if got := tuning.scale(4, 1); got != 2 {
+		t.Errorf("expected the concurrency to be halved to 2, got %d", got)
+	}
+
+	for i := range tuningThreshold {
+		if tuning.rateLimited(now.Add(time.Duration(i) * 2 * tuningWindow)) {
+			t.Fatal("expected rate limits spread beyond the window not to reduce further")
+		}
+	}
+
+	for range tuningRecovery - 1 {
+		if tuning.succeeded() {
+			t.Fatal("expected no restoration before enough successful responses")
+		}
+	}
+	if !tuning.succeeded() {
+		t.Fatalf("expected a restoration after %d successful responses", tuningRecovery)
+	}
+	if got := tuning.scale(1000, minTunedPageLimit); got != 1000 {
+		t.Errorf("expected the page size to be restored to 1000, got %d", got)
+	}
+}
+
+func TestRateLimitTuning_Floors(t *testing.T) {
+	tuning := rateLimitTuning{level: tuningMaxLevel}
+	if got := tuning.scale(1000, minTunedPageLimit); got != 125 {
+		t.Errorf("expected 125, got %d", got)
+	}
+	if got := tuning.scale(150, minTunedPageLimit); got != minTunedPageLimit {
+		t.Errorf("expected the floor of %d, got %d", minTunedPageLimit, got)
+	}
+	if got := tuning.scale(50, minTunedPageLimit); got != 50 {
+		t.Errorf("expected values below the floor to be kept, got %d", got)
+	}
+	if got := tuning.scale(4, 1); got != 1 {
+		t.Errorf("expected a concurrency of 1, got %d", got)
+	}
+}
+
+func TestDoRequest_RepeatedRateLimitsReduceConcurrencyAndPageSize(t *testing.T) {
+	var requests atomic.Int32
+	var lastLimit atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastLimit.Store(r.URL.Query().Get("limit"))
+		if requests.Add(1) <= tuningThreshold {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(t, map[string]any{"data": []Data{}, "meta": Meta{}}))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server)
+	c.SetMaxConcurrency(8)
+	if _, err := collectPages[Data](context.Background(), c, "/v1/orgDevices", nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := c.TuningLevel(); got != 1 {
+		t.Fatalf("expected tuning level 1, got %d", got)
+	}
+	if got := c.MaxConcurrency(); got != 4 {
+		t.Errorf("expected the concurrency to be halved to 4, got %d", got)
+	}
+
+	if _, err := collectPages[Data](context.Background(), c, "/v1/mdmServers", nil, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := lastLimit.Load(); got != "500" {
+		t.Errorf("expected the next read to request pages of 500, got %v", got)
+	}
+}
@@ -5,12 +5,9 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // UserGroupType represents the type of a user group.
@@ -80,58 +77,7 @@ type UserGroupUsersLinkagesResponse struct {
 
 // GetUserGroups retrieves all user groups in the organization.
 func (c *Client) GetUserGroups(ctx context.Context, queryParams url.Values) ([]UserGroup, error) {
-	var allGroups []UserGroup
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/userGroups", c.baseURL), nil)
-		if err != nil {
-			return nil, err
-		}
-		params := make(url.Values)
-		maps.Copy(params, queryParams)
-		params.Set("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			params.Set("cursor", nextCursor)
-		}
-		req.URL.RawQuery = params.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response UserGroupsResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			allGroups = append(allGroups, response.Data...)
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allGroups, nil
+	return collectPages[UserGroup](ctx, c, "/v1/userGroups", queryParams, defaultPageLimit)
 }
 
 // GetUserGroup retrieves a single user group by ID.
@@ -159,7 +105,7 @@ func (c *Client) GetUserGroup(ctx context.Context, id string, queryParams url.Va
 	}
 
 	var response UserGroupResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.decodeJSON(resp.Body, &response); err != nil {
 		return nil, fmt.Errorf("failed to decode response JSON: %w", err)
 	}
 
@@ -168,61 +114,5 @@ func (c *Client) GetUserGroup(ctx context.Context, id string, queryParams url.Va
 
 // GetUserGroupUserIDs retrieves all user IDs for a user group.
 func (c *Client) GetUserGroupUserIDs(ctx context.Context, groupID string) ([]string, error) {
-	var allUserIDs []string
-	nextCursor := ""
-	limit := 1000
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
-			fmt.Sprintf("%s/v1/userGroups/%s/relationships/users", c.baseURL, groupID), nil)
-		if err != nil {
-			return nil, err
-		}
-
-		q := req.URL.Query()
-		q.Add("limit", strconv.Itoa(limit))
-		if nextCursor != "" {
-			q.Add("cursor", nextCursor)
-		}
-		req.URL.RawQuery = q.Encode()
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := c.doRequest(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := func() error {
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				return c.handleErrorResponse(resp)
-			}
-
-			var response UserGroupUsersLinkagesResponse
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				return fmt.Errorf("failed to decode response JSON: %w", err)
-			}
-
-			for _, user := range response.Data {
-				if user.Type == "users" {
-					allUserIDs = append(allUserIDs, user.ID)
-				}
-			}
-
-			nextCursor = response.Meta.Paging.NextCursor
-			return nil
-		}(); err != nil {
-			return nil, err
-		}
-
-		if nextCursor == "" {
-			break
-		}
-	}
-
-	return allUserIDs, nil
+	return collectLinkageIDs(ctx, c, fmt.Sprintf("/v1/userGroups/%s/relationships/users", groupID), "users")
 }
@@ -7,6 +7,15 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// quoteFolder maps typographic quotation marks to their ASCII equivalents so that names
+// typed with smart quotes match names typed with straight quotes.
+var quoteFolder = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'",
+	"“", "\"", "”", "\"", "„", "\"", "‟", "\"", "″", "\"",
 )
 
 // NormalizedFilterString returns a trimmed string and whether it should be used as a filter.
@@ -22,3 +31,11 @@ func NormalizedFilterString(value types.String) (string, bool) {
 
 	return trimmed, true
 }
+
+// FoldFilterString returns the form of value used for case-insensitive name comparisons.
+// The value is trimmed, normalized to Unicode NFC so that precomposed and combining accents
+// compare equal, case folded, and has typographic quotes replaced with ASCII quotes.
+func FoldFilterString(value string) string {
+	folded := cases.Fold().String(norm.NFC.String(strings.TrimSpace(value)))
+	return norm.NFC.String(quoteFolder.Replace(folded))
+}
@@ -0,0 +1,62 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizedFilterString(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  types.String
+		want   string
+		wantOK bool
+	}{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "blank", value: types.StringValue("   ")},
+		{name: "trimmed", value: types.StringValue("  Primary MDM "), want: "Primary MDM", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizedFilterString(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizedFilterString() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFoldFilterString(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{name: "ascii_case", a: "Primary MDM", b: "primary mdm"},
+		{name: "surrounding_whitespace", a: "  Primary MDM ", b: "primary mdm"},
+		{name: "precomposed_vs_combining_accent", a: "Caf\u00e9 Devices", b: "cafe\u0301 devices"},
+		{name: "accented_uppercase", a: "ÉCOLE", b: "école"},
+		{name: "sharp_s", a: "STRASSE", b: "straße"},
+		{name: "smart_single_quote", a: "Neil’s Macs", b: "neil's macs"},
+		{name: "smart_double_quotes", a: "“Lab” Devices", b: "\"lab\" devices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if FoldFilterString(tt.a) != FoldFilterString(tt.b) {
+				t.Errorf("expected %q and %q to fold to the same value, got %q and %q",
+					tt.a, tt.b, FoldFilterString(tt.a), FoldFilterString(tt.b))
+			}
+		})
+	}
+
+	if FoldFilterString("Café") == FoldFilterString("Cafe") {
+		t.Error("expected accents to remain significant after folding")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// OfflineOrgDevices returns the cached device inventory in place of a read that failed with
+// err, when the provider's offline_fallback setting is enabled and err shows that the API
+// could not be reached. A warning naming the time the inventory was cached is added. It
+// reports false, leaving err to be reported by the caller, when the fallback does not apply
+// or no usable inventory is cached.
+func OfflineOrgDevices(c *client.Client, err error, diags *diag.Diagnostics) ([]client.OrgDevice, bool) {
+	if !c.OfflineFallback() || !client.IsAPIUnreachable(err) {
+		return nil, false
+	}
+
+	inventory, cacheErr := c.CachedOrgDevices()
+	if cacheErr != nil {
+		diags.AddWarning(
+			"Cached Device Inventory Unavailable",
+			fmt.Sprintf("The Apple API could not be reached and offline_fallback is enabled, but the cached device inventory cannot be used: %s.", cacheErr),
+		)
+		return nil, false
+	}
+
+	age := c.Clock().Now().Sub(inventory.CachedAt).Truncate(time.Second)
+	diags.AddWarning(
+		"Using Cached Device Inventory: Results May Be Out of Date",
+		fmt.Sprintf("The Apple API could not be reached, so offline_fallback read %d devices from the inventory cached at %s (%s ago) in %s. "+
+			"Changes made in Apple Business or School Manager since then are not reflected.\n\nAPI error: %s",
+			len(inventory.Devices), inventory.CachedAt.UTC().Format(time.RFC3339), age, inventory.Path, err),
+	)
+	return inventory.Devices, true
+}
+
+// FindOrgDevice returns the device in devices whose ID, or case-insensitively whose serial
+// number, is identifier.
+func FindOrgDevice(devices []client.OrgDevice, identifier string) (client.OrgDevice, bool) {
+	for _, device := range devices {
+		if device.ID == identifier || strings.EqualFold(device.Attributes.SerialNumber, identifier) {
+			return device, true
+		}
+	}
+	return client.OrgDevice{}, false
+}
@@ -0,0 +1,50 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestOfflineOrgDevices(t *testing.T) {
+	unreachable := &url.Error{Op: "Get", URL: "https://api", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	var diags diag.Diagnostics
+	c := &client.Client{}
+	if _, ok := OfflineOrgDevices(c, unreachable, &diags); ok || diags.WarningsCount() > 0 {
+		t.Errorf("expected no fallback while disabled, got %v", diags)
+	}
+
+	c.SetOfflineFallback(true)
+	if _, ok := OfflineOrgDevices(c, &client.APIError{StatusCode: 404, Code: client.ErrorCodeNotFound}, &diags); ok || diags.WarningsCount() > 0 {
+		t.Errorf("expected no fallback for an API error, got %v", diags)
+	}
+
+	if _, ok := OfflineOrgDevices(c, unreachable, &diags); ok || diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning and no fallback without a cache, got %v", diags)
+	}
+}
+
+func TestFindOrgDevice(t *testing.T) {
+	devices := []client.OrgDevice{
+		{ID: "DEV1", Attributes: client.DeviceAttribute{SerialNumber: "SN001"}},
+		{ID: "DEV2", Attributes: client.DeviceAttribute{SerialNumber: "SN002"}},
+	}
+	if device, ok := FindOrgDevice(devices, "DEV2"); !ok || device.ID != "DEV2" {
+		t.Errorf("expected DEV2 by ID, got %+v", device)
+	}
+	if device, ok := FindOrgDevice(devices, "sn001"); !ok || device.ID != "DEV1" {
+		t.Errorf("expected DEV1 by serial number, got %+v", device)
+	}
+	if _, ok := FindOrgDevice(devices, "SN404"); ok {
+		t.Error("expected no device for an unknown identifier")
+	}
+}
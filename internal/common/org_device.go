@@ -0,0 +1,185 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// OrgDeviceModel holds the attributes of an organization device shared by every
+// device data source. Embed it in a data source model alongside the data source's
+// own id and timeouts fields.
+type OrgDeviceModel struct {
+	Type                    types.String   `tfsdk:"type"`
+	SerialNumber            types.String   `tfsdk:"serial_number"`
+	AddedToOrgDateTime      types.String   `tfsdk:"added_to_org_date_time"`
+	ReleasedFromOrgDateTime types.String   `tfsdk:"released_from_org_date_time"`
+	UpdatedDateTime         types.String   `tfsdk:"updated_date_time"`
+	DeviceModel             types.String   `tfsdk:"device_model"`
+	ProductFamily           types.String   `tfsdk:"product_family"`
+	ProductType             types.String   `tfsdk:"product_type"`
+	DeviceCapacity          types.String   `tfsdk:"device_capacity"`
+	PartNumber              types.String   `tfsdk:"part_number"`
+	OrderNumber             types.String   `tfsdk:"order_number"`
+	Color                   types.String   `tfsdk:"color"`
+	Status                  types.String   `tfsdk:"status"`
+	OrderDateTime           types.String   `tfsdk:"order_date_time"`
+	IMEI                    []types.String `tfsdk:"imei"`
+	MEID                    []types.String `tfsdk:"meid"`
+	EID                     types.String   `tfsdk:"eid"`
+	PurchaseSourceID        types.String   `tfsdk:"purchase_source_id"`
+	PurchaseSourceType      types.String   `tfsdk:"purchase_source_type"`
+	WifiMacAddress          types.String   `tfsdk:"wifi_mac_address"`
+	BluetoothMacAddress     types.String   `tfsdk:"bluetooth_mac_address"`
+	EthernetMacAddress      []types.String `tfsdk:"ethernet_mac_address"`
+	ReleaserEntityType      types.String   `tfsdk:"releaser_entity_type"`
+	ReleaserID              types.String   `tfsdk:"releaser_id"`
+	SelfLink                types.String   `tfsdk:"self_link"`
+}
+
+// NewOrgDeviceModel maps an API organization device to its Terraform model. Absent
+// optional values become null and timestamps are normalized to RFC 3339.
+func NewOrgDeviceModel(device client.OrgDevice, diags *diag.Diagnostics) OrgDeviceModel {
+	attrs := device.Attributes
+	return OrgDeviceModel{
+		Type:                    types.StringValue(device.Type),
+		SerialNumber:            types.StringValue(attrs.SerialNumber),
+		AddedToOrgDateTime:      TimestampValue(attrs.AddedToOrgDateTime, "added_to_org_date_time", diags),
+		ReleasedFromOrgDateTime: TimestampValue(attrs.ReleasedFromOrgDateTime, "released_from_org_date_time", diags),
+		UpdatedDateTime:         TimestampValue(attrs.UpdatedDateTime, "updated_date_time", diags),
+		DeviceModel:             types.StringValue(attrs.DeviceModel),
+		ProductFamily:           types.StringValue(attrs.ProductFamily),
+		ProductType:             types.StringValue(attrs.ProductType),
+		DeviceCapacity:          types.StringValue(attrs.DeviceCapacity),
+		PartNumber:              OptionalString(attrs.PartNumber),
+		OrderNumber:             OptionalString(attrs.OrderNumber),
+		Color:                   types.StringValue(attrs.Color),
+		Status:                  types.StringValue(string(attrs.Status)),
+		OrderDateTime:           TimestampValue(attrs.OrderDateTime, "order_date_time", diags),
+		IMEI:                    OptionalStrings(attrs.IMEI),
+		MEID:                    OptionalStrings(attrs.MEID),
+		EID:                     OptionalString(attrs.EID),
+		PurchaseSourceID:        types.StringValue(attrs.PurchaseSourceID),
+		PurchaseSourceType:      types.StringValue(string(attrs.PurchaseSourceType)),
+		WifiMacAddress:          OptionalString(attrs.WifiMacAddress),
+		BluetoothMacAddress:     OptionalString(attrs.BluetoothMacAddress),
+		EthernetMacAddress:      OptionalStrings(attrs.EthernetMacAddress),
+		ReleaserEntityType:      OptionalString(attrs.ReleaserEntityType),
+		ReleaserID:              OptionalString(attrs.ReleaserID),
+		SelfLink:                OptionalString(device.Links.Self),
+	}
+}
+
+// OrgDeviceSchemaAttributes returns the computed data source schema attributes matching OrgDeviceModel.
+func OrgDeviceSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The type of the device.",
+		},
+		"serial_number": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's serial number.",
+		},
+		"added_to_org_date_time": schema.StringAttribute{
+			Computed:    true,
+			Description: "The date and time of adding the device to an organization. Normalized to RFC 3339 in UTC.",
+		},
+		"released_from_org_date_time": schema.StringAttribute{
+			Computed:    true,
+			Description: "The date and time the device was released from an organization. This will be null if the device hasn't been released. Currently only querying by a single device is supported. Batch device queries aren't currently supported for this property. Normalized to RFC 3339 in UTC.",
+		},
+		"updated_date_time": schema.StringAttribute{
+			Computed:    true,
+			Description: "The date and time of the most-recent update for the device. Normalized to RFC 3339 in UTC.",
+		},
+		"device_model": schema.StringAttribute{
+			Computed:    true,
+			Description: "The model name.",
+		},
+		"product_family": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's Apple product family: iPhone, iPad,Mac, AppleTV, Watch, or Vision.",
+		},
+		"product_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's product type: (examples: iPhone14,3, iPad13,4, MacBookPro14,2).",
+		},
+		"device_capacity": schema.StringAttribute{
+			Computed:    true,
+			Description: "The capacity of the device.",
+		},
+		"part_number": schema.StringAttribute{
+			Computed:    true,
+			Description: "The part number of the device.",
+		},
+		"order_number": schema.StringAttribute{
+			Computed:    true,
+			Description: "The order number of the device.",
+		},
+		"color": schema.StringAttribute{
+			Computed:    true,
+			Description: "The color of the device.",
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's status. Possible values: 'ASSIGNED', 'UNASSIGNED'. If ASSIGNED, use a separate API to get the information of the assigned server.",
+		},
+		"order_date_time": schema.StringAttribute{
+			Computed:    true,
+			Description: "The date and time of placing the device's order. Normalized to RFC 3339 in UTC.",
+		},
+		"imei": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "The device's IMEI (if available).",
+		},
+		"meid": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "The device's MEID (if available).",
+		},
+		"eid": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's EID (if available).",
+		},
+		"purchase_source_id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The unique ID of the purchase source type: Apple Customer Number or Reseller Number.",
+		},
+		"purchase_source_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The type of the purchase source. Possible values: 'APPLE' for devices purchased directly from Apple, 'RESELLER' for devices purchased from an authorized reseller, 'MANUALLY_ADDED' for devices added with Apple Configurator.",
+		},
+		"wifi_mac_address": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's Wi-Fi MAC address.",
+		},
+		"bluetooth_mac_address": schema.StringAttribute{
+			Computed:    true,
+			Description: "The device's Bluetooth MAC address.",
+		},
+		"ethernet_mac_address": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "The device's built-in Ethernet MAC addresses.",
+		},
+		"releaser_entity_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The type of entity that released the device from the organization.",
+		},
+		"releaser_id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the entity that released the device from the organization.",
+		},
+		"self_link": schema.StringAttribute{
+			Computed:    true,
+			Description: "The API URL of the device resource, as returned in links.self. Null if the API did not return a link.",
+		},
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// OrgDeviceFieldsParam is the query parameter selecting the attributes the API returns for
+// organization devices.
+const OrgDeviceFieldsParam = "fields[orgDevices]"
+
+// orgDeviceFields maps each OrgDeviceModel attribute that can be selected in a fields
+// attribute to its name in the fields[orgDevices] query parameter.
+var orgDeviceFields = map[string]string{
+	"serial_number":               "serialNumber",
+	"added_to_org_date_time":      "addedToOrgDateTime",
+	"released_from_org_date_time": "releasedFromOrgDateTime",
+	"updated_date_time":           "updatedDateTime",
+	"device_model":                "deviceModel",
+	"product_family":              "productFamily",
+	"product_type":                "productType",
+	"device_capacity":             "deviceCapacity",
+	"part_number":                 "partNumber",
+	"order_number":                "orderNumber",
+	"color":                       "color",
+	"status":                      "status",
+	"order_date_time":             "orderDateTime",
+	"imei":                        "imei",
+	"meid":                        "meid",
+	"eid":                         "eid",
+	"purchase_source_id":          "purchaseSourceId",
+	"purchase_source_type":        "purchaseSourceType",
+	"wifi_mac_address":            "wifiMacAddress",
+	"bluetooth_mac_address":       "bluetoothMacAddress",
+	"ethernet_mac_address":        "ethernetMacAddress",
+	"releaser_entity_type":        "releaserEntityType",
+	"releaser_id":                 "releaserId",
+}
+
+// OrgDeviceFieldNames returns the attribute names accepted in a fields attribute, sorted.
+func OrgDeviceFieldNames() []string {
+	return slices.Sorted(maps.Keys(orgDeviceFields))
+}
+
+// OrgDeviceFieldsAttribute returns the optional fields attribute of the organization device
+// data sources.
+func OrgDeviceFieldsAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: "Device attributes to request from the API, such as serial_number and status, sent as a fields[orgDevices] sparse " +
+			"fieldset so that less data is transferred for large inventories. Attributes not listed are null. type and self_link are " +
+			"always set. Defaults to every attribute.",
+		Optional:    true,
+		ElementType: types.StringType,
+		Validators: []validator.Set{
+			setvalidator.SizeAtLeast(1),
+			setvalidator.ValueStringsAre(stringvalidator.OneOf(OrgDeviceFieldNames()...)),
+		},
+	}
+}
+
+// OrgDeviceFieldsValue returns the fields[orgDevices] value requesting attributes, sorted
+// and without duplicates. Unknown attribute names are ignored.
+func OrgDeviceFieldsValue(attributes ...string) string {
+	var names []string
+	for _, attribute := range attributes {
+		if name, ok := orgDeviceFields[attribute]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return strings.Join(names, ",")
+}
+
+// WithFields returns a copy of m in which every selectable attribute not in attributes is
+// null. An empty attributes keeps every attribute.
+func (m OrgDeviceModel) WithFields(attributes []string) OrgDeviceModel {
+	if len(attributes) == 0 {
+		return m
+	}
+	v := reflect.ValueOf(&m).Elem()
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Tag.Get("tfsdk")
+		if _, selectable := orgDeviceFields[name]; !selectable || slices.Contains(attributes, name) {
+			continue
+		}
+		switch field := v.Field(i); field.Interface().(type) {
+		case types.String:
+			field.Set(reflect.ValueOf(types.StringNull()))
+		case []types.String:
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+	return m
+}
@@ -0,0 +1,74 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestOrgDeviceFields_CoversEveryAPIField(t *testing.T) {
+	apiType := reflect.TypeOf(client.DeviceAttribute{})
+	var apiNames []string
+	for i := range apiType.NumField() {
+		name, _, _ := strings.Cut(apiType.Field(i).Tag.Get("json"), ",")
+		apiNames = append(apiNames, name)
+	}
+	slices.Sort(apiNames)
+
+	var mapped []string
+	for _, name := range orgDeviceFields {
+		mapped = append(mapped, name)
+	}
+	slices.Sort(mapped)
+	if !slices.Equal(mapped, apiNames) {
+		t.Errorf("expected fields for %v, got %v", apiNames, mapped)
+	}
+
+	attributes := OrgDeviceSchemaAttributes()
+	for _, name := range OrgDeviceFieldNames() {
+		if _, ok := attributes[name]; !ok {
+			t.Errorf("field %q has no schema attribute", name)
+		}
+	}
+}
+
+func TestOrgDeviceFieldsValue(t *testing.T) {
+	got := OrgDeviceFieldsValue("status", "serial_number", "status", "unknown", "wifi_mac_address")
+	if want := "serialNumber,status,wifiMacAddress"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := OrgDeviceFieldsValue(); got != "" {
+		t.Errorf("expected no fields, got %q", got)
+	}
+}
+
+func TestOrgDeviceModel_WithFields(t *testing.T) {
+	var diags diag.Diagnostics
+	model := NewOrgDeviceModel(populatedOrgDevice(t), &diags)
+
+	if got := model.WithFields(nil); !reflect.DeepEqual(got, model) {
+		t.Errorf("expected no fields to keep every attribute, got %+v", got)
+	}
+
+	got := model.WithFields([]string{"serial_number", "imei"})
+	if got.SerialNumber.IsNull() || got.IMEI == nil {
+		t.Errorf("expected selected attributes to be kept, got %+v", got)
+	}
+	if got.Type.IsNull() || got.SelfLink.IsNull() {
+		t.Errorf("expected type and self_link to be kept, got %+v", got)
+	}
+	if !got.Status.IsNull() || got.MEID != nil || !got.ReleaserID.IsNull() {
+		t.Errorf("expected unselected attributes to be null, got %+v", got)
+	}
+	if model.Status.IsNull() {
+		t.Error("expected the original model to be unchanged")
+	}
+}
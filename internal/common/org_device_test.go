@@ -0,0 +1,125 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// populatedOrgDevice returns an OrgDevice with every attribute field set to a non-empty value.
+func populatedOrgDevice(t *testing.T) client.OrgDevice {
+	t.Helper()
+	device := client.OrgDevice{ID: "DEVICE1", Type: "orgDevices", Links: client.ResourceLinks{Self: "https://api-business.apple.com/v1/orgDevices/DEVICE1"}}
+	v := reflect.ValueOf(&device.Attributes).Elem()
+	for i := range v.NumField() {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString("2025-01-02T03:04:05Z")
+		case reflect.Slice:
+			field.Set(reflect.ValueOf([]string{"value"}))
+		default:
+			t.Fatalf("unhandled DeviceAttribute field kind %s for %s", field.Kind(), v.Type().Field(i).Name)
+		}
+	}
+	return device
+}
+
+func TestNewOrgDeviceModel_CoversEveryAPIField(t *testing.T) {
+	var diags diag.Diagnostics
+	model := NewOrgDeviceModel(populatedOrgDevice(t), &diags)
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	apiFields := reflect.TypeOf(client.DeviceAttribute{}).NumField()
+	modelFields := reflect.TypeOf(model).NumField()
+	if modelFields != apiFields+2 {
+		t.Errorf("OrgDeviceModel has %d fields, expected %d (every DeviceAttribute field plus type and self_link)", modelFields, apiFields+2)
+	}
+
+	v := reflect.ValueOf(model)
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Name
+		switch value := v.Field(i).Interface().(type) {
+		case types.String:
+			if value.IsNull() || value.ValueString() == "" {
+				t.Errorf("field %s was not mapped", name)
+			}
+		case []types.String:
+			if len(value) == 0 {
+				t.Errorf("field %s was not mapped", name)
+			}
+		default:
+			t.Errorf("field %s has unexpected type %T", name, value)
+		}
+	}
+}
+
+func TestOrgDeviceSchemaAttributes_MatchesModel(t *testing.T) {
+	attributes := OrgDeviceSchemaAttributes()
+	modelType := reflect.TypeOf(OrgDeviceModel{})
+
+	tags := make(map[string]bool, modelType.NumField())
+	for i := range modelType.NumField() {
+		tag := modelType.Field(i).Tag.Get("tfsdk")
+		tags[tag] = true
+		if _, ok := attributes[tag]; !ok {
+			t.Errorf("model field %q has no schema attribute", tag)
+		}
+	}
+	for name, attr := range attributes {
+		if !tags[name] {
+			t.Errorf("schema attribute %q has no model field", name)
+		}
+		if !attr.IsComputed() {
+			t.Errorf("schema attribute %q should be Computed", name)
+		}
+	}
+}
+
+func TestOrgDeviceModel_EmbedsInState(t *testing.T) {
+	type wrapper struct {
+		ID types.String `tfsdk:"id"`
+		OrgDeviceModel
+	}
+
+	attributes := OrgDeviceSchemaAttributes()
+	attributes["id"] = schema.StringAttribute{Computed: true}
+	s := schema.Schema{Attributes: attributes}
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+
+	var diags diag.Diagnostics
+	data := wrapper{
+		ID:             types.StringValue("DEVICE1"),
+		OrgDeviceModel: NewOrgDeviceModel(client.OrgDevice{Type: "orgDevices"}, &diags),
+	}
+	diags.Append(state.Set(context.Background(), &data)...)
+	if diags.HasError() {
+		t.Fatalf("failed to set state: %v", diags)
+	}
+
+	var got wrapper
+	diags.Append(state.Get(context.Background(), &got)...)
+	if diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if got.Type.ValueString() != "orgDevices" || !got.ReleasedFromOrgDateTime.IsNull() {
+		t.Errorf("unexpected round-trip model: %+v", got)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// CredentialsProfileAttribute returns the schema for the optional credentials_profile attribute
+// that reads a data source with an alternate profile from the shared credentials file.
+func CredentialsProfileAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Description: "Name of a profile in the provider's shared credentials file to read with instead of the provider's credentials, " +
+			"such as one for a second organization. The profile must set client_id, key_id and private_key_path, and its scope " +
+			"defaults to business.api. Other provider settings, such as retries and read_only, still apply. Defaults to the " +
+			"provider's credentials.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// ClientForProfile returns the client authenticated with the credentials profile named by
+// profile, or c itself when profile is null or empty.
+func ClientForProfile(c *client.Client, profile types.String) (*client.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if profile.IsNull() || profile.IsUnknown() || profile.ValueString() == "" {
+		return c, diags
+	}
+
+	profileClient, err := c.ForProfile(profile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("credentials_profile"), "Unable to Use Credentials Profile", err.Error())
+		return nil, diags
+	}
+	return profileClient, diags
+}
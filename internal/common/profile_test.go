@@ -0,0 +1,34 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestClientForProfile(t *testing.T) {
+	parent := &client.Client{}
+	profileClient := &client.Client{}
+	parent.SetProfileClientFactory(func(profile string) (*client.Client, error) {
+		if profile != "acquired" {
+			return nil, errors.New("profile not found")
+		}
+		return profileClient, nil
+	})
+
+	if c, diags := ClientForProfile(parent, types.StringNull()); diags.HasError() || c != parent {
+		t.Errorf("expected a null profile to use the provider client, got %p, %v", c, diags)
+	}
+	if c, diags := ClientForProfile(parent, types.StringValue("acquired")); diags.HasError() || c != profileClient {
+		t.Errorf("expected the profile client, got %p, %v", c, diags)
+	}
+	if _, diags := ClientForProfile(parent, types.StringValue("missing")); !diags.HasError() {
+		t.Error("expected an error for an unknown profile")
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// RedactDiagnostics replaces the serial numbers in the summary and detail of every diagnostic
+// in diags with their hashes when the provider's redact_serials setting is enabled, keeping
+// each diagnostic's severity and attribute path. Handlers defer it so that it also covers
+// diagnostics added on early returns.
+func RedactDiagnostics(c *client.Client, diags *diag.Diagnostics) {
+	if !c.RedactsSerials() || len(*diags) == 0 {
+		return
+	}
+
+	redacted := make(diag.Diagnostics, 0, len(*diags))
+	for _, d := range *diags {
+		summary, detail := c.RedactText(d.Summary()), c.RedactText(d.Detail())
+		withPath, hasPath := d.(diag.DiagnosticWithPath)
+		switch {
+		case hasPath && d.Severity() == diag.SeverityError:
+			redacted = append(redacted, diag.NewAttributeErrorDiagnostic(withPath.Path(), summary, detail))
+		case hasPath:
+			redacted = append(redacted, diag.NewAttributeWarningDiagnostic(withPath.Path(), summary, detail))
+		case d.Severity() == diag.SeverityError:
+			redacted = append(redacted, diag.NewErrorDiagnostic(summary, detail))
+		default:
+			redacted = append(redacted, diag.NewWarningDiagnostic(summary, detail))
+		}
+	}
+	*diags = redacted
+}
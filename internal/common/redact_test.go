@@ -0,0 +1,49 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestRedactDiagnostics(t *testing.T) {
+	newDiags := func() diag.Diagnostics {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to Read Device C02XG0FDH7JY", "Device C02XG0FDH7JY was not found.")
+		diags.AddAttributeWarning(path.Root("device_ids"), "Devices Skipped", "Skipped DMPXJ1ABCDEF.")
+		return diags
+	}
+
+	diags := newDiags()
+	RedactDiagnostics(&client.Client{}, &diags)
+	if !diags.Equal(newDiags()) {
+		t.Errorf("expected diagnostics to be unchanged when disabled, got %v", diags)
+	}
+
+	c := &client.Client{}
+	c.SetRedactSerials(true)
+	RedactDiagnostics(c, &diags)
+
+	if diags.ErrorsCount() != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected severities to be kept, got %v", diags)
+	}
+	for _, d := range diags {
+		if strings.Contains(d.Summary()+d.Detail(), "C02XG0FDH7JY") || strings.Contains(d.Detail(), "DMPXJ1ABCDEF") {
+			t.Errorf("expected serials to be redacted, got %q: %q", d.Summary(), d.Detail())
+		}
+	}
+	if want := "Device " + c.RedactSerial("C02XG0FDH7JY") + " was not found."; diags[0].Detail() != want {
+		t.Errorf("expected %q, got %q", want, diags[0].Detail())
+	}
+	withPath, ok := diags[1].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("device_ids")) {
+		t.Errorf("expected the attribute path to be kept, got %v", diags[1])
+	}
+}
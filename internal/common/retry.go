@@ -0,0 +1,78 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// RetryModel describes the resource-level retry overrides shared by resources that
+// submit mutating requests.
+type RetryModel struct {
+	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+	InitialBackoff types.String `tfsdk:"initial_backoff"`
+	MaxBackoff     types.String `tfsdk:"max_backoff"`
+	MaxRetryAfter  types.String `tfsdk:"max_retry_after"`
+}
+
+// RetryAttribute returns the optional nested `retry` attribute that lets a resource
+// override the provider retry policy for its own API calls.
+func RetryAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Overrides the provider retry policy for API calls made by this resource. Unset fields inherit the provider policy.",
+		Attributes: map[string]schema.Attribute{
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of times a rate-limited (429) or transient server error (502, 503, 504) response is retried after the first attempt. Must be at least 1.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"initial_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: `Initial backoff before retrying a transient server error, doubled on each attempt (e.g. "2s").`,
+				Validators: []validator.String{
+					Duration(),
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: `Upper bound for the exponential backoff between retries (e.g. "30s").`,
+				Validators: []validator.String{
+					Duration(),
+				},
+			},
+			"max_retry_after": schema.StringAttribute{
+				Optional:    true,
+				Description: `Longest Retry-After value honoured on a 429 response before failing (e.g. "60s").`,
+				Validators: []validator.String{
+					Duration(),
+				},
+			},
+		},
+	}
+}
+
+// WithRetryOverrides returns a context carrying the retry policy described by model.
+// A nil model returns ctx unchanged.
+func WithRetryOverrides(ctx context.Context, model *RetryModel) context.Context {
+	if model == nil {
+		return ctx
+	}
+
+	return client.WithRetryPolicy(ctx, client.RetryPolicy{
+		MaxRetries:            int(model.MaxRetries.ValueInt64()),
+		InitialBackoff:        DurationValue(model.InitialBackoff, 0),
+		MaxBackoff:            DurationValue(model.MaxBackoff, 0),
+		MaxRetryAfterDuration: DurationValue(model.MaxRetryAfter, 0),
+	})
+}
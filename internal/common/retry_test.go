@@ -0,0 +1,43 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWithRetryOverrides_NilModel(t *testing.T) {
+	ctx := context.Background()
+	if got := WithRetryOverrides(ctx, nil); got != ctx {
+		t.Fatal("expected context to be returned unchanged for nil model")
+	}
+}
+
+func TestWithRetryOverrides_SetsPolicy(t *testing.T) {
+	ctx := context.Background()
+	model := &RetryModel{
+		MaxRetries:     types.Int64Value(8),
+		InitialBackoff: types.StringValue("5s"),
+		MaxBackoff:     types.StringNull(),
+		MaxRetryAfter:  types.StringNull(),
+	}
+	if got := WithRetryOverrides(ctx, model); got == ctx {
+		t.Fatal("expected a derived context carrying the retry policy")
+	}
+}
+
+func TestRetryAttribute(t *testing.T) {
+	attr := RetryAttribute()
+	if !attr.IsOptional() {
+		t.Error("expected retry attribute to be Optional")
+	}
+	for _, name := range []string{"max_retries", "initial_backoff", "max_backoff", "max_retry_after"} {
+		if _, ok := attr.Attributes[name]; !ok {
+			t.Errorf("nested attribute %q not found", name)
+		}
+	}
+}
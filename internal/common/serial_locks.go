@@ -0,0 +1,31 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// SerialLockConflictDetail describes devices the serial lock backend records as owned by
+// another workspace.
+func SerialLockConflictDetail(owners map[string]string) string {
+	return fmt.Sprintf("The serial lock backend records %d device(s) as managed by another workspace: %s\n\n"+
+		"Remove them from this configuration, or release them in the other workspace first.", len(owners), client.FormatSerialOwners(owners))
+}
+
+// ReleaseSerials releases the workspace's serial locks on devices that are no longer assigned,
+// reporting failures as warnings because the unassignment has already happened.
+func ReleaseSerials(ctx context.Context, c *client.Client, deviceIDs []string, diags *diag.Diagnostics) {
+	if err := c.ReleaseSerials(ctx, deviceIDs); err != nil {
+		diags.AddWarning(
+			"Failed to release serial locks",
+			fmt.Sprintf("%d unassigned device(s) remain locked to this workspace in the serial lock backend.\n\n%v", len(deviceIDs), err),
+		)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSerialLockConflictDetail(t *testing.T) {
+	detail := SerialLockConflictDetail(map[string]string{"SN002": "workspace-b", "SN001": "workspace-a"})
+
+	if !strings.Contains(detail, "2 device(s)") {
+		t.Errorf("expected the device count in %q", detail)
+	}
+	if !strings.Contains(detail, "SN001 (workspace-a), SN002 (workspace-b)") {
+		t.Errorf("expected the sorted owners in %q", detail)
+	}
+}
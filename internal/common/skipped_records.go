@@ -0,0 +1,51 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// maxSkippedRecordDetails bounds how many skipped records are itemized in a warning.
+const maxSkippedRecordDetails = 10
+
+// CollectSkippedRecords returns a context that collects the records a collection read skips
+// because they could not be decoded, and a function that reports them as a warning in diags
+// once the read has finished.
+func CollectSkippedRecords(ctx context.Context) (context.Context, func(diags *diag.Diagnostics)) {
+	skipped := &client.SkippedRecords{}
+	report := func(diags *diag.Diagnostics) {
+		addSkippedRecordsWarning(skipped.Records(), diags)
+	}
+	return client.WithSkippedRecords(ctx, skipped), report
+}
+
+// addSkippedRecordsWarning adds a warning itemizing records to diags. It adds nothing when
+// records is empty.
+func addSkippedRecordsWarning(records []client.SkippedRecord, diags *diag.Diagnostics) {
+	if len(records) == 0 {
+		return
+	}
+
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "%d record(s) returned by the API could not be decoded and were omitted:", len(records))
+	for i, record := range records {
+		if i == maxSkippedRecordDetails {
+			fmt.Fprintf(&detail, "\n- ... and %d more", len(records)-i)
+			break
+		}
+		id := record.ID
+		if id == "" {
+			id = "(unknown ID)"
+		}
+		fmt.Fprintf(&detail, "\n- %s %s: %v", record.Endpoint, id, record.Err)
+	}
+	diags.AddWarning("Skipped Undecodable Records", detail.String())
+}
@@ -0,0 +1,47 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestCollectSkippedRecords_None(t *testing.T) {
+	_, report := CollectSkippedRecords(context.Background())
+	var diags diag.Diagnostics
+	report(&diags)
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestAddSkippedRecordsWarning(t *testing.T) {
+	records := []client.SkippedRecord{
+		{Endpoint: "/v1/orgDevices", ID: "DEV002", Err: errors.New("bad imei")},
+		{Endpoint: "/v1/orgDevices", Err: errors.New("bad record")},
+	}
+	for i := range maxSkippedRecordDetails {
+		records = append(records, client.SkippedRecord{Endpoint: "/v1/orgDevices", ID: fmt.Sprintf("DEV1%02d", i), Err: errors.New("bad")})
+	}
+
+	var diags diag.Diagnostics
+	addSkippedRecordsWarning(records, &diags)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected one warning, got %v", diags)
+	}
+	detail := diags.Warnings()[0].Detail()
+	for _, want := range []string{"12 record(s)", "/v1/orgDevices DEV002: bad imei", "(unknown ID): bad record", "... and 2 more"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected warning detail to contain %q, got:\n%s", want, detail)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timestampLayouts lists the timestamp formats accepted from the API, most common first.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a timestamp in any accepted layout. Timestamps without a zone are
+// treated as UTC.
+func ParseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp format %q", value)
+}
+
+// NormalizeTimestamp parses an API timestamp in any accepted layout and returns it
+// formatted as RFC 3339 in UTC. Timestamps without a zone are treated as UTC.
+func NormalizeTimestamp(value string) (string, error) {
+	t, err := ParseTimestamp(value)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// TimestampValue converts an optional API timestamp to a normalized RFC 3339 types.String.
+// Empty values map to null. Values that cannot be parsed are passed through unchanged and
+// reported as a warning against attribute.
+func TimestampValue(value, attribute string, diags *diag.Diagnostics) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	normalized, err := NormalizeTimestamp(value)
+	if err != nil {
+		diags.AddWarning(
+			"Unrecognized timestamp returned by the API",
+			fmt.Sprintf("The %s value could not be normalized to RFC 3339 and is stored as returned: %s", attribute, err),
+		)
+		return types.StringValue(value)
+	}
+	return types.StringValue(normalized)
+}
@@ -0,0 +1,60 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestNormalizeTimestamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "rfc3339_utc", input: "2025-03-01T10:15:30Z", want: "2025-03-01T10:15:30Z"},
+		{name: "fractional_seconds", input: "2025-03-01T10:15:30.123Z", want: "2025-03-01T10:15:30Z"},
+		{name: "offset", input: "2025-03-01T12:15:30+02:00", want: "2025-03-01T10:15:30Z"},
+		{name: "compact_offset", input: "2025-03-01T10:15:30.5+0000", want: "2025-03-01T10:15:30Z"},
+		{name: "no_zone", input: "2025-03-01T10:15:30", want: "2025-03-01T10:15:30Z"},
+		{name: "date_only", input: "2025-03-01", want: "2025-03-01T00:00:00Z"},
+		{name: "invalid", input: "March 1st", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTimestamp(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTimestampValue(t *testing.T) {
+	var diags diag.Diagnostics
+
+	if v := TimestampValue("", "start_date_time", &diags); !v.IsNull() {
+		t.Errorf("expected null for empty value, got %q", v.ValueString())
+	}
+	if v := TimestampValue("2025-03-01T10:15:30.000Z", "start_date_time", &diags); v.ValueString() != "2025-03-01T10:15:30Z" {
+		t.Errorf("expected normalized value, got %q", v.ValueString())
+	}
+	if diags.WarningsCount() != 0 {
+		t.Fatalf("expected no warnings, got %d", diags.WarningsCount())
+	}
+
+	if v := TimestampValue("not-a-date", "start_date_time", &diags); v.ValueString() != "not-a-date" {
+		t.Errorf("expected raw value to pass through, got %q", v.ValueString())
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("expected 1 warning, got %d", diags.WarningsCount())
+	}
+}
@@ -30,6 +30,24 @@ func StringsToList[T ~string](ctx context.Context, values []T) types.List {
 	return list
 }
 
+// OptionalString converts an optional API string value to a types.String,
+// mapping the empty string, which the API uses for absent values, to null.
+func OptionalString[T ~string](v T) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(string(v))
+}
+
+// OptionalStrings converts an optional API string slice to a []types.String,
+// returning nil for an absent or empty slice so the attribute is null.
+func OptionalStrings[T ~string](values []T) []types.String {
+	if len(values) == 0 {
+		return nil
+	}
+	return StringsToTypesStrings(values)
+}
+
 // StringPointerOrNil returns a pointer to the string if it is non-empty,
 // otherwise nil. This is useful for optional API fields that should map
 // to null Terraform attributes.
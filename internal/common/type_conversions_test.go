@@ -67,3 +67,50 @@ func TestStringPointerOrNil(t *testing.T) {
 
 	_ = types.StringType
 }
+
+func TestOptionalString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantNull bool
+	}{
+		{name: "empty_string", input: "", wantNull: true},
+		{name: "non_empty_string", input: "00:11:22:33:44:55", wantNull: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := OptionalString(tt.input)
+			if result.IsNull() != tt.wantNull {
+				t.Fatalf("expected IsNull=%v, got %v", tt.wantNull, result.IsNull())
+			}
+			if !tt.wantNull && result.ValueString() != tt.input {
+				t.Errorf("expected %q, got %q", tt.input, result.ValueString())
+			}
+		})
+	}
+}
+
+func TestOptionalStrings(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		wantNil bool
+	}{
+		{name: "nil_slice", input: nil, wantNil: true},
+		{name: "empty_slice", input: []string{}, wantNil: true},
+		{name: "populated_slice", input: []string{"a", "b"}, wantNil: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := OptionalStrings(tt.input)
+			if (result == nil) != tt.wantNil {
+				t.Fatalf("expected nil=%v, got %v", tt.wantNil, result)
+			}
+			if !tt.wantNil && len(result) != len(tt.input) {
+				t.Errorf("expected length %d, got %d", len(tt.input), len(result))
+			}
+		})
+	}
+}
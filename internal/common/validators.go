@@ -0,0 +1,131 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/text/language"
+)
+
+var _ validator.String = durationValidator{}
+var _ validator.String = acceptLanguageValidator{}
+var _ validator.String = timestampValidator{}
+
+// durationValidator validates that a string parses as a non-negative Go duration.
+type durationValidator struct{}
+
+// Duration returns a validator which ensures a string attribute is a non-negative
+// duration such as "30s" or "2m".
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return `value must be a non-negative duration such as "30s" or "2m"`
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d < 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%s, got: %q", v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// DurationValue parses a validated duration attribute, returning fallback when the value is
+// null, unknown, or unparsable.
+func DurationValue(value types.String, fallback time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return fallback
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		return fallback
+	}
+	return d
+}
+
+// acceptLanguageValidator validates that a string is a valid Accept-Language header value.
+type acceptLanguageValidator struct{}
+
+// AcceptLanguage returns a validator which ensures a string attribute is an Accept-Language
+// header value such as "fr-FR" or "de-DE, en;q=0.8".
+func AcceptLanguage() validator.String {
+	return acceptLanguageValidator{}
+}
+
+func (v acceptLanguageValidator) Description(ctx context.Context) string {
+	return `value must be an Accept-Language header value such as "fr-FR" or "de-DE, en;q=0.8"`
+}
+
+func (v acceptLanguageValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v acceptLanguageValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(req.ConfigValue.ValueString())
+	if err != nil || len(tags) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Accept-Language",
+			fmt.Sprintf("%s, got: %q", v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// timestampValidator validates that a string is an RFC 3339 timestamp or a calendar date.
+type timestampValidator struct{}
+
+// Timestamp returns a validator which ensures a string attribute is an RFC 3339 timestamp
+// such as "2025-01-31T09:00:00Z" or a date such as "2025-01-31".
+func Timestamp() validator.String {
+	return timestampValidator{}
+}
+
+func (v timestampValidator) Description(ctx context.Context) string {
+	return `value must be an RFC 3339 timestamp such as "2025-01-31T09:00:00Z" or a date such as "2025-01-31"`
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Timestamp",
+		fmt.Sprintf("%s, got: %q", v.Description(ctx), value),
+	)
+}
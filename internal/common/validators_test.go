@@ -0,0 +1,102 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{name: "null", value: types.StringNull(), wantErr: false},
+		{name: "unknown", value: types.StringUnknown(), wantErr: false},
+		{name: "seconds", value: types.StringValue("30s"), wantErr: false},
+		{name: "zero", value: types.StringValue("0s"), wantErr: false},
+		{name: "negative", value: types.StringValue("-5s"), wantErr: true},
+		{name: "invalid", value: types.StringValue("soon"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("test"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			Duration().ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestAcceptLanguageValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{name: "null", value: types.StringNull(), wantErr: false},
+		{name: "tag", value: types.StringValue("fr-FR"), wantErr: false},
+		{name: "weighted_list", value: types.StringValue("de-DE, en;q=0.8"), wantErr: false},
+		{name: "empty", value: types.StringValue(""), wantErr: true},
+		{name: "invalid", value: types.StringValue("not a language!"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("test"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			AcceptLanguage().ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestTimestampValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{name: "null", value: types.StringNull(), wantErr: false},
+		{name: "rfc3339", value: types.StringValue("2025-01-31T09:00:00Z"), wantErr: false},
+		{name: "offset", value: types.StringValue("2025-01-31T09:00:00+01:00"), wantErr: false},
+		{name: "date", value: types.StringValue("2025-01-31"), wantErr: false},
+		{name: "invalid", value: types.StringValue("31/01/2025"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("test"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			Timestamp().ValidateString(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	if got := DurationValue(types.StringNull(), 5*time.Second); got != 5*time.Second {
+		t.Errorf("expected fallback for null, got %v", got)
+	}
+	if got := DurationValue(types.StringValue("2m"), 0); got != 2*time.Minute {
+		t.Errorf("expected 2m, got %v", got)
+	}
+	if got := DurationValue(types.StringValue("bad"), time.Second); got != time.Second {
+		t.Errorf("expected fallback for invalid value, got %v", got)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// csvEncoder writes records as CSV with a header row, flattened by its columns.
+type csvEncoder[T any] struct {
+	columns Columns[T]
+}
+
+func (csvEncoder[T]) Format() string { return FormatCSV }
+
+func (e csvEncoder[T]) Encode(records []T) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(e.columns.Header)
+	for _, record := range records {
+		_ = w.Write(e.columns.Row(record))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (e csvEncoder[T]) Decode(content []byte) ([]T, error) {
+	rows, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CSV: %w", err)
+	}
+	if len(rows) == 0 || !slices.Equal(rows[0], e.columns.Header) {
+		return nil, errors.New("CSV header does not match the expected columns")
+	}
+	records := make([]T, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		records = append(records, e.columns.Parse(row))
+	}
+	return records, nil
+}
+
+func (csvEncoder[T]) Detect(content []byte) bool {
+	return true
+}
@@ -0,0 +1,88 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package export encodes records written to local files by export features, such as device
+// inventory snapshots, in a format selected by the user. Formats sit behind the Encoder
+// interface so that new ones can be added here without changing the resources using them.
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Names of the supported formats.
+const (
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// Columns describes how records of type T are flattened into rows for tabular formats. JSON
+// based formats use the record's JSON encoding instead.
+type Columns[T any] struct {
+	// Header lists the column names in output order.
+	Header []string
+	// Row returns the values of a record in Header order.
+	Row func(record T) []string
+	// Parse builds a record from a row with one value per Header column.
+	Parse func(row []string) T
+}
+
+// Encoder writes records of type T in one file format and reads them back.
+type Encoder[T any] interface {
+	// Format returns the name the encoder is selected by.
+	Format() string
+	// Encode serializes records. Equal records always produce identical content.
+	Encode(records []T) ([]byte, error)
+	// Decode parses content written by Encode.
+	Decode(content []byte) ([]T, error)
+	// Detect reports whether content, with surrounding whitespace removed, looks like the
+	// encoder's output.
+	Detect(content []byte) bool
+}
+
+// encoders returns an encoder for every supported format. Decode tries them in this order,
+// so formats detected by their leading bytes come before CSV, which accepts anything.
+func encoders[T any](columns Columns[T]) []Encoder[T] {
+	return []Encoder[T]{
+		jsonEncoder[T]{},
+		ndjsonEncoder[T]{},
+		csvEncoder[T]{columns: columns},
+	}
+}
+
+// Formats returns the names of every supported format.
+func Formats() []string {
+	return []string{FormatJSON, FormatCSV, FormatNDJSON}
+}
+
+// New returns the encoder for format. An empty format selects JSON.
+func New[T any](format string, columns Columns[T]) (Encoder[T], error) {
+	if format == "" {
+		format = FormatJSON
+	}
+	i := slices.IndexFunc(encoders(columns), func(e Encoder[T]) bool { return e.Format() == format })
+	if i < 0 {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return encoders(columns)[i], nil
+}
+
+// Decode parses content written in any supported format, detecting the format from the
+// content itself so that files written before a format change can still be read. Empty
+// content decodes to no records.
+func Decode[T any](content []byte, columns Columns[T]) ([]T, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	for _, encoder := range encoders(columns) {
+		if encoder.Detect(trimmed) {
+			return encoder.Decode(content)
+		}
+	}
+	return nil, errors.New("unrecognized export format")
+}
@@ -0,0 +1,125 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package export
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testRecord struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+var testColumns = Columns[testRecord]{
+	Header: []string{"id", "tags"},
+	Row: func(r testRecord) []string {
+		return []string{r.ID, strings.Join(r.Tags, ";")}
+	},
+	Parse: func(row []string) testRecord {
+		r := testRecord{ID: row[0]}
+		if row[1] != "" {
+			r.Tags = strings.Split(row[1], ";")
+		}
+		return r
+	},
+}
+
+var testRecords = []testRecord{
+	{ID: "a", Tags: []string{"x", "y"}},
+	{ID: "b"},
+}
+
+func TestEncoders_RoundTrip(t *testing.T) {
+	for _, format := range Formats() {
+		t.Run(format, func(t *testing.T) {
+			encoder, err := New(format, testColumns)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if encoder.Format() != format {
+				t.Errorf("expected format %q, got %q", format, encoder.Format())
+			}
+
+			content, err := encoder.Encode(testRecords)
+			if err != nil {
+				t.Fatalf("unexpected encode error: %v", err)
+			}
+			decoded, err := encoder.Decode(content)
+			if err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, testRecords) {
+				t.Errorf("expected %+v, got %+v", testRecords, decoded)
+			}
+
+			detected, err := Decode(content, testColumns)
+			if err != nil {
+				t.Fatalf("unexpected detection error: %v", err)
+			}
+			if !reflect.DeepEqual(detected, testRecords) {
+				t.Errorf("expected detected format to decode %+v, got %+v", testRecords, detected)
+			}
+		})
+	}
+}
+
+func TestEncoders_Output(t *testing.T) {
+	tests := map[string]string{
+		FormatJSON:   "[\n  {\n    \"id\": \"a\",\n    \"tags\": [\n      \"x\",\n      \"y\"\n    ]\n  },\n  {\n    \"id\": \"b\"\n  }\n]\n",
+		FormatCSV:    "id,tags\na,x;y\nb,\n",
+		FormatNDJSON: "{\"id\":\"a\",\"tags\":[\"x\",\"y\"]}\n{\"id\":\"b\"}\n",
+	}
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			encoder, _ := New(format, testColumns)
+			content, err := encoder.Encode(testRecords)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(content) != want {
+				t.Errorf("expected %q, got %q", want, content)
+			}
+		})
+	}
+}
+
+func TestNew_DefaultsToJSON(t *testing.T) {
+	encoder, err := New("", testColumns)
+	if err != nil || encoder.Format() != FormatJSON {
+		t.Errorf("expected the JSON encoder, got %v, %v", encoder, err)
+	}
+	if _, err := New("xml", testColumns); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestEncodeJSON_NoRecords(t *testing.T) {
+	encoder, _ := New(FormatJSON, testColumns)
+	content, err := encoder.Encode(nil)
+	if err != nil || string(content) != "[]\n" {
+		t.Errorf("expected an empty array, got %q, %v", content, err)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"json":       "[{",
+		"ndjson":     "{\"id\":\"a\"}\n{",
+		"csv_header": "tags,id\nx,a\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode([]byte(content), testColumns); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	records, err := Decode([]byte("  \n"), testColumns)
+	if err != nil || records != nil {
+		t.Errorf("expected no records for empty content, got %v, %v", records, err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonEncoder writes records as an indented JSON array.
+type jsonEncoder[T any] struct{}
+
+func (jsonEncoder[T]) Format() string { return FormatJSON }
+
+func (jsonEncoder[T]) Encode(records []T) ([]byte, error) {
+	if records == nil {
+		records = []T{}
+	}
+	content, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return append(content, '\n'), nil
+}
+
+func (jsonEncoder[T]) Decode(content []byte) ([]T, error) {
+	var records []T
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	return records, nil
+}
+
+func (jsonEncoder[T]) Detect(content []byte) bool {
+	return len(content) > 0 && content[0] == '['
+}
+
+// ndjsonEncoder writes one compact JSON object per line, so that large exports can be
+// streamed and appended to by line-oriented tools.
+type ndjsonEncoder[T any] struct{}
+
+func (ndjsonEncoder[T]) Format() string { return FormatNDJSON }
+
+func (ndjsonEncoder[T]) Encode(records []T) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode NDJSON: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (ndjsonEncoder[T]) Decode(content []byte) ([]T, error) {
+	var records []T
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 10<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var record T
+		if err := json.Unmarshal(text, &record); err != nil {
+			return nil, fmt.Errorf("failed to decode NDJSON line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode NDJSON: %w", err)
+	}
+	return records, nil
+}
+
+func (ndjsonEncoder[T]) Detect(content []byte) bool {
+	return len(content) > 0 && content[0] == '{'
+}
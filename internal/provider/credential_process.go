@@ -0,0 +1,151 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// credentialProcessTimeout bounds how long the credential process may run.
+const credentialProcessTimeout = 60 * time.Second
+
+// credentialProcessVersion is the only supported version of the credential process output.
+const credentialProcessVersion = 1
+
+// credentialProcessOutput describes the JSON document a credential process writes to stdout.
+// Either the signing credentials or a pre-issued access token must be supplied.
+type credentialProcessOutput struct {
+	Version     int    `json:"version"`
+	TeamID      string `json:"team_id"`
+	ClientID    string `json:"client_id"`
+	KeyID       string `json:"key_id"`
+	PrivateKey  string `json:"private_key"`
+	Scope       string `json:"scope"`
+	AccessToken string `json:"access_token"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// expiry parses ExpiresAt, returning the zero time when it is unset.
+func (o credentialProcessOutput) expiry() (time.Time, error) {
+	if o.ExpiresAt == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, o.ExpiresAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expires_at must be an RFC 3339 timestamp, got: %q", o.ExpiresAt)
+	}
+	return t, nil
+}
+
+// runCredentialProcess runs command and parses the credentials it writes to stdout. The
+// command's stderr is included in the returned error when the command fails; stdout is never
+// included because it may contain secrets.
+func runCredentialProcess(ctx context.Context, command string) (credentialProcessOutput, error) {
+	args, err := splitCommandLine(command)
+	if err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("invalid credential_process: %w", err)
+	}
+	if len(args) == 0 {
+		return credentialProcessOutput{}, errors.New("invalid credential_process: command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	defer func() { clear(stdout.Bytes()) }()
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return credentialProcessOutput{}, fmt.Errorf("credential process %s did not finish within %s", args[0], credentialProcessTimeout)
+		}
+		detail := strings.TrimSpace(stderr.String())
+		if len(detail) > 500 {
+			detail = detail[:500] + "..."
+		}
+		if detail != "" {
+			return credentialProcessOutput{}, fmt.Errorf("credential process %s failed: %w: %s", args[0], err, detail)
+		}
+		return credentialProcessOutput{}, fmt.Errorf("credential process %s failed: %w", args[0], err)
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("credential process %s did not write a valid JSON document to stdout", args[0])
+	}
+	if output.Version != credentialProcessVersion {
+		return credentialProcessOutput{}, fmt.Errorf("credential process %s returned unsupported version %d, expected %d", args[0], output.Version, credentialProcessVersion)
+	}
+	if _, err := output.expiry(); err != nil {
+		return credentialProcessOutput{}, fmt.Errorf("credential process %s returned an invalid document: %w", args[0], err)
+	}
+	if output.AccessToken == "" && output.ClientID == "" && output.KeyID == "" && output.PrivateKey == "" {
+		return credentialProcessOutput{}, fmt.Errorf("credential process %s returned neither an access token nor signing credentials", args[0])
+	}
+	return output, nil
+}
+
+// splitCommandLine splits a command line into arguments. Arguments are separated by
+// whitespace and single or double quotes group text containing whitespace. Outside single
+// quotes a backslash escapes a following whitespace, quote or backslash character and is
+// otherwise kept literally, so Windows paths need no escaping.
+func splitCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && i+1 < len(runes) && strings.ContainsRune(" \t\n\r'\"\\", runes[i+1]):
+			i++
+			current.WriteRune(runes[i])
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("command has an unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
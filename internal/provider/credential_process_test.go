@@ -0,0 +1,135 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []string
+		wantErr string
+	}{
+		{name: "single", line: "broker", want: []string{"broker"}},
+		{name: "whitespace", line: "  broker  get\tcreds ", want: []string{"broker", "get", "creds"}},
+		{name: "double_quotes", line: `broker --name "prod key"`, want: []string{"broker", "--name", "prod key"}},
+		{name: "single_quotes", line: `broker '--filter=a "b"'`, want: []string{"broker", `--filter=a "b"`}},
+		{name: "escaped_space", line: `/opt/my\ tools/broker`, want: []string{"/opt/my tools/broker"}},
+		{name: "windows_path", line: `C:\Tools\broker.exe get`, want: []string{`C:\Tools\broker.exe`, "get"}},
+		{name: "empty_quoted_argument", line: `broker ""`, want: []string{"broker", ""}},
+		{name: "empty", line: "   ", want: nil},
+		{name: "unterminated_quote", line: `broker "prod`, wantErr: "unterminated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitCommandLine(tt.line)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// writeCredentialScript writes an executable shell script that runs body and returns its path.
+func writeCredentialScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential process scripts require a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "broker.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0700); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestRunCredentialProcess_SigningCredentials(t *testing.T) {
+	script := writeCredentialScript(t, `echo '{"version": 1, "client_id": "BUSINESSAPI.x", "key_id": "key-1", "private_key": "PEM", "scope": "school.api", "team_id": "'"$1"'"}'`)
+
+	output, err := runCredentialProcess(context.Background(), script+" team-from-arg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.ClientID != "BUSINESSAPI.x" || output.KeyID != "key-1" || output.PrivateKey != "PEM" || output.Scope != "school.api" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+	if output.TeamID != "team-from-arg" {
+		t.Errorf("expected arguments to be passed to the command, got team_id %q", output.TeamID)
+	}
+}
+
+func TestRunCredentialProcess_AccessToken(t *testing.T) {
+	script := writeCredentialScript(t, `echo '{"version": 1, "access_token": "tok", "expires_at": "2030-01-02T03:04:05Z"}'`)
+
+	output, err := runCredentialProcess(context.Background(), script)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.AccessToken != "tok" {
+		t.Errorf("expected access token %q, got %q", "tok", output.AccessToken)
+	}
+	expiry, err := output.expiry()
+	if err != nil {
+		t.Fatalf("unexpected expiry error: %v", err)
+	}
+	if !expiry.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected expiry %s", expiry)
+	}
+}
+
+func TestRunCredentialProcess_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "non_zero_exit", body: `echo "vault sealed" >&2; exit 3`, wantErr: "vault sealed"},
+		{name: "invalid_json", body: `echo not-json`, wantErr: "valid JSON"},
+		{name: "wrong_version", body: `echo '{"version": 2, "access_token": "tok"}'`, wantErr: "unsupported version 2"},
+		{name: "bad_expiry", body: `echo '{"version": 1, "access_token": "tok", "expires_at": "tomorrow"}'`, wantErr: "RFC 3339"},
+		{name: "no_credentials", body: `echo '{"version": 1, "scope": "business.api"}'`, wantErr: "neither an access token nor signing credentials"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := writeCredentialScript(t, tt.body)
+			_, err := runCredentialProcess(context.Background(), script)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunCredentialProcess_DoesNotLeakStdout(t *testing.T) {
+	script := writeCredentialScript(t, `echo '{"version": 1, "private_key": "SECRET-KEY-MATERIAL"'`)
+
+	_, err := runCredentialProcess(context.Background(), script)
+	if err == nil {
+		t.Fatal("expected an error for truncated JSON")
+	}
+	if strings.Contains(err.Error(), "SECRET-KEY-MATERIAL") {
+		t.Errorf("error must not include stdout, got: %v", err)
+	}
+}
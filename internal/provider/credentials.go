@@ -0,0 +1,399 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/aws"
+	"github.com/neilmartin83/terraform-provider-axm/internal/azure"
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// defaultCredentialsFile is the shared credentials file location relative to the user's home directory.
+const defaultCredentialsFile = ".axm/credentials"
+
+// credentialsFile describes the JSON layout of the shared credentials file.
+type credentialsFile struct {
+	Profiles map[string]credentialsProfile `json:"profiles"`
+}
+
+// credentialsProfile holds the authentication settings stored under a named profile.
+type credentialsProfile struct {
+	TeamID         string `json:"team_id"`
+	ClientID       string `json:"client_id"`
+	KeyID          string `json:"key_id"`
+	PrivateKeyPath string `json:"private_key_path"`
+	Scope          string `json:"scope"`
+}
+
+// defaultCredentialsFilePath returns the shared credentials file path in the user's home directory.
+func defaultCredentialsFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, defaultCredentialsFile), nil
+}
+
+// expandHome replaces a leading ~ in path with the user's home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// loadCredentialsProfile reads the shared credentials file at path and returns the named profile.
+func loadCredentialsProfile(path, name string) (credentialsProfile, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return credentialsProfile{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return credentialsProfile{}, fmt.Errorf("failed to read credentials file %s: %w", path, err)
+	}
+
+	var file credentialsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return credentialsProfile{}, fmt.Errorf("failed to parse credentials file %s: %w", path, err)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok {
+		return credentialsProfile{}, fmt.Errorf("profile %q not found in credentials file %s", name, path)
+	}
+	return profile, nil
+}
+
+// newProfileClient creates a client authenticated with the named profile of the shared
+// credentials file at path, or of the default credentials file when path is empty. The profile
+// must supply client_id, key_id and private_key_path.
+func newProfileClient(path, name string) (*client.Client, error) {
+	if path == "" {
+		defaultPath, err := defaultCredentialsFilePath()
+		if err != nil {
+			return nil, err
+		}
+		path = defaultPath
+	}
+
+	profile, err := loadCredentialsProfile(path, name)
+	if err != nil {
+		return nil, err
+	}
+	if profile.ClientID == "" || profile.KeyID == "" || profile.PrivateKeyPath == "" {
+		return nil, fmt.Errorf("profile %q must set client_id, key_id and private_key_path", name)
+	}
+
+	scope := profile.Scope
+	if scope == "" {
+		scope = "business.api"
+	}
+	baseURL, err := scopeBaseURL(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := readPrivateKeyFile(profile.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	teamID := profile.TeamID
+	if teamID == "" {
+		teamID = profile.ClientID
+	}
+	return client.NewClient(baseURL, teamID, profile.ClientID, profile.KeyID, scope, privateKey)
+}
+
+// scopeBaseURL returns the API base URL serving scope.
+func scopeBaseURL(scope string) (string, error) {
+	switch scope {
+	case "business.api":
+		return "https://api-business.apple.com", nil
+	case "school.api":
+		return "https://api-school.apple.com", nil
+	default:
+		return "", fmt.Errorf("scope must be either 'business.api' or 'school.api', got: %s", scope)
+	}
+}
+
+// defaultScope is the scope used when none is configured and strict_scope is not set.
+const defaultScope = "business.api"
+
+// applyDefaultScope returns the scope to use when none was configured by any means. Unless
+// strict is set, it returns defaultScope with a warning, because school organizations left on
+// it see every request fail with 403 Forbidden; with strict set it returns an empty scope and
+// adds an error.
+func applyDefaultScope(strict bool, diags *diag.Diagnostics) string {
+	if strict {
+		diags.AddError(
+			"Missing Scope",
+			"scope must be provided in the provider configuration, via the AXM_SCOPE environment variable, through a credentials profile, or by a credential process, because strict_scope is set. "+
+				"Use 'business.api' for Apple Business Manager or 'school.api' for Apple School Manager.",
+		)
+		return ""
+	}
+	diags.AddWarning(
+		"Defaulting Scope to business.api",
+		"No scope was provided, so the provider uses 'business.api' for Apple Business Manager. "+
+			"Organizations in Apple School Manager must set scope = \"school.api\", or every API request fails with 403 Forbidden. "+
+			"Set scope explicitly to silence this warning, or set strict_scope = true to require it.",
+	)
+	return defaultScope
+}
+
+// readPrivateKeyFile returns the contents of the private key file at path.
+func readPrivateKeyFile(path string) (string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read private key file %s: %w", path, err)
+	}
+	defer clear(data)
+	return string(data), nil
+}
+
+// credentials holds what the provider authenticates with: the signing credentials, or an access
+// token issued by a credential process, which replaces them.
+type credentials struct {
+	teamID            string
+	clientID          string
+	keyID             string
+	scope             string
+	privateKey        string
+	signer            client.AssertionSigner
+	accessToken       string
+	accessTokenExpiry time.Time
+}
+
+// resolveCredentials gathers the provider's credentials. Each value is taken from the provider
+// arguments, then the environment, then a credential process and finally a credentials profile,
+// and the private key from the first configured key source. The private key is not required
+// when a credential process returns an access token. Failures are added to diags.
+func resolveCredentials(ctx context.Context, data AxmProviderModel, diags *diag.Diagnostics) credentials {
+	teamID := data.TeamID.ValueString()
+	if teamID == "" {
+		teamID = getenv(envTeamID)
+	}
+	clientID := data.ClientID.ValueString()
+	if clientID == "" {
+		clientID = getenv(envClientID)
+	}
+	keyID := data.KeyID.ValueString()
+	if keyID == "" {
+		keyID = getenv(envKeyID)
+	}
+	privateKey, signer := resolvePrivateKey(ctx, data, diags)
+	if diags.HasError() {
+		return credentials{}
+	}
+	scope := data.Scope.ValueString()
+	if scope == "" {
+		scope = getenv(envScope)
+	}
+
+	credentialProcess := data.CredentialProcess.ValueString()
+	if credentialProcess == "" {
+		credentialProcess = getenv(envCredentialProcess)
+	}
+	var accessToken string
+	var accessTokenExpiry time.Time
+	if credentialProcess != "" {
+		output, err := runCredentialProcess(ctx, credentialProcess)
+		if err != nil {
+			diags.AddError("Credential Process Failed", err.Error())
+			return credentials{}
+		}
+		if teamID == "" {
+			teamID = output.TeamID
+		}
+		if clientID == "" {
+			clientID = output.ClientID
+		}
+		if keyID == "" {
+			keyID = output.KeyID
+		}
+		if privateKey == "" && signer == nil {
+			privateKey = output.PrivateKey
+		}
+		if scope == "" {
+			scope = output.Scope
+		}
+		accessToken = output.AccessToken
+		accessTokenExpiry, _ = output.expiry()
+	}
+
+	profileName := data.Profile.ValueString()
+	if profileName == "" {
+		profileName = getenv(envProfile)
+	}
+	if profileName != "" {
+		credentialsPath := data.CredentialsFile.ValueString()
+		if credentialsPath == "" {
+			credentialsPath = getenv(envCredentialsFile)
+		}
+		if credentialsPath == "" {
+			path, err := defaultCredentialsFilePath()
+			if err != nil {
+				diags.AddError("Unable to Locate Credentials File", err.Error())
+				return credentials{}
+			}
+			credentialsPath = path
+		}
+
+		profile, err := loadCredentialsProfile(credentialsPath, profileName)
+		if err != nil {
+			diags.AddError("Unable to Load Credentials Profile", err.Error())
+			return credentials{}
+		}
+
+		if teamID == "" {
+			teamID = profile.TeamID
+		}
+		if clientID == "" {
+			clientID = profile.ClientID
+		}
+		if keyID == "" {
+			keyID = profile.KeyID
+		}
+		if scope == "" {
+			scope = profile.Scope
+		}
+		if privateKey == "" && signer == nil && profile.PrivateKeyPath != "" {
+			privateKey, err = readPrivateKeyFile(profile.PrivateKeyPath)
+			if err != nil {
+				diags.AddError("Unable to Read Private Key", err.Error())
+				return credentials{}
+			}
+		}
+	}
+
+	if scope == "" {
+		strictScope, _ := resolveBool(data.StrictScope, envStrictScope, "Invalid Strict Scope", diags)
+		if diags.HasError() {
+			return credentials{}
+		}
+		scope = applyDefaultScope(strictScope, diags)
+	}
+
+	if accessToken == "" {
+		if clientID == "" {
+			diags.AddError(
+				"Missing Client ID",
+				"client_id must be provided in the provider configuration, via the AXM_CLIENT_ID environment variable, through a credentials profile, or by a credential process.",
+			)
+		}
+		if keyID == "" {
+			diags.AddError(
+				"Missing Key ID",
+				"key_id must be provided in the provider configuration, via the AXM_KEY_ID environment variable, through a credentials profile, or by a credential process.",
+			)
+		}
+		if privateKey == "" && signer == nil {
+			diags.AddError(
+				"Missing Private Key",
+				"private_key, private_key_path, private_key_secret_arn or private_key_keyvault_id must be provided in the provider configuration, via the AXM_PRIVATE_KEY, AXM_PRIVATE_KEY_FILE, AXM_PRIVATE_KEY_SECRET_ARN or AXM_PRIVATE_KEY_KEYVAULT_ID environment variables, through a credentials profile, or by a credential process.",
+			)
+		}
+	}
+
+	if teamID == "" {
+		teamID = clientID
+	}
+
+	return credentials{
+		teamID:            teamID,
+		clientID:          clientID,
+		keyID:             keyID,
+		scope:             scope,
+		privateKey:        privateKey,
+		signer:            signer,
+		accessToken:       accessToken,
+		accessTokenExpiry: accessTokenExpiry,
+	}
+}
+
+// resolvePrivateKey loads the private key from the first key source set in the provider
+// arguments, or else in the environment: the key itself, a key file, an AWS Secrets Manager
+// secret or an Azure Key Vault secret. An Azure Key Vault key is returned as a signer instead,
+// because its private key never leaves the vault. Both are empty when no source is set.
+func resolvePrivateKey(ctx context.Context, data AxmProviderModel, diags *diag.Diagnostics) (string, client.AssertionSigner) {
+	privateKey := data.PrivateKey.ValueString()
+	privateKeyPath := data.PrivateKeyPath.ValueString()
+	privateKeySecretARN := data.PrivateKeySecretARN.ValueString()
+	privateKeyKeyVault := data.PrivateKeyKeyVaultID.ValueString()
+	keySourceSet := func() bool {
+		return privateKey != "" || privateKeyPath != "" || privateKeySecretARN != "" || privateKeyKeyVault != ""
+	}
+	if !keySourceSet() {
+		privateKey = getenv(envPrivateKey)
+	}
+	if !keySourceSet() {
+		privateKeyPath = getenv(envPrivateKeyFile)
+	}
+	if !keySourceSet() {
+		privateKeySecretARN = getenv(envPrivateKeySecretARN)
+	}
+	if !keySourceSet() {
+		privateKeyKeyVault = getenv(envPrivateKeyKeyVaultID)
+	}
+	if privateKey == "" && privateKeyPath != "" {
+		key, err := readPrivateKeyFile(privateKeyPath)
+		if err != nil {
+			diags.AddError("Unable to Read Private Key", err.Error())
+			return "", nil
+		}
+		privateKey = key
+	}
+	if privateKey == "" && privateKeySecretARN != "" {
+		key, err := aws.FetchPrivateKey(ctx, aws.KeyReference{
+			ARN:     privateKeySecretARN,
+			Region:  data.AWSRegion.ValueString(),
+			RoleARN: data.AWSRoleARN.ValueString(),
+		})
+		if err != nil {
+			diags.AddError("Unable to Fetch Private Key from AWS", err.Error())
+			return "", nil
+		}
+		privateKey = key
+	}
+	var signer client.AssertionSigner
+	if privateKey == "" && privateKeyKeyVault != "" {
+		if azure.IsKeyID(privateKeyKeyVault) {
+			keyVaultSigner, err := azure.NewSigner(ctx, privateKeyKeyVault)
+			if err != nil {
+				diags.AddError("Unable to Use Signing Key from Azure Key Vault", err.Error())
+				return "", nil
+			}
+			signer = keyVaultSigner
+		} else {
+			key, err := azure.FetchPrivateKey(ctx, privateKeyKeyVault)
+			if err != nil {
+				diags.AddError("Unable to Fetch Private Key from Azure Key Vault", err.Error())
+				return "", nil
+			}
+			privateKey = key
+		}
+	}
+	return privateKey, signer
+}
@@ -0,0 +1,112 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// NewProtocol5Compatible returns a protocol version 6 server for the provider whose schemas can
+// be downgraded to protocol version 5, for Terraform CLI versions before 1.0. Protocol 5 cannot
+// describe nested attributes, so each one is reported as an attribute of the equivalent object,
+// list, set or map type. Values keep the same shape on the wire; on those CLI versions every
+// field of such an object must be set in configuration, to null when it is not needed.
+func NewProtocol5Compatible(version string) func() tfprotov6.ProviderServer {
+	server := providerserver.NewProtocol6(New(version)())
+	return func() tfprotov6.ProviderServer {
+		return protocol5Compatible{ProviderServer: server()}
+	}
+}
+
+// protocol5Compatible serves a provider with its nested attributes flattened into object typed
+// attributes.
+type protocol5Compatible struct {
+	tfprotov6.ProviderServer
+}
+
+// GetProviderSchema returns the provider schema with every nested attribute flattened.
+func (s protocol5Compatible) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	flattenSchema(resp.Provider)
+	flattenSchema(resp.ProviderMeta)
+	for _, schemas := range []map[string]*tfprotov6.Schema{resp.ResourceSchemas, resp.DataSourceSchemas, resp.ListResourceSchemas, resp.EphemeralResourceSchemas} {
+		for _, schema := range schemas {
+			flattenSchema(schema)
+		}
+	}
+	for _, schema := range resp.ActionSchemas {
+		if schema != nil {
+			flattenSchema(schema.Schema)
+		}
+	}
+	return resp, nil
+}
+
+// flattenSchema replaces the nested attributes of schema with object typed attributes.
+func flattenSchema(schema *tfprotov6.Schema) {
+	if schema != nil {
+		flattenBlock(schema.Block)
+	}
+}
+
+// flattenBlock replaces the nested attributes of block and its nested blocks with object typed
+// attributes.
+func flattenBlock(block *tfprotov6.SchemaBlock) {
+	if block == nil {
+		return
+	}
+	for i, attribute := range block.Attributes {
+		if attribute.NestedType == nil {
+			continue
+		}
+		flattened := *attribute
+		flattened.Type = nestedAttributeType(attribute.NestedType)
+		flattened.Sensitive = attribute.Sensitive || hasSensitiveAttribute(attribute.NestedType)
+		flattened.NestedType = nil
+		block.Attributes[i] = &flattened
+	}
+	for _, nested := range block.BlockTypes {
+		flattenBlock(nested.Block)
+	}
+}
+
+// nestedAttributeType returns the type of the values of a nested attribute.
+func nestedAttributeType(object *tfprotov6.SchemaObject) tftypes.Type {
+	attributeTypes := make(map[string]tftypes.Type, len(object.Attributes))
+	for _, attribute := range object.Attributes {
+		if attribute.NestedType != nil {
+			attributeTypes[attribute.Name] = nestedAttributeType(attribute.NestedType)
+		} else {
+			attributeTypes[attribute.Name] = attribute.Type
+		}
+	}
+	elementType := tftypes.Object{AttributeTypes: attributeTypes}
+	switch object.Nesting {
+	case tfprotov6.SchemaObjectNestingModeList:
+		return tftypes.List{ElementType: elementType}
+	case tfprotov6.SchemaObjectNestingModeSet:
+		return tftypes.Set{ElementType: elementType}
+	case tfprotov6.SchemaObjectNestingModeMap:
+		return tftypes.Map{ElementType: elementType}
+	default:
+		return elementType
+	}
+}
+
+// hasSensitiveAttribute reports whether any attribute of object, at any depth, is sensitive.
+func hasSensitiveAttribute(object *tfprotov6.SchemaObject) bool {
+	for _, attribute := range object.Attributes {
+		if attribute.Sensitive || (attribute.NestedType != nil && hasSensitiveAttribute(attribute.NestedType)) {
+			return true
+		}
+	}
+	return false
+}
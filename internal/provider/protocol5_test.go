@@ -0,0 +1,64 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+)
+
+func nestedAttributes(block *tfprotov6.SchemaBlock) []string {
+	var names []string
+	for _, attribute := range block.Attributes {
+		if attribute.NestedType != nil {
+			names = append(names, attribute.Name)
+		}
+	}
+	for _, nested := range block.BlockTypes {
+		names = append(names, nestedAttributes(nested.Block)...)
+	}
+	return names
+}
+
+func TestNewProtocol5Compatible(t *testing.T) {
+	server := provider.NewProtocol5Compatible("test")()
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	for _, schemas := range []map[string]*tfprotov6.Schema{resp.ResourceSchemas, resp.DataSourceSchemas, resp.ListResourceSchemas, resp.EphemeralResourceSchemas} {
+		for name, schema := range schemas {
+			if nested := nestedAttributes(schema.Block); len(nested) > 0 {
+				t.Errorf("%s still has nested attributes: %v", name, nested)
+			}
+		}
+	}
+	for name, schema := range resp.ActionSchemas {
+		if nested := nestedAttributes(schema.Schema.Block); len(nested) > 0 {
+			t.Errorf("%s still has nested attributes: %v", name, nested)
+		}
+	}
+
+	var filter *tfprotov6.SchemaAttribute
+	for _, attribute := range resp.ResourceSchemas["axm_device_management_service"].Block.Attributes {
+		if attribute.Name == "device_filter" {
+			filter = attribute
+		}
+	}
+	want := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"product_family": tftypes.String}}
+	if filter == nil || !filter.Type.Equal(want) || !filter.Optional {
+		t.Errorf("expected device_filter to be an optional %s attribute, got %+v", want, filter)
+	}
+}
@@ -6,37 +6,69 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/access_token"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/app"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/apple_device_management_device"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/apple_device_management_devices"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/apps"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/assignment_compliance"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/audit_events"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/blueprint"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/blueprints"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/chunk_serials"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/client_stats"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/configuration"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/configurations"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/default_device_assignment"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_assignment"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_assignment_eligibility"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_management_service"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_management_service_serialnumbers"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_management_services"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/fleet_policy"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/inventory_snapshot"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_activities"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_activity"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_activity_log"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_applecare_coverage"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_assigned_server_information"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_devices"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_devices_by_serial"
 	packageinfo "github.com/neilmartin83/terraform-provider-axm/internal/resources/package"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/packages"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/parse_device_serial"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/provider_info"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/release_devices"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/render_inventory_markdown"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/retry_failed_device_activity"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/service_status"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/stale_organization_devices"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/user"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/user_group"
 	"github.com/neilmartin83/terraform-provider-axm/internal/resources/user_groups"
@@ -45,16 +77,48 @@ import (
 
 // Constants for environment variable names.
 const (
-	envTeamID     = "AXM_TEAM_ID"
-	envClientID   = "AXM_CLIENT_ID"
-	envKeyID      = "AXM_KEY_ID"
-	envPrivateKey = "AXM_PRIVATE_KEY"
-	envScope      = "AXM_SCOPE"
+	envTeamID               = "AXM_TEAM_ID"
+	envClientID             = "AXM_CLIENT_ID"
+	envKeyID                = "AXM_KEY_ID"
+	envPrivateKey           = "AXM_PRIVATE_KEY"
+	envPrivateKeyFile       = "AXM_PRIVATE_KEY_FILE"
+	envScope                = "AXM_SCOPE"
+	envStrictScope          = "AXM_STRICT_SCOPE"
+	envProfile              = "AXM_PROFILE"
+	envCredentialsFile      = "AXM_CREDENTIALS_FILE"
+	envAuditLogPath         = "AXM_AUDIT_LOG_PATH"
+	envMaxConcurrency       = "AXM_MAX_CONCURRENCY"
+	envCredentialProcess    = "AXM_CREDENTIAL_PROCESS"
+	envPrivateKeySecretARN  = "AXM_PRIVATE_KEY_SECRET_ARN"
+	envPrivateKeyKeyVaultID = "AXM_PRIVATE_KEY_KEYVAULT_ID"
+	envRetryableErrorCodes  = "AXM_RETRYABLE_ERROR_CODES"
+	envAcceptLanguage       = "AXM_ACCEPT_LANGUAGE"
+	envMaxAPITime           = "AXM_MAX_API_TIME_PER_OPERATION"
+	envMaxRequestsInFlight  = "AXM_MAX_REQUESTS_IN_FLIGHT"
+	envRequestsPerMinute    = "AXM_REQUESTS_PER_MINUTE"
+	envDeviceWarning        = "AXM_DEVICE_WARNING_THRESHOLD"
+	envActivityChunkSize    = "AXM_ACTIVITY_CHUNK_SIZE"
+	envSerialLockPath       = "AXM_SERIAL_LOCK_PATH"
+	envSerialLockURL        = "AXM_SERIAL_LOCK_URL"
+	envSerialLockToken      = "AXM_SERIAL_LOCK_TOKEN"
+	envSerialLockOwner      = "AXM_SERIAL_LOCK_OWNER"
+	envReadOnly             = "AXM_READ_ONLY"
+	envMaxRetries           = "AXM_MAX_RETRIES"
+	envMaxRetryWait         = "AXM_MAX_RETRY_WAIT"
+	envRetryOn5xx           = "AXM_RETRY_ON_5XX"
+	envPageConcurrency      = "AXM_PAGE_CONCURRENCY"
+	envOfflineFallback      = "AXM_OFFLINE_FALLBACK"
+	envRedactSerials        = "AXM_REDACT_SERIALS"
+	envCacheTTL             = "AXM_CACHE_TTL"
+	envCacheOnDisk          = "AXM_CACHE_ON_DISK"
 )
 
 // Ensure AxmProvider satisfies the provider.Provider interfaces.
 var _ provider.Provider = &AxmProvider{}
 var _ provider.ProviderWithListResources = &AxmProvider{}
+var _ provider.ProviderWithActions = &AxmProvider{}
+var _ provider.ProviderWithFunctions = &AxmProvider{}
+var _ provider.ProviderWithEphemeralResources = &AxmProvider{}
 
 // AxmProvider defines the provider implementation.
 type AxmProvider struct {
@@ -64,11 +128,52 @@ type AxmProvider struct {
 
 // AxmProviderModel describes the provider data model for configuration.
 type AxmProviderModel struct {
-	TeamID     types.String `tfsdk:"team_id"`
-	ClientID   types.String `tfsdk:"client_id"`
-	KeyID      types.String `tfsdk:"key_id"`
-	PrivateKey types.String `tfsdk:"private_key"`
-	Scope      types.String `tfsdk:"scope"`
+	TeamID                 types.String   `tfsdk:"team_id"`
+	ClientID               types.String   `tfsdk:"client_id"`
+	KeyID                  types.String   `tfsdk:"key_id"`
+	PrivateKey             types.String   `tfsdk:"private_key"`
+	PrivateKeyPath         types.String   `tfsdk:"private_key_path"`
+	Scope                  types.String   `tfsdk:"scope"`
+	StrictScope            types.Bool     `tfsdk:"strict_scope"`
+	Profile                types.String   `tfsdk:"profile"`
+	CredentialsFile        types.String   `tfsdk:"credentials_file"`
+	AuditLogPath           types.String   `tfsdk:"audit_log_path"`
+	StrictKeyHygiene       types.Bool     `tfsdk:"strict_key_hygiene"`
+	MaxConcurrency         types.Int64    `tfsdk:"max_concurrency"`
+	CredentialProcess      types.String   `tfsdk:"credential_process"`
+	PrivateKeySecretARN    types.String   `tfsdk:"private_key_secret_arn"`
+	AWSRegion              types.String   `tfsdk:"aws_region"`
+	AWSRoleARN             types.String   `tfsdk:"aws_role_arn"`
+	PrivateKeyKeyVaultID   types.String   `tfsdk:"private_key_keyvault_id"`
+	RetryableErrorCodes    types.List     `tfsdk:"retryable_error_codes"`
+	AcceptLanguage         types.String   `tfsdk:"accept_language"`
+	SkipUndecodableRecords types.Bool     `tfsdk:"skip_undecodable_records"`
+	MaxAPITimePerOperation types.String   `tfsdk:"max_api_time_per_operation"`
+	MaxRequestsInFlight    types.Int64    `tfsdk:"max_requests_in_flight"`
+	RequestsPerMinute      types.Int64    `tfsdk:"requests_per_minute"`
+	DeviceWarningThreshold types.Int64    `tfsdk:"device_warning_threshold"`
+	ActivityChunkSize      types.Int64    `tfsdk:"activity_chunk_size"`
+	SerialLockPath         types.String   `tfsdk:"serial_lock_path"`
+	SerialLockURL          types.String   `tfsdk:"serial_lock_url"`
+	SerialLockToken        types.String   `tfsdk:"serial_lock_token"`
+	SerialLockOwner        types.String   `tfsdk:"serial_lock_owner"`
+	ReadOnly               types.Bool     `tfsdk:"read_only"`
+	MaxRetries             types.Int64    `tfsdk:"max_retries"`
+	MaxRetryWait           types.String   `tfsdk:"max_retry_wait"`
+	RetryOn5xx             types.Bool     `tfsdk:"retry_on_5xx"`
+	PageConcurrency        types.Int64    `tfsdk:"page_concurrency"`
+	OfflineFallback        types.Bool     `tfsdk:"offline_fallback"`
+	RedactSerials          types.Bool     `tfsdk:"redact_serials"`
+	CacheTTL               types.String   `tfsdk:"cache_ttl"`
+	CacheOnDisk            types.Bool     `tfsdk:"cache_on_disk"`
+	Features               *FeaturesModel `tfsdk:"features"`
+}
+
+// FeaturesModel describes the features block, which gates provider behavior changes.
+type FeaturesModel struct {
+	DeleteUnassignsDevices   types.Bool `tfsdk:"delete_unassigns_devices"`
+	StrictDecoding           types.Bool `tfsdk:"strict_decoding"`
+	AuthoritativeAssignments types.Bool `tfsdk:"authoritative_assignments"`
 }
 
 func (p *AxmProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -97,13 +202,260 @@ func (p *AxmProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Sensitive:   true,
 				Description: "Contents of the private key downloaded from Apple Business or School Manager. Can also be set via the AXM_PRIVATE_KEY environment variable.",
 			},
+			"private_key_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the private key file downloaded from Apple Business or School Manager. Conflicts with private_key. Can also be set via the AXM_PRIVATE_KEY_FILE environment variable, which is used only when AXM_PRIVATE_KEY is unset.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key")),
+				},
+			},
+			"private_key_secret_arn": schema.StringAttribute{
+				Optional: true,
+				Description: "ARN of an AWS Secrets Manager secret or SSM Parameter Store parameter holding the private key, fetched during provider configuration. " +
+					"The stored value may be the PEM key itself or a JSON object with a private_key field. AWS credentials are resolved by the AWS SDK default " +
+					"credential chain, including environment variables, shared config profiles and SSO, web identity, ECS and EC2 instance roles. Conflicts with private_key and private_key_path. " +
+					"Can also be set via the AXM_PRIVATE_KEY_SECRET_ARN environment variable, which is used only when AXM_PRIVATE_KEY and AXM_PRIVATE_KEY_FILE are unset.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key"), path.MatchRoot("private_key_path")),
+				},
+			},
+			"aws_region": schema.StringAttribute{
+				Optional:    true,
+				Description: "AWS region used to fetch private_key_secret_arn. Defaults to the region in the ARN, then the AWS SDK default region.",
+			},
+			"aws_role_arn": schema.StringAttribute{
+				Optional:    true,
+				Description: "ARN of an IAM role to assume before fetching private_key_secret_arn.",
+			},
+			"private_key_keyvault_id": schema.StringAttribute{
+				Optional: true,
+				Description: "Azure Key Vault identifier of the private key. A secret identifier such as https://example.vault.azure.net/secrets/axm is fetched during provider configuration; " +
+					"the stored value may be the PEM key itself or a JSON object with a private_key field. A key identifier such as https://example.vault.azure.net/keys/axm " +
+					"must name an EC P-256 key, which signs client assertions inside the vault so the key material never leaves it. Azure credentials are resolved by the " +
+					"Azure SDK DefaultAzureCredential chain, including environment variables, workload identity, managed identity and the Azure CLI. " +
+					"Conflicts with private_key, private_key_path and private_key_secret_arn. Can also be set via the AXM_PRIVATE_KEY_KEYVAULT_ID environment variable, " +
+					"which is used only when AXM_PRIVATE_KEY, AXM_PRIVATE_KEY_FILE and AXM_PRIVATE_KEY_SECRET_ARN are unset.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("private_key"), path.MatchRoot("private_key_path"), path.MatchRoot("private_key_secret_arn")),
+				},
+			},
 			"scope": schema.StringAttribute{
 				Optional:    true,
-				Description: "API scope to use. Valid values are 'business.api' or 'school.api'. Can also be set via the AXM_SCOPE environment variable.",
+				Description: "API scope to use. Valid values are 'business.api' or 'school.api'. Defaults to 'business.api' with a warning unless strict_scope is set. Can also be set via the AXM_SCOPE environment variable.",
 				Validators: []validator.String{
 					stringvalidator.OneOf("business.api", "school.api"),
 				},
 			},
+			"strict_scope": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, provider configuration fails unless scope is provided in the configuration, via the AXM_SCOPE environment variable, " +
+					"through a credentials profile, or by a credential process. When false, a missing scope defaults to 'business.api' with a warning, " +
+					"because Apple School Manager organizations left on it see every request fail with 403 Forbidden. Defaults to false. " +
+					"Can also be set via the AXM_STRICT_SCOPE environment variable.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of a profile in the shared credentials file supplying team_id, client_id, key_id, private_key_path and scope. Values set explicitly or via environment variables take precedence over the profile. Can also be set via the AXM_PROFILE environment variable.",
+			},
+			"credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the shared JSON credentials file containing named profiles, used by profile and by the credentials_profile attribute of data sources. Defaults to ~/.axm/credentials. Can also be set via the AXM_CREDENTIALS_FILE environment variable.",
+			},
+			"credential_process": schema.StringAttribute{
+				Optional: true,
+				Description: "Command run during provider configuration that writes credentials to stdout as JSON: " +
+					`{"version": 1, "client_id": "...", "key_id": "...", "private_key": "...", "team_id": "...", "scope": "..."}, ` +
+					`or {"version": 1, "access_token": "...", "expires_at": "<RFC 3339>"} to supply a pre-issued access token instead of signing credentials. ` +
+					"Values returned by the command fill in any settings not set explicitly or via environment variables, and take precedence over a credentials profile. " +
+					"Arguments are split on whitespace and may be quoted. Can also be set via the AXM_CREDENTIAL_PROCESS environment variable.",
+			},
+			"strict_key_hygiene": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, the private key is parsed once during provider configuration, verified with a sign/verify round-trip that confirms it is a P-256 key usable for ES256, and the PEM key material held by the client is then zeroed. Configuration fails if the self-test does not pass.",
+			},
+			"audit_log_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a local file to which one JSON Lines audit record is appended for every device assignment or unassignment activity the provider performs, and for every client assertion it signs. Records include the timestamp, CI and user identity environment variables, activity type and result; activity records add the server ID, device count and activity ID, and assertion records add the assertion's JTI, issuance time and expiry. Can also be set via the AXM_AUDIT_LOG_PATH environment variable.",
+			},
+			"serial_lock_path": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a JSON file, shared by every workspace that manages device assignments, that records which workspace owns which device serial numbers. " +
+					"Plans fail when a device is already owned by another workspace, devices are claimed before they are assigned and released when they are unassigned. " +
+					"Conflicts with serial_lock_url and requires serial_lock_owner. Can also be set via the AXM_SERIAL_LOCK_PATH environment variable.",
+			},
+			"serial_lock_url": schema.StringAttribute{
+				Optional: true,
+				Description: "Base URL of an HTTP service that records which workspace owns which device serial numbers, as an alternative to serial_lock_path. " +
+					`The provider posts {"owner": "...", "serials": [...]} to {url}/owners, {url}/claim and {url}/release; owners responds with {"owners": {"<serial>": "<owner>"}}, ` +
+					"and a claim of devices owned by another workspace responds 409 Conflict with the conflicting owners in the same form. " +
+					"Requires serial_lock_owner. Can also be set via the AXM_SERIAL_LOCK_URL environment variable.",
+			},
+			"serial_lock_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Bearer token sent to serial_lock_url. Can also be set via the AXM_SERIAL_LOCK_TOKEN environment variable.",
+			},
+			"serial_lock_owner": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name recorded as the owner of the devices this workspace claims in the serial lock backend, such as the workspace or repository name. Must differ between workspaces. Can also be set via the AXM_SERIAL_LOCK_OWNER environment variable.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, the provider reads from the API but refuses every request that would change data, such as device assignment activities, " +
+					"and every serial lock claim or release, failing the operation with an error naming the refused request. Plans, refreshes and drift detection " +
+					"work as usual, so production credentials can be used safely where only reads are intended. Defaults to false. " +
+					"Can also be set via the AXM_READ_ONLY environment variable.",
+			},
+			"offline_fallback": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, every full read of the organization device inventory is cached in the provider cache directory, and when the API cannot be reached, " +
+					"because connections or token requests fail or the API keeps responding with server errors through every retry, the device data sources " +
+					"axm_organization_device, axm_organization_devices, axm_organization_devices_by_serial, axm_stale_organization_devices and axm_fleet_policy " +
+					"read that cached inventory instead of failing, with a warning naming when it was cached. Lets scheduled plans produce drift reports during Apple outages. " +
+					"Defaults to false. Can also be set via the AXM_OFFLINE_FALLBACK environment variable.",
+			},
+			"redact_serials": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, device serial numbers are replaced with a short hash, such as serial-3fa2c1d09b, in every provider log line, " +
+					"including logged API requests and responses, and in the warnings and errors reported by the device resources, data sources and actions. " +
+					"The same serial number always produces the same hash, so log lines about one device can still be correlated. Full serial numbers are kept in state. " +
+					"Use this when logs are shipped to third-party aggregators. Defaults to false. Can also be set via the AXM_REDACT_SERIALS environment variable.",
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of per-device or per-server API requests issued in parallel when a read must enrich many records individually, such as assigned-server and AppleCare coverage lookups. After repeated rate-limit (429) responses within a minute the provider halves this, and the page size of collection reads, for up to three steps, and restores them one step at a time as requests succeed. Defaults to 4. Can also be set via the AXM_MAX_CONCURRENCY environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"activity_chunk_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of devices submitted in a single assignment or unassignment activity. Larger device sets are split into several activities, submitted and monitored one after another, so that no request exceeds Apple's payload limits; a failed activity does not stop the remaining ones. Defaults to 500. Can also be set via the AXM_ACTIVITY_CHUNK_SIZE environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"device_warning_threshold": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of devices above which axm_organization_devices warns that it is writing a large inventory into state, recommending filters or alternatives that keep state small. Set to 0 to disable the warning. Defaults to 5000. Can also be set via the AXM_DEVICE_WARNING_THRESHOLD environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_requests_in_flight": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests the provider sends at once across all resources and data sources, which Terraform reads concurrently. A rate-limit (429) response seen by any request also pauses the others until its Retry-After delay has elapsed, and identical reads in flight at the same time share one request. Defaults to 8. Can also be set via the AXM_MAX_REQUESTS_IN_FLIGHT environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_minute": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests the provider sends per minute, spaced evenly and shared by every resource and data source in the run, so that large applies stay under Apple's rate limits instead of relying on rate-limit (429) responses and their Retry-After delays. Retries count towards the limit. Unset means no limit. Can also be set via the AXM_REQUESTS_PER_MINUTE environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"page_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of partitions of a large device inventory, one per product family, paged through in parallel when reading organization devices. Pages of one partition are always read in sequence because cursors are opaque, and the inventory is read page by page whenever the partitions cannot be shown to cover it. Devices are then returned grouped by product family. Requests still count towards max_requests_in_flight and pause together on rate limits. Halved along with max_concurrency after repeated rate limits. Defaults to 1, which reads page by page. Can also be set via the AXM_PAGE_CONCURRENCY environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"accept_language": schema.StringAttribute{
+				Optional:    true,
+				Description: `Accept-Language header sent with every API request, such as "fr-FR" or "de-DE, en;q=0.8", so that error details Apple localizes are reported in diagnostics in the preferred language. Can also be set via the AXM_ACCEPT_LANGUAGE environment variable.`,
+				Validators: []validator.String{
+					common.AcceptLanguage(),
+				},
+			},
+			"cache_ttl": schema.StringAttribute{
+				Optional: true,
+				Description: `Enables caching the responses of reads of MDM servers and organization devices, expressed as a duration such as "5m", so that repeated reads of the same URL, ` +
+					"such as many data sources listing the same servers or devices, do not each transfer it again. Device assignment relationships are never cached, " +
+					"so refreshes and verify_after_apply see assignment changes as soon as Apple reports them. Responses carrying an ETag or Last-Modified header are revalidated " +
+					"with a conditional request on every read and reused when Apple reports them unchanged; other responses are reused without a request for this long. " +
+					"Any request that changes data, and any activity finishing, empties the cache. Cache hits and misses are reported by axm_client_stats. " +
+					"Unset means no caching. Can also be set via the AXM_CACHE_TTL environment variable.",
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
+			"cache_on_disk": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, the responses cached because of cache_ttl are also kept in the provider cache directory, so that later plans and applies " +
+					"can reuse or revalidate them. The files hold device and server data and are readable only by the current user. Ignored when cache_ttl is unset. " +
+					"Defaults to false. Can also be set via the AXM_CACHE_ON_DISK environment variable.",
+			},
+			"skip_undecodable_records": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, records in a paginated response that cannot be decoded, such as a device whose attributes have an unexpected type, are skipped with a warning naming each record instead of failing the whole read. A page whose response envelope cannot be decoded still fails. Defaults to false.",
+			},
+			"max_api_time_per_operation": schema.StringAttribute{
+				Optional: true,
+				Description: `Budget for the cumulative time the provider spends in API requests during a single plan or apply, including Retry-After and backoff waits, expressed as a duration such as "30m". ` +
+					"Once it is used up, remaining reads fail immediately with a diagnostic naming this setting instead of letting a rate-limited run continue unattended; " +
+					"requests that change data are still sent. Concurrent requests each count their full duration. Unset means no limit. " +
+					"Can also be set via the AXM_MAX_API_TIME_PER_OPERATION environment variable.",
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
+			"retryable_error_codes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "API error codes, such as UNEXPECTED_ERROR, whose responses are retried with exponential backoff in addition to rate-limit and transient server error responses. A code also matches its dot-separated sub-codes. Useful when Apple introduces a new transient error before the provider recognizes it. Can also be set via the AXM_RETRYABLE_ERROR_CODES environment variable as a comma-separated list.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of times a rate-limited (429) or transient server error response is retried, with exponential backoff and jitter, after the first attempt. Must be at least 1. Resources can override it in their retry block. Defaults to 5. Can also be set via the AXM_MAX_RETRIES environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retry_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: `Longest Retry-After delay honoured on a rate-limited (429) response before the request fails, expressed as a duration such as "5m". Resources can override it in their retry block. Defaults to "60s". Can also be set via the AXM_MAX_RETRY_WAIT environment variable.`,
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
+			"retry_on_5xx": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, 500 and every other 5xx response are retried with exponential backoff and jitter, not only 502, 503 and 504. " +
+					"A request that changes data, such as a device assignment activity, may then be sent again after the API failed partway through it. " +
+					"Defaults to false. Can also be set via the AXM_RETRY_ON_5XX environment variable.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"features": schema.SingleNestedBlock{
+				Description: "Opts in to or out of provider behavior changes, so that improvements which change what an existing configuration does can ship " +
+					"without surprising current users. Unset features keep the provider's established behavior.",
+				Attributes: map[string]schema.Attribute{
+					"delete_unassigns_devices": schema.BoolAttribute{
+						Optional: true,
+						Description: "When true, destroying an axm_device_management_service first unassigns every device assigned to it and waits for the unassignment to finish. " +
+							"When false, the server is deleted without submitting an unassignment activity, its devices are left for Apple Business Manager to handle, " +
+							"and max_unassign_without_confirmation does not guard the destroy. Defaults to true.",
+					},
+					"strict_decoding": schema.BoolAttribute{
+						Optional: true,
+						Description: "When true, an API response containing a field the provider does not recognize fails the request instead of the field being ignored, " +
+							"so that changes to the API are noticed before they cause subtle drift. Combine with skip_undecodable_records to skip such records in paginated reads. " +
+							"Defaults to false.",
+					},
+					"authoritative_assignments": schema.BoolAttribute{
+						Optional: true,
+						Description: "When true, the device_ids of an axm_device_management_service is authoritative: devices assigned to the server outside Terraform appear " +
+							"in device_ids on refresh and the next apply unassigns them. When false, such devices are left out of device_ids and left assigned, " +
+							"and are only reported in externally_added_devices. Defaults to true.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -118,90 +470,237 @@ func (p *AxmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	teamID := data.TeamID.ValueString()
-	if teamID == "" {
-		teamID = getenv(envTeamID)
+	if !req.Config.Raw.IsFullyKnown() {
+		if req.ClientCapabilities.DeferralAllowed {
+			tflog.Info(ctx, "Deferring provider configuration until its unknown values are known")
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		for _, name := range unknownConfigAttributes(req.Config) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unknown Provider Configuration Value",
+				fmt.Sprintf("The provider cannot be configured because %s depends on a value that is not known until apply. "+
+					"Apply the resources it depends on first, for example with -target, or use a Terraform version that supports deferred changes.", name),
+			)
+		}
+		return
+	}
+
+	creds := resolveCredentials(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	baseURL, err := scopeBaseURL(creds.scope)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Scope", err.Error())
+		return
 	}
-	clientID := data.ClientID.ValueString()
-	if clientID == "" {
-		clientID = getenv(envClientID)
+
+	var clientObj *client.Client
+	switch {
+	case creds.accessToken != "":
+		clientObj, err = client.NewClientWithAccessToken(baseURL, creds.scope, creds.accessToken, creds.accessTokenExpiry)
+	case creds.signer != nil:
+		clientObj, err = client.NewClientWithSigner(baseURL, creds.teamID, creds.clientID, creds.keyID, creds.scope, creds.signer)
+	default:
+		clientObj, err = client.NewClient(
+			baseURL,
+			creds.teamID,
+			creds.clientID,
+			creds.keyID,
+			creds.scope,
+			creds.privateKey,
+		)
 	}
-	keyID := data.KeyID.ValueString()
-	if keyID == "" {
-		keyID = getenv(envKeyID)
+	if err != nil {
+		resp.Diagnostics.AddError("AXM Client Init Failed", err.Error())
+		return
+	}
+
+	clientObj.SetLogger(NewTerraformLogger())
+	clientObj.SetProviderVersion(p.version)
+
+	credentialsPath := data.CredentialsFile.ValueString()
+	if credentialsPath == "" {
+		credentialsPath = getenv(envCredentialsFile)
 	}
-	privateKey := data.PrivateKey.ValueString()
-	if privateKey == "" {
-		privateKey = getenv(envPrivateKey)
+	clientObj.SetProfileClientFactory(func(profile string) (*client.Client, error) {
+		return newProfileClient(credentialsPath, profile)
+	})
+
+	if data.StrictKeyHygiene.ValueBool() {
+		if creds.accessToken != "" {
+			resp.Diagnostics.AddError(
+				"Private Key Self-Test Failed",
+				"strict_key_hygiene requires a private key, but the credential process returned a pre-issued access token.",
+			)
+			return
+		}
+		if err := clientObj.EnableStrictKeyHygiene(); err != nil {
+			resp.Diagnostics.AddError("Private Key Self-Test Failed", err.Error())
+			return
+		}
 	}
-	scope := data.Scope.ValueString()
-	if scope == "" {
-		scope = getenv(envScope)
+
+	auditLogPath := data.AuditLogPath.ValueString()
+	if auditLogPath == "" {
+		auditLogPath = getenv(envAuditLogPath)
 	}
-	if scope == "" {
-		scope = "business.api"
+	if auditLogPath != "" {
+		path, err := expandHome(auditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Audit Log Path", err.Error())
+			return
+		}
+		clientObj.SetAuditLogPath(path)
 	}
 
-	if clientID == "" {
-		resp.Diagnostics.AddError(
-			"Missing Client ID",
-			"client_id must be provided either in the provider configuration or via the AXM_CLIENT_ID environment variable.",
-		)
+	serialLockPath := data.SerialLockPath.ValueString()
+	if serialLockPath == "" {
+		serialLockPath = getenv(envSerialLockPath)
 	}
-	if keyID == "" {
-		resp.Diagnostics.AddError(
-			"Missing Key ID",
-			"key_id must be provided either in the provider configuration or via the AXM_KEY_ID environment variable.",
-		)
+	serialLockURL := data.SerialLockURL.ValueString()
+	if serialLockURL == "" {
+		serialLockURL = getenv(envSerialLockURL)
 	}
-	if privateKey == "" {
-		resp.Diagnostics.AddError(
-			"Missing Private Key",
-			"private_key must be provided either in the provider configuration or via the AXM_PRIVATE_KEY environment variable.",
-		)
+	if serialLockPath != "" || serialLockURL != "" {
+		serialLockOwner := data.SerialLockOwner.ValueString()
+		if serialLockOwner == "" {
+			serialLockOwner = getenv(envSerialLockOwner)
+		}
+		serialLockToken := data.SerialLockToken.ValueString()
+		if serialLockToken == "" {
+			serialLockToken = getenv(envSerialLockToken)
+		}
+
+		switch {
+		case serialLockPath != "" && serialLockURL != "":
+			resp.Diagnostics.AddError(
+				"Conflicting Serial Lock Backends",
+				"Only one of serial_lock_path and serial_lock_url may be set.",
+			)
+			return
+		case serialLockOwner == "":
+			resp.Diagnostics.AddError(
+				"Missing Serial Lock Owner",
+				fmt.Sprintf("serial_lock_owner must be set, or %s exported, when a serial lock backend is configured.", envSerialLockOwner),
+			)
+			return
+		case serialLockPath != "":
+			path, err := expandHome(serialLockPath)
+			if err != nil {
+				resp.Diagnostics.AddError("Invalid Serial Lock Path", err.Error())
+				return
+			}
+			clientObj.SetSerialLocks(client.NewFileSerialLocks(path), serialLockOwner)
+		default:
+			if _, err := url.ParseRequestURI(serialLockURL); err != nil {
+				resp.Diagnostics.AddError("Invalid Serial Lock URL", err.Error())
+				return
+			}
+			clientObj.SetSerialLocks(client.NewHTTPSerialLocks(serialLockURL, serialLockToken), serialLockOwner)
+		}
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	if n, ok := resolveInt64(data.MaxConcurrency, envMaxConcurrency, "Invalid Max Concurrency", 1, &resp.Diagnostics); ok {
+		clientObj.SetMaxConcurrency(n)
 	}
 
-	var baseURL string
-	switch scope {
-	case "business.api":
-		baseURL = "https://api-business.apple.com"
-	case "school.api":
-		baseURL = "https://api-school.apple.com"
-	default:
-		resp.Diagnostics.AddError(
-			"Invalid Scope",
-			fmt.Sprintf("Scope must be either 'business.api' or 'school.api', got: %s", scope),
-		)
-		return
+	if n, ok := resolveInt64(data.MaxRequestsInFlight, envMaxRequestsInFlight, "Invalid Max Requests In Flight", 1, &resp.Diagnostics); ok {
+		clientObj.SetMaxRequestsInFlight(n)
 	}
 
-	if teamID == "" {
-		teamID = clientID
+	if n, ok := resolveInt64(data.RequestsPerMinute, envRequestsPerMinute, "Invalid Requests Per Minute", 1, &resp.Diagnostics); ok {
+		clientObj.SetRequestsPerMinute(n)
 	}
 
-	clientObj, err := client.NewClient(
-		baseURL,
-		teamID,
-		clientID,
-		keyID,
-		scope,
-		privateKey,
-	)
-	if err != nil {
-		resp.Diagnostics.AddError("AXM Client Init Failed", err.Error())
-		return
+	if n, ok := resolveInt64(data.PageConcurrency, envPageConcurrency, "Invalid Page Concurrency", 1, &resp.Diagnostics); ok {
+		clientObj.SetPageConcurrency(n)
 	}
 
-	clientObj.SetLogger(NewTerraformLogger())
+	if n, ok := resolveInt64(data.DeviceWarningThreshold, envDeviceWarning, "Invalid Device Warning Threshold", 0, &resp.Diagnostics); ok {
+		clientObj.SetDeviceWarningThreshold(n)
+	}
+
+	if n, ok := resolveInt64(data.ActivityChunkSize, envActivityChunkSize, "Invalid Activity Chunk Size", 1, &resp.Diagnostics); ok {
+		clientObj.SetActivityChunkSize(n)
+	}
+
+	var retryableErrorCodes []string
+	if !data.RetryableErrorCodes.IsNull() {
+		resp.Diagnostics.Append(data.RetryableErrorCodes.ElementsAs(ctx, &retryableErrorCodes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if value := getenv(envRetryableErrorCodes); value != "" {
+		for code := range strings.SplitSeq(value, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				retryableErrorCodes = append(retryableErrorCodes, code)
+			}
+		}
+	}
+	clientObj.SetRetryableErrorCodes(retryableErrorCodes)
+
+	if n, ok := resolveInt64(data.MaxRetries, envMaxRetries, "Invalid Max Retries", 1, &resp.Diagnostics); ok {
+		clientObj.SetMaxRetries(n)
+	}
+
+	if wait, ok := resolveDuration(data.MaxRetryWait, envMaxRetryWait, "Invalid Max Retry Wait", false, &resp.Diagnostics); ok {
+		clientObj.SetMaxRetryWait(wait)
+	}
+
+	if value, ok := resolveBool(data.RetryOn5xx, envRetryOn5xx, "Invalid Retry On 5xx", &resp.Diagnostics); ok {
+		clientObj.SetRetryServerErrors(value)
+	}
+
+	clientObj.SetAcceptLanguage(resolveAcceptLanguage(data.AcceptLanguage, &resp.Diagnostics))
+	clientObj.SetSkipUndecodableRecords(data.SkipUndecodableRecords.ValueBool())
+
+	if value, ok := resolveBool(data.ReadOnly, envReadOnly, "Invalid Read Only", &resp.Diagnostics); ok {
+		clientObj.SetReadOnly(value)
+	}
+
+	if value, ok := resolveBool(data.OfflineFallback, envOfflineFallback, "Invalid Offline Fallback", &resp.Diagnostics); ok {
+		clientObj.SetOfflineFallback(value)
+	}
+
+	if value, ok := resolveBool(data.RedactSerials, envRedactSerials, "Invalid Redact Serials", &resp.Diagnostics); ok {
+		clientObj.SetRedactSerials(value)
+	}
+
+	features := client.DefaultFeatures()
+	if data.Features != nil {
+		if !data.Features.DeleteUnassignsDevices.IsNull() {
+			features.DeleteUnassignsDevices = data.Features.DeleteUnassignsDevices.ValueBool()
+		}
+		if !data.Features.StrictDecoding.IsNull() {
+			features.StrictDecoding = data.Features.StrictDecoding.ValueBool()
+		}
+		if !data.Features.AuthoritativeAssignments.IsNull() {
+			features.AuthoritativeAssignments = data.Features.AuthoritativeAssignments.ValueBool()
+		}
+	}
+	clientObj.SetFeatures(features)
+
+	if budget, ok := resolveDuration(data.MaxAPITimePerOperation, envMaxAPITime, "Invalid Max API Time Per Operation", true, &resp.Diagnostics); ok {
+		clientObj.SetAPITimeBudget(budget)
+	}
+
+	cacheTTL, _ := resolveDuration(data.CacheTTL, envCacheTTL, "Invalid Cache TTL", true, &resp.Diagnostics)
+	cacheOnDisk, _ := resolveBool(data.CacheOnDisk, envCacheOnDisk, "Invalid Cache On Disk", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clientObj.SetResponseCache(cacheTTL, cacheOnDisk)
 
 	p.client = clientObj
 	resp.DataSourceData = clientObj
 	resp.ResourceData = clientObj
 	resp.ListResourceData = clientObj
+	resp.ActionData = clientObj
+	resp.EphemeralResourceData = clientObj
 }
 
 func (p *AxmProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -209,7 +708,9 @@ func (p *AxmProvider) Resources(ctx context.Context) []func() resource.Resource
 		blueprint.NewBlueprintResource,
 		configuration.NewConfigurationResource,
 		default_device_assignment.NewDefaultDeviceAssignmentResource,
+		device_assignment.NewDeviceAssignmentResource,
 		device_management_service.NewDeviceManagementServiceResource,
+		inventory_snapshot.NewInventorySnapshotResource,
 	}
 }
 
@@ -219,20 +720,31 @@ func (p *AxmProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		apple_device_management_devices.NewAppleDeviceManagementDevicesDataSource,
 		app.NewAppDataSource,
 		apps.NewAppsDataSource,
+		assignment_compliance.NewAssignmentComplianceDataSource,
 		audit_events.NewAuditEventsDataSource,
 		blueprint.NewBlueprintDataSource,
 		blueprints.NewBlueprintsDataSource,
+		client_stats.NewClientStatsDataSource,
 		configuration.NewConfigurationDataSource,
 		configurations.NewConfigurationsDataSource,
 		organization_device.NewOrganizationDeviceDataSource,
 		organization_devices.NewOrganizationDevicesDataSource,
+		organization_devices_by_serial.NewOrganizationDevicesBySerialDataSource,
+		device_assignment_eligibility.NewDeviceAssignmentEligibilityDataSource,
 		device_management_service.NewDeviceManagementServiceDataSource,
 		device_management_services.NewDeviceManagementServicesDataSource,
 		device_management_service_serialnumbers.NewDeviceManagementServiceSerialNumbersDataSource,
+		fleet_policy.NewFleetPolicyDataSource,
 		organization_device_assigned_server_information.NewOrganizationDeviceAssignedServerInformationDataSource,
 		organization_device_applecare_coverage.NewOrganizationDeviceAppleCareCoverageDataSource,
+		organization_device_activities.NewOrganizationDeviceActivitiesDataSource,
+		organization_device_activity.NewOrganizationDeviceActivityDataSource,
+		organization_device_activity_log.NewOrganizationDeviceActivityLogDataSource,
 		packageinfo.NewPackageDataSource,
 		packages.NewPackagesDataSource,
+		provider_info.NewProviderInfoDataSource,
+		service_status.NewServiceStatusDataSource,
+		stale_organization_devices.NewStaleOrganizationDevicesDataSource,
 		user.NewUserDataSource,
 		user_group.NewUserGroupDataSource,
 		user_groups.NewUserGroupsDataSource,
@@ -244,10 +756,32 @@ func (p *AxmProvider) ListResources(ctx context.Context) []func() list.ListResou
 	return []func() list.ListResource{
 		blueprint.NewBlueprintListResource,
 		configuration.NewConfigurationListResource,
+		device_assignment.NewDeviceAssignmentListResource,
 		device_management_service.NewDeviceManagementServiceListResource,
 	}
 }
 
+func (p *AxmProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		release_devices.NewReleaseDevicesAction,
+		retry_failed_device_activity.NewRetryFailedDeviceActivityAction,
+	}
+}
+
+func (p *AxmProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		access_token.NewAccessTokenEphemeralResource,
+	}
+}
+
+func (p *AxmProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		chunk_serials.NewChunkSerialsFunction,
+		parse_device_serial.NewParseDeviceSerialFunction,
+		render_inventory_markdown.NewRenderInventoryMarkdownFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &AxmProvider{
@@ -256,6 +790,23 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// unknownConfigAttributes returns the names of the provider arguments whose values are not yet
+// known, in alphabetical order.
+func unknownConfigAttributes(config tfsdk.Config) []string {
+	var attributes map[string]tftypes.Value
+	if err := config.Raw.As(&attributes); err != nil {
+		return nil
+	}
+	var names []string
+	for name, value := range attributes {
+		if !value.IsFullyKnown() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // getenv is a helper to get an environment variable, returns empty string if not set.
 func getenv(key string) string {
 	v, _ := os.LookupEnv(key)
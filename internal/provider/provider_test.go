@@ -9,12 +9,17 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
 	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
@@ -68,7 +73,40 @@ func TestProviderSchema(t *testing.T) {
 		{"client_id", false},
 		{"key_id", false},
 		{"private_key", true},
+		{"private_key_path", false},
 		{"scope", false},
+		{"strict_scope", false},
+		{"profile", false},
+		{"credentials_file", false},
+		{"audit_log_path", false},
+		{"strict_key_hygiene", false},
+		{"max_concurrency", false},
+		{"credential_process", false},
+		{"private_key_secret_arn", false},
+		{"aws_region", false},
+		{"aws_role_arn", false},
+		{"private_key_keyvault_id", false},
+		{"retryable_error_codes", false},
+		{"accept_language", false},
+		{"skip_undecodable_records", false},
+		{"max_api_time_per_operation", false},
+		{"max_requests_in_flight", false},
+		{"requests_per_minute", false},
+		{"device_warning_threshold", false},
+		{"activity_chunk_size", false},
+		{"serial_lock_path", false},
+		{"serial_lock_url", false},
+		{"serial_lock_token", true},
+		{"serial_lock_owner", false},
+		{"read_only", false},
+		{"max_retries", false},
+		{"max_retry_wait", false},
+		{"retry_on_5xx", false},
+		{"page_concurrency", false},
+		{"offline_fallback", false},
+		{"redact_serials", false},
+		{"cache_ttl", false},
+		{"cache_on_disk", false},
 	}
 
 	for _, tt := range tests {
@@ -93,6 +131,64 @@ func TestProviderSchema(t *testing.T) {
 	}
 }
 
+// unknownClientIDConfig returns a provider configuration whose client_id is unknown and whose
+// other arguments are null.
+func unknownClientIDConfig(t *testing.T, p tfprovider.Provider) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := tfprovider.SchemaResponse{}
+	p.Schema(ctx, tfprovider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["client_id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
+
+func TestProviderConfigure_DefersUnknownConfig(t *testing.T) {
+	p := provider.New("test")()
+	req := tfprovider.ConfigureRequest{
+		Config:             unknownClientIDConfig(t, p),
+		ClientCapabilities: tfprovider.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+	}
+	resp := tfprovider.ConfigureResponse{}
+	p.Configure(context.Background(), req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if resp.Deferred == nil || resp.Deferred.Reason != tfprovider.DeferredReasonProviderConfigUnknown {
+		t.Errorf("expected configuration to be deferred for unknown provider config, got %+v", resp.Deferred)
+	}
+	if resp.ResourceData != nil || resp.DataSourceData != nil {
+		t.Error("expected no client to be configured while deferred")
+	}
+}
+
+func TestProviderConfigure_UnknownConfigWithoutDeferral(t *testing.T) {
+	p := provider.New("test")()
+	req := tfprovider.ConfigureRequest{Config: unknownClientIDConfig(t, p)}
+	resp := tfprovider.ConfigureResponse{}
+	p.Configure(context.Background(), req, &resp)
+
+	if resp.Deferred != nil {
+		t.Error("expected no deferral when the client does not allow it")
+	}
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Unknown Provider Configuration Value" {
+		t.Errorf("unexpected error summary %q", summary)
+	}
+}
+
 func TestProviderSchema_ScopeHasValidator(t *testing.T) {
 	p := provider.New("test")()
 	resp := tfprovider.SchemaResponse{}
@@ -112,13 +208,34 @@ func TestProviderSchema_ScopeHasValidator(t *testing.T) {
 	}
 }
 
+func TestProviderSchema_FeaturesBlock(t *testing.T) {
+	p := provider.New("test")()
+	resp := tfprovider.SchemaResponse{}
+	p.Schema(context.Background(), tfprovider.SchemaRequest{}, &resp)
+
+	block, ok := resp.Schema.Blocks["features"].(schema.SingleNestedBlock)
+	if !ok {
+		t.Fatal("features block not found or not a SingleNestedBlock")
+	}
+	for _, name := range []string{"delete_unassigns_devices", "strict_decoding", "authoritative_assignments"} {
+		attr, ok := block.Attributes[name]
+		if !ok {
+			t.Errorf("attribute %q not found in the features block", name)
+			continue
+		}
+		if !attr.IsOptional() || attr.IsRequired() || attr.IsComputed() {
+			t.Errorf("expected attribute %q to be Optional only", name)
+		}
+	}
+}
+
 func TestProviderResources(t *testing.T) {
 	p := provider.New("test")()
 	ctx := context.Background()
 	resources := p.Resources(ctx)
 
-	if len(resources) != 4 {
-		t.Fatalf("expected 4 resources, got %d", len(resources))
+	if len(resources) != 6 {
+		t.Fatalf("expected 6 resources, got %d", len(resources))
 	}
 
 	var got []string
@@ -133,7 +250,9 @@ func TestProviderResources(t *testing.T) {
 		"axm_blueprint",
 		"axm_configuration",
 		"axm_default_device_assignment",
+		"axm_device_assignment",
 		"axm_device_management_service",
+		"axm_inventory_snapshot",
 	}
 
 	sort.Strings(got)
@@ -149,8 +268,8 @@ func TestProviderDataSources(t *testing.T) {
 	ctx := context.Background()
 	dataSources := p.DataSources(ctx)
 
-	if len(dataSources) != 22 {
-		t.Fatalf("expected 22 data sources, got %d", len(dataSources))
+	if len(dataSources) != 33 {
+		t.Fatalf("expected 33 data sources, got %d", len(dataSources))
 	}
 
 	expected := []string{
@@ -158,20 +277,31 @@ func TestProviderDataSources(t *testing.T) {
 		"axm_apple_device_management_device",
 		"axm_apple_device_management_devices",
 		"axm_apps",
+		"axm_assignment_compliance",
 		"axm_audit_events",
 		"axm_blueprint",
 		"axm_blueprints",
+		"axm_client_stats",
 		"axm_configuration",
 		"axm_configurations",
+		"axm_device_assignment_eligibility",
 		"axm_device_management_service",
 		"axm_device_management_service_serial_numbers",
 		"axm_device_management_services",
+		"axm_fleet_policy",
 		"axm_organization_device",
+		"axm_organization_device_activities",
+		"axm_organization_device_activity",
+		"axm_organization_device_activity_log",
 		"axm_organization_device_applecare_coverage",
 		"axm_organization_device_assigned_server_information",
 		"axm_organization_devices",
+		"axm_organization_devices_by_serial",
 		"axm_package",
 		"axm_packages",
+		"axm_provider_info",
+		"axm_service_status",
+		"axm_stale_organization_devices",
 		"axm_user",
 		"axm_user_group",
 		"axm_user_groups",
@@ -207,8 +337,8 @@ func TestProviderListResources(t *testing.T) {
 	}
 
 	listResources := plr.ListResources(ctx)
-	if len(listResources) != 3 {
-		t.Fatalf("expected 3 list resources, got %d", len(listResources))
+	if len(listResources) != 4 {
+		t.Fatalf("expected 4 list resources, got %d", len(listResources))
 	}
 
 	var got []string
@@ -222,6 +352,7 @@ func TestProviderListResources(t *testing.T) {
 	expected := []string{
 		"axm_blueprint",
 		"axm_configuration",
+		"axm_device_assignment",
 		"axm_device_management_service",
 	}
 
@@ -233,6 +364,82 @@ func TestProviderListResources(t *testing.T) {
 	}
 }
 
+func TestProviderActions(t *testing.T) {
+	p := provider.New("test")()
+	ctx := context.Background()
+
+	pa, ok := p.(tfprovider.ProviderWithActions)
+	if !ok {
+		t.Fatal("provider does not implement ProviderWithActions")
+	}
+
+	actions := pa.Actions(ctx)
+	expected := []string{
+		"axm_release_devices",
+		"axm_retry_failed_device_activity",
+	}
+	if len(actions) != len(expected) {
+		t.Fatalf("expected %d actions, got %d", len(expected), len(actions))
+	}
+
+	for i, a := range actions {
+		resp := action.MetadataResponse{}
+		a().Metadata(ctx, action.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+		if resp.TypeName != expected[i] {
+			t.Errorf("expected action %q, got %q", expected[i], resp.TypeName)
+		}
+	}
+}
+
+func TestProviderEphemeralResources(t *testing.T) {
+	p := provider.New("test")()
+	ctx := context.Background()
+
+	pe, ok := p.(tfprovider.ProviderWithEphemeralResources)
+	if !ok {
+		t.Fatal("provider does not implement ProviderWithEphemeralResources")
+	}
+
+	ephemeralResources := pe.EphemeralResources(ctx)
+	if len(ephemeralResources) != 1 {
+		t.Fatalf("expected 1 ephemeral resource, got %d", len(ephemeralResources))
+	}
+
+	resp := ephemeral.MetadataResponse{}
+	ephemeralResources[0]().Metadata(ctx, ephemeral.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+	if resp.TypeName != "axm_access_token" {
+		t.Errorf("expected ephemeral resource %q, got %q", "axm_access_token", resp.TypeName)
+	}
+}
+
+func TestProviderFunctions(t *testing.T) {
+	p := provider.New("test")()
+	ctx := context.Background()
+
+	pf, ok := p.(tfprovider.ProviderWithFunctions)
+	if !ok {
+		t.Fatal("provider does not implement ProviderWithFunctions")
+	}
+
+	functions := pf.Functions(ctx)
+	expected := []string{
+		"chunk_serials",
+		"parse_device_serial",
+		"render_inventory_markdown",
+	}
+	if len(functions) != len(expected) {
+		t.Fatalf("expected %d functions, got %d", len(expected), len(functions))
+	}
+
+	for i, f := range functions {
+		resp := function.MetadataResponse{}
+		f().Metadata(ctx, function.MetadataRequest{}, &resp)
+		if resp.Name != expected[i] {
+			t.Errorf("expected function %q, got %q", expected[i], resp.Name)
+		}
+	}
+}
+
 func TestAccProviderConfiguresSuccessfully(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
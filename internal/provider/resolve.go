@@ -0,0 +1,100 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/text/language"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+// resolveInt64 returns the value of attr when it is set, and otherwise the integer in the
+// environment variable env, which must be at least min. It reports false when neither is set
+// or the environment value is invalid, which is added to diags under summary.
+func resolveInt64(attr types.Int64, env, summary string, min int, diags *diag.Diagnostics) (int, bool) {
+	if !attr.IsNull() {
+		return int(attr.ValueInt64()), true
+	}
+	value := getenv(env)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min {
+		kind := "a positive integer"
+		if min == 0 {
+			kind = "a non-negative integer"
+		}
+		diags.AddError(summary, fmt.Sprintf("%s must be %s, got: %s", env, kind, value))
+		return 0, false
+	}
+	return n, true
+}
+
+// resolveBool returns the value of attr when it is set, and otherwise the boolean in the
+// environment variable env. It reports false when neither is set or the environment value is
+// invalid, which is added to diags under summary.
+func resolveBool(attr types.Bool, env, summary string, diags *diag.Diagnostics) (bool, bool) {
+	if !attr.IsNull() {
+		return attr.ValueBool(), true
+	}
+	value := getenv(env)
+	if value == "" {
+		return false, false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		diags.AddError(summary, fmt.Sprintf("%s must be true or false, got: %s", env, value))
+		return false, false
+	}
+	return b, true
+}
+
+// resolveDuration returns the duration in attr when it is set, and otherwise the duration in
+// the environment variable env, which must be positive, or non-negative when allowZero is set.
+// It reports false when neither is set or the environment value is invalid, which is added to
+// diags under summary.
+func resolveDuration(attr types.String, env, summary string, allowZero bool, diags *diag.Diagnostics) (time.Duration, bool) {
+	if !attr.IsNull() {
+		return common.DurationValue(attr, 0), true
+	}
+	value := getenv(env)
+	if value == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 || (d == 0 && !allowZero) {
+		kind := "a positive duration"
+		if allowZero {
+			kind = "a non-negative duration"
+		}
+		diags.AddError(summary, fmt.Sprintf("%s must be %s such as \"5m\", got: %s", env, kind, value))
+		return 0, false
+	}
+	return d, true
+}
+
+// resolveAcceptLanguage returns accept_language, or the AXM_ACCEPT_LANGUAGE environment
+// variable when it is not set, adding an error to diags when the result is not a valid
+// Accept-Language header value.
+func resolveAcceptLanguage(attr types.String, diags *diag.Diagnostics) string {
+	acceptLanguage := attr.ValueString()
+	if acceptLanguage == "" {
+		acceptLanguage = getenv(envAcceptLanguage)
+	}
+	if _, _, err := language.ParseAcceptLanguage(acceptLanguage); err != nil {
+		diags.AddError(
+			"Invalid Accept Language",
+			fmt.Sprintf("accept_language, or %s, must be an Accept-Language header value, got: %s", envAcceptLanguage, acceptLanguage),
+		)
+		return ""
+	}
+	return acceptLanguage
+}
@@ -0,0 +1,133 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveInt64(t *testing.T) {
+	const env = "AXM_TEST_RESOLVE_INT"
+
+	tests := []struct {
+		name    string
+		attr    types.Int64
+		env     string
+		min     int
+		want    int
+		wantOK  bool
+		wantErr bool
+	}{
+		{name: "attribute_wins", attr: types.Int64Value(3), env: "7", min: 1, want: 3, wantOK: true},
+		{name: "environment", attr: types.Int64Null(), env: "7", min: 1, want: 7, wantOK: true},
+		{name: "unset", attr: types.Int64Null(), min: 1},
+		{name: "zero_allowed", attr: types.Int64Null(), env: "0", min: 0, want: 0, wantOK: true},
+		{name: "below_min", attr: types.Int64Null(), env: "0", min: 1, wantErr: true},
+		{name: "not_a_number", attr: types.Int64Null(), env: "many", min: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(env, tt.env)
+			var diags diag.Diagnostics
+			got, ok := resolveInt64(tt.attr, env, "Invalid Test", tt.min, &diags)
+			if got != tt.want || ok != tt.wantOK || diags.HasError() != tt.wantErr {
+				t.Errorf("expected (%d, %v, error=%v), got (%d, %v, %v)", tt.want, tt.wantOK, tt.wantErr, got, ok, diags)
+			}
+		})
+	}
+}
+
+func TestResolveBool(t *testing.T) {
+	const env = "AXM_TEST_RESOLVE_BOOL"
+
+	tests := []struct {
+		name    string
+		attr    types.Bool
+		env     string
+		want    bool
+		wantOK  bool
+		wantErr bool
+	}{
+		{name: "attribute_wins", attr: types.BoolValue(false), env: "true", want: false, wantOK: true},
+		{name: "environment", attr: types.BoolNull(), env: "true", want: true, wantOK: true},
+		{name: "unset", attr: types.BoolNull()},
+		{name: "invalid", attr: types.BoolNull(), env: "maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(env, tt.env)
+			var diags diag.Diagnostics
+			got, ok := resolveBool(tt.attr, env, "Invalid Test", &diags)
+			if got != tt.want || ok != tt.wantOK || diags.HasError() != tt.wantErr {
+				t.Errorf("expected (%v, %v, error=%v), got (%v, %v, %v)", tt.want, tt.wantOK, tt.wantErr, got, ok, diags)
+			}
+		})
+	}
+}
+
+func TestResolveDuration(t *testing.T) {
+	const env = "AXM_TEST_RESOLVE_DURATION"
+
+	tests := []struct {
+		name      string
+		attr      types.String
+		env       string
+		allowZero bool
+		want      time.Duration
+		wantOK    bool
+		wantErr   bool
+	}{
+		{name: "attribute_wins", attr: types.StringValue("2m"), env: "5m", want: 2 * time.Minute, wantOK: true},
+		{name: "environment", attr: types.StringNull(), env: "5m", want: 5 * time.Minute, wantOK: true},
+		{name: "unset", attr: types.StringNull()},
+		{name: "zero_allowed", attr: types.StringNull(), env: "0s", allowZero: true, wantOK: true},
+		{name: "zero_rejected", attr: types.StringNull(), env: "0s", wantErr: true},
+		{name: "negative", attr: types.StringNull(), env: "-1m", allowZero: true, wantErr: true},
+		{name: "invalid", attr: types.StringNull(), env: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(env, tt.env)
+			var diags diag.Diagnostics
+			got, ok := resolveDuration(tt.attr, env, "Invalid Test", tt.allowZero, &diags)
+			if got != tt.want || ok != tt.wantOK || diags.HasError() != tt.wantErr {
+				t.Errorf("expected (%v, %v, error=%v), got (%v, %v, %v)", tt.want, tt.wantOK, tt.wantErr, got, ok, diags)
+			}
+		})
+	}
+}
+
+func TestResolveAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		attr    types.String
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{name: "attribute_wins", attr: types.StringValue("fr-FR"), env: "de-DE", want: "fr-FR"},
+		{name: "environment", attr: types.StringNull(), env: "de-DE, en;q=0.8", want: "de-DE, en;q=0.8"},
+		{name: "unset", attr: types.StringNull()},
+		{name: "invalid_environment", attr: types.StringNull(), env: "fr-FR;q=high", wantErr: true},
+		{name: "invalid_attribute", attr: types.StringValue("fr-FR;q=high"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envAcceptLanguage, tt.env)
+			var diags diag.Diagnostics
+			got := resolveAcceptLanguage(tt.attr, &diags)
+			if got != tt.want || diags.HasError() != tt.wantErr {
+				t.Errorf("expected (%q, error=%v), got (%q, %v)", tt.want, tt.wantErr, got, diags)
+			}
+		})
+	}
+}
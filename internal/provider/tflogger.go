@@ -83,7 +83,25 @@ func (l *TerraformLogger) LogResponse(ctx context.Context, statusCode int, heade
 	tflog.Debug(ctx, "HTTP Response", fields)
 }
 
+// LogPage logs the progress of a paginated collection request using tflog at DEBUG level
+func (l *TerraformLogger) LogPage(ctx context.Context, page client.PageFetch) {
+	tflog.Debug(ctx, "Fetched page", map[string]any{
+		"endpoint":   page.Endpoint,
+		"cursor":     page.Cursor,
+		"page_size":  page.PageSize,
+		"page":       page.Page,
+		"items":      page.Items,
+		"total":      page.Total,
+		"elapsed_ms": page.Elapsed.Milliseconds(),
+	})
+}
+
 // LogAuth logs authentication-related events using tflog at DEBUG level
 func (l *TerraformLogger) LogAuth(ctx context.Context, message string, fields map[string]any) {
 	tflog.Debug(ctx, message, fields)
 }
+
+// LogWarning logs a recoverable problem using tflog at WARN level
+func (l *TerraformLogger) LogWarning(ctx context.Context, message string, fields map[string]any) {
+	tflog.Warn(ctx, message, fields)
+}
@@ -0,0 +1,48 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestTerraformLogger_ConcurrentDeviceReads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":{"id":%q,"type":"orgDevices","attributes":{"serialNumber":%q}}}`, id, id)
+	}))
+	defer server.Close()
+
+	c, err := client.NewClientWithAccessToken(server.URL, "business.api", "token", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.SetLogger(NewTerraformLogger())
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Go(func() {
+			id := fmt.Sprintf("DEVICE%d", i%5)
+			device, err := c.GetOrgDevice(context.Background(), id, nil)
+			if err != nil {
+				t.Errorf("read %d: unexpected error: %v", i, err)
+				return
+			}
+			if device.ID != id {
+				t.Errorf("read %d: expected device %q, got %q", i, id, device.ID)
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package access_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ ephemeral.EphemeralResource = &AccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+
+// NewAccessTokenEphemeralResource returns a new ephemeral resource exposing the provider's OAuth access token.
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+// AccessTokenEphemeralResource defines the ephemeral resource implementation.
+type AccessTokenEphemeralResource struct {
+	client *client.Client
+}
+
+// AccessTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AccessTokenEphemeralResourceModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Scope       types.String `tfsdk:"scope"`
+	BaseURL     types.String `tfsdk:"base_url"`
+}
+
+func (r *AccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes the short-lived OAuth access token the provider authenticates with, so that other providers and " +
+			"provisioners can call Apple Business Manager or Apple School Manager API endpoints this provider does not cover. " +
+			"The token is obtained with the provider's credentials each time the ephemeral resource is opened, and a new one is " +
+			"requested when the current token is missing or about to expire. It is never written to plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Description: "The OAuth access token, sent as a bearer token in the Authorization header.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token_type": schema.StringAttribute{
+				Description: "The type of the access token, normally Bearer.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "The RFC 3339 time at which the access token expires. Null when the expiry is unknown.",
+				Computed:    true,
+			},
+			"scope": schema.StringAttribute{
+				Description: "The OAuth scope the token was issued for: business.api or school.api.",
+				Computed:    true,
+			},
+			"base_url": schema.StringAttribute{
+				Description: "The API base URL the token is valid for, such as https://api-business.apple.com.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Ephemeral Resource")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.client = c
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Provider",
+			"The provider has not been configured yet. Re-run the command after `terraform init` has completed successfully.",
+		)
+		return
+	}
+
+	token, err := r.client.AccessToken()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Obtain Access Token",
+			err.Error(),
+		)
+		return
+	}
+
+	data := AccessTokenEphemeralResourceModel{
+		AccessToken: types.StringValue(token.AccessToken),
+		TokenType:   types.StringValue(token.Type()),
+		ExpiresAt:   types.StringNull(),
+		Scope:       types.StringValue(r.client.Scope()),
+		BaseURL:     types.StringValue(r.client.BaseURL()),
+	}
+	if !token.Expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+
+	tflog.Debug(ctx, "Opened access token", map[string]any{
+		"expires_at": data.ExpiresAt.ValueString(),
+		"scope":      data.Scope.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,101 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package access_token_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/access_token"
+)
+
+func TestAccessTokenEphemeralResourceMetadata(t *testing.T) {
+	r := access_token.NewAccessTokenEphemeralResource()
+	resp := ephemeral.MetadataResponse{}
+	r.Metadata(context.Background(), ephemeral.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_access_token" {
+		t.Errorf("expected TypeName %q, got %q", "axm_access_token", resp.TypeName)
+	}
+}
+
+func TestAccessTokenEphemeralResourceSchema(t *testing.T) {
+	r := access_token.NewAccessTokenEphemeralResource()
+	resp := ephemeral.SchemaResponse{}
+	r.Schema(context.Background(), ephemeral.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	token, ok := resp.Schema.Attributes["access_token"]
+	if !ok {
+		t.Fatal("attribute 'access_token' not found")
+	}
+	if !token.IsSensitive() {
+		t.Error("expected 'access_token' to be Sensitive")
+	}
+
+	for _, name := range []string{"access_token", "token_type", "expires_at", "scope", "base_url"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("attribute %q not found", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected %q to be Computed", name)
+		}
+	}
+}
+
+func TestAccessTokenEphemeralResourceOpen(t *testing.T) {
+	ctx := context.Background()
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	c, err := client.NewClientWithAccessToken("https://api-business.apple.com", "business.api", "secret", expiry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := access_token.NewAccessTokenEphemeralResource()
+	configureResp := ephemeral.ConfigureResponse{}
+	r.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: c}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := ephemeral.SchemaResponse{}
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	resp := ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Open(ctx, ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected open diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got access_token.AccessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(resp.Result.Get(ctx, &got)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected result diagnostics: %v", resp.Diagnostics)
+	}
+	if got.AccessToken.ValueString() != "secret" || got.TokenType.ValueString() != "Bearer" {
+		t.Errorf("unexpected token: %q %q", got.TokenType.ValueString(), got.AccessToken.ValueString())
+	}
+	if got.ExpiresAt.ValueString() != "2030-01-02T03:04:05Z" {
+		t.Errorf("unexpected expires_at: %q", got.ExpiresAt.ValueString())
+	}
+	if got.Scope.ValueString() != "business.api" || got.BaseURL.ValueString() != "https://api-business.apple.com" {
+		t.Errorf("unexpected scope or base URL: %q %q", got.Scope.ValueString(), got.BaseURL.ValueString())
+	}
+}
@@ -110,7 +110,7 @@ func (d *AppleDeviceManagementDeviceDataSource) Schema(ctx context.Context, req
 			},
 			"last_check_in_date_time": schema.StringAttribute{
 				Computed:    true,
-				Description: "The date and time the device last checked in.",
+				Description: "The date and time the device last checked in. Normalized to RFC 3339 in UTC.",
 			},
 			"lost_mode_status": schema.StringAttribute{
 				Computed:    true,
@@ -159,6 +159,8 @@ func (d *AppleDeviceManagementDeviceDataSource) Configure(ctx context.Context, r
 }
 
 func (d *AppleDeviceManagementDeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data AppleDeviceManagementDeviceDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -186,27 +188,27 @@ func (d *AppleDeviceManagementDeviceDataSource) Read(ctx context.Context, req da
 
 	data.ID = types.StringValue(detail.ID)
 	data.Type = types.StringValue(detail.Type)
-	data.BluetoothMacAddress = types.StringValue(detail.Attributes.BluetoothMacAddress)
-	data.DeviceEraseStatus = types.StringValue(detail.Attributes.DeviceEraseStatus)
-	data.DeviceLockStatus = types.StringValue(detail.Attributes.DeviceLockStatus)
-	data.DeviceModel = types.StringValue(detail.Attributes.DeviceModel)
-	data.DeviceName = types.StringValue(detail.Attributes.DeviceName)
-	data.EthernetMacAddress = types.StringValue(detail.Attributes.EthernetMacAddress)
-	data.IMEI = common.StringsToTypesStrings(detail.Attributes.IMEI)
+	data.BluetoothMacAddress = common.OptionalString(detail.Attributes.BluetoothMacAddress)
+	data.DeviceEraseStatus = common.OptionalString(detail.Attributes.DeviceEraseStatus)
+	data.DeviceLockStatus = common.OptionalString(detail.Attributes.DeviceLockStatus)
+	data.DeviceModel = common.OptionalString(detail.Attributes.DeviceModel)
+	data.DeviceName = common.OptionalString(detail.Attributes.DeviceName)
+	data.EthernetMacAddress = common.OptionalString(detail.Attributes.EthernetMacAddress)
+	data.IMEI = common.OptionalStrings(detail.Attributes.IMEI)
 	data.IsFileVaultEnabled = types.BoolPointerValue(detail.Attributes.IsFileVaultEnabled)
 	data.IsFirewallEnabled = types.BoolPointerValue(detail.Attributes.IsFirewallEnabled)
-	data.LastCheckInDateTime = types.StringValue(detail.Attributes.LastCheckInDateTime)
-	data.LostModeStatus = types.StringValue(detail.Attributes.LostModeStatus)
-	data.MEID = common.StringsToTypesStrings(detail.Attributes.MEID)
-	data.OsVersion = types.StringValue(detail.Attributes.OsVersion)
-	data.Platform = types.StringValue(detail.Attributes.Platform)
-	data.SerialNumber = types.StringValue(detail.Attributes.SerialNumber)
+	data.LastCheckInDateTime = common.TimestampValue(detail.Attributes.LastCheckInDateTime, "last_check_in_date_time", &resp.Diagnostics)
+	data.LostModeStatus = common.OptionalString(detail.Attributes.LostModeStatus)
+	data.MEID = common.OptionalStrings(detail.Attributes.MEID)
+	data.OsVersion = common.OptionalString(detail.Attributes.OsVersion)
+	data.Platform = common.OptionalString(detail.Attributes.Platform)
+	data.SerialNumber = common.OptionalString(detail.Attributes.SerialNumber)
 	data.StorageFreeCapacity = types.Int64PointerValue(detail.Attributes.StorageFreeCapacity)
 	data.StorageTotalCapacity = types.Int64PointerValue(detail.Attributes.StorageTotalCapacity)
-	data.WifiMacAddress = types.StringValue(detail.Attributes.WifiMacAddress)
+	data.WifiMacAddress = common.OptionalString(detail.Attributes.WifiMacAddress)
 
 	tflog.Debug(ctx, "Read apple device management device", map[string]any{
-		"device_id": data.ID.ValueString(),
+		"device_id": d.client.RedactSerial(data.ID.ValueString()),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -106,6 +106,8 @@ func (d *AppleDeviceManagementDevicesDataSource) Configure(ctx context.Context,
 }
 
 func (d *AppleDeviceManagementDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data AppleDeviceManagementDevicesDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -120,6 +122,7 @@ func (d *AppleDeviceManagementDevicesDataSource) Read(ctx context.Context, req d
 		return
 	}
 	defer cancel()
+	readCtx, reportSkipped := common.CollectSkippedRecords(readCtx)
 
 	devices, err := d.client.GetMdmDevices(readCtx, nil)
 	if err != nil {
@@ -129,16 +132,17 @@ func (d *AppleDeviceManagementDevicesDataSource) Read(ctx context.Context, req d
 		)
 		return
 	}
+	reportSkipped(&resp.Diagnostics)
 
 	data.Devices = make([]AppleDeviceManagementDeviceModel, 0, len(devices))
 	for _, device := range devices {
 		deviceModel := AppleDeviceManagementDeviceModel{
 			ID:             types.StringValue(device.ID),
 			Type:           types.StringValue(device.Type),
-			DeviceName:     types.StringValue(device.Attributes.DeviceName),
-			EnrolledUserID: types.StringValue(device.Attributes.EnrolledUserID),
-			ProductFamily:  types.StringValue(device.Attributes.ProductFamily),
-			SerialNumber:   types.StringValue(device.Attributes.SerialNumber),
+			DeviceName:     common.OptionalString(device.Attributes.DeviceName),
+			EnrolledUserID: common.OptionalString(device.Attributes.EnrolledUserID),
+			ProductFamily:  common.OptionalString(device.Attributes.ProductFamily),
+			SerialNumber:   common.OptionalString(device.Attributes.SerialNumber),
 		}
 
 		data.Devices = append(data.Devices, deviceModel)
@@ -0,0 +1,284 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package assignment_compliance
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &AssignmentComplianceDataSource{}
+
+// NewAssignmentComplianceDataSource returns a new data source for checking device assignment compliance.
+func NewAssignmentComplianceDataSource() datasource.DataSource {
+	return &AssignmentComplianceDataSource{}
+}
+
+// AssignmentComplianceDataSource defines the data source implementation.
+type AssignmentComplianceDataSource struct {
+	client *client.Client
+}
+
+// AssignmentComplianceDataSourceModel describes the data source data model.
+type AssignmentComplianceDataSourceModel struct {
+	ID                      types.String            `tfsdk:"id"`
+	Timeouts                timeouts.Value          `tfsdk:"timeouts"`
+	Expected                types.Map               `tfsdk:"expected"`
+	Exclusive               types.Bool              `tfsdk:"exclusive"`
+	Compliant               types.Bool              `tfsdk:"compliant"`
+	MissingSerialNumbers    []types.String          `tfsdk:"missing_serial_numbers"`
+	UnexpectedSerialNumbers []types.String          `tfsdk:"unexpected_serial_numbers"`
+	Servers                 []ServerComplianceModel `tfsdk:"servers"`
+}
+
+// ServerComplianceModel describes the compliance result for a single device management service.
+type ServerComplianceModel struct {
+	ServerID                types.String   `tfsdk:"server_id"`
+	Compliant               types.Bool     `tfsdk:"compliant"`
+	AssignedDeviceCount     types.Int64    `tfsdk:"assigned_device_count"`
+	MissingSerialNumbers    []types.String `tfsdk:"missing_serial_numbers"`
+	UnexpectedSerialNumbers []types.String `tfsdk:"unexpected_serial_numbers"`
+}
+
+func (d *AssignmentComplianceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assignment_compliance"
+}
+
+func (d *AssignmentComplianceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compares expected device assignments against the devices currently assigned to each device management service. " +
+			"Designed to be used in a Terraform check block, e.g. assert { condition = data.axm_assignment_compliance.this.compliant }.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"expected": schema.MapAttribute{
+				Description: "Map of device management service ID to the set of device serial numbers expected to be assigned to it. " +
+					"Serial numbers are compared case-insensitively and may appear under only one server.",
+				Required:    true,
+				ElementType: types.SetType{ElemType: types.StringType},
+			},
+			"exclusive": schema.BoolAttribute{
+				Description: "When true, devices assigned to a listed server but absent from its expected set are also out of compliance. Defaults to false.",
+				Optional:    true,
+			},
+			"compliant": schema.BoolAttribute{
+				Description: "True when every listed server is compliant.",
+				Computed:    true,
+			},
+			"missing_serial_numbers": schema.ListAttribute{
+				Description: "Expected serial numbers, across all servers, that are not assigned to their expected server.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"unexpected_serial_numbers": schema.ListAttribute{
+				Description: "Serial numbers, across all servers, that are assigned to a listed server without being expected there. Only populated when exclusive is true.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"servers": schema.ListNestedAttribute{
+				Description: "Per-server compliance results, ordered by server ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"server_id": schema.StringAttribute{
+							Description: "The opaque resource ID that uniquely identifies the device management service.",
+							Computed:    true,
+						},
+						"compliant": schema.BoolAttribute{
+							Description: "True when the server has no missing devices and, if exclusive is true, no unexpected devices.",
+							Computed:    true,
+						},
+						"assigned_device_count": schema.Int64Attribute{
+							Description: "The number of devices currently assigned to the server.",
+							Computed:    true,
+						},
+						"missing_serial_numbers": schema.ListAttribute{
+							Description: "Expected serial numbers that are not assigned to the server.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"unexpected_serial_numbers": schema.ListAttribute{
+							Description: "Serial numbers assigned to the server without being expected there. Only populated when exclusive is true.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AssignmentComplianceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *AssignmentComplianceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data AssignmentComplianceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expected map[string][]string
+	resp.Diagnostics.Append(data.Expected.ElementsAs(ctx, &expected, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := validateExpected(expected); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expected"),
+			"Conflicting Expected Assignments",
+			err.Error(),
+		)
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	exclusive := data.Exclusive.ValueBool()
+	serverIDs := slices.Sorted(maps.Keys(expected))
+
+	data.Compliant = types.BoolValue(true)
+	data.MissingSerialNumbers = []types.String{}
+	data.UnexpectedSerialNumbers = []types.String{}
+	data.Servers = make([]ServerComplianceModel, 0, len(serverIDs))
+
+	assigned := make([][]string, len(serverIDs))
+	err := client.ForEachConcurrent(readCtx, d.client.MaxConcurrency(), len(serverIDs), func(ctx context.Context, i int) error {
+		serials, err := d.client.GetDeviceManagementServiceSerialNumbers(ctx, serverIDs[i])
+		if err != nil {
+			return fmt.Errorf("could not read devices assigned to server %s: %w", serverIDs[i], err)
+		}
+		assigned[i] = serials
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Device Management Service Serial Numbers",
+			err.Error(),
+		)
+		return
+	}
+
+	for i, serverID := range serverIDs {
+		result := evaluateCompliance(expected[serverID], assigned[i], exclusive)
+		data.Servers = append(data.Servers, ServerComplianceModel{
+			ServerID:                types.StringValue(serverID),
+			Compliant:               types.BoolValue(result.compliant()),
+			AssignedDeviceCount:     types.Int64Value(int64(len(assigned[i]))),
+			MissingSerialNumbers:    common.StringsToTypesStrings(result.missing),
+			UnexpectedSerialNumbers: common.StringsToTypesStrings(result.unexpected),
+		})
+		data.MissingSerialNumbers = append(data.MissingSerialNumbers, common.StringsToTypesStrings(result.missing)...)
+		data.UnexpectedSerialNumbers = append(data.UnexpectedSerialNumbers, common.StringsToTypesStrings(result.unexpected)...)
+		if !result.compliant() {
+			data.Compliant = types.BoolValue(false)
+		}
+	}
+
+	data.ID = types.StringValue("assignment_compliance")
+
+	tflog.Debug(ctx, "Evaluated assignment compliance", map[string]any{
+		"server_count":     len(serverIDs),
+		"compliant":        data.Compliant.ValueBool(),
+		"missing_count":    len(data.MissingSerialNumbers),
+		"unexpected_count": len(data.UnexpectedSerialNumbers),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// complianceResult holds the out-of-compliance serial numbers for a single server.
+type complianceResult struct {
+	missing    []string
+	unexpected []string
+}
+
+// compliant reports whether the server has no out-of-compliance devices.
+func (r complianceResult) compliant() bool {
+	return len(r.missing) == 0 && len(r.unexpected) == 0
+}
+
+// evaluateCompliance compares the expected serial numbers for a server with those currently
+// assigned to it. Unexpected serial numbers are only reported when exclusive is true.
+func evaluateCompliance(expected, assigned []string, exclusive bool) complianceResult {
+	assignedSet := make(map[string]struct{}, len(assigned))
+	for _, serial := range assigned {
+		assignedSet[normalizeSerial(serial)] = struct{}{}
+	}
+	expectedSet := make(map[string]struct{}, len(expected))
+
+	result := complianceResult{missing: []string{}, unexpected: []string{}}
+	for _, serial := range expected {
+		key := normalizeSerial(serial)
+		expectedSet[key] = struct{}{}
+		if _, ok := assignedSet[key]; !ok {
+			result.missing = append(result.missing, serial)
+		}
+	}
+
+	if exclusive {
+		for _, serial := range assigned {
+			if _, ok := expectedSet[normalizeSerial(serial)]; !ok {
+				result.unexpected = append(result.unexpected, serial)
+			}
+		}
+	}
+
+	slices.Sort(result.missing)
+	slices.Sort(result.unexpected)
+	return result
+}
+
+// validateExpected returns an error when a serial number is expected on more than one server.
+func validateExpected(expected map[string][]string) error {
+	owners := make(map[string]string)
+	for _, serverID := range slices.Sorted(maps.Keys(expected)) {
+		for _, serial := range expected[serverID] {
+			key := normalizeSerial(serial)
+			if owner, ok := owners[key]; ok && owner != serverID {
+				return fmt.Errorf("serial number %s is expected on both server %s and server %s", serial, owner, serverID)
+			}
+			owners[key] = serverID
+		}
+	}
+	return nil
+}
+
+// normalizeSerial returns the form of a serial number used for comparisons.
+func normalizeSerial(serial string) string {
+	return strings.ToUpper(strings.TrimSpace(serial))
+}
@@ -0,0 +1,123 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package assignment_compliance_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/assignment_compliance"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestAssignmentComplianceDataSourceMetadata(t *testing.T) {
+	ds := assignment_compliance.NewAssignmentComplianceDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_assignment_compliance" {
+		t.Errorf("expected TypeName %q, got %q", "axm_assignment_compliance", resp.TypeName)
+	}
+}
+
+func TestAssignmentComplianceDataSourceSchema(t *testing.T) {
+	ds := assignment_compliance.NewAssignmentComplianceDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	tests := []struct {
+		name     string
+		required bool
+		optional bool
+		computed bool
+	}{
+		{"id", false, false, true},
+		{"timeouts", false, true, false},
+		{"expected", true, false, false},
+		{"exclusive", false, true, false},
+		{"compliant", false, false, true},
+		{"missing_serial_numbers", false, false, true},
+		{"unexpected_serial_numbers", false, false, true},
+		{"servers", false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, ok := resp.Schema.Attributes[tt.name]
+			if !ok {
+				t.Fatalf("attribute %q not found in schema", tt.name)
+			}
+			if attr.IsRequired() != tt.required {
+				t.Errorf("expected Required=%v, got %v", tt.required, attr.IsRequired())
+			}
+			if attr.IsOptional() != tt.optional {
+				t.Errorf("expected Optional=%v, got %v", tt.optional, attr.IsOptional())
+			}
+			if attr.IsComputed() != tt.computed {
+				t.Errorf("expected Computed=%v, got %v", tt.computed, attr.IsComputed())
+			}
+		})
+	}
+
+	if len(resp.Schema.Attributes) != len(tests) {
+		t.Errorf("expected %d attributes, got %d", len(tests), len(resp.Schema.Attributes))
+	}
+}
+
+func TestAccAssignmentComplianceDataSource(t *testing.T) {
+	serverID := os.Getenv("AXM_TEST_SERVER_ID")
+	if serverID == "" {
+		t.Skip("AXM_TEST_SERVER_ID must be set for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "axm_assignment_compliance" "test" {
+						expected = {
+							%q = []
+						}
+					}
+				`, serverID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_assignment_compliance.test", "compliant", "true"),
+					resource.TestCheckResourceAttr("data.axm_assignment_compliance.test", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.axm_assignment_compliance.test", "servers.0.server_id", serverID),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package assignment_compliance
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEvaluateCompliance(t *testing.T) {
+	tests := []struct {
+		name           string
+		expected       []string
+		assigned       []string
+		exclusive      bool
+		wantMissing    []string
+		wantUnexpected []string
+	}{
+		{
+			name:           "all_assigned",
+			expected:       []string{"SER1", "SER2"},
+			assigned:       []string{"SER2", "SER1", "SER3"},
+			wantMissing:    []string{},
+			wantUnexpected: []string{},
+		},
+		{
+			name:           "missing_sorted",
+			expected:       []string{"SER9", "SER1", "SER5"},
+			assigned:       []string{"SER5"},
+			wantMissing:    []string{"SER1", "SER9"},
+			wantUnexpected: []string{},
+		},
+		{
+			name:           "case_insensitive",
+			expected:       []string{" ser1 "},
+			assigned:       []string{"SER1"},
+			wantMissing:    []string{},
+			wantUnexpected: []string{},
+		},
+		{
+			name:           "exclusive_reports_unexpected",
+			expected:       []string{"SER1"},
+			assigned:       []string{"SER3", "SER1", "SER2"},
+			exclusive:      true,
+			wantMissing:    []string{},
+			wantUnexpected: []string{"SER2", "SER3"},
+		},
+		{
+			name:           "empty_expected_exclusive",
+			assigned:       []string{"SER1"},
+			exclusive:      true,
+			wantMissing:    []string{},
+			wantUnexpected: []string{"SER1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluateCompliance(tt.expected, tt.assigned, tt.exclusive)
+			if !slices.Equal(result.missing, tt.wantMissing) {
+				t.Errorf("missing: expected %v, got %v", tt.wantMissing, result.missing)
+			}
+			if !slices.Equal(result.unexpected, tt.wantUnexpected) {
+				t.Errorf("unexpected: expected %v, got %v", tt.wantUnexpected, result.unexpected)
+			}
+			wantCompliant := len(tt.wantMissing) == 0 && len(tt.wantUnexpected) == 0
+			if result.compliant() != wantCompliant {
+				t.Errorf("expected compliant=%v, got %v", wantCompliant, result.compliant())
+			}
+		})
+	}
+}
+
+func TestValidateExpected(t *testing.T) {
+	if err := validateExpected(map[string][]string{
+		"server-a": {"SER1", "SER2"},
+		"server-b": {"SER3"},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := validateExpected(map[string][]string{
+		"server-a": {"SER1"},
+		"server-b": {"ser1"},
+	}); err == nil {
+		t.Error("expected error for serial number listed under two servers")
+	}
+}
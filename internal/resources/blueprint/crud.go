@@ -6,7 +6,6 @@ package blueprint
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -96,7 +95,7 @@ func (r *BlueprintResource) Read(ctx context.Context, req resource.ReadRequest,
 	defer cancel()
 
 	if err := r.refreshBlueprintAttributes(readCtx, state.ID.ValueString(), &state); err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -221,7 +220,7 @@ func (r *BlueprintResource) Delete(ctx context.Context, req resource.DeleteReque
 	defer cancel()
 
 	if err := r.client.DeleteBlueprint(deleteCtx, state.ID.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			return
 		}
 		resp.Diagnostics.AddError("Failed to delete Blueprint", err.Error())
@@ -6,7 +6,6 @@ package blueprint
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -150,7 +149,7 @@ func (d *BlueprintDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	bp, err := d.client.GetBlueprint(readCtx, blueprintID, nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			resp.Diagnostics.AddError("Blueprint not found", fmt.Sprintf("Blueprint with ID %q not found.", blueprintID))
 			return
 		}
@@ -163,13 +163,13 @@ func filterBlueprintList(blueprints []client.Blueprint, cfg BlueprintListResourc
 	containsName, hasContains := common.NormalizedFilterString(cfg.NameContains)
 	status, hasStatus := common.NormalizedFilterString(cfg.Status)
 
-	exactName = strings.ToLower(exactName)
-	containsName = strings.ToLower(containsName)
-	status = strings.ToLower(status)
+	exactName = common.FoldFilterString(exactName)
+	containsName = common.FoldFilterString(containsName)
+	status = common.FoldFilterString(status)
 
 	for _, blueprint := range blueprints {
-		currentName := strings.ToLower(strings.TrimSpace(blueprint.Attributes.Name))
-		currentStatus := strings.ToLower(strings.TrimSpace(string(blueprint.Attributes.Status)))
+		currentName := common.FoldFilterString(blueprint.Attributes.Name)
+		currentStatus := common.FoldFilterString(string(blueprint.Attributes.Status))
 
 		if hasExact && currentName != exactName {
 			continue
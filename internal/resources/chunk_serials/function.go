@@ -0,0 +1,75 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package chunk_serials
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ChunkSerialsFunction{}
+
+// NewChunkSerialsFunction returns a new function that splits a set of serial numbers into batches.
+func NewChunkSerialsFunction() function.Function {
+	return &ChunkSerialsFunction{}
+}
+
+// ChunkSerialsFunction defines the function implementation.
+type ChunkSerialsFunction struct{}
+
+func (f *ChunkSerialsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "chunk_serials"
+}
+
+func (f *ChunkSerialsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Splits a set of device serial numbers into batches",
+		MarkdownDescription: "Splits a set of device serial numbers into batches of at most `size` serial numbers, such as one batch per " +
+			"`axm_device_management_service` resource. Serial numbers are sorted before batching so the batches are stable " +
+			"across runs, and only the last batch may be smaller than `size`. An empty set returns an empty list.",
+		Parameters: []function.Parameter{
+			function.SetParameter{
+				Name:        "serials",
+				Description: "Device serial numbers to batch.",
+				ElementType: types.StringType,
+			},
+			function.Int64Parameter{
+				Name:        "size",
+				Description: "Maximum number of serial numbers in each batch. Must be at least 1.",
+				Validators: []function.Int64ParameterValidator{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ListType{ElemType: types.StringType},
+		},
+	}
+}
+
+func (f *ChunkSerialsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serials []string
+	var size int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &serials, &size))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, chunkSerials(serials, int(size))))
+}
+
+// chunkSerials sorts serials and splits them into consecutive batches of at most size elements.
+func chunkSerials(serials []string, size int) [][]string {
+	sorted := slices.Sorted(slices.Values(serials))
+	batches := make([][]string, 0, (len(sorted)+size-1)/size)
+	for batch := range slices.Chunk(sorted, size) {
+		batches = append(batches, batch)
+	}
+	return batches
+}
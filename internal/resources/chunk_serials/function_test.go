@@ -0,0 +1,92 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package chunk_serials_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/chunk_serials"
+)
+
+func TestFunctionMetadata(t *testing.T) {
+	f := chunk_serials.NewChunkSerialsFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+
+	if resp.Name != "chunk_serials" {
+		t.Errorf("expected Name %q, got %q", "chunk_serials", resp.Name)
+	}
+}
+
+func TestFunctionDefinition(t *testing.T) {
+	f := chunk_serials.NewChunkSerialsFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+
+	if resp.Definition.Summary == "" {
+		t.Error("expected non-empty Summary")
+	}
+	if len(resp.Definition.Parameters) != 2 {
+		t.Fatalf("expected 2 parameters, got %d", len(resp.Definition.Parameters))
+	}
+	if _, ok := resp.Definition.Parameters[0].(function.SetParameter); !ok {
+		t.Errorf("expected first parameter to be a SetParameter, got %T", resp.Definition.Parameters[0])
+	}
+	if _, ok := resp.Definition.Parameters[1].(function.Int64Parameter); !ok {
+		t.Errorf("expected second parameter to be an Int64Parameter, got %T", resp.Definition.Parameters[1])
+	}
+}
+
+func TestFunctionRun(t *testing.T) {
+	batchType := types.ListType{ElemType: types.StringType}
+	batch := func(serials ...string) attr.Value {
+		values := make([]attr.Value, len(serials))
+		for i, serial := range serials {
+			values[i] = types.StringValue(serial)
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	tests := []struct {
+		name    string
+		serials []string
+		size    int64
+		want    []attr.Value
+	}{
+		{"uneven", []string{"SN5", "SN1", "SN3", "SN2", "SN4"}, 2, []attr.Value{batch("SN1", "SN2"), batch("SN3", "SN4"), batch("SN5")}},
+		{"single_batch", []string{"SN2", "SN1"}, 10, []attr.Value{batch("SN1", "SN2")}},
+		{"empty", nil, 3, []attr.Value{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elements := make([]attr.Value, len(tt.serials))
+			for i, serial := range tt.serials {
+				elements[i] = types.StringValue(serial)
+			}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.SetValueMust(types.StringType, elements),
+					types.Int64Value(tt.size),
+				}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.ListUnknown(batchType))}
+
+			chunk_serials.NewChunkSerialsFunction().Run(context.Background(), req, &resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			want := types.ListValueMust(batchType, tt.want)
+			if got := resp.Result.Value(); !got.Equal(want) {
+				t.Errorf("expected %s, got %s", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client_stats
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &ClientStatsDataSource{}
+
+// NewClientStatsDataSource returns a new data source exposing the API traffic of the current operation.
+func NewClientStatsDataSource() datasource.DataSource {
+	return &ClientStatsDataSource{}
+}
+
+// ClientStatsDataSource defines the data source implementation.
+type ClientStatsDataSource struct {
+	client *client.Client
+}
+
+// ClientStatsDataSourceModel describes the data source data model.
+type ClientStatsDataSourceModel struct {
+	ID                   types.String  `tfsdk:"id"`
+	Requests             types.Int64   `tfsdk:"requests"`
+	Retries              types.Int64   `tfsdk:"retries"`
+	RateLimitWaitSeconds types.Float64 `tfsdk:"rate_limit_wait_seconds"`
+	CacheHits            types.Int64   `tfsdk:"cache_hits"`
+	CacheMisses          types.Int64   `tfsdk:"cache_misses"`
+	CacheHitRate         types.Float64 `tfsdk:"cache_hit_rate"`
+	LastResponseHeaders  types.Map     `tfsdk:"last_response_headers"`
+	LastErrorStatusCode  types.Int64   `tfsdk:"last_error_status_code"`
+	LastErrorHeaders     types.Map     `tfsdk:"last_error_response_headers"`
+}
+
+func (d *ClientStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client_stats"
+}
+
+func (d *ClientStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes the API requests, retries, and rate-limit waits the provider has made during the current Terraform operation, " +
+			"so that pipelines can record API budget consumption per run. Terraform starts the provider afresh for each plan or apply, " +
+			"and the counts cover the requests made up to the point this data source is read. Use depends_on to read it after the " +
+			"resources and data sources whose traffic should be included. The request and correlation IDs Apple returned for the most " +
+			"recent response, and the most recent error response, can be quoted in support cases.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"requests": schema.Int64Attribute{
+				Description: "The number of HTTP requests sent to the API, including retried requests.",
+				Computed:    true,
+			},
+			"retries": schema.Int64Attribute{
+				Description: "The number of requests retried after a rate-limit (429), transient server error, or retryable error code response.",
+				Computed:    true,
+			},
+			"rate_limit_wait_seconds": schema.Float64Attribute{
+				Description: "The total time, in seconds, spent waiting on the Retry-After header of rate-limit (429) responses.",
+				Computed:    true,
+			},
+			"cache_hits": schema.Int64Attribute{
+				Description: "The number of reads answered by the response cache enabled with the provider's cache_ttl, either without a request or after Apple reported the cached response unchanged.",
+				Computed:    true,
+			},
+			"cache_misses": schema.Int64Attribute{
+				Description: "The number of cacheable reads whose response was fetched from the API.",
+				Computed:    true,
+			},
+			"cache_hit_rate": schema.Float64Attribute{
+				Description: "The fraction of cacheable reads answered by the response cache, from 0 to 1. Null when there has been no cacheable read, as when cache_ttl is unset.",
+				Computed:    true,
+			},
+			"last_response_headers": schema.MapAttribute{
+				Description: "Identifying headers of the most recent API response, keyed by lower-case header name: x-request-id, " +
+					"x-apple-request-uuid and x-apple-jingle-correlation-key, when present. Empty before the first request.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"last_error_status_code": schema.Int64Attribute{
+				Description: "The HTTP status code of the most recent API response with a status of 400 or above, including responses that were retried. Null when there has been none.",
+				Computed:    true,
+			},
+			"last_error_response_headers": schema.MapAttribute{
+				Description: "Identifying headers of the most recent API response with a status of 400 or above, in the same form as last_response_headers. Empty when there has been none.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ClientStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *ClientStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClientStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stats := d.client.Stats()
+	data.ID = types.StringValue("client_stats")
+	data.Requests = types.Int64Value(stats.Requests)
+	data.Retries = types.Int64Value(stats.Retries)
+	data.RateLimitWaitSeconds = types.Float64Value(stats.RateLimitWait.Seconds())
+	data.CacheHits = types.Int64Value(stats.CacheHits)
+	data.CacheMisses = types.Int64Value(stats.CacheMisses)
+	data.CacheHitRate = types.Float64Null()
+	if reads := stats.CacheHits + stats.CacheMisses; reads > 0 {
+		data.CacheHitRate = types.Float64Value(float64(stats.CacheHits) / float64(reads))
+	}
+
+	last, lastError := d.client.LastResponseIDs()
+	data.LastErrorStatusCode = types.Int64Null()
+	if lastError != nil {
+		data.LastErrorStatusCode = types.Int64Value(int64(lastError.StatusCode))
+	}
+	var diags diag.Diagnostics
+	data.LastResponseHeaders, diags = responseHeadersValue(ctx, last)
+	resp.Diagnostics.Append(diags...)
+	data.LastErrorHeaders, diags = responseHeadersValue(ctx, lastError)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read client stats", map[string]any{
+		"requests":                stats.Requests,
+		"retries":                 stats.Retries,
+		"rate_limit_wait_seconds": stats.RateLimitWait.Seconds(),
+		"cache_hits":              stats.CacheHits,
+		"cache_misses":            stats.CacheMisses,
+		"last_error_status_code":  data.LastErrorStatusCode.ValueInt64(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// responseHeadersValue returns the identifying headers of ids as a map, empty when ids is nil.
+func responseHeadersValue(ctx context.Context, ids *client.ResponseIDs) (types.Map, diag.Diagnostics) {
+	headers := map[string]string{}
+	if ids != nil {
+		headers = ids.Headers
+	}
+	return types.MapValueFrom(ctx, types.StringType, headers)
+}
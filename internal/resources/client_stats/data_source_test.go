@@ -0,0 +1,92 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client_stats_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/client_stats"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestClientStatsDataSourceMetadata(t *testing.T) {
+	ds := client_stats.NewClientStatsDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_client_stats" {
+		t.Errorf("expected TypeName %q, got %q", "axm_client_stats", resp.TypeName)
+	}
+}
+
+func TestClientStatsDataSourceSchema(t *testing.T) {
+	ds := client_stats.NewClientStatsDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	for _, name := range []string{"id", "requests", "retries", "rate_limit_wait_seconds", "cache_hits", "cache_misses", "cache_hit_rate", "last_response_headers", "last_error_status_code", "last_error_response_headers"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("attribute %q not found", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected %q to be Computed", name)
+		}
+	}
+}
+
+func TestAccClientStatsDataSource(t *testing.T) {
+	testAccPreCheck(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "axm_organization_devices" "test" {}
+
+data "axm_client_stats" "test" {
+  depends_on = [data.axm_organization_devices.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_client_stats.test", "id", "client_stats"),
+					resource.TestCheckResourceAttrSet("data.axm_client_stats.test", "requests"),
+					resource.TestCheckResourceAttrSet("data.axm_client_stats.test", "retries"),
+					resource.TestCheckResourceAttrSet("data.axm_client_stats.test", "rate_limit_wait_seconds"),
+				),
+			},
+		},
+	})
+}
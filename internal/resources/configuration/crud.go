@@ -6,7 +6,6 @@ package configuration
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -97,7 +96,7 @@ func (r *ConfigurationResource) Read(ctx context.Context, req resource.ReadReque
 
 	configuration, err := r.client.GetConfiguration(readCtx, state.ID.ValueString(), nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -213,7 +212,7 @@ func (r *ConfigurationResource) Delete(ctx context.Context, req resource.DeleteR
 	defer cancel()
 
 	if err := r.client.DeleteConfiguration(deleteCtx, state.ID.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			return
 		}
 		resp.Diagnostics.AddError("Failed to delete Configuration", err.Error())
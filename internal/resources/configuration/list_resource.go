@@ -157,11 +157,11 @@ func filterConfigurationList(configurations []client.Configuration, cfg Configur
 	exactName, hasExact := common.NormalizedFilterString(cfg.Name)
 	containsName, hasContains := common.NormalizedFilterString(cfg.NameContains)
 
-	exactName = strings.ToLower(exactName)
-	containsName = strings.ToLower(containsName)
+	exactName = common.FoldFilterString(exactName)
+	containsName = common.FoldFilterString(containsName)
 
 	for _, configuration := range configurations {
-		currentName := strings.ToLower(strings.TrimSpace(configuration.Attributes.Name))
+		currentName := common.FoldFilterString(configuration.Attributes.Name)
 
 		if hasExact && currentName != exactName {
 			continue
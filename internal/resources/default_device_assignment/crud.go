@@ -5,8 +5,8 @@ package default_device_assignment
 
 import (
 	"context"
-	"fmt"
-	"net/url"
+	"maps"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -57,17 +57,15 @@ func (r *DefaultDeviceAssignmentResource) Read(ctx context.Context, req resource
 		return
 	}
 
-	// GET each referenced server and build the current family → serverID map.
-	current := make(map[string]string) // family constant → server ID
-	for id := range serverIDs {
-		srv, err := r.client.GetDeviceManagementService(ctx, id, url.Values{})
-		if err != nil {
-			resp.Diagnostics.AddError(fmt.Sprintf("Failed to read MDM server %s", id), err.Error())
-			return
-		}
-		for _, family := range srv.Attributes.DefaultProductFamilies {
-			current[string(family)] = id
-		}
+	assignments, err := r.client.GetDefaultDeviceAssignments(ctx, slices.Sorted(maps.Keys(serverIDs)))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read MDM servers", err.Error())
+		return
+	}
+
+	current := make(map[string]string, len(assignments))
+	for family, serverID := range assignments {
+		current[string(family)] = serverID
 	}
 
 	// Reconcile state: if a family field has a server ID but that server no longer holds
@@ -114,95 +112,18 @@ func (r *DefaultDeviceAssignmentResource) Update(ctx context.Context, req resour
 func (r *DefaultDeviceAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 }
 
-// applyAssignments computes the required PATCHes and executes them in the correct order.
-// Phase A: reduce/clear servers that are losing families.
-// Phase B: set servers that are gaining families.
+// applyAssignments moves the default server designations from state to plan.
 func (r *DefaultDeviceAssignmentResource) applyAssignments(ctx context.Context, state, plan DefaultDeviceAssignmentModel) error {
-	// Build family → server maps for state and plan.
-	stateMap := familyServerMap(state)
-	planMap := familyServerMap(plan)
-
-	// Per-server family sets for phase A (servers that need to be reduced/cleared).
-	// A server enters phase A when it loses at least one family.
-	// We compute its full desired set (which may be empty or smaller) and send one PATCH.
-	phaseA := make(map[string][]client.MdmServerProductFamily) // serverID → new desired families (after removals)
-	phaseB := make(map[string][]client.MdmServerProductFamily) // serverID → new desired families (gains)
-
-	allFamilies := []client.MdmServerProductFamily{"APPLE_TV", "VISION", "IPAD", "IPHONE", "IPOD", "MAC", "WATCH"}
-
-	// For each server currently holding families, compute what it should hold after the plan.
-	serversLosingFamilies := make(map[string]bool)
-	for _, family := range allFamilies {
-		oldServer := stateMap[string(family)]
-		newServer := planMap[string(family)]
-		if oldServer != "" && oldServer != newServer {
-			serversLosingFamilies[oldServer] = true
-		}
-	}
-
-	for server := range serversLosingFamilies {
-		var keep []client.MdmServerProductFamily
-		for _, family := range allFamilies {
-			if planMap[string(family)] == server {
-				keep = append(keep, family)
-			}
-		}
-		phaseA[server] = keep // may be nil (clear all)
-	}
-
-	// For each server gaining families, collect its full desired set.
-	serversGainingFamilies := make(map[string]bool)
-	for _, family := range allFamilies {
-		oldServer := stateMap[string(family)]
-		newServer := planMap[string(family)]
-		if newServer != "" && newServer != oldServer {
-			serversGainingFamilies[newServer] = true
-		}
-	}
-
-	for server := range serversGainingFamilies {
-		var gain []client.MdmServerProductFamily
-		for _, family := range allFamilies {
-			if planMap[string(family)] == server {
-				gain = append(gain, family)
-			}
-		}
-		phaseB[server] = gain
-	}
-
-	// Phase A: clear or reduce servers losing families.
-	for serverID, families := range phaseA {
-		if err := r.setFamilies(ctx, serverID, families); err != nil {
-			return fmt.Errorf("phase A PATCH server %s: %w", serverID, err)
-		}
-	}
-
-	// Phase B: set servers gaining families.
-	for serverID, families := range phaseB {
-		if err := r.setFamilies(ctx, serverID, families); err != nil {
-			return fmt.Errorf("phase B PATCH server %s: %w", serverID, err)
-		}
-	}
-
-	return nil
+	return r.client.ApplyDefaultDeviceAssignments(ctx, defaultAssignments(state), defaultAssignments(plan))
 }
 
-// setFamilies sends a single PATCH for a server. Empty slice clears all families.
-func (r *DefaultDeviceAssignmentResource) setFamilies(ctx context.Context, serverID string, families []client.MdmServerProductFamily) error {
-	if len(families) == 0 {
-		_, err := r.client.ClearDeviceManagementServiceDefaultFamilies(ctx, serverID)
-		return err
-	}
-	_, err := r.client.UpdateDeviceManagementService(ctx, client.MdmServerUpdateRequest{
-		Data: client.MdmServerUpdateRequestData{
-			Type: "mdmServers",
-			ID:   serverID,
-			Attributes: client.MdmServerUpdateAttributes{
-				DefaultProductFamilies: families,
-			},
-		},
-	})
-	return err
+// defaultAssignments converts the family fields to the client representation.
+func defaultAssignments(data DefaultDeviceAssignmentModel) client.DefaultDeviceAssignments {
+	assignments := make(client.DefaultDeviceAssignments)
+	for family, serverID := range familyServerMap(data) {
+		assignments[client.MdmServerProductFamily(family)] = serverID
+	}
+	return assignments
 }
 
 // familyServerMap builds a map from Apple family constant to MDM server ID from state/plan.
@@ -0,0 +1,339 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_management_service"
+)
+
+// Create assigns the device to the configured MDM server, unless it is already assigned to it.
+func (r *DeviceAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var data DeviceAssignmentModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout := defaultCreateTimeout
+	if !data.Timeouts.IsNull() && !data.Timeouts.IsUnknown() {
+		configuredTimeout, timeoutDiags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+		resp.Diagnostics.Append(timeoutDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createTimeout = configuredTimeout
+	}
+
+	createCtx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	createCtx = common.WithRetryOverrides(createCtx, data.Retry)
+
+	identifier := strings.TrimSpace(data.DeviceID.ValueString())
+	deviceID, err := r.resolveDeviceID(createCtx, identifier)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve device identifier", err.Error())
+		return
+	}
+	if deviceID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("device_id"),
+			"Device not found",
+			fmt.Sprintf("No device with the serial number or device ID %q was found in the organization.", identifier),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(deviceID)
+	r.assign(createCtx, deviceID, data.ServerID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, deviceAssignmentIdentityModel{
+			DeviceID: data.DeviceID,
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Created device assignment", map[string]any{
+		"device_id":     r.client.RedactSerial(deviceID),
+		"mdm_server_id": data.ServerID.ValueString(),
+	})
+
+	data.Timeouts = ensureDeviceAssignmentTimeouts(data.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the server the device is assigned to. The resource is removed from state when
+// the device is no longer assigned to any server or no longer belongs to the organization.
+func (r *DeviceAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var data DeviceAssignmentModel
+
+	if req.State.Raw.IsNull() {
+		if req.Identity == nil {
+			resp.Diagnostics.AddError(
+				"Missing resource identity",
+				"Terraform requested a refresh for this resource without any prior state or identity information.",
+			)
+			return
+		}
+
+		var identity deviceAssignmentIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if identity.DeviceID.IsNull() || identity.DeviceID.IsUnknown() || identity.DeviceID.ValueString() == "" {
+			resp.Diagnostics.AddError(
+				"Missing device ID",
+				"The resource identity did not include a 'device_id' attribute.",
+			)
+			return
+		}
+
+		data.DeviceID = identity.DeviceID
+	} else {
+		resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+	readCtx = common.WithRetryOverrides(readCtx, data.Retry)
+
+	deviceID := data.ID.ValueString()
+	if deviceID == "" {
+		var err error
+		deviceID, err = r.resolveDeviceID(readCtx, strings.TrimSpace(data.DeviceID.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to resolve device identifier", err.Error())
+			return
+		}
+		if deviceID == "" {
+			resp.Diagnostics.AddError(
+				"Device not found",
+				fmt.Sprintf("No device with the serial number or device ID %q was found in the organization.", data.DeviceID.ValueString()),
+			)
+			return
+		}
+	}
+
+	assigned, err := r.client.GetOrgDeviceAssignedServerID(readCtx, deviceID)
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read assigned server", err.Error())
+		return
+	}
+	if assigned.ID == "" {
+		tflog.Info(ctx, "Device is no longer assigned to an MDM server; removing from state", map[string]any{
+			"device_id": r.client.RedactSerial(deviceID),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(deviceID)
+	data.ServerID = types.StringValue(assigned.ID)
+
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, deviceAssignmentIdentityModel{
+			DeviceID: data.DeviceID,
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.Timeouts = ensureDeviceAssignmentTimeouts(data.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update assigns the device to the newly configured MDM server.
+func (r *DeviceAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var plan, state DeviceAssignmentModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout := defaultUpdateTimeout
+	if !plan.Timeouts.IsNull() && !plan.Timeouts.IsUnknown() {
+		configuredTimeout, timeoutDiags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+		resp.Diagnostics.Append(timeoutDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		updateTimeout = configuredTimeout
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	updateCtx = common.WithRetryOverrides(updateCtx, plan.Retry)
+
+	plan.ID = state.ID
+	if !plan.ServerID.Equal(state.ServerID) {
+		r.assign(updateCtx, plan.ID.ValueString(), plan.ServerID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, deviceAssignmentIdentityModel{
+			DeviceID: plan.DeviceID,
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Updated device assignment", map[string]any{
+		"device_id":           r.client.RedactSerial(plan.ID.ValueString()),
+		"mdm_server_id":       plan.ServerID.ValueString(),
+		"prior_mdm_server_id": state.ServerID.ValueString(),
+	})
+
+	plan.Timeouts = ensureDeviceAssignmentTimeouts(plan.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete unassigns the device, unless it has since been assigned to a different server or
+// left the organization.
+func (r *DeviceAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var data DeviceAssignmentModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout := defaultDeleteTimeout
+	if !data.Timeouts.IsNull() && !data.Timeouts.IsUnknown() {
+		configuredTimeout, timeoutDiags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+		resp.Diagnostics.Append(timeoutDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		deleteTimeout = configuredTimeout
+	}
+
+	deleteCtx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	deleteCtx = common.WithRetryOverrides(deleteCtx, data.Retry)
+
+	deviceID, serverID := data.ID.ValueString(), data.ServerID.ValueString()
+	assigned, err := r.client.GetOrgDeviceAssignedServerID(deleteCtx, deviceID)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read assigned server before unassignment", err.Error())
+		return
+	}
+	if assigned.ID != serverID {
+		tflog.Info(ctx, "Device is no longer assigned to the MDM server in state; skipping unassignment", map[string]any{
+			"device_id":              r.client.RedactSerial(deviceID),
+			"mdm_server_id":          serverID,
+			"assigned_mdm_server_id": assigned.ID,
+		})
+		return
+	}
+
+	if _, err := device_management_service.RunDeviceActivity(deleteCtx, r.client, serverID, []string{deviceID}, false, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError("Failed to unassign device", err.Error())
+		return
+	}
+	common.ReleaseSerials(deleteCtx, r.client, []string{deviceID}, &resp.Diagnostics)
+}
+
+// assign claims deviceID in the serial lock backend and submits an ASSIGN_DEVICES activity for it
+// unless the device is already assigned to serverID, and reports an error when the activity could
+// not find the device.
+func (r *DeviceAssignmentResource) assign(ctx context.Context, deviceID, serverID string, diags *diag.Diagnostics) {
+	if err := r.client.ClaimSerials(ctx, []string{deviceID}); err != nil {
+		diags.AddError("Failed to claim device", err.Error())
+		return
+	}
+
+	assigned, err := r.client.GetOrgDeviceAssignedServerID(ctx, deviceID)
+	if err != nil {
+		diags.AddError("Failed to read assigned server", err.Error())
+		return
+	}
+	if assigned.ID == serverID {
+		tflog.Debug(ctx, "Device already assigned to the MDM server; no activity submitted", map[string]any{
+			"device_id":     r.client.RedactSerial(deviceID),
+			"mdm_server_id": serverID,
+		})
+		return
+	}
+
+	notFound, err := device_management_service.RunDeviceActivity(ctx, r.client, serverID, []string{deviceID}, true, diags)
+	if err != nil {
+		diags.AddError("Failed to assign device", err.Error())
+		return
+	}
+	if len(notFound) > 0 {
+		diags.AddError(
+			"Device not found",
+			fmt.Sprintf("Device %s is no longer in the organization and could not be assigned to MDM server %s.", deviceID, serverID),
+		)
+	}
+}
+
+// resolveDeviceID returns the canonical organization device ID for a serial number or device
+// ID, or an empty string when no such device is in the organization. The device is read
+// directly first, and the inventory is searched only when that fails.
+func (r *DeviceAssignmentResource) resolveDeviceID(ctx context.Context, identifier string) (string, error) {
+	device, err := r.client.GetOrgDevice(ctx, identifier, url.Values{"fields[orgDevices]": {"serialNumber"}})
+	if err == nil {
+		return device.ID, nil
+	}
+	if !client.IsNotFound(err) {
+		return "", err
+	}
+
+	resolved, err := r.client.ResolveOrgDeviceIDs(ctx, []string{identifier})
+	if err != nil {
+		return "", err
+	}
+	return resolved[identifier], nil
+}
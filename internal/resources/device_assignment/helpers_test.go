@@ -0,0 +1,28 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollectDeviceAssignments(t *testing.T) {
+	got := collectDeviceAssignments(
+		[]string{"SERVER1", "SERVER2"},
+		[][]string{{"SN003", "SN001", ""}, {"SN002", "SN001"}},
+	)
+	want := []deviceAssignment{
+		{deviceID: "SN001", serverID: "SERVER1"},
+		{deviceID: "SN002", serverID: "SERVER2"},
+		{deviceID: "SN003", serverID: "SERVER1"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := collectDeviceAssignments(nil, nil); len(got) != 0 {
+		t.Errorf("expected no assignments, got %v", got)
+	}
+}
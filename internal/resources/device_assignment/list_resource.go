@@ -0,0 +1,188 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ list.ListResource = &DeviceAssignmentListResource{}
+var _ list.ListResourceWithConfigure = &DeviceAssignmentListResource{}
+
+// NewDeviceAssignmentListResource returns a new list resource for device assignments.
+func NewDeviceAssignmentListResource() list.ListResource {
+	return &DeviceAssignmentListResource{}
+}
+
+// DeviceAssignmentListResource implements terraform query list support for the
+// device_assignment resource type.
+type DeviceAssignmentListResource struct {
+	client *client.Client
+}
+
+// deviceAssignment pairs a device with the MDM server it is assigned to.
+type deviceAssignment struct {
+	deviceID string
+	serverID string
+}
+
+func (r *DeviceAssignmentListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_assignment"
+}
+
+func (r *DeviceAssignmentListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "List")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.client = c
+}
+
+func (r *DeviceAssignmentListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Searches for devices assigned to Apple Business Manager device management services. Each result carries the " +
+			"resource identity, and the full resource state when include_resource is set, so that terraform query -generate-config-out " +
+			"can generate import blocks and configuration for managing existing assignments device by device.",
+		Attributes: map[string]listschema.Attribute{
+			"server_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Limits results to the devices assigned to this MDM server. Defaults to every server in the organization.",
+			},
+		},
+	}
+}
+
+func (r *DeviceAssignmentListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"Unconfigured Provider",
+				"The provider has not been configured yet. Re-run the command after `terraform init` has completed successfully.",
+			),
+		})
+		return
+	}
+
+	var config DeviceAssignmentListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var serverIDs []string
+	if serverID, ok := common.NormalizedFilterString(config.ServerID); ok {
+		serverIDs = []string{serverID}
+	} else {
+		servers, err := r.client.GetDeviceManagementServices(ctx, nil)
+		if err != nil {
+			stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Unable to list device management services",
+					err.Error(),
+				),
+			})
+			return
+		}
+		for _, server := range servers {
+			serverIDs = append(serverIDs, server.ID)
+		}
+	}
+
+	serials := make([][]string, len(serverIDs))
+	err := client.ForEachConcurrent(ctx, r.client.MaxConcurrency(), len(serverIDs), func(ctx context.Context, i int) error {
+		var err error
+		serials[i], err = r.client.GetDeviceManagementServiceSerialNumbers(ctx, serverIDs[i])
+		return err
+	})
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"Unable to read device assignments",
+				err.Error(),
+			),
+		})
+		return
+	}
+
+	assignments := collectDeviceAssignments(serverIDs, serials)
+
+	if req.Limit > 0 && req.Limit < int64(len(assignments)) {
+		assignments = assignments[:req.Limit]
+	}
+
+	results := make([]list.ListResult, 0, len(assignments))
+	for _, assignment := range assignments {
+		result := req.NewListResult(ctx)
+		result.DisplayName = assignment.deviceID
+		identity := deviceAssignmentIdentityModel{
+			DeviceID: types.StringValue(assignment.deviceID),
+		}
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+		if req.IncludeResource {
+			state := DeviceAssignmentModel{
+				ID:       types.StringValue(assignment.deviceID),
+				DeviceID: types.StringValue(assignment.deviceID),
+				ServerID: types.StringValue(assignment.serverID),
+				Timeouts: newDeviceAssignmentTimeoutsNullValue(),
+			}
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, state)...)
+		}
+
+		results = append(results, result)
+	}
+
+	tflog.Debug(ctx, "Listed device assignments", map[string]any{
+		"requested_limit": req.Limit,
+		"returned":        len(results),
+		"servers":         len(serverIDs),
+		"filters": map[string]string{
+			"server_id": config.ServerID.ValueString(),
+		},
+	})
+
+	if len(results) == 0 {
+		stream.Results = list.NoListResults
+		return
+	}
+
+	stream.Results = slices.Values(results)
+}
+
+// collectDeviceAssignments pairs each device with the server it was listed under, ordered by
+// device ID. serials[i] holds the devices assigned to serverIDs[i]. A device listed under more
+// than one server is reported once, under the first.
+func collectDeviceAssignments(serverIDs []string, serials [][]string) []deviceAssignment {
+	seen := make(map[string]bool)
+	var assignments []deviceAssignment
+	for i, serverID := range serverIDs {
+		for _, deviceID := range serials[i] {
+			if deviceID == "" || seen[deviceID] {
+				continue
+			}
+			seen[deviceID] = true
+			assignments = append(assignments, deviceAssignment{deviceID: deviceID, serverID: serverID})
+		}
+	}
+	slices.SortFunc(assignments, func(a, b deviceAssignment) int {
+		return strings.Compare(a.deviceID, b.deviceID)
+	})
+	return assignments
+}
@@ -0,0 +1,31 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+// deviceAssignmentIdentityModel captures the resource identity of a single device assignment,
+// shared between resource CRUD and terraform query list support.
+type deviceAssignmentIdentityModel struct {
+	DeviceID types.String `tfsdk:"device_id"`
+}
+
+// DeviceAssignmentModel describes the Terraform state for the assignment of one device to an MDM server.
+type DeviceAssignmentModel struct {
+	ID       types.String       `tfsdk:"id"`
+	DeviceID types.String       `tfsdk:"device_id"`
+	ServerID types.String       `tfsdk:"server_id"`
+	Retry    *common.RetryModel `tfsdk:"retry"`
+	Timeouts timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// DeviceAssignmentListResourceModel captures filters supported by the device assignment list query.
+type DeviceAssignmentListResourceModel struct {
+	ServerID types.String `tfsdk:"server_id"`
+}
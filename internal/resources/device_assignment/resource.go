@@ -0,0 +1,145 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ resource.Resource = &DeviceAssignmentResource{}
+var _ resource.ResourceWithIdentity = &DeviceAssignmentResource{}
+var _ resource.ResourceWithImportState = &DeviceAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &DeviceAssignmentResource{}
+
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// NewDeviceAssignmentResource returns a new resource for managing the MDM server assignment of a single device.
+func NewDeviceAssignmentResource() resource.Resource {
+	return &DeviceAssignmentResource{}
+}
+
+// DeviceAssignmentResource implements the Terraform resource for the assignment of one device
+// to an MDM server. Its activities are submitted and audited by the device management service
+// resource's activity runner, so that both report them the same way.
+type DeviceAssignmentResource struct {
+	client *client.Client
+}
+
+func (r *DeviceAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_assignment"
+}
+
+// Schema defines the schema for the resource.
+func (r *DeviceAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the MDM server assignment of a single device, so that assignments can be managed device by device with for_each " +
+			"instead of modelling a server's entire device set. Creating the resource submits an ASSIGN_DEVICES activity, changing server_id " +
+			"assigns the device to the new server, and destroying it submits an UNASSIGN_DEVICES activity. Drift is detected from the " +
+			"device's assigned server relationship. Do not also list the device in the device_ids of an axm_device_management_service resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The canonical organization device ID of the assigned device.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_id": schema.StringAttribute{
+				Required: true,
+				Description: "The device to assign, given as a serial number or an opaque organization device ID. " +
+					"State keeps the form used in configuration. Changing it replaces the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the MDM server to assign the device to. Changing it assigns the device to the new server without unassigning it first.",
+			},
+			"retry": common.RetryAttribute(),
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *DeviceAssignmentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"device_id": identityschema.StringAttribute{
+				Description:       "Serial number or organization device ID of the assigned device.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *DeviceAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Resource")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.client = c
+}
+
+// ImportState imports an assignment by the device's serial number or organization device ID.
+// The server the device is currently assigned to is read during the following refresh.
+func (r *DeviceAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("device_id"), path.Root("device_id"), req, resp)
+}
+
+// ModifyPlan fails the plan when the serial lock backend records the device as managed by
+// another workspace.
+func (r *DeviceAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan DeviceAssignmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := plan.ID
+	if deviceID.IsNull() || deviceID.IsUnknown() {
+		deviceID = plan.DeviceID
+	}
+	if deviceID.IsNull() || deviceID.IsUnknown() {
+		return
+	}
+
+	conflicts, err := r.client.SerialLockConflicts(ctx, []string{strings.TrimSpace(deviceID.ValueString())})
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("device_id"), "Failed to check serial locks", err.Error())
+		return
+	}
+	if len(conflicts) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("device_id"), "Device managed by another workspace", common.SerialLockConflictDetail(conflicts))
+	}
+}
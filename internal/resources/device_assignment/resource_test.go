@@ -0,0 +1,197 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_assignment"
+)
+
+func TestDeviceAssignmentResourceMetadata(t *testing.T) {
+	r := device_assignment.NewDeviceAssignmentResource()
+	resp := tfresource.MetadataResponse{}
+	r.Metadata(context.Background(), tfresource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_device_assignment" {
+		t.Errorf("expected TypeName %q, got %q", "axm_device_assignment", resp.TypeName)
+	}
+}
+
+func TestDeviceAssignmentResourceSchema(t *testing.T) {
+	r := device_assignment.NewDeviceAssignmentResource()
+	resp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	tests := []struct {
+		name     string
+		required bool
+		optional bool
+		computed bool
+	}{
+		{"id", false, false, true},
+		{"device_id", true, false, false},
+		{"server_id", true, false, false},
+		{"retry", false, true, false},
+		{"timeouts", false, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, ok := resp.Schema.Attributes[tt.name]
+			if !ok {
+				t.Fatalf("attribute %q not found in schema", tt.name)
+			}
+			if attr.IsRequired() != tt.required {
+				t.Errorf("expected Required=%v, got %v", tt.required, attr.IsRequired())
+			}
+			if attr.IsOptional() != tt.optional {
+				t.Errorf("expected Optional=%v, got %v", tt.optional, attr.IsOptional())
+			}
+			if attr.IsComputed() != tt.computed {
+				t.Errorf("expected Computed=%v, got %v", tt.computed, attr.IsComputed())
+			}
+		})
+	}
+
+	if len(resp.Schema.Attributes) != len(tests) {
+		t.Errorf("expected %d attributes, got %d", len(tests), len(resp.Schema.Attributes))
+	}
+}
+
+func TestDeviceAssignmentResourceIdentitySchema(t *testing.T) {
+	r := device_assignment.NewDeviceAssignmentResource()
+
+	ri, ok := r.(tfresource.ResourceWithIdentity)
+	if !ok {
+		t.Fatal("resource does not implement ResourceWithIdentity")
+	}
+
+	resp := tfresource.IdentitySchemaResponse{}
+	ri.IdentitySchema(context.Background(), tfresource.IdentitySchemaRequest{}, &resp)
+
+	attr, ok := resp.IdentitySchema.Attributes["device_id"].(identityschema.StringAttribute)
+	if !ok {
+		t.Fatal("identity schema missing 'device_id' string attribute")
+	}
+	if !attr.RequiredForImport {
+		t.Error("expected identity 'device_id' to have RequiredForImport=true")
+	}
+}
+
+func TestDeviceAssignmentImportBySerial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/orgDevices/C02XXXXXXXXX":
+			_, _ = io.WriteString(w, `{"data": {"type": "orgDevices", "id": "C02XXXXXXXXX", "attributes": {"serialNumber": "C02XXXXXXXXX"}}}`)
+		case "/v1/orgDevices/C02XXXXXXXXX/relationships/assignedServer":
+			_, _ = io.WriteString(w, `{"data": {"type": "mdmServers", "id": "SERVER1"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	c, err := client.NewClientWithAccessToken(server.URL, "business.api", "token", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	r := device_assignment.NewDeviceAssignmentResource()
+	r.(tfresource.ResourceWithConfigure).Configure(ctx, tfresource.ConfigureRequest{ProviderData: c}, &tfresource.ConfigureResponse{})
+
+	schemaResp := tfresource.SchemaResponse{}
+	r.Schema(ctx, tfresource.SchemaRequest{}, &schemaResp)
+	identityResp := tfresource.IdentitySchemaResponse{}
+	r.(tfresource.ResourceWithIdentity).IdentitySchema(ctx, tfresource.IdentitySchemaRequest{}, &identityResp)
+
+	nullState := func() tfsdk.State {
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	}
+	nullIdentity := func() *tfsdk.ResourceIdentity {
+		return &tfsdk.ResourceIdentity{Schema: identityResp.IdentitySchema, Raw: tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil)}
+	}
+	identityFor := func(deviceID string) *tfsdk.ResourceIdentity {
+		identity := nullIdentity()
+		identity.Raw = tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"device_id": tftypes.NewValue(tftypes.String, deviceID),
+		})
+		return identity
+	}
+
+	tests := []struct {
+		name string
+		req  tfresource.ImportStateRequest
+	}{
+		{name: "string_id", req: tfresource.ImportStateRequest{ID: "C02XXXXXXXXX"}},
+		{name: "identity", req: tfresource.ImportStateRequest{Identity: identityFor("C02XXXXXXXXX")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importResp := tfresource.ImportStateResponse{State: nullState(), Identity: nullIdentity()}
+			r.(tfresource.ResourceWithImportState).ImportState(ctx, tt.req, &importResp)
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf("ImportState returned diagnostics: %v", importResp.Diagnostics)
+			}
+
+			readResp := tfresource.ReadResponse{State: importResp.State, Identity: importResp.Identity}
+			r.Read(ctx, tfresource.ReadRequest{State: importResp.State, Identity: importResp.Identity}, &readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("Read returned diagnostics: %v", readResp.Diagnostics)
+			}
+
+			for attribute, want := range map[string]string{"id": "C02XXXXXXXXX", "device_id": "C02XXXXXXXXX", "server_id": "SERVER1"} {
+				var got string
+				readResp.State.GetAttribute(ctx, path.Root(attribute), &got)
+				if got != want {
+					t.Errorf("expected %s %q, got %q", attribute, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceAssignmentListResource(t *testing.T) {
+	lr := device_assignment.NewDeviceAssignmentListResource()
+
+	metadata := tfresource.MetadataResponse{}
+	lr.Metadata(context.Background(), tfresource.MetadataRequest{ProviderTypeName: "axm"}, &metadata)
+	if metadata.TypeName != "axm_device_assignment" {
+		t.Errorf("expected TypeName %q, got %q", "axm_device_assignment", metadata.TypeName)
+	}
+
+	resp := list.ListResourceSchemaResponse{}
+	lr.ListResourceConfigSchema(context.Background(), list.ListResourceSchemaRequest{}, &resp)
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+	attr, ok := resp.Schema.Attributes["server_id"]
+	if !ok {
+		t.Fatal("attribute \"server_id\" not found in schema")
+	}
+	if !attr.IsOptional() {
+		t.Error("expected server_id to be Optional")
+	}
+	if len(resp.Schema.Attributes) != 1 {
+		t.Errorf("expected 1 attribute, got %d", len(resp.Schema.Attributes))
+	}
+}
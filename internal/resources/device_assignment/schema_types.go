@@ -0,0 +1,31 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var deviceAssignmentTimeoutAttributeTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+// newDeviceAssignmentTimeoutsNullValue returns a timeouts.Value with all attributes set to null.
+func newDeviceAssignmentTimeoutsNullValue() timeouts.Value {
+	return ensureDeviceAssignmentTimeouts(timeouts.Value{})
+}
+
+// ensureDeviceAssignmentTimeouts ensures that the timeouts.Value is not null by initializing it
+// with null attributes if necessary.
+func ensureDeviceAssignmentTimeouts(value timeouts.Value) timeouts.Value {
+	if value.IsNull() && !value.IsUnknown() {
+		value.Object = types.ObjectNull(deviceAssignmentTimeoutAttributeTypes)
+	}
+	return value
+}
@@ -0,0 +1,249 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment_eligibility
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &DeviceAssignmentEligibilityDataSource{}
+
+// NewDeviceAssignmentEligibilityDataSource returns a new data source for checking whether
+// devices can be assigned to a device management service.
+func NewDeviceAssignmentEligibilityDataSource() datasource.DataSource {
+	return &DeviceAssignmentEligibilityDataSource{}
+}
+
+// DeviceAssignmentEligibilityDataSource defines the data source implementation.
+type DeviceAssignmentEligibilityDataSource struct {
+	client *client.Client
+}
+
+// DeviceAssignmentEligibilityDataSourceModel describes the data source data model.
+type DeviceAssignmentEligibilityDataSourceModel struct {
+	ID                      types.String             `tfsdk:"id"`
+	Timeouts                timeouts.Value           `tfsdk:"timeouts"`
+	CredentialsProfile      types.String             `tfsdk:"credentials_profile"`
+	ServerID                types.String             `tfsdk:"server_id"`
+	SerialNumbers           types.Set                `tfsdk:"serial_numbers"`
+	PurchaseSourceTypes     types.Set                `tfsdk:"purchase_source_types"`
+	ServerType              types.String             `tfsdk:"server_type"`
+	EligibleSerialNumbers   []types.String           `tfsdk:"eligible_serial_numbers"`
+	IneligibleSerialNumbers []types.String           `tfsdk:"ineligible_serial_numbers"`
+	Devices                 []DeviceEligibilityModel `tfsdk:"devices"`
+}
+
+// DeviceEligibilityModel describes the eligibility of a single device.
+type DeviceEligibilityModel struct {
+	SerialNumber       types.String `tfsdk:"serial_number"`
+	DeviceID           types.String `tfsdk:"device_id"`
+	Eligible           types.Bool   `tfsdk:"eligible"`
+	Reason             types.String `tfsdk:"reason"`
+	Detail             types.String `tfsdk:"detail"`
+	Status             types.String `tfsdk:"status"`
+	PurchaseSourceType types.String `tfsdk:"purchase_source_type"`
+}
+
+func (d *DeviceAssignmentEligibilityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_assignment_eligibility"
+}
+
+func (d *DeviceAssignmentEligibilityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether devices can be assigned to a device management service before assignment resources are created, so that pipelines can filter their input instead of failing partway through an apply. " +
+			"A device is eligible when it is in the organization, has not been released and, when purchase_source_types is set, was acquired from one of them. " +
+			"Serial numbers are looked up in batches with the API's serial number filter, falling back to reading each device when the API rejects it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the data source.",
+				Computed:    true,
+			},
+			"timeouts":            timeouts.Attributes(ctx),
+			"credentials_profile": common.CredentialsProfileAttribute(),
+			"server_id": schema.StringAttribute{
+				Description: "The ID of the device management service the devices would be assigned to. The read fails when it does not exist.",
+				Required:    true,
+			},
+			"serial_numbers": schema.SetAttribute{
+				Description: "The serial numbers of the candidate devices. Case-insensitive.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"purchase_source_types": schema.SetAttribute{
+				Description: "Purchase source types devices must have been acquired from to be eligible, such as APPLE and RESELLER to exclude manually added devices. Valid values are 'APPLE', 'RESELLER' and 'MANUALLY_ADDED'. Defaults to any purchase source.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(client.PurchaseSourceTypeValues()...)),
+				},
+			},
+			"server_type": schema.StringAttribute{
+				Description: "The type of the device management service. Devices assigned to an APPLE_CONFIGURATOR server are not enrolled in MDM.",
+				Computed:    true,
+			},
+			"eligible_serial_numbers": schema.ListAttribute{
+				Description: "The serial numbers of the eligible devices, sorted, as reported by the API.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"ineligible_serial_numbers": schema.ListAttribute{
+				Description: "The serial numbers that are not eligible, sorted, as reported by the API or as configured when no device was found.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"devices": schema.ListNestedAttribute{
+				Description: "The eligibility of each configured serial number, ordered by serial number.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Description: "The serial number, as reported by the API or as configured when no device was found.",
+							Computed:    true,
+						},
+						"device_id": schema.StringAttribute{
+							Description: "The ID of the device, or null when no device was found.",
+							Computed:    true,
+						},
+						"eligible": schema.BoolAttribute{
+							Description: "Whether the device can be assigned to the device management service.",
+							Computed:    true,
+						},
+						"reason": schema.StringAttribute{
+							Description: "Why the device is not eligible, as the sub-status code an assignment activity would report for it: DEVICE_NOT_FOUND, DEVICE_RELEASED or DEVICE_NOT_ELIGIBLE. Null when the device is eligible.",
+							Computed:    true,
+						},
+						"detail": schema.StringAttribute{
+							Description: "A human-readable explanation of reason. Null when the device is eligible.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The device's status. Possible values: 'ASSIGNED', 'UNASSIGNED'. Null when no device was found.",
+							Computed:    true,
+						},
+						"purchase_source_type": schema.StringAttribute{
+							Description: "The type of the purchase source. Possible values: 'APPLE', 'RESELLER', 'MANUALLY_ADDED'. Null when no device was found.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DeviceAssignmentEligibilityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *DeviceAssignmentEligibilityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data DeviceAssignmentEligibilityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	c, profileDiags := common.ClientForProfile(d.client, data.CredentialsProfile)
+	resp.Diagnostics.Append(profileDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverID := data.ServerID.ValueString()
+	srv, err := c.GetDeviceManagementService(readCtx, serverID, nil)
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Device Management Service Not Found",
+				fmt.Sprintf("No device management service with ID %s exists, so no device is eligible for assignment to it.", serverID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read Device Management Service",
+			err.Error(),
+		)
+		return
+	}
+
+	serials := common.SetToStrings(data.SerialNumbers)
+	purchaseSourceTypes := common.SetToStrings(data.PurchaseSourceTypes)
+	slices.Sort(purchaseSourceTypes)
+	params := url.Values{common.OrgDeviceFieldsParam: {common.OrgDeviceFieldsValue("serial_number", "status", "purchase_source_type", "released_from_org_date_time")}}
+
+	found, err := c.GetOrgDevicesBySerial(readCtx, serials, params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Devices",
+			err.Error(),
+		)
+		return
+	}
+
+	results := checkEligibility(serials, found, purchaseSourceTypes)
+	data.ServerType = types.StringValue(srv.Attributes.ServerType)
+	data.EligibleSerialNumbers = []types.String{}
+	data.IneligibleSerialNumbers = []types.String{}
+	data.Devices = make([]DeviceEligibilityModel, 0, len(results))
+	for _, result := range results {
+		if result.eligible() {
+			data.EligibleSerialNumbers = append(data.EligibleSerialNumbers, types.StringValue(result.serial))
+		} else {
+			data.IneligibleSerialNumbers = append(data.IneligibleSerialNumbers, types.StringValue(result.serial))
+		}
+		data.Devices = append(data.Devices, DeviceEligibilityModel{
+			SerialNumber:       types.StringValue(result.serial),
+			DeviceID:           common.OptionalString(result.deviceID),
+			Eligible:           types.BoolValue(result.eligible()),
+			Reason:             common.OptionalString(result.reason),
+			Detail:             common.OptionalString(result.detail),
+			Status:             common.OptionalString(result.status),
+			PurchaseSourceType: common.OptionalString(result.purchaseSourceType),
+		})
+	}
+	data.ID = types.StringValue(serverID)
+
+	tflog.Debug(ctx, "Checked device assignment eligibility", map[string]any{
+		"server_id":        serverID,
+		"requested_count":  len(serials),
+		"eligible_count":   len(data.EligibleSerialNumbers),
+		"ineligible_count": len(data.IneligibleSerialNumbers),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,110 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment_eligibility_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_assignment_eligibility"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestDeviceAssignmentEligibilityDataSourceMetadata(t *testing.T) {
+	ds := device_assignment_eligibility.NewDeviceAssignmentEligibilityDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_device_assignment_eligibility" {
+		t.Errorf("expected TypeName %q, got %q", "axm_device_assignment_eligibility", resp.TypeName)
+	}
+}
+
+func TestDeviceAssignmentEligibilityDataSourceSchema(t *testing.T) {
+	ds := device_assignment_eligibility.NewDeviceAssignmentEligibilityDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	for _, name := range []string{"server_id", "serial_numbers"} {
+		if attr, ok := resp.Schema.Attributes[name]; !ok || !attr.IsRequired() {
+			t.Errorf("expected %q to be Required", name)
+		}
+	}
+	if attr, ok := resp.Schema.Attributes["purchase_source_types"]; !ok || !attr.IsOptional() {
+		t.Error("expected 'purchase_source_types' to be Optional")
+	}
+	for _, name := range []string{"server_type", "eligible_serial_numbers", "ineligible_serial_numbers"} {
+		if attr, ok := resp.Schema.Attributes[name]; !ok || !attr.IsComputed() {
+			t.Errorf("expected %q to be Computed", name)
+		}
+	}
+
+	devicesAttr, ok := resp.Schema.Attributes["devices"].(dsschema.ListNestedAttribute)
+	if !ok {
+		t.Fatal("expected 'devices' to be a ListNestedAttribute")
+	}
+	for _, name := range []string{"serial_number", "device_id", "eligible", "reason", "detail", "status", "purchase_source_type"} {
+		if _, ok := devicesAttr.NestedObject.Attributes[name]; !ok {
+			t.Errorf("nested attribute %q not found", name)
+		}
+	}
+}
+
+func TestAccDeviceAssignmentEligibilityDataSource(t *testing.T) {
+	serverID := os.Getenv("AXM_TEST_SERVER_ID")
+	serial := os.Getenv("AXM_TEST_DEVICE_SERIAL_1")
+	if serverID == "" || serial == "" {
+		t.Skip("AXM_TEST_SERVER_ID and AXM_TEST_DEVICE_SERIAL_1 must be set for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "axm_device_assignment_eligibility" "test" {
+						server_id      = %q
+						serial_numbers = [%q, "NOTAREALSERIAL0"]
+					}
+				`, serverID, serial),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_device_assignment_eligibility.test", "devices.#", "2"),
+					resource.TestCheckResourceAttr("data.axm_device_assignment_eligibility.test", "ineligible_serial_numbers.#", "1"),
+					resource.TestCheckResourceAttr("data.axm_device_assignment_eligibility.test", "ineligible_serial_numbers.0", "NOTAREALSERIAL0"),
+				),
+			},
+		},
+	})
+}
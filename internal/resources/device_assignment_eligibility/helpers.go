@@ -0,0 +1,80 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment_eligibility
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+// Reasons a device is not eligible for assignment. They are the sub-status codes the API
+// reports for the device when an assignment activity is attempted anyway.
+const (
+	reasonNotFound    = "DEVICE_NOT_FOUND"
+	reasonReleased    = "DEVICE_RELEASED"
+	reasonNotEligible = "DEVICE_NOT_ELIGIBLE"
+)
+
+// eligibility is the result of checking one configured serial number.
+type eligibility struct {
+	serial             string
+	deviceID           string
+	status             string
+	purchaseSourceType string
+	reason             string
+	detail             string
+}
+
+// eligible reports whether the device may be assigned.
+func (e eligibility) eligible() bool {
+	return e.reason == ""
+}
+
+// checkEligibility checks each configured serial number, ordered by serial number and without
+// duplicates, against the devices found for them, keyed by upper-cased serial number. A device
+// is eligible when it is in the organization, has not been released and, when
+// purchaseSourceTypes is not empty, was acquired from one of them.
+func checkEligibility(serials []string, found map[string]client.OrgDevice, purchaseSourceTypes []string) []eligibility {
+	sorted := slices.Clone(serials)
+	slices.SortFunc(sorted, func(a, b string) int {
+		return strings.Compare(strings.ToUpper(strings.TrimSpace(a)), strings.ToUpper(strings.TrimSpace(b)))
+	})
+
+	results := make([]eligibility, 0, len(sorted))
+	seen := make(map[string]bool, len(sorted))
+	for _, serial := range sorted {
+		key := strings.ToUpper(strings.TrimSpace(serial))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		device, ok := found[key]
+		if !ok {
+			explanation, _ := client.ExplainSubStatus(reasonNotFound)
+			results = append(results, eligibility{serial: serial, reason: reasonNotFound, detail: explanation})
+			continue
+		}
+
+		result := eligibility{
+			serial:             device.Attributes.SerialNumber,
+			deviceID:           device.ID,
+			status:             string(device.Attributes.Status),
+			purchaseSourceType: string(device.Attributes.PurchaseSourceType),
+		}
+		switch {
+		case device.Attributes.ReleasedFromOrgDateTime != "":
+			result.reason = reasonReleased
+			result.detail = fmt.Sprintf("the device was released from the organization at %s and can no longer be assigned", device.Attributes.ReleasedFromOrgDateTime)
+		case len(purchaseSourceTypes) > 0 && !slices.Contains(purchaseSourceTypes, result.purchaseSourceType):
+			result.reason = reasonNotEligible
+			result.detail = fmt.Sprintf("the device's purchase source type %s is not one of %s", result.purchaseSourceType, strings.Join(purchaseSourceTypes, ", "))
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,61 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package device_assignment_eligibility
+
+import (
+	"testing"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestCheckEligibility(t *testing.T) {
+	found := map[string]client.OrgDevice{
+		"SN001": {ID: "DEV001", Attributes: client.DeviceAttribute{SerialNumber: "SN001", Status: client.OrgDeviceStatusUnassigned, PurchaseSourceType: client.PurchaseSourceTypeApple}},
+		"SN002": {ID: "DEV002", Attributes: client.DeviceAttribute{SerialNumber: "SN002", Status: client.OrgDeviceStatusAssigned, PurchaseSourceType: client.PurchaseSourceTypeManuallyAdded}},
+		"SN003": {ID: "DEV003", Attributes: client.DeviceAttribute{SerialNumber: "SN003", PurchaseSourceType: client.PurchaseSourceTypeReseller, ReleasedFromOrgDateTime: "2026-01-01T00:00:00Z"}},
+	}
+
+	tests := []struct {
+		name                string
+		purchaseSourceTypes []string
+		want                map[string]string
+	}{
+		{
+			name: "any_purchase_source",
+			want: map[string]string{"SN001": "", "SN002": "", "SN003": reasonReleased, "sn404": reasonNotFound},
+		},
+		{
+			name:                "purchase_source_types",
+			purchaseSourceTypes: []string{"APPLE", "RESELLER"},
+			want:                map[string]string{"SN001": "", "SN002": reasonNotEligible, "SN003": reasonReleased, "sn404": reasonNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := checkEligibility([]string{"sn404", "sn002", "SN001", "SN002", "SN003"}, found, tt.purchaseSourceTypes)
+
+			var serials []string
+			for _, result := range results {
+				serials = append(serials, result.serial)
+				if want := tt.want[result.serial]; result.reason != want {
+					t.Errorf("%s: expected reason %q, got %q", result.serial, want, result.reason)
+				}
+				if result.eligible() != (result.reason == "") || (result.reason != "") != (result.detail != "") {
+					t.Errorf("%s: expected a detail exactly when ineligible, got reason %q and detail %q", result.serial, result.reason, result.detail)
+				}
+			}
+			if want := []string{"SN001", "SN002", "SN003", "sn404"}; len(serials) != len(want) {
+				t.Fatalf("expected %v once each, got %v", want, serials)
+			} else {
+				for i := range want {
+					if serials[i] != want[i] {
+						t.Errorf("expected %v in order, got %v", want, serials)
+						break
+					}
+				}
+			}
+		})
+	}
+}
@@ -5,8 +5,9 @@ package device_management_service
 
 import (
 	"context"
-	"strings"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -17,6 +18,8 @@ import (
 
 // Create creates a new MDM server (business scope) and optionally assigns devices to it.
 func (r *DeviceManagementServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
 	var data MdmDeviceAssignmentModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -36,6 +39,7 @@ func (r *DeviceManagementServiceResource) Create(ctx context.Context, req resour
 
 	createCtx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
+	createCtx = common.WithRetryOverrides(createCtx, data.Retry)
 
 	if !r.client.IsBusinessScope() {
 		resp.Diagnostics.AddError(
@@ -53,6 +57,11 @@ func (r *DeviceManagementServiceResource) Create(ctx context.Context, req resour
 		return
 	}
 
+	if data.DryRun.ValueBool() && data.DeviceFilter == nil && len(data.DeviceIDs.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("device_ids"), "Dry run of a new MDM server with devices", dryRunCreateDetail(len(data.DeviceIDs.Elements())))
+		return
+	}
+
 	enableDisown := data.AllowRelease.ValueBoolPointer()
 	attrs := client.MdmServerCreateAttributes{
 		ServerName: data.Name.ValueString(),
@@ -79,21 +88,61 @@ func (r *DeviceManagementServiceResource) Create(ctx context.Context, req resour
 	data.LastConnectedIp = types.StringPointerValue(srv.Attributes.LastConnectedIp)
 	data.CreatedDateTime = types.StringValue(srv.Attributes.CreatedDateTime)
 	data.UpdatedDateTime = types.StringValue(srv.Attributes.UpdatedDateTime)
+	data.SelfLink = common.OptionalString(srv.Links.Self)
 	data.DefaultProductFamilies = common.StringsToList(ctx, srv.Attributes.DefaultProductFamilies)
 	// AllowRelease is not reliably echoed by the create response; keep the plan value.
 	// Read will reconcile on the next refresh if Apple silently ignored it.
 
 	deviceIDs := extractStrings(data.DeviceIDs)
-	if len(deviceIDs) > 0 {
-		activity, err := r.client.AssignDevicesToMDMServer(createCtx, srv.ID, deviceIDs, true)
+	var known []string
+	if family, ok := filterProductFamily(data.DeviceFilter); ok {
+		deviceIDs, err = r.filteredDeviceIDs(createCtx, family)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to assign devices", err.Error())
+			resp.Diagnostics.AddError("Failed to resolve device_filter", err.Error())
 			return
 		}
-		if err := r.waitForActivityCompletion(createCtx, activity.ID, &resp.Diagnostics); err != nil {
-			resp.Diagnostics.AddError("Failed to complete device assignment", err.Error())
+		known = deviceIDs
+	}
+	managed := deviceIDs
+	var assigned []string
+	results := newActivityResults(data.ActivityResultsMaxRows)
+	if len(deviceIDs) > 0 && data.DryRun.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Dry run: device assignment activities were not submitted",
+			dryRunSummary(deviceIDs, nil),
+		)
+		deviceIDs, managed = nil, nil
+	} else if len(deviceIDs) > 0 {
+		if blocksAssignment(srv.Attributes.ServerType, data.AllowNonMdmServer) {
+			resp.Diagnostics.AddError("Device assignment to a non-MDM server", nonMdmServerDetail(srv.Attributes.ServerType, len(deviceIDs)))
+			return
+		}
+		canonicalIDs, err := r.canonicalizeDeviceIDs(createCtx, deviceIDs, known)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to resolve device identifiers", err.Error())
 			return
 		}
+		if err := r.client.ClaimSerials(createCtx, canonicalIDs); err != nil {
+			resp.Diagnostics.AddError("Failed to claim devices", err.Error())
+			return
+		}
+		notFound, err := r.runDeviceActivity(createCtx, srv.ID, canonicalIDs, true, data.ActivityLogPath.ValueString(), common.DurationValue(data.ActivityPollInterval, client.DefaultActivityPollInterval), common.DurationValue(data.BatchDelay, 0), nil, results, &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to assign devices", err.Error())
+			return
+		}
+		managed, assigned = canonicalIDs, withoutDevices(canonicalIDs, notFound)
+		if known != nil {
+			deviceIDs, managed = withoutDevices(deviceIDs, notFound), assigned
+		} else {
+			warnNotFoundKept(notFound, &resp.Diagnostics)
+		}
+		if data.VerifyAfterApply.ValueBool() {
+			if err := r.verifyAssignments(createCtx, srv.ID, assigned); err != nil {
+				resp.Diagnostics.AddError("Post-apply verification failed", err.Error())
+				return
+			}
+		}
 	}
 
 	// Resolve device_ids to a known value — required because it is Optional+Computed and
@@ -104,6 +153,15 @@ func (r *DeviceManagementServiceResource) Create(ctx context.Context, req resour
 		return
 	}
 	data.DeviceIDs = deviceSet
+	data.ActivityResults = results.value(ctx, &resp.Diagnostics)
+	data.LastActivity = results.lastActivity(ctx, types.ObjectNull(lastActivityAttrTypes), &resp.Diagnostics)
+
+	data.ExternallyAddedDevices, data.MissingDevices, diags = assignmentDrift(managed, assigned, true)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setManagedDeviceIDs(ctx, resp.Private, managed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if resp.Identity != nil {
 		resp.Diagnostics.Append(resp.Identity.Set(ctx, deviceManagementServiceIdentityModel{
@@ -116,15 +174,18 @@ func (r *DeviceManagementServiceResource) Create(ctx context.Context, req resour
 
 	tflog.Debug(ctx, "Created MDM server", map[string]any{
 		"mdm_server_id": srv.ID,
-		"device_ids":    deviceIDs,
+		"device_ids":    r.client.RedactSerials(deviceIDs),
 	})
 
 	data.Timeouts = ensureDeviceManagementServiceTimeouts(data.Timeouts)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Read retrieves the current state of the MDM server and its device assignments.
+// Read retrieves the current state of the MDM server and its device assignments, and reconciles
+// the activities an interrupted apply stopped waiting for.
 func (r *DeviceManagementServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
 	var data MdmDeviceAssignmentModel
 
 	if req.State.Raw.IsNull() {
@@ -164,10 +225,11 @@ func (r *DeviceManagementServiceResource) Read(ctx context.Context, req resource
 		return
 	}
 	defer cancel()
+	readCtx = common.WithRetryOverrides(readCtx, data.Retry)
 
 	srv, err := r.client.GetDeviceManagementService(readCtx, data.ID.ValueString(), nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -175,16 +237,7 @@ func (r *DeviceManagementServiceResource) Read(ctx context.Context, req resource
 		return
 	}
 
-	data.Name = types.StringValue(srv.Attributes.ServerName)
-	data.Type = types.StringValue(srv.Attributes.ServerType)
-	data.Status = common.StringPointerToTypesString(srv.Attributes.Status)
-	data.DeviceCount = types.Int64PointerValue(srv.Attributes.DeviceCount)
-	data.LastConnectedDateTime = types.StringPointerValue(srv.Attributes.LastConnectedDateTime)
-	data.LastConnectedIp = types.StringPointerValue(srv.Attributes.LastConnectedIp)
-	data.CreatedDateTime = types.StringValue(srv.Attributes.CreatedDateTime)
-	data.UpdatedDateTime = types.StringValue(srv.Attributes.UpdatedDateTime)
-	data.DefaultProductFamilies = common.StringsToList(ctx, srv.Attributes.DefaultProductFamilies)
-	data.AllowRelease = types.BoolPointerValue(srv.Attributes.EnableMdmDisownFlag)
+	applyServerAttributes(ctx, &data, srv)
 
 	deviceIDs, err := r.client.GetDeviceManagementServiceSerialNumbers(readCtx, data.ID.ValueString())
 	if err != nil {
@@ -192,6 +245,40 @@ func (r *DeviceManagementServiceResource) Read(ctx context.Context, req resource
 		return
 	}
 
+	managed, tracked, privateDiags := managedDeviceIDs(ctx, req.Private)
+	resp.Diagnostics.Append(privateDiags...)
+	pending, pendingDiags := loadPendingActivities(ctx, req.Private)
+	resp.Diagnostics.Append(pendingDiags...)
+	if len(pending) > 0 {
+		managed, pending = r.reconcilePendingActivities(readCtx, managed, pending, &resp.Diagnostics)
+		if tracked {
+			resp.Diagnostics.Append(setManagedDeviceIDs(ctx, resp.Private, managed)...)
+		}
+		resp.Diagnostics.Append(setPendingActivities(ctx, resp.Private, pending)...)
+	}
+	externallyAdded, missing, driftDiags := assignmentDrift(managed, deviceIDs, tracked)
+	resp.Diagnostics.Append(driftDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ExternallyAddedDevices = externallyAdded
+	data.MissingDevices = missing
+	if tracked && !r.client.Features().AuthoritativeAssignments {
+		deviceIDs = withoutDevices(deviceIDs, extractStrings(externallyAdded))
+	}
+	if data.ActivityResults.IsNull() {
+		data.ActivityResults = types.ListNull(types.ObjectType{AttrTypes: activityResultAttrTypes})
+	}
+	if data.LastActivity.IsNull() {
+		data.LastActivity = types.ObjectNull(lastActivityAttrTypes)
+	}
+
+	deviceIDs, err = r.normalizeDeviceIDs(readCtx, extractStrings(data.DeviceIDs), deviceIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve device identifiers", err.Error())
+		return
+	}
+
 	deviceSet, diags := stringsToSet(deviceIDs)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -214,6 +301,8 @@ func (r *DeviceManagementServiceResource) Read(ctx context.Context, req resource
 
 // Update applies changes to the MDM server attributes and device assignments.
 func (r *DeviceManagementServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
 	var plan, state MdmDeviceAssignmentModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -234,6 +323,55 @@ func (r *DeviceManagementServiceResource) Update(ctx context.Context, req resour
 
 	updateCtx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
+	updateCtx = common.WithRetryOverrides(updateCtx, plan.Retry)
+
+	ledger, ledgerDiags := loadActivityLedger(ctx, req.Private)
+	resp.Diagnostics.Append(ledgerDiags...)
+	defer ledger.persist(ctx, resp.Private, &resp.Diagnostics)
+
+	currentDeviceIDs, err := r.client.GetDeviceManagementServiceSerialNumbers(updateCtx, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get current device assignments", err.Error())
+		return
+	}
+
+	planned, known := extractStrings(plan.DeviceIDs), currentDeviceIDs
+	if family, ok := filterProductFamily(plan.DeviceFilter); ok && plan.DeviceIDs.IsUnknown() {
+		matching, err := r.filteredDeviceIDs(updateCtx, family)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to resolve device_filter", err.Error())
+			return
+		}
+		toAdd, _ := diffDeviceIDs(currentDeviceIDs, matching)
+		planned = append(slices.Clone(currentDeviceIDs), toAdd...)
+		known = planned
+	}
+
+	plannedDevices, err := r.canonicalizeDeviceIDs(updateCtx, planned, known)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve device identifiers", err.Error())
+		return
+	}
+	toAssign, toUnassign := diffDeviceIDs(currentDeviceIDs, plannedDevices)
+	var retained []string
+	if !r.client.Features().AuthoritativeAssignments {
+		managed, tracked, privateDiags := managedDeviceIDs(ctx, req.Private)
+		resp.Diagnostics.Append(privateDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if tracked {
+			toUnassign, retained = retainExternallyAdded(currentDeviceIDs, managed, toUnassign)
+		}
+	}
+
+	if !plan.DryRun.ValueBool() && exceedsUnassignLimit(plan.MaxUnassignWithoutConfirmation, plan.ConfirmBulkUnassign, len(toUnassign)) {
+		resp.Diagnostics.AddError(
+			"Bulk unassignment requires confirmation",
+			bulkUnassignDetail(len(toUnassign), plan.MaxUnassignWithoutConfirmation.ValueInt64()),
+		)
+		return
+	}
 
 	if r.client.IsBusinessScope() {
 		serverAttrs := client.MdmServerUpdateAttributes{}
@@ -278,60 +416,67 @@ func (r *DeviceManagementServiceResource) Update(ctx context.Context, req resour
 			plan.LastConnectedIp = types.StringPointerValue(srv.Attributes.LastConnectedIp)
 			plan.CreatedDateTime = types.StringValue(srv.Attributes.CreatedDateTime)
 			plan.UpdatedDateTime = types.StringValue(srv.Attributes.UpdatedDateTime)
+			plan.SelfLink = common.OptionalString(srv.Links.Self)
 			plan.DefaultProductFamilies = common.StringsToList(ctx, srv.Attributes.DefaultProductFamilies)
 			plan.AllowRelease = types.BoolPointerValue(srv.Attributes.EnableMdmDisownFlag)
 		}
 	}
 
-	currentDeviceIDs, err := r.client.GetDeviceManagementServiceSerialNumbers(updateCtx, plan.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to get current device assignments", err.Error())
-		return
+	if plan.DryRun.ValueBool() {
+		if len(toAssign) > 0 || len(toUnassign) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Dry run: device assignment activities were not submitted",
+				dryRunSummary(toAssign, toUnassign),
+			)
+		}
+		toAssign, toUnassign = nil, nil
 	}
 
-	plannedDevices := extractStrings(plan.DeviceIDs)
-	plannedMap := make(map[string]bool, len(plannedDevices))
-	for _, id := range plannedDevices {
-		plannedMap[id] = true
-	}
-	currentMap := make(map[string]bool, len(currentDeviceIDs))
-	for _, id := range currentDeviceIDs {
-		currentMap[id] = true
+	if len(toAssign) > 0 && blocksAssignment(plan.Type.ValueString(), plan.AllowNonMdmServer) {
+		resp.Diagnostics.AddError("Device assignment to a non-MDM server", nonMdmServerDetail(plan.Type.ValueString(), len(toAssign)))
+		return
 	}
 
-	var toUnassign []string
-	for _, id := range currentDeviceIDs {
-		if !plannedMap[id] {
-			toUnassign = append(toUnassign, id)
-		}
-	}
-	var toAssign []string
-	for _, id := range plannedDevices {
-		if !currentMap[id] {
-			toAssign = append(toAssign, id)
+	if len(toAssign) > 0 {
+		if err := r.client.ClaimSerials(updateCtx, plannedDevices); err != nil {
+			resp.Diagnostics.AddError("Failed to claim devices", err.Error())
+			return
 		}
 	}
 
+	assigned, managed := plannedDevices, plannedDevices
+	results := newActivityResults(plan.ActivityResultsMaxRows)
 	if len(toUnassign) > 0 {
-		activity, err := r.client.AssignDevicesToMDMServer(updateCtx, plan.ID.ValueString(), toUnassign, false)
-		if err != nil {
+		if _, err := r.runDeviceActivity(updateCtx, plan.ID.ValueString(), toUnassign, false, plan.ActivityLogPath.ValueString(), common.DurationValue(plan.ActivityPollInterval, client.DefaultActivityPollInterval), common.DurationValue(plan.BatchDelay, 0), ledger, results, &resp.Diagnostics); err != nil {
 			resp.Diagnostics.AddError("Failed to unassign devices", err.Error())
 			return
 		}
-		if err := r.waitForActivityCompletion(updateCtx, activity.ID, &resp.Diagnostics); err != nil {
-			resp.Diagnostics.AddError("Failed to complete device unassignment", err.Error())
-			return
-		}
+		common.ReleaseSerials(updateCtx, r.client, toUnassign, &resp.Diagnostics)
 	}
 
 	if len(toAssign) > 0 {
-		activity, err := r.client.AssignDevicesToMDMServer(updateCtx, plan.ID.ValueString(), toAssign, true)
+		if len(toUnassign) > 0 {
+			if err := waitBatchDelay(updateCtx, r.client.Clock(), common.DurationValue(plan.BatchDelay, 0)); err != nil {
+				resp.Diagnostics.AddError("Failed to complete device assignment", err.Error())
+				return
+			}
+		}
+		notFound, err := r.runDeviceActivity(updateCtx, plan.ID.ValueString(), toAssign, true, plan.ActivityLogPath.ValueString(), common.DurationValue(plan.ActivityPollInterval, client.DefaultActivityPollInterval), common.DurationValue(plan.BatchDelay, 0), ledger, results, &resp.Diagnostics)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to assign devices", err.Error())
 			return
 		}
-		if err := r.waitForActivityCompletion(updateCtx, activity.ID, &resp.Diagnostics); err != nil {
-			resp.Diagnostics.AddError("Failed to complete device assignment", err.Error())
+		assigned = withoutDevices(plannedDevices, notFound)
+		if plan.DeviceIDs.IsUnknown() {
+			managed = assigned
+		} else {
+			warnNotFoundKept(notFound, &resp.Diagnostics)
+		}
+	}
+
+	if plan.VerifyAfterApply.ValueBool() && (len(toAssign) > 0 || len(toUnassign) > 0) {
+		if err := r.verifyAssignments(updateCtx, plan.ID.ValueString(), assigned); err != nil {
+			resp.Diagnostics.AddError("Post-apply verification failed", err.Error())
 			return
 		}
 	}
@@ -345,18 +490,45 @@ func (r *DeviceManagementServiceResource) Update(ctx context.Context, req resour
 		}
 	}
 
+	live := append(slices.Clone(assigned), retained...)
+	if plan.DryRun.ValueBool() {
+		assigned, live = currentDeviceIDs, currentDeviceIDs
+	}
+	externallyAdded, missing, driftDiags := assignmentDrift(managed, live, true)
+	resp.Diagnostics.Append(driftDiags...)
+	resp.Diagnostics.Append(setManagedDeviceIDs(ctx, resp.Private, managed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ExternallyAddedDevices = externallyAdded
+	plan.MissingDevices = missing
+	plan.ActivityResults = results.value(ctx, &resp.Diagnostics)
+	plan.LastActivity = results.lastActivity(ctx, state.LastActivity, &resp.Diagnostics)
+
+	if plan.DeviceIDs.IsUnknown() {
+		deviceSet, diags := stringsToSet(assigned)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.DeviceIDs = deviceSet
+	}
+
 	tflog.Debug(ctx, "Updated MDM server", map[string]any{
 		"mdm_server_id": plan.ID.ValueString(),
-		"assigned":      toAssign,
-		"unassigned":    toUnassign,
+		"assigned":      r.client.RedactSerials(toAssign),
+		"unassigned":    r.client.RedactSerials(toUnassign),
 	})
 
 	plan.Timeouts = ensureDeviceManagementServiceTimeouts(plan.Timeouts)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-// Delete removes an MDM server (business scope only). In education scope it removes the resource from state.
+// Delete removes an MDM server (business scope only), unassigning its devices first unless the
+// delete_unassigns_devices feature is disabled. In education scope it removes the resource from state.
 func (r *DeviceManagementServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
 	var data MdmDeviceAssignmentModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -380,17 +552,47 @@ func (r *DeviceManagementServiceResource) Delete(ctx context.Context, req resour
 
 	deleteCtx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
+	deleteCtx = common.WithRetryOverrides(deleteCtx, data.Retry)
+
+	ledger, ledgerDiags := loadActivityLedger(ctx, req.Private)
+	resp.Diagnostics.Append(ledgerDiags...)
+	defer ledger.persist(ctx, resp.Private, &resp.Diagnostics)
 
 	// GET the server first — confirms it exists and reveals current family assignments.
 	srv, err := r.client.GetDeviceManagementService(deleteCtx, data.ID.ValueString(), nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			return
 		}
 		resp.Diagnostics.AddError("Failed to read MDM server before deletion", err.Error())
 		return
 	}
 
+	// Unassign all devices before deletion.
+	currentDeviceIDs, err := r.client.GetDeviceManagementServiceSerialNumbers(deleteCtx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get device assignments before deletion", err.Error())
+		return
+	}
+	unassign := r.client.Features().DeleteUnassignsDevices
+
+	if unassign && exceedsUnassignLimit(data.MaxUnassignWithoutConfirmation, data.ConfirmBulkUnassign, len(currentDeviceIDs)) {
+		resp.Diagnostics.AddError(
+			"Bulk unassignment requires confirmation",
+			bulkUnassignDetail(len(currentDeviceIDs), data.MaxUnassignWithoutConfirmation.ValueInt64()),
+		)
+		return
+	}
+
+	if unassign && len(currentDeviceIDs) > 0 && data.DryRun.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Dry run enabled",
+			"Deleting this MDM server requires unassigning its devices, which dry_run prevents. "+
+				"Set dry_run = false and apply before destroying.\n\n"+dryRunSummary(nil, currentDeviceIDs),
+		)
+		return
+	}
+
 	// Clear default product family assignments only if any are set.
 	// Apple returns 400 when PATCHing defaultProductFamilies: [] on a server that has none.
 	if len(srv.Attributes.DefaultProductFamilies) > 0 {
@@ -400,29 +602,23 @@ func (r *DeviceManagementServiceResource) Delete(ctx context.Context, req resour
 		}
 	}
 
-	// Unassign all devices before deletion.
-	currentDeviceIDs, err := r.client.GetDeviceManagementServiceSerialNumbers(deleteCtx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to get device assignments before deletion", err.Error())
-		return
-	}
-
-	if len(currentDeviceIDs) > 0 {
-		activity, err := r.client.AssignDevicesToMDMServer(deleteCtx, data.ID.ValueString(), currentDeviceIDs, false)
-		if err != nil {
+	if unassign && len(currentDeviceIDs) > 0 {
+		if _, err := r.runDeviceActivity(deleteCtx, data.ID.ValueString(), currentDeviceIDs, false, "", common.DurationValue(data.ActivityPollInterval, client.DefaultActivityPollInterval), common.DurationValue(data.BatchDelay, 0), ledger, nil, &resp.Diagnostics); err != nil {
 			resp.Diagnostics.AddError("Failed to unassign devices before deletion", err.Error())
 			return
 		}
-		if err := r.waitForActivityCompletion(deleteCtx, activity.ID, &resp.Diagnostics); err != nil {
-			resp.Diagnostics.AddError("Failed to complete device unassignment before deletion", err.Error())
-			return
-		}
+		common.ReleaseSerials(deleteCtx, r.client, currentDeviceIDs, &resp.Diagnostics)
 	}
 
 	if err := r.client.DeleteDeviceManagementService(deleteCtx, data.ID.ValueString()); err != nil {
-		if strings.Contains(err.Error(), "NOT_FOUND") {
+		if client.IsNotFound(err) {
 			return
 		}
 		resp.Diagnostics.AddError("Failed to delete MDM server", err.Error())
+		return
+	}
+
+	if !unassign && len(currentDeviceIDs) > 0 {
+		common.ReleaseSerials(deleteCtx, r.client, currentDeviceIDs, &resp.Diagnostics)
 	}
 }
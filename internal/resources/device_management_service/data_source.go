@@ -5,10 +5,14 @@ package device_management_service
 
 import (
 	"context"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -32,6 +36,7 @@ type DeviceManagementServiceDataSource struct {
 type DeviceManagementServiceDataSourceModel struct {
 	ID                     types.String   `tfsdk:"id"`
 	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+	CredentialsProfile     types.String   `tfsdk:"credentials_profile"`
 	Type                   types.String   `tfsdk:"type"`
 	ServerName             types.String   `tfsdk:"server_name"`
 	ServerType             types.String   `tfsdk:"server_type"`
@@ -43,6 +48,9 @@ type DeviceManagementServiceDataSourceModel struct {
 	AllowRelease           types.Bool     `tfsdk:"allow_release"`
 	CreatedDateTime        types.String   `tfsdk:"created_date_time"`
 	UpdatedDateTime        types.String   `tfsdk:"updated_date_time"`
+	SelfLink               types.String   `tfsdk:"self_link"`
+	IncludeSerialNumbers   types.Bool     `tfsdk:"include_serial_numbers"`
+	SerialNumbers          types.List     `tfsdk:"serial_numbers"`
 }
 
 func (d *DeviceManagementServiceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -51,20 +59,30 @@ func (d *DeviceManagementServiceDataSource) Metadata(ctx context.Context, req da
 
 func (d *DeviceManagementServiceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetches information about a specific device management service (MDM server) from Apple Business Manager.",
+		Description: "Fetches information about a specific device management service (MDM server) from Apple Business Manager, identified by its ID or by its name.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:    true,
-				Description: "The opaque resource ID that uniquely identifies the resource.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The opaque resource ID that uniquely identifies the resource. Exactly one of id and server_name must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("server_name")),
+				},
 			},
-			"timeouts": timeouts.Attributes(ctx),
+			"timeouts":            timeouts.Attributes(ctx),
+			"credentials_profile": common.CredentialsProfileAttribute(),
 			"type": schema.StringAttribute{
 				Computed:    true,
 				Description: "The type of the resource (mdmServers).",
 			},
 			"server_name": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The device management service's name.",
+				Description: "The device management service's name. When set, the service is looked up by this exact, case-sensitive name, and the read fails when no service or more than one service has it. Exactly one of id and server_name must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"server_type": schema.StringAttribute{
 				Computed:    true,
@@ -103,6 +121,19 @@ func (d *DeviceManagementServiceDataSource) Schema(ctx context.Context, req data
 				Computed:    true,
 				Description: "The date and time of the most-recent update for the resource.",
 			},
+			"self_link": schema.StringAttribute{
+				Computed:    true,
+				Description: "The API URL of the device management service resource, as returned in links.self. Null if the API did not return a link.",
+			},
+			"include_serial_numbers": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, serial_numbers is read as well, which pages through every device assigned to the service. Defaults to false.",
+			},
+			"serial_numbers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The serial numbers of the devices assigned to the device management service, sorted. Null unless include_serial_numbers is true.",
+			},
 		},
 	}
 }
@@ -117,6 +148,8 @@ func (d *DeviceManagementServiceDataSource) Configure(ctx context.Context, req d
 }
 
 func (d *DeviceManagementServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data DeviceManagementServiceDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -131,12 +164,51 @@ func (d *DeviceManagementServiceDataSource) Read(ctx context.Context, req dataso
 	}
 	defer cancel()
 
-	srv, err := d.client.GetDeviceManagementService(readCtx, data.ID.ValueString(), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to read device management service", err.Error())
+	c, profileDiags := common.ClientForProfile(d.client, data.CredentialsProfile)
+	resp.Diagnostics.Append(profileDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var srv *client.MdmServer
+	if !data.ServerName.IsNull() {
+		servers, err := c.GetDeviceManagementServices(readCtx, nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read device management services", err.Error())
+			return
+		}
+		id, err := serverIDByName(servers, data.ServerName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to find device management service", err.Error())
+			return
+		}
+		for i := range servers {
+			if servers[i].ID == id {
+				srv = &servers[i]
+			}
+		}
+	} else {
+		var err error
+		srv, err = c.GetDeviceManagementService(readCtx, data.ID.ValueString(), nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read device management service", err.Error())
+			return
+		}
+	}
+
+	data.SerialNumbers = types.ListNull(types.StringType)
+	if data.IncludeSerialNumbers.ValueBool() {
+		serials, err := c.GetDeviceManagementServiceSerialNumbers(readCtx, srv.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read device management service serial numbers", err.Error())
+			return
+		}
+		slices.Sort(serials)
+		data.SerialNumbers = common.StringsToList(ctx, serials)
+	}
+
+	data.ID = types.StringValue(srv.ID)
+
 	data.Type = types.StringValue(srv.Type)
 	data.ServerName = types.StringValue(srv.Attributes.ServerName)
 	data.ServerType = types.StringValue(srv.Attributes.ServerType)
@@ -148,6 +220,7 @@ func (d *DeviceManagementServiceDataSource) Read(ctx context.Context, req dataso
 	data.AllowRelease = types.BoolPointerValue(srv.Attributes.EnableMdmDisownFlag)
 	data.CreatedDateTime = types.StringValue(srv.Attributes.CreatedDateTime)
 	data.UpdatedDateTime = types.StringValue(srv.Attributes.UpdatedDateTime)
+	data.SelfLink = common.OptionalString(srv.Links.Self)
 
 	tflog.Debug(ctx, "Read device management service", map[string]any{
 		"server_id": data.ID.ValueString(),
@@ -33,15 +33,18 @@ func TestDeviceManagementServiceDataSourceSchema(t *testing.T) {
 		t.Error("expected non-empty schema Description")
 	}
 
-	idAttr, ok := resp.Schema.Attributes["id"]
-	if !ok {
-		t.Fatal("attribute 'id' not found")
-	}
-	if !idAttr.IsRequired() {
-		t.Error("expected 'id' to be Required")
+	for _, name := range []string{"id", "server_name", "include_serial_numbers"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("attribute %q not found", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected attribute %q to be Optional", name)
+		}
 	}
 
-	computed := []string{"type", "server_name", "server_type", "status", "device_count", "default_product_families", "last_connected_date_time", "last_connected_ip", "allow_release", "created_date_time", "updated_date_time"}
+	computed := []string{"id", "type", "server_name", "server_type", "status", "device_count", "default_product_families", "last_connected_date_time", "last_connected_ip", "allow_release", "created_date_time", "updated_date_time", "self_link", "serial_numbers"}
 	for _, name := range computed {
 		attr, ok := resp.Schema.Attributes[name]
 		if !ok {
@@ -77,6 +80,21 @@ func TestAccDeviceManagementServiceDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.axm_device_management_service.test", "server_type"),
 					resource.TestCheckResourceAttrSet("data.axm_device_management_service.test", "created_date_time"),
 					resource.TestCheckResourceAttrSet("data.axm_device_management_service.test", "updated_date_time"),
+					resource.TestCheckNoResourceAttr("data.axm_device_management_service.test", "serial_numbers"),
+				),
+			},
+			{
+				Config: `data "axm_device_management_service" "test" {
+					id = "` + serverID + `"
+				}
+
+				data "axm_device_management_service" "by_name" {
+					server_name            = data.axm_device_management_service.test.server_name
+					include_serial_numbers = true
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_device_management_service.by_name", "id", serverID),
+					resource.TestCheckResourceAttrSet("data.axm_device_management_service.by_name", "serial_numbers.#"),
 				),
 			},
 		},
@@ -4,18 +4,24 @@
 package device_management_service
 
 import (
-	"bytes"
 	"context"
-	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
 )
 
 // extractStrings converts a types.Set containing string values into a slice of strings,
@@ -43,85 +49,646 @@ func stringsToSet(values []string) (types.Set, diag.Diagnostics) {
 	return types.SetValue(types.StringType, elements)
 }
 
-// downloadAndParseActivityLog downloads the CSV from a pre-signed URL and parses it into a summary.
-// This is a standalone function (not a client method) because the URL is pre-signed and doesn't
-// require authentication - it's a utility operation, not an API call.
-func downloadAndParseActivityLog(ctx context.Context, downloadURL string) (string, error) {
-	if downloadURL == "" {
-		return "", fmt.Errorf("no download URL provided")
+// filterProductFamily returns the product family selected by device_filter in the casing the API
+// reports, and false when no filter is configured or its value is not yet known.
+func filterProductFamily(filter *DeviceFilterModel) (string, bool) {
+	if filter == nil || filter.ProductFamily.IsNull() || filter.ProductFamily.IsUnknown() {
+		return "", false
+	}
+	value := strings.TrimSpace(filter.ProductFamily.ValueString())
+	for _, family := range client.OrgDeviceProductFamilyValues() {
+		if strings.EqualFold(family, value) {
+			return family, true
+		}
 	}
+	return value, value != ""
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+// filteredDeviceIDs returns the canonical IDs of the organization devices in productFamily, sorted.
+// The family is sent as a filter query parameter and also applied locally, and every device is
+// listed if the API rejects the parameter.
+func (r *DeviceManagementServiceResource) filteredDeviceIDs(ctx context.Context, productFamily string) ([]string, error) {
+	params := url.Values{
+		"fields[orgDevices]":    {"productFamily"},
+		"filter[productFamily]": {productFamily},
+	}
+	devices, err := r.client.GetOrgDevices(ctx, params)
+	if err != nil && client.IsParameterError(err) {
+		tflog.Warn(ctx, "API rejected the device_filter parameters; filtering all devices locally", map[string]any{
+			"error": err.Error(),
+		})
+		params.Del("filter[productFamily]")
+		devices, err = r.client.GetOrgDevices(ctx, params)
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	return productFamilyDeviceIDs(devices, productFamily), nil
+}
+
+// productFamilyDeviceIDs returns the sorted IDs of the devices in productFamily.
+func productFamilyDeviceIDs(devices []client.OrgDevice, productFamily string) []string {
+	var ids []string
+	for _, device := range devices {
+		if strings.EqualFold(device.Attributes.ProductFamily, productFamily) {
+			ids = append(ids, device.ID)
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
 
-	resp, err := http.DefaultClient.Do(req)
+// canonicalizeDeviceIDs converts configured device identifiers, which may be serial numbers or
+// opaque orgDevice IDs, into a de-duplicated list of canonical orgDevice IDs. Identifiers already
+// present in known are treated as canonical without a lookup. Identifiers that cannot be resolved
+// are passed through unchanged so that Apple reports them in the activity log.
+func (r *DeviceManagementServiceResource) canonicalizeDeviceIDs(ctx context.Context, identifiers, known []string) ([]string, error) {
+	knownSet := make(map[string]bool, len(known))
+	for _, id := range known {
+		knownSet[id] = true
+	}
+
+	var unresolved []string
+	for _, id := range identifiers {
+		if !knownSet[id] {
+			unresolved = append(unresolved, id)
+		}
+	}
+
+	resolved := make(map[string]string)
+	if len(unresolved) > 0 {
+		lookup, err := r.client.ResolveOrgDeviceIDs(ctx, unresolved)
+		if err != nil {
+			return nil, err
+		}
+		resolved = lookup
+	}
+
+	seen := make(map[string]bool, len(identifiers))
+	canonical := make([]string, 0, len(identifiers))
+	for _, id := range identifiers {
+		target := id
+		if v, ok := resolved[id]; ok {
+			target = v
+		} else if !knownSet[id] {
+			tflog.Warn(ctx, "Unable to resolve device identifier; submitting as-is", map[string]any{
+				"device_id": r.client.RedactSerial(id),
+			})
+		}
+		if !seen[target] {
+			seen[target] = true
+			canonical = append(canonical, target)
+		}
+	}
+
+	return canonical, nil
+}
+
+// normalizeDeviceIDs returns the assigned device IDs reported by the API, substituting the
+// identifier form used in prior state (e.g. a serial number) wherever it resolves to the same
+// device. This keeps state aligned with configuration when inputs mix serials and opaque IDs.
+func (r *DeviceManagementServiceResource) normalizeDeviceIDs(ctx context.Context, prior, current []string) ([]string, error) {
+	currentSet := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+
+	var unmatched []string
+	for _, id := range prior {
+		if !currentSet[id] {
+			unmatched = append(unmatched, id)
+		}
+	}
+	if len(unmatched) == 0 {
+		return current, nil
+	}
+
+	resolved, err := r.client.ResolveOrgDeviceIDs(ctx, unmatched)
 	if err != nil {
-		return "", fmt.Errorf("failed to download activity log: %w", err)
+		return nil, err
+	}
+
+	return applyDeviceIDAliases(current, resolved), nil
+}
+
+// applyDeviceIDAliases replaces canonical device IDs with the identifiers that resolved to them.
+// resolved maps a configured identifier to its canonical device ID.
+func applyDeviceIDAliases(current []string, resolved map[string]string) []string {
+	aliases := make(map[string]string, len(resolved))
+	for identifier, canonical := range resolved {
+		if identifier != canonical {
+			aliases[canonical] = identifier
+		}
+	}
+
+	result := make([]string, len(current))
+	for i, id := range current {
+		if alias, ok := aliases[id]; ok {
+			result[i] = alias
+		} else {
+			result[i] = id
+		}
+	}
+	return result
+}
+
+// diffDeviceIDs returns the device IDs present in planned but not current (to assign) and those
+// present in current but not planned (to unassign).
+func diffDeviceIDs(current, planned []string) (toAssign, toUnassign []string) {
+	plannedMap := make(map[string]bool, len(planned))
+	for _, id := range planned {
+		plannedMap[id] = true
+	}
+	currentMap := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentMap[id] = true
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to download activity log: HTTP %d", resp.StatusCode)
+	for _, id := range current {
+		if !plannedMap[id] {
+			toUnassign = append(toUnassign, id)
+		}
 	}
+	for _, id := range planned {
+		if !currentMap[id] {
+			toAssign = append(toAssign, id)
+		}
+	}
+	return toAssign, toUnassign
+}
+
+// managedDeviceIDsKey is the private state key holding the canonical device IDs that device_ids
+// resolved to when Terraform last applied the resource.
+const managedDeviceIDsKey = "managed_device_ids"
+
+// privateStateGetter and privateStateSetter are satisfied by the private state of resource
+// requests and responses.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
 
-	data, err := io.ReadAll(resp.Body)
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setManagedDeviceIDs records the canonical device IDs managed by configuration in private state.
+func setManagedDeviceIDs(ctx context.Context, private privateStateSetter, deviceIDs []string) diag.Diagnostics {
+	if deviceIDs == nil {
+		deviceIDs = []string{}
+	}
+	value, err := json.Marshal(deviceIDs)
 	if err != nil {
-		return "", fmt.Errorf("failed to read activity log: %w", err)
+		var diags diag.Diagnostics
+		diags.AddError("Failed to record managed devices", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, managedDeviceIDsKey, value)
+}
+
+// managedDeviceIDs returns the canonical device IDs recorded by setManagedDeviceIDs, and false
+// when none were recorded.
+func managedDeviceIDs(ctx context.Context, private privateStateGetter) ([]string, bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, managedDeviceIDsKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, false, diags
+	}
+	var deviceIDs []string
+	if err := json.Unmarshal(value, &deviceIDs); err != nil {
+		diags.AddError("Failed to read managed devices", err.Error())
+		return nil, false, diags
 	}
+	return deviceIDs, true, diags
+}
+
+// activityLedgerKey is the private state key holding the activities submitted by an apply that
+// failed, keyed by idempotency key, so that retrying the apply reuses them instead of
+// resubmitting identical activities.
+const activityLedgerKey = "activity_idempotency_keys"
+
+// activityIdempotencyWindow is how long after its submission a recorded activity may be reused
+// in place of an identical one.
+const activityIdempotencyWindow = time.Hour
 
-	reader := csv.NewReader(bytes.NewReader(data))
-	reader.FieldsPerRecord = -1
-	records, err := reader.ReadAll()
+// submittedActivity records an activity accepted for an idempotency key.
+type submittedActivity struct {
+	ActivityID  string    `json:"activity_id"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// activityLedger tracks activities by client.ActivityIdempotencyKey. prior holds those recorded
+// by the previous apply and current those submitted or reused by this one. pending holds the
+// activities earlier applies stopped waiting for and abandoned those this apply stopped waiting
+// for. A nil ledger disables reuse.
+type activityLedger struct {
+	prior     map[string]submittedActivity
+	current   map[string]submittedActivity
+	pending   []pendingActivity
+	abandoned []pendingActivity
+}
+
+// loadActivityLedger returns a ledger holding the activities recorded in private state, including
+// those earlier applies stopped waiting for.
+func loadActivityLedger(ctx context.Context, private privateStateGetter) (*activityLedger, diag.Diagnostics) {
+	pending, diags := loadPendingActivities(ctx, private)
+	ledger := &activityLedger{current: map[string]submittedActivity{}, pending: pending}
+	value, valueDiags := private.GetKey(ctx, activityLedgerKey)
+	diags.Append(valueDiags...)
+	if diags.HasError() || len(value) == 0 {
+		return ledger, diags
+	}
+	if err := json.Unmarshal(value, &ledger.prior); err != nil {
+		diags.AddWarning(
+			"Failed to read submitted activities",
+			fmt.Sprintf("Previously submitted activities could not be read from private state and will not be reused: %s", err),
+		)
+	}
+	return ledger, diags
+}
+
+// lookup returns the ID of the activity the previous apply recorded for key, and false when
+// none was recorded or it was submitted more than activityIdempotencyWindow before now.
+func (l *activityLedger) lookup(key string, now time.Time) (string, bool) {
+	if l == nil {
+		return "", false
+	}
+	entry, ok := l.prior[key]
+	if !ok || entry.ActivityID == "" || now.Sub(entry.SubmittedAt) > activityIdempotencyWindow {
+		return "", false
+	}
+	return entry.ActivityID, true
+}
+
+// record notes that activityID was submitted for key at submittedAt.
+func (l *activityLedger) record(key, activityID string, submittedAt time.Time) {
+	if l == nil {
+		return
+	}
+	l.current[key] = submittedActivity{ActivityID: activityID, SubmittedAt: submittedAt}
+}
+
+// persist records the activities of this apply in private state when diags holds an error, so
+// that a retry can reuse them, and clears them otherwise. Activities are only reused after a
+// failed apply, so that a later apply that repeats an earlier activity, such as reassigning
+// devices unassigned outside Terraform, is always submitted. Activities this apply stopped
+// waiting for are added to the pending activities that Read reconciles, and a successful apply
+// clears them because it records the devices it manages itself.
+func (l *activityLedger) persist(ctx context.Context, private privateStateSetter, diags *diag.Diagnostics) {
+	if l == nil {
+		return
+	}
+	switch {
+	case len(l.abandoned) > 0:
+		diags.Append(setPendingActivities(ctx, private, append(slices.Clone(l.pending), l.abandoned...))...)
+	case !diags.HasError() && len(l.pending) > 0:
+		diags.Append(setPendingActivities(ctx, private, nil)...)
+	}
+	if !diags.HasError() || len(l.current) == 0 {
+		diags.Append(private.SetKey(ctx, activityLedgerKey, nil)...)
+		return
+	}
+	value, err := json.Marshal(l.current)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse CSV: %w", err)
+		diags.AddWarning("Failed to record submitted activities", err.Error())
+		return
 	}
+	diags.Append(private.SetKey(ctx, activityLedgerKey, value)...)
+}
 
-	var summary strings.Builder
-	var inDataSection bool
-	var headers []string
-	var errorCount int
-	var errors []map[string]string
+// pendingActivitiesKey is the private state key holding the activities an apply stopped waiting
+// for before they finished, such as when it was interrupted or timed out, so that Read can account
+// for their devices once they complete.
+const pendingActivitiesKey = "pending_activities"
+
+// pendingActivity records an assignment or unassignment activity an apply stopped waiting for.
+type pendingActivity struct {
+	ActivityID  string    `json:"activity_id"`
+	Assign      bool      `json:"assign"`
+	DeviceIDs   []string  `json:"device_ids"`
+	AbandonedAt time.Time `json:"abandoned_at"`
+}
+
+// abandon records that this apply stopped waiting for an activity before it finished.
+func (l *activityLedger) abandon(activity pendingActivity) {
+	if l == nil {
+		return
+	}
+	l.abandoned = append(l.abandoned, activity)
+}
 
-	for _, record := range records {
-		if len(record) == 0 {
+// loadPendingActivities returns the activities recorded by setPendingActivities.
+func loadPendingActivities(ctx context.Context, private privateStateGetter) ([]pendingActivity, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, pendingActivitiesKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+	var pending []pendingActivity
+	if err := json.Unmarshal(value, &pending); err != nil {
+		diags.AddWarning(
+			"Failed to read pending activities",
+			fmt.Sprintf("Activities an earlier apply stopped waiting for could not be read from private state and will not be reconciled: %s", err),
+		)
+		return nil, diags
+	}
+	return pending, diags
+}
+
+// setPendingActivities records pending in private state, clearing the key when it is empty.
+func setPendingActivities(ctx context.Context, private privateStateSetter, pending []pendingActivity) diag.Diagnostics {
+	if len(pending) == 0 {
+		return private.SetKey(ctx, pendingActivitiesKey, nil)
+	}
+	value, err := json.Marshal(pending)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddWarning("Failed to record pending activities", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, pendingActivitiesKey, value)
+}
+
+// abandonActivity reports that the wait for an activity ended with ctx before the activity
+// finished, and records it in ledger so that the next Read reconciles its devices. The Apple
+// Business Manager API cannot stop an activity, so it continues to run after Terraform stops
+// waiting; the warning names it so that its progress can be followed in the portal.
+func (r *DeviceManagementServiceResource) abandonActivity(ctx context.Context, activity *client.OrgDeviceActivity, deviceIDs []string, assign bool, ledger *activityLedger, diags *diag.Diagnostics) {
+	tflog.Warn(ctx, "Stopped waiting for device activity before it finished; the activity continues to run", map[string]any{
+		"activity_id":  activity.ID,
+		"assign":       assign,
+		"device_count": len(deviceIDs),
+		"error":        context.Cause(ctx).Error(),
+	})
+	ledger.abandon(pendingActivity{
+		ActivityID:  activity.ID,
+		Assign:      assign,
+		DeviceIDs:   deviceIDs,
+		AbandonedAt: r.client.Clock().Now(),
+	})
+	diags.AddWarning(
+		fmt.Sprintf("Stopped waiting for activity %s", activity.ID),
+		fmt.Sprintf("The operation was cancelled or timed out while activity %s for %d device(s) was still running. "+
+			"Apple Business Manager cannot stop a submitted activity, so it continues in the background; follow it in the Activity Log in the AxM portal. "+
+			"The next refresh checks the activity and records its devices once it completes, and retrying the apply within an hour reuses it.",
+			activity.ID, len(deviceIDs)),
+	)
+}
+
+// reconcilePendingActivities checks the activities earlier applies stopped waiting for. The
+// devices of each completed activity are added to or removed from managed, and activities that
+// failed, stopped or no longer exist are dropped. Activities still in progress, or whose status
+// cannot be read, are reported as warnings and returned so that the next Read checks them again.
+func (r *DeviceManagementServiceResource) reconcilePendingActivities(ctx context.Context, managed []string, pending []pendingActivity, diags *diag.Diagnostics) ([]string, []pendingActivity) {
+	var remaining []pendingActivity
+	for _, p := range pending {
+		activity, err := r.client.GetOrgDeviceActivity(ctx, p.ActivityID, nil)
+		switch {
+		case err != nil && client.IsNotFound(err):
+			continue
+		case err != nil:
+			diags.AddWarning(
+				"Failed to check pending activity",
+				fmt.Sprintf("Activity ID: %s\n\n%v\n\nIt will be checked again on the next refresh.", p.ActivityID, err),
+			)
+			remaining = append(remaining, p)
 			continue
 		}
 
-		allEmpty := true
-		for _, field := range record {
-			if strings.TrimSpace(field) != "" {
-				allEmpty = false
-				break
-			}
+		tflog.Info(ctx, "Checked activity an earlier apply stopped waiting for", map[string]any{
+			"activity_id": p.ActivityID,
+			"status":      activity.Attributes.Status,
+			"sub_status":  activity.Attributes.SubStatus,
+		})
+		switch activity.Attributes.Status {
+		case client.ActivityStatusInProgress:
+			diags.AddWarning(
+				fmt.Sprintf("Activity %s is still in progress", p.ActivityID),
+				fmt.Sprintf("An earlier apply stopped waiting for this activity for %d device(s). "+
+					"device_ids may change again once it completes.", len(p.DeviceIDs)),
+			)
+			remaining = append(remaining, p)
+		case client.ActivityStatusCompleted:
+			managed = applyPendingActivity(managed, p)
 		}
-		if allEmpty {
-			continue
+	}
+	return managed, remaining
+}
+
+// applyPendingActivity returns managed with the devices of a completed pending activity added,
+// for an assignment, or removed, for an unassignment.
+func applyPendingActivity(managed []string, p pendingActivity) []string {
+	if !p.Assign {
+		return withoutDevices(managed, p.DeviceIDs)
+	}
+	result := slices.Clone(managed)
+	for _, id := range p.DeviceIDs {
+		if !slices.Contains(result, id) {
+			result = append(result, id)
 		}
+	}
+	return result
+}
 
-		if !inDataSection && len(record) > 0 && strings.Contains(strings.ToLower(record[0]), "serial_number") {
-			inDataSection = true
-			headers = record
-			continue
+// assignmentDrift returns the sets of devices assigned outside configuration and of managed
+// devices no longer assigned, or null sets when managed is not known.
+func assignmentDrift(managed, assigned []string, known bool) (externallyAdded, missing types.Set, diags diag.Diagnostics) {
+	if !known {
+		return types.SetNull(types.StringType), types.SetNull(types.StringType), nil
+	}
+	missingIDs, addedIDs := diffDeviceIDs(assigned, managed)
+	externallyAdded, diags = stringsToSet(addedIDs)
+	missing, missingDiags := stringsToSet(missingIDs)
+	diags.Append(missingDiags...)
+	return externallyAdded, missing, diags
+}
+
+// applyServerAttributes copies the server attributes returned by the API into data, leaving
+// the device assignments and configuration-only attributes untouched.
+func applyServerAttributes(ctx context.Context, data *MdmDeviceAssignmentModel, srv *client.MdmServer) {
+	data.Name = types.StringValue(srv.Attributes.ServerName)
+	data.Type = types.StringValue(srv.Attributes.ServerType)
+	data.Status = common.StringPointerToTypesString(srv.Attributes.Status)
+	data.DeviceCount = types.Int64PointerValue(srv.Attributes.DeviceCount)
+	data.LastConnectedDateTime = types.StringPointerValue(srv.Attributes.LastConnectedDateTime)
+	data.LastConnectedIp = types.StringPointerValue(srv.Attributes.LastConnectedIp)
+	data.CreatedDateTime = types.StringValue(srv.Attributes.CreatedDateTime)
+	data.UpdatedDateTime = types.StringValue(srv.Attributes.UpdatedDateTime)
+	data.SelfLink = common.OptionalString(srv.Links.Self)
+	data.DefaultProductFamilies = common.StringsToList(ctx, srv.Attributes.DefaultProductFamilies)
+	data.AllowRelease = types.BoolPointerValue(srv.Attributes.EnableMdmDisownFlag)
+}
+
+// serverNameImportPrefix introduces an import ID naming the device management service to import
+// instead of giving its ID.
+const serverNameImportPrefix = "serverName="
+
+// serverIDByName returns the ID of the only server in servers named name. It fails when no server
+// or more than one server has that name.
+func serverIDByName(servers []client.MdmServer, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("import ID %q names no server; use %s<name> or the server ID", serverNameImportPrefix, serverNameImportPrefix)
+	}
+
+	var ids []string
+	for _, srv := range servers {
+		if srv.Attributes.ServerName == name {
+			ids = append(ids, srv.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no device management service is named %q", name)
+	case 1:
+		return ids[0], nil
+	default:
+		slices.Sort(ids)
+		return "", fmt.Errorf("%d device management services are named %q (%s); refer to one of them by ID instead", len(ids), name, strings.Join(ids, ", "))
+	}
+}
+
+// verifyInitialInterval and verifyMaxInterval bound the backoff between the re-reads of a
+// server's device relationship made by verifyAssignments.
+const (
+	verifyInitialInterval = 2 * time.Second
+	verifyMaxInterval     = 30 * time.Second
+)
+
+// verifyAssignments re-reads the server's device relationship after activities complete until
+// every planned device is assigned, doubling the wait between reads because the relationship is
+// only eventually consistent. It returns an error listing the planned devices still missing when
+// ctx ends. Devices assigned to the server outside the plan are not checked.
+func (r *DeviceManagementServiceResource) verifyAssignments(ctx context.Context, serverID string, planned []string) error {
+	var missing []string
+	interval := verifyInitialInterval
+	for {
+		current, err := r.client.GetDeviceManagementServiceSerialNumbers(ctx, serverID)
+		switch {
+		case err != nil && missing != nil && ctx.Err() != nil:
+			return missingAssignmentsError(missing)
+		case err != nil:
+			return fmt.Errorf("failed to re-read device assignments: %w", err)
 		}
 
-		if inDataSection && len(headers) > 0 {
-			rowData := make(map[string]string)
-			for i, header := range headers {
-				if i < len(record) {
-					rowData[strings.TrimSpace(header)] = strings.TrimSpace(record[i])
-				}
-			}
+		missing = withoutDevices(planned, current)
+		if len(missing) == 0 {
+			return nil
+		}
+		tflog.Debug(ctx, "Planned devices not yet assigned; re-reading device assignments", map[string]any{
+			"mdm_server_id": serverID,
+			"missing":       len(missing),
+			"interval":      interval.String(),
+		})
 
-			status := rowData["operation_status"]
-			if status != "" && status != "SUCCESS" {
-				errorCount++
-				errors = append(errors, rowData)
-			}
+		select {
+		case <-ctx.Done():
+			return missingAssignmentsError(missing)
+		case <-r.client.Clock().After(interval):
 		}
+		interval = min(interval*2, verifyMaxInterval)
+	}
+}
+
+// missingAssignmentsError describes the planned devices verifyAssignments found missing.
+func missingAssignmentsError(missing []string) error {
+	return fmt.Errorf("the assignment activity reported completion, but %d planned device(s) were still not assigned to the server "+
+		"when the timeout for this operation elapsed: %s. Apple may still be propagating the change; re-run apply to retry, "+
+		"or increase the timeouts to wait longer", len(missing), strings.Join(missing, ", "))
+}
+
+// dryRunSummary formats the assign and unassign sets reported when dry_run is enabled.
+func dryRunSummary(toAssign, toUnassign []string) string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Would assign %d device(s)", len(toAssign))
+	if len(toAssign) > 0 {
+		fmt.Fprintf(&summary, ": %s", strings.Join(toAssign, ", "))
+	}
+	fmt.Fprintf(&summary, "\nWould unassign %d device(s)", len(toUnassign))
+	if len(toUnassign) > 0 {
+		fmt.Fprintf(&summary, ": %s", strings.Join(toUnassign, ", "))
+	}
+	return summary.String()
+}
+
+// waitBatchDelay pauses for the configured batch_delay before a follow-up activity submission.
+func waitBatchDelay(ctx context.Context, clock client.Clock, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
 	}
 
+	tflog.Debug(ctx, "Waiting before next assignment activity submission", map[string]any{
+		"batch_delay": delay.String(),
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(delay):
+		return nil
+	}
+}
+
+// activityLog is the activity log of one finished activity. It is downloaded and parsed the
+// first time it is read, and every later reader shares that result.
+type activityLog struct {
+	downloadURL string
+	fetched     bool
+	data        []byte
+	rows        []map[string]string
+	downloadErr error
+	parseErr    error
+}
+
+// newActivityLog returns the activity log of activity, without downloading it.
+func newActivityLog(activity *client.OrgDeviceActivity) *activityLog {
+	return &activityLog{downloadURL: activity.Attributes.DownloadURL}
+}
+
+// fetch downloads and parses the log, once.
+func (l *activityLog) fetch(ctx context.Context) {
+	if l.fetched {
+		return
+	}
+	l.fetched = true
+	l.data, l.downloadErr = client.DownloadActivityLog(ctx, l.downloadURL)
+	if l.downloadErr == nil {
+		l.rows, l.parseErr = client.ParseActivityLogRows(l.data)
+	}
+}
+
+// content returns the CSV of the log as downloaded.
+func (l *activityLog) content(ctx context.Context) ([]byte, error) {
+	l.fetch(ctx)
+	return l.data, l.downloadErr
+}
+
+// parsed returns the rows of the log.
+func (l *activityLog) parsed(ctx context.Context) ([]map[string]string, error) {
+	l.fetch(ctx)
+	if l.downloadErr != nil {
+		return nil, l.downloadErr
+	}
+	return l.rows, l.parseErr
+}
+
+// summary returns a human-readable summary of the failed rows of the log.
+func (l *activityLog) summary(ctx context.Context) (string, error) {
+	rows, err := l.parsed(ctx)
+	if err != nil {
+		return "", err
+	}
+	return activityLogSummary(rows), nil
+}
+
+// activityLogSummary summarizes the failed rows of an activity log.
+func activityLogSummary(rows []map[string]string) string {
+	var summary strings.Builder
+	var errors []map[string]string
+	for _, row := range rows {
+		if isFailedRow(row) {
+			errors = append(errors, row)
+		}
+	}
+	errorCount := len(errors)
+
 	if errorCount == 0 {
 		summary.WriteString("Activity completed but detailed results are available in the activity log.")
 	} else {
@@ -139,64 +706,634 @@ func downloadAndParseActivityLog(ctx context.Context, downloadURL string) (strin
 
 			fmt.Fprintf(&summary, "  • Serial: %s - Status: %s", serial, status)
 			if subStatus != "" {
-				fmt.Fprintf(&summary, " (%s)", subStatus)
+				fmt.Fprintf(&summary, " (%s)", client.DescribeSubStatus(subStatus))
 			}
 			summary.WriteString("\n")
 		}
 	}
 
-	return summary.String(), nil
+	return summary.String()
 }
 
-// waitForActivityCompletion polls the activity status until it completes, fails, or times out
-func (r *DeviceManagementServiceResource) waitForActivityCompletion(ctx context.Context, activityID string, diags *diag.Diagnostics) error {
-	maxAttempts := 30
-	retryInterval := 5 * time.Second
+// isFailedRow reports whether an activity log row records a device the activity did not process successfully.
+func isFailedRow(row map[string]string) bool {
+	status := row["operation_status"]
+	return status != "" && status != "SUCCESS"
+}
 
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(retryInterval):
+// FailedSerialNumbers returns the unique serial numbers of the failed rows of an activity log,
+// in log order.
+func FailedSerialNumbers(rows []map[string]string) []string {
+	seen := make(map[string]bool)
+	var serialNumbers []string
+	for _, row := range rows {
+		serial := row["serial_number"]
+		if serial == "" || !isFailedRow(row) || seen[serial] {
+			continue
 		}
+		seen[serial] = true
+		serialNumbers = append(serialNumbers, serial)
+	}
+	return serialNumbers
+}
+
+// defaultActivityResultsMaxRows is the number of devices recorded in activity_results when
+// activity_results_max_rows is not set.
+const defaultActivityResultsMaxRows = 100
+
+// activityResultAttrTypes describes one device recorded in activity_results.
+var activityResultAttrTypes = map[string]attr.Type{
+	"serial_number": types.StringType,
+	"status":        types.StringType,
+	"sub_status":    types.StringType,
+	"message":       types.StringType,
+}
+
+// deviceResultAttrTypes describes the outcome of one device in last_activity.device_results.
+var deviceResultAttrTypes = map[string]attr.Type{
+	"status":     types.StringType,
+	"sub_status": types.StringType,
+}
+
+// lastActivityAttrTypes describes last_activity.
+var lastActivityAttrTypes = map[string]attr.Type{
+	"id":                  types.StringType,
+	"type":                types.StringType,
+	"status":              types.StringType,
+	"sub_status":          types.StringType,
+	"completed_date_time": types.StringType,
+	"device_results":      types.MapType{ElemType: types.ObjectType{AttrTypes: deviceResultAttrTypes}},
+}
+
+// activityResults collects the failed rows of the activity logs of the activities submitted by
+// one create or update, keeping at most maxRows of them, and the last activity to finish. A nil
+// collector collects nothing.
+type activityResults struct {
+	maxRows  int
+	rows     []ActivityResultModel
+	failed   int
+	last     *client.OrgDeviceActivity
+	lastType string
+	lastLog  *activityLog
+}
 
-		activity, err := r.client.GetOrgDeviceActivity(ctx, activityID, nil)
+// newActivityResults returns a collector keeping at most maxRows devices, or
+// defaultActivityResultsMaxRows when maxRows is not set.
+func newActivityResults(maxRows types.Int64) *activityResults {
+	limit := defaultActivityResultsMaxRows
+	if !maxRows.IsNull() && !maxRows.IsUnknown() {
+		limit = int(maxRows.ValueInt64())
+	}
+	return &activityResults{maxRows: limit, rows: []ActivityResultModel{}}
+}
+
+// collect records the failed rows of log, the activity log of activity, returning the summary
+// reported in the warning about the activity.
+func (a *activityResults) collect(ctx context.Context, activity *client.OrgDeviceActivity, log *activityLog) (string, error) {
+	rows, err := log.parsed(ctx)
+	if err != nil {
+		return "", err
+	}
+	failed := a.add(activity.ID, rows)
+	return fmt.Sprintf("Activity completed with %d error(s). The devices are recorded in the activity_results attribute.", failed), nil
+}
+
+// add records the failed rows of the activity log of activityID and returns how many there were.
+func (a *activityResults) add(activityID string, rows []map[string]string) int {
+	if a == nil {
+		return 0
+	}
+	failed := 0
+	for _, row := range rows {
+		if !isFailedRow(row) {
+			continue
+		}
+		failed++
+		if len(a.rows) >= a.maxRows {
+			continue
+		}
+		outcome := row["operation_status"]
+		if subStatus := row["operation_substatus"]; subStatus != "" {
+			outcome = fmt.Sprintf("%s (%s)", outcome, client.DescribeSubStatus(subStatus))
+		}
+		a.rows = append(a.rows, ActivityResultModel{
+			SerialNumber: types.StringValue(row["serial_number"]),
+			Status:       types.StringValue(row["operation_status"]),
+			SubStatus:    common.OptionalString(row["operation_substatus"]),
+			Message:      types.StringValue(fmt.Sprintf("Activity %s reported %s for this device.", activityID, outcome)),
+		})
+	}
+	a.failed += failed
+	return failed
+}
+
+// observe records activity, of the given activity type, as the last activity to finish, with
+// log, its activity log.
+func (a *activityResults) observe(activity *client.OrgDeviceActivity, activityType string, log *activityLog) {
+	if a == nil {
+		return
+	}
+	a.last = activity
+	a.lastType = activityType
+	a.lastLog = log
+}
+
+// lastActivity returns the last_activity value for the last activity observed, reading its
+// activity log for the per-device results, or prior when no activity finished. A log that
+// cannot be read is reported as a warning and leaves device_results null.
+func (a *activityResults) lastActivity(ctx context.Context, prior types.Object, diags *diag.Diagnostics) types.Object {
+	if a == nil || a.last == nil {
+		return prior
+	}
+	var rows []map[string]string
+	if a.last.Attributes.DownloadURL != "" && a.lastLog != nil {
+		var err error
+		rows, err = a.lastLog.parsed(ctx)
 		if err != nil {
-			return fmt.Errorf("error checking activity status: %w", err)
+			rows = nil
+			diags.AddWarning(
+				"Failed to read activity log",
+				fmt.Sprintf("Activity ID: %s\n\nThe per-device results of last_activity are not available.\n\n%v", a.last.ID, err),
+			)
 		}
+	}
+	return lastActivityValue(ctx, a.last, a.lastType, rows, diags)
+}
 
-		switch activity.Attributes.Status {
-		case "COMPLETED":
-			if activity.Attributes.SubStatus != "COMPLETED_WITH_SUCCESS" {
-				summary := fmt.Sprintf("Activity ID: %s\n\nCompleted with SubStatus: %s", activityID, activity.Attributes.SubStatus)
-
-				if activity.Attributes.DownloadURL != "" {
-					logSummary, err := downloadAndParseActivityLog(ctx, activity.Attributes.DownloadURL)
-					if err == nil {
-						summary = fmt.Sprintf("Activity ID: %s\n\n%s", activityID, logSummary)
-					} else {
-						summary = fmt.Sprintf("%s\n\nFailed to download activity log: %v\n\nActivity log available at: %s", summary, err, activity.Attributes.DownloadURL)
-					}
+// lastActivityValue builds the last_activity value for activity from the rows of its activity
+// log, keyed by serial number. device_results is null when rows is nil.
+func lastActivityValue(ctx context.Context, activity *client.OrgDeviceActivity, activityType string, rows []map[string]string, diags *diag.Diagnostics) types.Object {
+	deviceResults := types.MapNull(types.ObjectType{AttrTypes: deviceResultAttrTypes})
+	if rows != nil {
+		results := make(map[string]DeviceResultModel, len(rows))
+		for _, row := range rows {
+			serial := row["serial_number"]
+			if serial == "" {
+				continue
+			}
+			results[serial] = DeviceResultModel{
+				Status:    common.OptionalString(row["operation_status"]),
+				SubStatus: common.OptionalString(row["operation_substatus"]),
+			}
+		}
+		var mapDiags diag.Diagnostics
+		deviceResults, mapDiags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: deviceResultAttrTypes}, results)
+		diags.Append(mapDiags...)
+	}
+
+	value, objDiags := types.ObjectValueFrom(ctx, lastActivityAttrTypes, LastActivityModel{
+		ID:                types.StringValue(activity.ID),
+		Type:              types.StringValue(activityType),
+		Status:            types.StringValue(activity.Attributes.Status),
+		SubStatus:         common.OptionalString(activity.Attributes.SubStatus),
+		CompletedDateTime: common.TimestampValue(activity.Attributes.CompletedDateTime, "last_activity.completed_date_time", diags),
+		DeviceResults:     deviceResults,
+	})
+	diags.Append(objDiags...)
+	return value
+}
+
+// value returns the collected devices as the activity_results value, warning when some were
+// omitted because of maxRows.
+func (a *activityResults) value(ctx context.Context, diags *diag.Diagnostics) types.List {
+	if omitted := a.failed - len(a.rows); omitted > 0 && a.maxRows > 0 {
+		diags.AddWarning(
+			"Activity results truncated",
+			fmt.Sprintf("The activities reported %d failed device(s). Only the first %d are recorded in activity_results because of activity_results_max_rows; "+
+				"%d more were omitted. Set activity_log_path to keep the complete activity logs.", a.failed, len(a.rows), omitted),
+		)
+	}
+	list, listDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: activityResultAttrTypes}, a.rows)
+	diags.Append(listDiags...)
+	return list
+}
+
+// activityLogPathPlaceholder is replaced with the activity ID in activity_log_path.
+const activityLogPathPlaceholder = "{activity_id}"
+
+// activityLogTarget resolves the templated activity_log_path for activityID. A template without
+// the placeholder has the activity ID appended to its file name, before any extension, so that
+// every activity of an apply is written to its own file.
+func activityLogTarget(pathTemplate, activityID string) string {
+	if strings.Contains(pathTemplate, activityLogPathPlaceholder) {
+		return strings.ReplaceAll(pathTemplate, activityLogPathPlaceholder, activityID)
+	}
+	ext := filepath.Ext(pathTemplate)
+	return strings.TrimSuffix(pathTemplate, ext) + "-" + activityID + ext
+}
+
+// writeActivityLog writes an activity log CSV to the templated activity_log_path,
+// creating parent directories as needed, and returns the resolved path.
+func writeActivityLog(pathTemplate, activityID string, data []byte) (string, error) {
+	target := activityLogTarget(pathTemplate, activityID)
+	if dir := filepath.Dir(target); dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return target, fmt.Errorf("failed to create activity log directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(target, data, 0o640); err != nil {
+		return target, fmt.Errorf("failed to write activity log: %w", err)
+	}
+	return target, nil
+}
+
+// persistActivityLog writes log, the activity log of activity, to logPath, reporting failures as
+// warnings because the activity itself has already finished.
+func persistActivityLog(ctx context.Context, activity *client.OrgDeviceActivity, log *activityLog, logPath string, diags *diag.Diagnostics) {
+	if logPath == "" {
+		return
+	}
+	if activity.Attributes.DownloadURL == "" {
+		diags.AddWarning(
+			"Activity log not available",
+			fmt.Sprintf("Activity %s did not provide a download URL, so no activity log was written.", activity.ID),
+		)
+		return
+	}
+
+	data, err := log.content(ctx)
+	if err != nil {
+		diags.AddWarning("Failed to download activity log", fmt.Sprintf("Activity ID: %s\n\n%v", activity.ID, err))
+		return
+	}
+
+	target, err := writeActivityLog(logPath, activity.ID, data)
+	if err != nil {
+		diags.AddWarning("Failed to persist activity log", fmt.Sprintf("Activity ID: %s\nPath: %s\n\n%v", activity.ID, target, err))
+		return
+	}
+
+	tflog.Info(ctx, "Persisted activity log", map[string]any{
+		"activity_id": activity.ID,
+		"path":        target,
+	})
+}
+
+// waitForActivityCompletion checks the activity status every pollInterval until it completes,
+// fails, or ctx ends, logging each status it observes, and returns the last observed activity
+// and, once it has finished, its activity log. The wait is bounded only by ctx, which carries
+// the deadline from the resource's timeouts block. The failed devices of an activity that
+// completes with errors are recorded in results, or listed in the warning about it when results
+// is nil.
+func (r *DeviceManagementServiceResource) waitForActivityCompletion(ctx context.Context, activityID, logPath string, pollInterval time.Duration, results *activityResults, diags *diag.Diagnostics) (*client.OrgDeviceActivity, *activityLog, error) {
+	started := r.client.Clock().Now()
+	activity, err := r.client.WaitForActivity(ctx, activityID, pollInterval, func(activity *client.OrgDeviceActivity) {
+		tflog.Debug(ctx, "Checked activity status", map[string]any{
+			"activity_id": activityID,
+			"status":      activity.Attributes.Status,
+			"sub_status":  activity.Attributes.SubStatus,
+			"elapsed":     r.client.Clock().Now().Sub(started).String(),
+		})
+	})
+	switch {
+	case err != nil && errors.Is(err, context.DeadlineExceeded):
+		return activity, nil, fmt.Errorf("timed out waiting for activity to complete; increase the timeouts for this operation to wait longer: %w", err)
+	case err != nil:
+		return activity, nil, err
+	}
+
+	log := newActivityLog(activity)
+	persistActivityLog(ctx, activity, log, logPath, diags)
+	switch activity.Attributes.Status {
+	case "COMPLETED":
+		if activity.Attributes.SubStatus != "COMPLETED_WITH_SUCCESS" {
+			summary := fmt.Sprintf("Activity ID: %s\n\nCompleted with SubStatus: %s", activityID, client.DescribeSubStatus(activity.Attributes.SubStatus))
+
+			if activity.Attributes.DownloadURL != "" {
+				var logSummary string
+				var err error
+				if results != nil {
+					logSummary, err = results.collect(ctx, activity, log)
+				} else {
+					logSummary, err = log.summary(ctx)
 				}
+				if err == nil {
+					summary = fmt.Sprintf("Activity ID: %s\n\n%s", activityID, logSummary)
+				} else {
+					summary = fmt.Sprintf("%s\n\nFailed to download activity log: %v\n\nActivity log available at: %s", summary, err, activity.Attributes.DownloadURL)
+				}
+			}
 
-				diags.AddWarning(
-					"Device operation completed with errors. Please check the Activity Log in the AxM portal for more details.",
-					summary,
-				)
+			diags.AddWarning(
+				"Device operation completed with errors. Please check the Activity Log in the AxM portal for more details.",
+				summary,
+			)
+		}
+		return activity, log, nil
+	case "FAILED":
+		return activity, log, fmt.Errorf("activity failed with sub-status: %s", client.DescribeSubStatus(activity.Attributes.SubStatus))
+	case "STOPPED":
+		return activity, log, fmt.Errorf("activity stopped with sub-status: %s", client.DescribeSubStatus(activity.Attributes.SubStatus))
+	default:
+		return activity, log, fmt.Errorf("unknown activity status: %s", activity.Attributes.Status)
+	}
+}
+
+// RunDeviceActivity assigns deviceIDs to, or unassigns them from, serverID with c, waiting for
+// each activity at the default poll interval and auditing it as this resource does. It returns
+// the serial numbers the activity logs report as no longer found in the organization.
+func RunDeviceActivity(ctx context.Context, c *client.Client, serverID string, deviceIDs []string, assign bool, diags *diag.Diagnostics) ([]string, error) {
+	r := &DeviceManagementServiceResource{client: c}
+	return r.runDeviceActivity(ctx, serverID, deviceIDs, assign, "", client.DefaultActivityPollInterval, 0, nil, nil, diags)
+}
+
+// runDeviceActivity submits assignment or unassignment activities for deviceIDs, in chunks of
+// at most the client's activity chunk size submitted at least batchDelay apart, waits for each to
+// finish, checking its status every pollInterval, and records each outcome in the provider audit
+// log. The activity log of each activity is downloaded at most once and shared by everything
+// that reads it. It returns the serial numbers of devices the activity logs report as no longer
+// found in the organization, such as devices released between plan and apply.
+// These are reported as a warning, and an activity that failed only because of them is not
+// treated as an error. A failed chunk does not stop the remaining chunks from being submitted;
+// the errors of every failed chunk are returned together. When ledger is not nil, a chunk
+// identical to one the previous apply recorded reuses that activity while it is in progress or
+// completed, and every activity used is recorded in ledger. An activity still running when ctx
+// ends is reported and recorded in ledger as pending. When results is not nil, the devices the
+// activities did not process successfully are recorded in it.
+func (r *DeviceManagementServiceResource) runDeviceActivity(ctx context.Context, serverID string, deviceIDs []string, assign bool, logPath string, pollInterval, batchDelay time.Duration, ledger *activityLedger, results *activityResults, diags *diag.Diagnostics) ([]string, error) {
+	var notFound []string
+	reuse := func(ctx context.Context, chunkIDs []string) *client.OrgDeviceActivity {
+		return r.reusableActivity(ctx, ledger, client.ActivityIdempotencyKey(serverID, chunkIDs, assign))
+	}
+	_, err := r.client.AssignDevicesToMDMServerInChunks(ctx, serverID, deviceIDs, assign, batchDelay, reuse, func(ctx context.Context, chunk client.DeviceActivityChunk) error {
+		if chunk.Activity != nil && !chunk.Reused {
+			ledger.record(client.ActivityIdempotencyKey(serverID, chunk.DeviceIDs, assign), chunk.Activity.ID, r.client.Clock().Now())
+		}
+		missing, err := r.completeDeviceActivity(ctx, serverID, chunk, assign, logPath, pollInterval, results, diags)
+		if err != nil && chunk.Activity != nil && ctx.Err() != nil {
+			r.abandonActivity(ctx, chunk.Activity, chunk.DeviceIDs, assign, ledger, diags)
+		}
+		notFound = append(notFound, missing...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notFound, nil
+}
+
+// reusableActivity returns the activity the previous apply recorded for key when it is still in
+// progress or has completed, recording it again in ledger, and nil when a new activity should be
+// submitted.
+func (r *DeviceManagementServiceResource) reusableActivity(ctx context.Context, ledger *activityLedger, key string) *client.OrgDeviceActivity {
+	activityID, ok := ledger.lookup(key, r.client.Clock().Now())
+	if !ok {
+		return nil
+	}
+	activity, err := r.client.GetOrgDeviceActivity(ctx, activityID, nil)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to read previously submitted activity; submitting a new one", map[string]any{
+			"activity_id": activityID,
+			"error":       err.Error(),
+		})
+		return nil
+	}
+	switch activity.Attributes.Status {
+	case client.ActivityStatusInProgress, client.ActivityStatusCompleted:
+		tflog.Info(ctx, "Reusing identical activity submitted by a previous apply", map[string]any{
+			"activity_id": activityID,
+			"status":      activity.Attributes.Status,
+		})
+		ledger.current[key] = ledger.prior[key]
+		return activity
+	}
+	return nil
+}
+
+// completeDeviceActivity waits for the activity submitted for one chunk of devices to finish,
+// records its outcome in the provider audit log, and returns the serial numbers of devices it
+// could not find.
+func (r *DeviceManagementServiceResource) completeDeviceActivity(ctx context.Context, serverID string, chunk client.DeviceActivityChunk, assign bool, logPath string, pollInterval time.Duration, results *activityResults, diags *diag.Diagnostics) ([]string, error) {
+	record := client.AuditRecord{
+		ServerID:     serverID,
+		ActivityType: client.ActivityTypeAssignDevices,
+		DeviceCount:  len(chunk.DeviceIDs),
+	}
+	if !assign {
+		record.ActivityType = client.ActivityTypeUnassignDevices
+	}
+
+	if chunk.Err != nil {
+		record.Result = "SUBMIT_FAILED"
+		record.Error = chunk.Err.Error()
+		r.writeAuditRecord(ctx, record, diags)
+		return nil, fmt.Errorf("failed to submit activity: %w", chunk.Err)
+	}
+	activity := chunk.Activity
+	record.ActivityID = activity.ID
+
+	final, log, err := r.waitForActivityCompletion(ctx, activity.ID, logPath, pollInterval, results, diags)
+	if final != nil && final.Attributes.Status != client.ActivityStatusInProgress {
+		results.observe(final, record.ActivityType, log)
+	}
+	var notFound []string
+	if log != nil && final.Attributes.DownloadURL != "" &&
+		(final.Attributes.Status == client.ActivityStatusFailed || (err == nil && final.Attributes.SubStatus != "COMPLETED_WITH_SUCCESS")) {
+		var onlyNotFound bool
+		notFound, onlyNotFound = notFoundDevices(ctx, log)
+		if err != nil && onlyNotFound {
+			err = nil
+			if results != nil {
+				if _, logErr := results.collect(ctx, final, log); logErr != nil {
+					tflog.Warn(ctx, "Unable to record failed devices from activity log", map[string]any{
+						"activity_id": activity.ID,
+						"error":       logErr.Error(),
+					})
+				}
 			}
-			return nil
-		case "FAILED":
-			return fmt.Errorf("activity failed with sub-status: %s", activity.Attributes.SubStatus)
-		case "STOPPED":
-			return fmt.Errorf("activity stopped with sub-status: %s", activity.Attributes.SubStatus)
-		case "IN_PROGRESS":
-			if attempt == maxAttempts {
-				return fmt.Errorf("timed out waiting for activity to complete after %d attempts", maxAttempts)
+		}
+	}
+
+	switch {
+	case final != nil && final.Attributes.SubStatus != "":
+		record.Result = final.Attributes.SubStatus
+	case final != nil:
+		record.Result = final.Attributes.Status
+	default:
+		record.Result = "UNKNOWN"
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	r.writeAuditRecord(ctx, record, diags)
+
+	if err != nil {
+		return nil, fmt.Errorf("activity %s did not complete: %w", activity.ID, err)
+	}
+	if len(notFound) > 0 {
+		diags.AddWarning(
+			"Devices no longer in the organization",
+			fmt.Sprintf("Activity %s could not find %d device(s), which may have been released from the organization since the plan: %s\n\n"+
+				"They were skipped and the remaining devices were processed. Remove them from configuration.",
+				activity.ID, len(notFound), strings.Join(notFound, ", ")),
+		)
+	}
+	return notFound, nil
+}
+
+// notFoundDevices returns the serial numbers of log whose operation failed because the device
+// was not found, and whether every failed device failed that way. A log that cannot be read
+// yields no devices.
+func notFoundDevices(ctx context.Context, log *activityLog) ([]string, bool) {
+	rows, err := log.parsed(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read activity log to classify failed devices", map[string]any{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+
+	notFound := notFoundSerialNumbers(rows)
+	return notFound, len(notFound) > 0 && len(notFound) == len(FailedSerialNumbers(rows))
+}
+
+// warnNotFoundKept reports that the devices in notFound, which device_ids lists but the
+// organization no longer has, stay in device_ids. Terraform requires the applied device_ids to
+// match the configuration, so they are kept and reported in missing_devices, and every plan
+// proposes assigning them again until they are removed from configuration.
+func warnNotFoundKept(notFound []string, diags *diag.Diagnostics) {
+	if len(notFound) == 0 {
+		return
+	}
+	diags.AddWarning(
+		"Devices not found remain in device_ids",
+		fmt.Sprintf("%d device(s) listed in device_ids were not found in the organization: %s\n\n"+
+			"They stay in device_ids because state must match the configuration, and are reported in missing_devices. "+
+			"Every plan proposes assigning them again until they are removed from device_ids.",
+			len(notFound), strings.Join(notFound, ", ")),
+	)
+}
+
+// isNotFoundRow reports whether an activity log row records a device that failed because it
+// was not found in the organization.
+func isNotFoundRow(row map[string]string) bool {
+	return isFailedRow(row) && strings.Contains(strings.ToUpper(row["operation_substatus"]), "NOT_FOUND")
+}
+
+// notFoundSerialNumbers returns the unique serial numbers of the rows of an activity log whose
+// device was not found, in log order.
+func notFoundSerialNumbers(rows []map[string]string) []string {
+	var notFound []map[string]string
+	for _, row := range rows {
+		if isNotFoundRow(row) {
+			notFound = append(notFound, row)
+		}
+	}
+	return FailedSerialNumbers(notFound)
+}
+
+// withoutDevices returns deviceIDs without the devices in removed. Serial numbers are compared
+// case-insensitively.
+func withoutDevices(deviceIDs, removed []string) []string {
+	if len(removed) == 0 {
+		return deviceIDs
+	}
+	skip := make(map[string]bool, len(removed))
+	for _, id := range removed {
+		skip[strings.ToUpper(id)] = true
+	}
+	result := make([]string, 0, len(deviceIDs))
+	for _, id := range deviceIDs {
+		if !skip[strings.ToUpper(id)] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// retainExternallyAdded removes from toUnassign the devices assigned to the server outside
+// Terraform, which are the current devices not among the managed device IDs. It returns the
+// devices left to unassign and the externally added devices kept on the server.
+func retainExternallyAdded(current, managed, toUnassign []string) (remaining, retained []string) {
+	_, externallyAdded := diffDeviceIDs(current, managed)
+	remaining = withoutDevices(toUnassign, externallyAdded)
+	if len(remaining) == len(toUnassign) {
+		return toUnassign, nil
+	}
+	return remaining, withoutDevices(toUnassign, remaining)
+}
+
+// ReleaseDevices submits a RELEASE_DEVICES activity for deviceIDs with c, waits for it to
+// finish, and records its outcome in the provider audit log. It returns the activity ID. The
+// activity log summary of a failed activity is included in the returned error.
+func ReleaseDevices(ctx context.Context, c *client.Client, deviceIDs []string, diags *diag.Diagnostics) (string, error) {
+	r := &DeviceManagementServiceResource{client: c}
+	record := client.AuditRecord{
+		ActivityType: client.ActivityTypeReleaseDevices,
+		DeviceCount:  len(deviceIDs),
+	}
+
+	activity, err := c.ReleaseDevices(ctx, deviceIDs)
+	if err != nil {
+		record.Result = "SUBMIT_FAILED"
+		record.Error = err.Error()
+		r.writeAuditRecord(ctx, record, diags)
+		return "", fmt.Errorf("failed to submit activity: %w", err)
+	}
+	record.ActivityID = activity.ID
+
+	final, log, err := r.waitForActivityCompletion(ctx, activity.ID, "", client.DefaultActivityPollInterval, nil, diags)
+	switch {
+	case final != nil && final.Attributes.SubStatus != "":
+		record.Result = final.Attributes.SubStatus
+	case final != nil:
+		record.Result = final.Attributes.Status
+	default:
+		record.Result = "UNKNOWN"
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	r.writeAuditRecord(ctx, record, diags)
+
+	if err != nil {
+		if log != nil && final.Attributes.DownloadURL != "" {
+			if summary, logErr := log.summary(ctx); logErr == nil {
+				err = fmt.Errorf("%w\n\n%s", err, summary)
 			}
-		default:
-			return fmt.Errorf("unknown activity status: %s", activity.Attributes.Status)
 		}
+		return activity.ID, fmt.Errorf("activity %s did not complete: %w", activity.ID, err)
+	}
+	return activity.ID, nil
+}
+
+// writeAuditRecord appends record to the provider audit log, reporting failures as warnings.
+func (r *DeviceManagementServiceResource) writeAuditRecord(ctx context.Context, record client.AuditRecord, diags *diag.Diagnostics) {
+	if err := r.client.WriteAuditRecord(record); err != nil {
+		tflog.Warn(ctx, "Failed to write audit record", map[string]any{
+			"activity_id": record.ActivityID,
+			"error":       err.Error(),
+		})
+		diags.AddWarning(
+			"Failed to write audit record",
+			fmt.Sprintf("Activity %s (%s) could not be recorded in the audit log: %s", record.ActivityID, record.ActivityType, err),
+		)
+	}
+}
+
+// blocksAssignment reports whether assigning devices to a server of serverType must be refused
+// because the server is not an MDM server and allow_non_mdm_server is not set.
+func blocksAssignment(serverType string, allowNonMdm types.Bool) bool {
+	return serverType == client.MdmServerTypeAppleConfigurator && !allowNonMdm.ValueBool()
+}
+
+// nonMdmServerDetail describes a blocked assignment to a non-MDM server.
+func nonMdmServerDetail(serverType string, count int) string {
+	return fmt.Sprintf("This change would assign %d device(s) to a server of type %s, which cannot enroll devices in MDM. "+
+		"Assign the devices to an MDM server instead, or set allow_non_mdm_server = true if this is intended.", count, serverType)
+}
+
+// exceedsUnassignLimit reports whether unassigning count devices breaches the configured
+// limit without explicit confirmation.
+func exceedsUnassignLimit(limit types.Int64, confirmed types.Bool, count int) bool {
+	if limit.IsNull() || limit.IsUnknown() || confirmed.ValueBool() {
+		return false
 	}
+	return int64(count) > limit.ValueInt64()
+}
 
-	return fmt.Errorf("unexpected error monitoring activity status")
+// missingDevicesDetail describes planned devices that do not exist in the organization.
+func missingDevicesDetail(missing []string) string {
+	return fmt.Sprintf("%d planned device(s) were not found in the organization: %s\n\n"+
+		"Check device_ids for typos, or remove validate_devices to skip this check.", len(missing), strings.Join(missing, ", "))
 }
@@ -5,13 +5,20 @@ package device_management_service
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	"github.com/neilmartin83/terraform-provider-axm/internal/client"
 )
@@ -119,9 +126,223 @@ func TestStringsToSet(t *testing.T) {
 	}
 }
 
-func TestDownloadAndParseActivityLog(t *testing.T) {
+func TestApplyDeviceIDAliases(t *testing.T) {
+	current := []string{"DEV001", "DEV002", "DEV003"}
+	resolved := map[string]string{
+		"SN001":  "DEV001",
+		"DEV002": "DEV002",
+	}
+
+	got := applyDeviceIDAliases(current, resolved)
+	want := []string{"SN001", "DEV002", "DEV003"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d elements, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("element[%d]: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestDiffDeviceIDs(t *testing.T) {
+	toAssign, toUnassign := diffDeviceIDs([]string{"SN001", "SN002"}, []string{"SN002", "SN003"})
+	if len(toAssign) != 1 || toAssign[0] != "SN003" {
+		t.Errorf("expected toAssign [SN003], got %v", toAssign)
+	}
+	if len(toUnassign) != 1 || toUnassign[0] != "SN001" {
+		t.Errorf("expected toUnassign [SN001], got %v", toUnassign)
+	}
+}
+
+func TestRetainExternallyAdded(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+	setManagedDeviceIDs(ctx, private, []string{"SN001", "SN002"})
+	managed, tracked, _ := managedDeviceIDs(ctx, private)
+	if !tracked {
+		t.Fatal("expected the managed devices to be tracked")
+	}
+
+	current := []string{"SN001", "SN002", "EXTERNAL"}
+	_, toUnassign := diffDeviceIDs(current, []string{"SN001", "SN002", "SN003"})
+	remaining, retained := retainExternallyAdded(current, managed, toUnassign)
+	if len(remaining) != 0 {
+		t.Errorf("expected nothing to unassign when only a device was added, got %v", remaining)
+	}
+	if !slices.Equal(retained, []string{"EXTERNAL"}) {
+		t.Errorf("expected the externally added device to be retained, got %v", retained)
+	}
+
+	_, toUnassign = diffDeviceIDs(current, []string{"SN001"})
+	remaining, retained = retainExternallyAdded(current, managed, toUnassign)
+	if !slices.Equal(remaining, []string{"SN002"}) {
+		t.Errorf("expected only the removed managed device to be unassigned, got %v", remaining)
+	}
+	if !slices.Equal(retained, []string{"EXTERNAL"}) {
+		t.Errorf("expected the externally added device to be retained, got %v", retained)
+	}
+}
+
+// fakePrivateState is an in-memory stand-in for resource private state.
+type fakePrivateState map[string][]byte
+
+func (f fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return f[key], nil
+}
+
+func (f fakePrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	f[key] = value
+	return nil
+}
+
+func TestManagedDeviceIDs(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+
+	if _, tracked, diags := managedDeviceIDs(ctx, private); tracked || diags.HasError() {
+		t.Fatalf("expected no managed devices before they are recorded, got tracked=%v diags=%v", tracked, diags)
+	}
+
+	if diags := setManagedDeviceIDs(ctx, private, nil); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	ids, tracked, _ := managedDeviceIDs(ctx, private)
+	if !tracked || len(ids) != 0 {
+		t.Errorf("expected an empty tracked set, got tracked=%v ids=%v", tracked, ids)
+	}
+
+	setManagedDeviceIDs(ctx, private, []string{"SN001", "SN002"})
+	ids, tracked, _ = managedDeviceIDs(ctx, private)
+	if !tracked || !slices.Equal(ids, []string{"SN001", "SN002"}) {
+		t.Errorf("expected [SN001 SN002], got tracked=%v ids=%v", tracked, ids)
+	}
+}
+
+func TestActivityLedger(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	private := fakePrivateState{}
+
+	ledger, diags := loadActivityLedger(ctx, private)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if _, ok := ledger.lookup("key-1", now); ok {
+		t.Fatal("expected no activities before any are recorded")
+	}
+
+	ledger.record("key-1", "act-1", now.Add(-30*time.Minute))
+	ledger.record("key-2", "act-2", now.Add(-2*time.Hour))
+	failed := diag.Diagnostics{}
+	failed.AddError("Failed to assign devices", "timed out")
+	ledger.persist(ctx, private, &failed)
+
+	retried, _ := loadActivityLedger(ctx, private)
+	if id, ok := retried.lookup("key-1", now); !ok || id != "act-1" {
+		t.Errorf("expected act-1 to be reusable after a failed apply, got %q, %v", id, ok)
+	}
+	if _, ok := retried.lookup("key-2", now); ok {
+		t.Error("expected an activity older than the idempotency window not to be reusable")
+	}
+
+	var succeeded diag.Diagnostics
+	retried.record("key-3", "act-3", now)
+	retried.persist(ctx, private, &succeeded)
+	if succeeded.HasError() || len(private[activityLedgerKey]) != 0 {
+		t.Errorf("expected a successful apply to clear recorded activities, got %s", private[activityLedgerKey])
+	}
+
+	var disabled *activityLedger
+	disabled.record("key-1", "act-1", now)
+	if _, ok := disabled.lookup("key-1", now); ok {
+		t.Error("expected a nil ledger to disable reuse")
+	}
+}
+
+func TestPendingActivities(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	private := fakePrivateState{}
+
+	ledger, _ := loadActivityLedger(ctx, private)
+	ledger.abandon(pendingActivity{ActivityID: "act-1", Assign: true, DeviceIDs: []string{"SN003"}, AbandonedAt: now})
+	interrupted := diag.Diagnostics{}
+	interrupted.AddError("Failed to assign devices", "context canceled")
+	ledger.persist(ctx, private, &interrupted)
+
+	pending, diags := loadPendingActivities(ctx, private)
+	if diags.HasError() || len(pending) != 1 || pending[0].ActivityID != "act-1" || !pending[0].Assign {
+		t.Fatalf("expected act-1 to be pending after an interrupted apply, got %+v, %v", pending, diags)
+	}
+
+	retried, _ := loadActivityLedger(ctx, private)
+	retried.abandon(pendingActivity{ActivityID: "act-2", DeviceIDs: []string{"SN001"}, AbandonedAt: now})
+	retried.persist(ctx, private, &interrupted)
+	if pending, _ = loadPendingActivities(ctx, private); len(pending) != 2 {
+		t.Fatalf("expected both interrupted activities to be pending, got %+v", pending)
+	}
+
+	var succeeded diag.Diagnostics
+	applied, _ := loadActivityLedger(ctx, private)
+	applied.persist(ctx, private, &succeeded)
+	if pending, _ = loadPendingActivities(ctx, private); len(pending) != 0 {
+		t.Errorf("expected a successful apply to clear pending activities, got %+v", pending)
+	}
+}
+
+func TestApplyPendingActivity(t *testing.T) {
+	managed := []string{"SN001", "SN002"}
+
+	got := applyPendingActivity(managed, pendingActivity{Assign: true, DeviceIDs: []string{"SN002", "SN003"}})
+	if !slices.Equal(got, []string{"SN001", "SN002", "SN003"}) {
+		t.Errorf("expected a completed assignment to add its devices, got %v", got)
+	}
+
+	got = applyPendingActivity(managed, pendingActivity{DeviceIDs: []string{"sn001"}})
+	if !slices.Equal(got, []string{"SN002"}) {
+		t.Errorf("expected a completed unassignment to remove its devices, got %v", got)
+	}
+	if !slices.Equal(managed, []string{"SN001", "SN002"}) {
+		t.Errorf("expected managed to be left unchanged, got %v", managed)
+	}
+}
+
+func TestAssignmentDrift(t *testing.T) {
+	added, missing, diags := assignmentDrift([]string{"SN001", "SN002"}, []string{"SN002", "SN003"}, true)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := extractStrings(added); !slices.Equal(got, []string{"SN003"}) {
+		t.Errorf("expected externally added [SN003], got %v", got)
+	}
+	if got := extractStrings(missing); !slices.Equal(got, []string{"SN001"}) {
+		t.Errorf("expected missing [SN001], got %v", got)
+	}
+
+	added, missing, _ = assignmentDrift(nil, []string{"SN002"}, false)
+	if !added.IsNull() || !missing.IsNull() {
+		t.Error("expected null drift sets when managed devices are not tracked")
+	}
+}
+
+func TestDryRunSummary(t *testing.T) {
+	summary := dryRunSummary([]string{"SN003"}, []string{"SN001", "SN002"})
+	if !strings.Contains(summary, "Would assign 1 device(s): SN003") {
+		t.Errorf("expected assign line, got %q", summary)
+	}
+	if !strings.Contains(summary, "Would unassign 2 device(s): SN001, SN002") {
+		t.Errorf("expected unassign line, got %q", summary)
+	}
+}
+
+func TestActivityLogSummary(t *testing.T) {
+	summarize := func(downloadURL string) (string, error) {
+		return newActivityLog(&client.OrgDeviceActivity{Attributes: client.OrgDeviceActivityAttributes{DownloadURL: downloadURL}}).summary(context.Background())
+	}
+
 	t.Run("empty_url", func(t *testing.T) {
-		_, err := downloadAndParseActivityLog(context.Background(), "")
+		_, err := summarize("")
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -137,7 +358,7 @@ func TestDownloadAndParseActivityLog(t *testing.T) {
 		}))
 		defer server.Close()
 
-		summary, err := downloadAndParseActivityLog(context.Background(), server.URL)
+		summary, err := summarize(server.URL)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -153,7 +374,7 @@ func TestDownloadAndParseActivityLog(t *testing.T) {
 		}))
 		defer server.Close()
 
-		summary, err := downloadAndParseActivityLog(context.Background(), server.URL)
+		summary, err := summarize(server.URL)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -179,7 +400,7 @@ func TestDownloadAndParseActivityLog(t *testing.T) {
 		}))
 		defer server.Close()
 
-		summary, err := downloadAndParseActivityLog(context.Background(), server.URL)
+		summary, err := summarize(server.URL)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -197,7 +418,7 @@ func TestDownloadAndParseActivityLog(t *testing.T) {
 		}))
 		defer server.Close()
 
-		_, err := downloadAndParseActivityLog(context.Background(), server.URL)
+		_, err := summarize(server.URL)
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -207,12 +428,234 @@ func TestDownloadAndParseActivityLog(t *testing.T) {
 	})
 }
 
+func TestFailedSerialNumbers(t *testing.T) {
+	rows := []map[string]string{
+		{"serial_number": "SN1", "operation_status": "SUCCESS"},
+		{"serial_number": "SN2", "operation_status": "FAILED", "operation_substatus": "DEVICE_NOT_FOUND"},
+		{"serial_number": "SN3", "operation_status": ""},
+		{"serial_number": "SN4", "operation_status": "FAILED"},
+		{"serial_number": "SN2", "operation_status": "FAILED"},
+		{"serial_number": "", "operation_status": "FAILED"},
+	}
+
+	got := FailedSerialNumbers(rows)
+	if want := []string{"SN2", "SN4"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestNotFoundSerialNumbers(t *testing.T) {
+	rows := []map[string]string{
+		{"serial_number": "SN1", "operation_status": "SUCCESS"},
+		{"serial_number": "SN2", "operation_status": "FAILED", "operation_substatus": "DEVICE_NOT_FOUND"},
+		{"serial_number": "SN3", "operation_status": "FAILED", "operation_substatus": "ALREADY_ASSIGNED"},
+		{"serial_number": "SN4", "operation_status": "FAILED", "operation_substatus": "device_not_found"},
+		{"serial_number": "SN2", "operation_status": "FAILED", "operation_substatus": "DEVICE_NOT_FOUND"},
+	}
+
+	got := notFoundSerialNumbers(rows)
+	if want := []string{"SN2", "SN4"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestNotFoundDevices(t *testing.T) {
+	tests := []struct {
+		name         string
+		csv          string
+		want         []string
+		onlyNotFound bool
+	}{
+		{
+			name:         "only_not_found",
+			csv:          "serial_number,operation_status,operation_substatus\nSN1,SUCCESS,\nSN2,FAILED,DEVICE_NOT_FOUND\n",
+			want:         []string{"SN2"},
+			onlyNotFound: true,
+		},
+		{
+			name: "mixed_failures",
+			csv:  "serial_number,operation_status,operation_substatus\nSN1,FAILED,ALREADY_ASSIGNED\nSN2,FAILED,DEVICE_NOT_FOUND\n",
+			want: []string{"SN2"},
+		},
+		{
+			name: "no_failures",
+			csv:  "serial_number,operation_status,operation_substatus\nSN1,SUCCESS,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.csv))
+			}))
+			defer server.Close()
+
+			got, onlyNotFound := notFoundDevices(context.Background(), newActivityLog(&client.OrgDeviceActivity{Attributes: client.OrgDeviceActivityAttributes{DownloadURL: server.URL}}))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if onlyNotFound != tt.onlyNotFound {
+				t.Errorf("expected onlyNotFound %v, got %v", tt.onlyNotFound, onlyNotFound)
+			}
+		})
+	}
+
+	t.Run("http_error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		got, onlyNotFound := notFoundDevices(context.Background(), newActivityLog(&client.OrgDeviceActivity{Attributes: client.OrgDeviceActivityAttributes{DownloadURL: server.URL}}))
+		if got != nil || onlyNotFound {
+			t.Errorf("expected no devices when the log cannot be read, got %v (%v)", got, onlyNotFound)
+		}
+	})
+}
+
+func TestWithoutDevices(t *testing.T) {
+	got := withoutDevices([]string{"SN1", "sn2", "SN3"}, []string{"SN2"})
+	if want := []string{"SN1", "SN3"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	ids := []string{"SN1"}
+	if got := withoutDevices(ids, nil); !slices.Equal(got, ids) {
+		t.Errorf("expected %v unchanged, got %v", ids, got)
+	}
+}
+
+func TestWarnNotFoundKept(t *testing.T) {
+	var diags diag.Diagnostics
+	warnNotFoundKept(nil, &diags)
+	if len(diags) != 0 {
+		t.Fatalf("expected no warning without devices, got %v", diags)
+	}
+	warnNotFoundKept([]string{"SN1", "SN2"}, &diags)
+	if diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "SN1, SN2") || !strings.Contains(diags[0].Detail(), "missing_devices") {
+		t.Errorf("expected a warning naming the devices kept in device_ids, got %v", diags)
+	}
+}
+
+func TestWriteActivityLog(t *testing.T) {
+	dir := t.TempDir()
+	template := filepath.Join(dir, "logs", "{activity_id}.csv")
+	data := []byte("serial_number,operation_status\nSN001,SUCCESS\n")
+
+	target, err := writeActivityLog(template, "act-123", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "logs", "act-123.csv"); target != want {
+		t.Errorf("expected path %q, got %q", want, target)
+	}
+	written, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("failed to read written log: %v", err)
+	}
+	if string(written) != string(data) {
+		t.Errorf("expected written content %q, got %q", data, written)
+	}
+}
+
+func TestActivityLogTarget(t *testing.T) {
+	tests := map[string]string{
+		"logs/{activity_id}.csv":     "logs/act-123.csv",
+		"logs/{activity_id}/log.csv": "logs/act-123/log.csv",
+		"logs/results.csv":           "logs/results-act-123.csv",
+		"logs/results":               "logs/results-act-123",
+	}
+	for template, want := range tests {
+		if got := activityLogTarget(template, "act-123"); got != want {
+			t.Errorf("activityLogTarget(%q) = %q, want %q", template, got, want)
+		}
+	}
+}
+
+func TestPersistActivityLog(t *testing.T) {
+	csvData := "serial_number,operation_status\nSN001,SUCCESS\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(csvData))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	activity := &client.OrgDeviceActivity{
+		ID:         "act-456",
+		Attributes: client.OrgDeviceActivityAttributes{DownloadURL: server.URL},
+	}
+
+	var diags diag.Diagnostics
+	persistActivityLog(context.Background(), activity, newActivityLog(activity), filepath.Join(dir, "{activity_id}.csv"), &diags)
+	if diags.WarningsCount() != 0 {
+		t.Fatalf("unexpected warnings: %v", diags.Warnings())
+	}
+	written, err := os.ReadFile(filepath.Join(dir, "act-456.csv"))
+	if err != nil {
+		t.Fatalf("failed to read written log: %v", err)
+	}
+	if string(written) != csvData {
+		t.Errorf("expected written content %q, got %q", csvData, written)
+	}
+
+	diags = diag.Diagnostics{}
+	noURL := &client.OrgDeviceActivity{ID: "act-789"}
+	persistActivityLog(context.Background(), noURL, newActivityLog(noURL), filepath.Join(dir, "{activity_id}.csv"), &diags)
+	if diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning when no download URL is available, got %d", diags.WarningsCount())
+	}
+}
+
+func TestApplyServerAttributes(t *testing.T) {
+	deviceCount := int64(12)
+	allowRelease := true
+	srv := &client.MdmServer{
+		ID: "srv-1",
+		Attributes: client.MdmServerAttribute{
+			ServerName:             "Jamf Pro",
+			ServerType:             "MDM",
+			DeviceCount:            &deviceCount,
+			EnableMdmDisownFlag:    &allowRelease,
+			DefaultProductFamilies: []client.MdmServerProductFamily{"Mac"},
+			CreatedDateTime:        "2026-01-01T00:00:00Z",
+			UpdatedDateTime:        "2026-02-01T00:00:00Z",
+		},
+		Links: client.ResourceLinks{Self: "https://api-business.apple.com/v1/mdmServers/srv-1"},
+	}
+
+	data := MdmDeviceAssignmentModel{DryRun: types.BoolValue(true)}
+	applyServerAttributes(context.Background(), &data, srv)
+
+	if data.Name.ValueString() != "Jamf Pro" || data.Type.ValueString() != "MDM" {
+		t.Errorf("unexpected name/type: %s/%s", data.Name, data.Type)
+	}
+	if data.DeviceCount.ValueInt64() != 12 {
+		t.Errorf("expected device_count 12, got %s", data.DeviceCount)
+	}
+	if !data.AllowRelease.ValueBool() {
+		t.Error("expected allow_release true")
+	}
+	if !data.LastConnectedDateTime.IsNull() || !data.LastConnectedIp.IsNull() {
+		t.Error("expected unreported last connection attributes to be null")
+	}
+	if len(data.DefaultProductFamilies.Elements()) != 1 {
+		t.Errorf("expected 1 default product family, got %s", data.DefaultProductFamilies)
+	}
+	if data.SelfLink.ValueString() != srv.Links.Self {
+		t.Errorf("expected self_link %q, got %s", srv.Links.Self, data.SelfLink)
+	}
+	if !data.DryRun.ValueBool() {
+		t.Error("expected configuration-only attributes to be left untouched")
+	}
+}
+
 func TestFilterDeviceManagementServiceList(t *testing.T) {
 	servers := []client.MdmServer{
 		{ID: "srv-1", Attributes: client.MdmServerAttribute{ServerName: "Jamf Pro", ServerType: "MDM"}},
 		{ID: "srv-2", Attributes: client.MdmServerAttribute{ServerName: "Mosyle", ServerType: "MDM"}},
 		{ID: "srv-3", Attributes: client.MdmServerAttribute{ServerName: "Apple Configurator", ServerType: "APPLE_CONFIGURATOR"}},
 		{ID: "srv-4", Attributes: client.MdmServerAttribute{ServerName: "  Jamf Pro Cloud  ", ServerType: "MDM"}},
+		{ID: "srv-5", Attributes: client.MdmServerAttribute{ServerName: "\u00c9cole Saint\u2019s MDM", ServerType: "MDM"}},
 	}
 
 	tests := []struct {
@@ -223,7 +666,7 @@ func TestFilterDeviceManagementServiceList(t *testing.T) {
 		{
 			name:    "no_filters",
 			config:  DeviceManagementServiceListResourceModel{},
-			wantIDs: []string{"srv-1", "srv-2", "srv-3", "srv-4"},
+			wantIDs: []string{"srv-1", "srv-2", "srv-3", "srv-4", "srv-5"},
 		},
 		{
 			name:    "exact_name_match",
@@ -255,6 +698,16 @@ func TestFilterDeviceManagementServiceList(t *testing.T) {
 			config:  DeviceManagementServiceListResourceModel{Name: types.StringValue("JAMF PRO")},
 			wantIDs: []string{"srv-1"},
 		},
+		{
+			name:    "unicode_normalized_exact_name",
+			config:  DeviceManagementServiceListResourceModel{Name: types.StringValue("e\u0301cole saint's mdm")},
+			wantIDs: []string{"srv-5"},
+		},
+		{
+			name:    "unicode_case_folded_contains",
+			config:  DeviceManagementServiceListResourceModel{NameContains: types.StringValue("\u00c9COLE")},
+			wantIDs: []string{"srv-5"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -299,3 +752,419 @@ func TestNormalizedFilterString(t *testing.T) {
 		})
 	}
 }
+
+func TestExceedsUnassignLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     types.Int64
+		confirmed types.Bool
+		count     int
+		want      bool
+	}{
+		{name: "no_limit", limit: types.Int64Null(), confirmed: types.BoolNull(), count: 1000, want: false},
+		{name: "unknown_limit", limit: types.Int64Unknown(), confirmed: types.BoolNull(), count: 1000, want: false},
+		{name: "within_limit", limit: types.Int64Value(10), confirmed: types.BoolNull(), count: 10, want: false},
+		{name: "over_limit", limit: types.Int64Value(10), confirmed: types.BoolNull(), count: 11, want: true},
+		{name: "over_limit_confirmed", limit: types.Int64Value(10), confirmed: types.BoolValue(true), count: 11, want: false},
+		{name: "over_limit_not_confirmed", limit: types.Int64Value(10), confirmed: types.BoolValue(false), count: 11, want: true},
+		{name: "zero_limit", limit: types.Int64Value(0), confirmed: types.BoolNull(), count: 1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exceedsUnassignLimit(tt.limit, tt.confirmed, tt.count); got != tt.want {
+				t.Errorf("exceedsUnassignLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlocksAssignment(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverType string
+		allow      types.Bool
+		want       bool
+	}{
+		{name: "mdm", serverType: client.MdmServerTypeMdm, allow: types.BoolNull(), want: false},
+		{name: "apple_mdm", serverType: client.MdmServerTypeAppleMdm, allow: types.BoolNull(), want: false},
+		{name: "configurator", serverType: client.MdmServerTypeAppleConfigurator, allow: types.BoolNull(), want: true},
+		{name: "configurator_not_allowed", serverType: client.MdmServerTypeAppleConfigurator, allow: types.BoolValue(false), want: true},
+		{name: "configurator_allowed", serverType: client.MdmServerTypeAppleConfigurator, allow: types.BoolValue(true), want: false},
+		{name: "unknown_type", serverType: "", allow: types.BoolNull(), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blocksAssignment(tt.serverType, tt.allow); got != tt.want {
+				t.Errorf("blocksAssignment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if detail := nonMdmServerDetail(client.MdmServerTypeAppleConfigurator, 3); !strings.Contains(detail, "3 device(s)") || !strings.Contains(detail, "APPLE_CONFIGURATOR") {
+		t.Errorf("expected detail to name the device count and server type, got %q", detail)
+	}
+}
+
+// stubClock is a client.Clock whose timers fire only when fire is closed.
+type stubClock struct {
+	fire chan time.Time
+	last time.Duration
+}
+
+func (c *stubClock) Now() time.Time { return time.Time{} }
+
+func (c *stubClock) After(d time.Duration) <-chan time.Time {
+	c.last = d
+	return c.fire
+}
+
+func TestWaitBatchDelay(t *testing.T) {
+	t.Run("zero_delay_does_not_wait", func(t *testing.T) {
+		clock := &stubClock{}
+		if err := waitBatchDelay(context.Background(), clock, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clock.last != 0 {
+			t.Error("expected no timer for a zero delay")
+		}
+	})
+
+	t.Run("waits_for_clock", func(t *testing.T) {
+		clock := &stubClock{fire: make(chan time.Time)}
+		close(clock.fire)
+		if err := waitBatchDelay(context.Background(), clock, time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if clock.last != time.Hour {
+			t.Errorf("expected timer of 1h, got %s", clock.last)
+		}
+	})
+
+	t.Run("context_cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		clock := &stubClock{fire: make(chan time.Time)}
+		if err := waitBatchDelay(ctx, clock, time.Hour); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestVerifyAssignments(t *testing.T) {
+	newResource := func(t *testing.T, reads [][]string) (*DeviceManagementServiceResource, *int) {
+		t.Helper()
+		var count int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ids := reads[min(count, len(reads)-1)]
+			count++
+			data := make([]client.Data, len(ids))
+			for i, id := range ids {
+				data[i] = client.Data{Type: "orgDevices", ID: id}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "meta": client.Meta{}})
+		}))
+		t.Cleanup(server.Close)
+		c, err := client.NewClientWithAccessToken(server.URL, "business.api", "token", time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		clock := &stubClock{fire: make(chan time.Time)}
+		close(clock.fire)
+		c.SetClock(clock)
+		return &DeviceManagementServiceResource{client: c}, &count
+	}
+
+	t.Run("waits_for_planned_devices", func(t *testing.T) {
+		r, count := newResource(t, [][]string{{"SN001"}, {"SN001"}, {"SN001", "SN002", "EXTERNAL"}})
+		if err := r.verifyAssignments(context.Background(), "srv-1", []string{"SN001", "SN002"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *count != 3 {
+			t.Errorf("expected 3 reads, got %d", *count)
+		}
+		if got := r.client.Clock().(*stubClock).last; got != 2*verifyInitialInterval {
+			t.Errorf("expected the wait to double between reads, got %s", got)
+		}
+	})
+
+	t.Run("rereads_with_the_response_cache_enabled", func(t *testing.T) {
+		r, count := newResource(t, [][]string{{"SN001"}, {"SN001", "SN002"}})
+		r.client.SetResponseCache(time.Hour, false)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := r.verifyAssignments(ctx, "srv-1", []string{"SN001", "SN002"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *count != 2 {
+			t.Errorf("expected every verification read to reach the API, got %d reads", *count)
+		}
+	})
+
+	t.Run("reports_missing_devices_at_timeout", func(t *testing.T) {
+		r, _ := newResource(t, [][]string{{"SN001"}})
+		r.client.SetClock(&stubClock{})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := r.verifyAssignments(ctx, "srv-1", []string{"SN001", "SN002"})
+		if err == nil || !strings.Contains(err.Error(), "1 planned device(s)") || !strings.Contains(err.Error(), "SN002") {
+			t.Errorf("expected the missing device to be reported, got %v", err)
+		}
+	})
+}
+
+func TestFilterProductFamily(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *DeviceFilterModel
+		want   string
+		wantOK bool
+	}{
+		{"no_filter", nil, "", false},
+		{"unknown", &DeviceFilterModel{ProductFamily: types.StringUnknown()}, "", false},
+		{"exact", &DeviceFilterModel{ProductFamily: types.StringValue("Mac")}, "Mac", true},
+		{"case_folded", &DeviceFilterModel{ProductFamily: types.StringValue(" appletv ")}, "AppleTV", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := filterProductFamily(tt.filter)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestProductFamilyDeviceIDs(t *testing.T) {
+	device := func(id, family string) client.OrgDevice {
+		d := client.OrgDevice{ID: id}
+		d.Attributes.ProductFamily = family
+		return d
+	}
+	devices := []client.OrgDevice{
+		device("MAC2", "Mac"),
+		device("PHONE1", "iPhone"),
+		device("MAC1", "mac"),
+		device("MAC2", "Mac"),
+	}
+
+	got := productFamilyDeviceIDs(devices, "Mac")
+	if want := []string{"MAC1", "MAC2"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := productFamilyDeviceIDs(devices, "Vision"); len(got) != 0 {
+		t.Errorf("expected no devices, got %v", got)
+	}
+}
+
+func TestServerIDByName(t *testing.T) {
+	servers := []client.MdmServer{
+		{ID: "SRV1", Attributes: client.MdmServerAttribute{ServerName: "Jamf Pro"}},
+		{ID: "SRV3", Attributes: client.MdmServerAttribute{ServerName: "Intune"}},
+		{ID: "SRV2", Attributes: client.MdmServerAttribute{ServerName: "Intune"}},
+	}
+
+	tests := []struct {
+		name    string
+		server  string
+		want    string
+		wantErr string
+	}{
+		{name: "unique", server: "Jamf Pro", want: "SRV1"},
+		{name: "surrounding_whitespace", server: " Jamf Pro ", want: "SRV1"},
+		{name: "ambiguous", server: "Intune", wantErr: `2 device management services are named "Intune" (SRV2, SRV3)`},
+		{name: "missing", server: "Kandji", wantErr: `no device management service is named "Kandji"`},
+		{name: "case_sensitive", server: "jamf pro", wantErr: "no device management service"},
+		{name: "empty", server: "", wantErr: "names no server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serverIDByName(servers, tt.server)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestActivityResults(t *testing.T) {
+	ctx := context.Background()
+	rows := []map[string]string{
+		{"serial_number": "SN1", "operation_status": "SUCCESS"},
+		{"serial_number": "SN2", "operation_status": "FAILED", "operation_substatus": "DEVICE_NOT_FOUND"},
+		{"serial_number": "SN3", "operation_status": "FAILED"},
+	}
+
+	results := newActivityResults(types.Int64Null())
+	if failed := results.add("ACT1", rows); failed != 2 {
+		t.Fatalf("expected 2 failed rows, got %d", failed)
+	}
+	var diags diag.Diagnostics
+	value := results.value(ctx, &diags)
+	if diags.HasError() || diags.WarningsCount() != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var got []ActivityResultModel
+	diags.Append(value.ElementsAs(ctx, &got, false)...)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(got))
+	}
+	if got[0].SerialNumber.ValueString() != "SN2" || got[0].SubStatus.ValueString() != "DEVICE_NOT_FOUND" ||
+		got[0].Message.ValueString() != "Activity ACT1 reported FAILED ("+client.DescribeSubStatus("DEVICE_NOT_FOUND")+") for this device." {
+		t.Errorf("unexpected first result: %+v", got[0])
+	}
+	if !got[1].SubStatus.IsNull() || got[1].Message.ValueString() != "Activity ACT1 reported FAILED for this device." {
+		t.Errorf("unexpected second result: %+v", got[1])
+	}
+
+	t.Run("max_rows", func(t *testing.T) {
+		results := newActivityResults(types.Int64Value(1))
+		results.add("ACT1", rows)
+		results.add("ACT2", rows)
+		var diags diag.Diagnostics
+		value := results.value(ctx, &diags)
+		if len(value.Elements()) != 1 || diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "3 more were omitted") {
+			t.Errorf("expected one result and a truncation warning, got %d results and %v", len(value.Elements()), diags)
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		var diags diag.Diagnostics
+		value := newActivityResults(types.Int64Value(0)).value(ctx, &diags)
+		if value.IsNull() || len(value.Elements()) != 0 {
+			t.Errorf("expected an empty list, got %v", value)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var results *activityResults
+		if failed := results.add("ACT1", rows); failed != 0 {
+			t.Errorf("expected a nil collector to record nothing, got %d", failed)
+		}
+	})
+}
+
+func TestLastActivity(t *testing.T) {
+	ctx := context.Background()
+	activity := &client.OrgDeviceActivity{ID: "ACT1", Attributes: client.OrgDeviceActivityAttributes{
+		Status:            client.ActivityStatusCompleted,
+		SubStatus:         "COMPLETED_WITH_ERRORS",
+		CompletedDateTime: "2026-01-02T03:04:05Z",
+	}}
+	rows := []map[string]string{
+		{"serial_number": "SN1", "operation_status": "SUCCESS"},
+		{"serial_number": "SN2", "operation_status": "FAILED", "operation_substatus": "DEVICE_NOT_FOUND"},
+	}
+
+	var diags diag.Diagnostics
+	value := lastActivityValue(ctx, activity, client.ActivityTypeAssignDevices, rows, &diags)
+	var got LastActivityModel
+	diags.Append(value.As(ctx, &got, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.ID.ValueString() != "ACT1" || got.Type.ValueString() != client.ActivityTypeAssignDevices ||
+		got.SubStatus.ValueString() != "COMPLETED_WITH_ERRORS" || got.CompletedDateTime.ValueString() != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected last activity: %+v", got)
+	}
+	var devices map[string]DeviceResultModel
+	diags.Append(got.DeviceResults.ElementsAs(ctx, &devices, false)...)
+	if len(devices) != 2 || devices["SN1"].Status.ValueString() != "SUCCESS" || !devices["SN1"].SubStatus.IsNull() ||
+		devices["SN2"].SubStatus.ValueString() != "DEVICE_NOT_FOUND" {
+		t.Errorf("unexpected device results: %+v", devices)
+	}
+
+	t.Run("without_log", func(t *testing.T) {
+		var diags diag.Diagnostics
+		value := lastActivityValue(ctx, activity, client.ActivityTypeUnassignDevices, nil, &diags)
+		if !value.Attributes()["device_results"].IsNull() {
+			t.Errorf("expected null device_results, got %v", value.Attributes()["device_results"])
+		}
+	})
+
+	t.Run("keeps_prior_without_activity", func(t *testing.T) {
+		var diags diag.Diagnostics
+		prior := lastActivityValue(ctx, activity, client.ActivityTypeAssignDevices, nil, &diags)
+		if got := newActivityResults(types.Int64Null()).lastActivity(ctx, prior, &diags); !got.Equal(prior) {
+			t.Errorf("expected the prior last_activity to be kept, got %v", got)
+		}
+	})
+}
+
+func TestRunDeviceActivityReadsLogOnce(t *testing.T) {
+	var downloads int
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	activity := func(status, subStatus string) client.OrgDeviceActivityResponse {
+		return client.OrgDeviceActivityResponse{Data: client.OrgDeviceActivity{
+			Type: "orgDeviceActivities",
+			ID:   "ACT1",
+			Attributes: client.OrgDeviceActivityAttributes{
+				Status:      status,
+				SubStatus:   subStatus,
+				DownloadURL: server.URL + "/log.csv",
+			},
+		}}
+	}
+	mux.HandleFunc("POST /v1/orgDeviceActivities", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(activity(client.ActivityStatusInProgress, ""))
+	})
+	mux.HandleFunc("GET /v1/orgDeviceActivities/ACT1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(activity(client.ActivityStatusCompleted, "COMPLETED_WITH_ERRORS"))
+	})
+	mux.HandleFunc("GET /log.csv", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		_, _ = w.Write([]byte("serial_number,operation_status,operation_substatus\nSN1,SUCCESS,\nSN2,FAILED,DEVICE_NOT_FOUND\n"))
+	})
+
+	c, err := client.NewClientWithAccessToken(server.URL, "business.api", "token", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	clock := &stubClock{fire: make(chan time.Time)}
+	close(clock.fire)
+	c.SetClock(clock)
+	r := &DeviceManagementServiceResource{client: c}
+
+	ctx := context.Background()
+	results := newActivityResults(types.Int64Null())
+	var diags diag.Diagnostics
+	logPath := filepath.Join(t.TempDir(), "{activity_id}.csv")
+	notFound, err := r.runDeviceActivity(ctx, "srv-1", []string{"SN1", "SN2"}, true, logPath, time.Second, 0, nil, results, &diags)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := results.lastActivity(ctx, types.ObjectNull(lastActivityAttrTypes), &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !slices.Equal(notFound, []string{"SN2"}) {
+		t.Errorf("expected SN2 to be reported as not found, got %v", notFound)
+	}
+	if results.failed != 1 || last.Attributes()["device_results"].IsNull() {
+		t.Errorf("expected the log to be recorded in activity_results and last_activity, got %d failed and %v", results.failed, last)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(logPath), "ACT1.csv")); err != nil {
+		t.Errorf("expected the activity log to be persisted: %v", err)
+	}
+	if downloads != 1 {
+		t.Errorf("expected the activity log to be downloaded once, got %d downloads", downloads)
+	}
+}
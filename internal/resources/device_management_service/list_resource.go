@@ -50,13 +50,15 @@ func (r *DeviceManagementServiceListResource) Configure(ctx context.Context, req
 
 func (r *DeviceManagementServiceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
 	resp.Schema = listschema.Schema{
-		Description: "Searches for Apple Business Manager device management services.",
+		Description: "Searches for Apple Business Manager device management services. Each result carries the resource identity, " +
+			"and the full resource state when include_resource is set, so that terraform query -generate-config-out can generate " +
+			"import blocks and configuration for adopting many existing servers at once.",
 		Attributes: map[string]listschema.Attribute{
 			"server_type": listschema.StringAttribute{
 				Optional:    true,
 				Description: "Filters results by the Apple Business Manager server type (MDM, APPLE_CONFIGURATOR, APPLE_MDM).",
 				Validators: []validator.String{
-					stringvalidator.OneOf("MDM", "APPLE_CONFIGURATOR", "APPLE_MDM"),
+					stringvalidator.OneOf(client.MdmServerTypeValues()...),
 				},
 			},
 			"name": listschema.StringAttribute{
@@ -102,19 +104,31 @@ func (r *DeviceManagementServiceListResource) List(ctx context.Context, req list
 
 	filtered := filterDeviceManagementServiceList(servers, config)
 
-	maxResults := req.Limit
-	if maxResults <= 0 || maxResults > int64(len(filtered)) {
-		maxResults = int64(len(filtered))
+	if req.Limit > 0 && req.Limit < int64(len(filtered)) {
+		filtered = filtered[:req.Limit]
 	}
 
-	results := make([]list.ListResult, 0, int(maxResults))
-	var emitted int64
-
-	for _, server := range filtered {
-		if maxResults > 0 && emitted >= maxResults {
-			break
+	var serials [][]string
+	if req.IncludeResource {
+		serials = make([][]string, len(filtered))
+		err := client.ForEachConcurrent(ctx, r.client.MaxConcurrency(), len(filtered), func(ctx context.Context, i int) error {
+			var err error
+			serials[i], err = r.client.GetDeviceManagementServiceSerialNumbers(ctx, filtered[i].ID)
+			return err
+		})
+		if err != nil {
+			stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"Unable to read device assignments",
+					err.Error(),
+				),
+			})
+			return
 		}
+	}
 
+	results := make([]list.ListResult, 0, len(filtered))
+	for i, server := range filtered {
 		result := req.NewListResult(ctx)
 		result.DisplayName = server.Attributes.ServerName
 		identity := deviceManagementServiceIdentityModel{
@@ -124,29 +138,21 @@ func (r *DeviceManagementServiceListResource) List(ctx context.Context, req list
 		result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
 
 		if req.IncludeResource {
-			serials, err := r.client.GetDeviceManagementServiceSerialNumbers(ctx, server.ID)
-			if err != nil {
-				stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{
-					diag.NewErrorDiagnostic(
-						"Unable to read device assignments",
-						err.Error(),
-					),
-				})
-				return
-			}
-
-			deviceSet, setDiags := stringsToSet(serials)
+			deviceSet, setDiags := stringsToSet(serials[i])
 			if setDiags.HasError() {
 				stream.Results = list.ListResultsStreamDiagnostics(setDiags)
 				return
 			}
 
 			state := MdmDeviceAssignmentModel{
-				ID:        types.StringValue(server.ID),
-				Name:      types.StringValue(server.Attributes.ServerName),
-				Type:      types.StringValue(server.Attributes.ServerType),
-				DeviceIDs: deviceSet,
+				ID:                     types.StringValue(server.ID),
+				DeviceIDs:              deviceSet,
+				ExternallyAddedDevices: types.SetNull(types.StringType),
+				MissingDevices:         types.SetNull(types.StringType),
+				ActivityResults:        types.ListNull(types.ObjectType{AttrTypes: activityResultAttrTypes}),
+				LastActivity:           types.ObjectNull(lastActivityAttrTypes),
 			}
+			applyServerAttributes(ctx, &state, &server)
 
 			// Provide a null object with the expected shape so Terraform can coerce the timeouts attribute.
 			state.Timeouts = newDeviceManagementServiceTimeoutsNullValue()
@@ -155,7 +161,6 @@ func (r *DeviceManagementServiceListResource) List(ctx context.Context, req list
 		}
 
 		results = append(results, result)
-		emitted++
 	}
 
 	tflog.Debug(ctx, "Listed device management services", map[string]any{
@@ -183,13 +188,13 @@ func filterDeviceManagementServiceList(servers []client.MdmServer, cfg DeviceMan
 	containsName, hasContains := normalizedFilterString(cfg.NameContains)
 	serverType, hasType := normalizedFilterString(cfg.ServerType)
 
-	exactName = strings.ToLower(exactName)
-	containsName = strings.ToLower(containsName)
-	serverType = strings.ToLower(serverType)
+	exactName = common.FoldFilterString(exactName)
+	containsName = common.FoldFilterString(containsName)
+	serverType = common.FoldFilterString(serverType)
 
 	for _, server := range servers {
-		currentName := strings.ToLower(strings.TrimSpace(server.Attributes.ServerName))
-		currentType := strings.ToLower(strings.TrimSpace(server.Attributes.ServerType))
+		currentName := common.FoldFilterString(server.Attributes.ServerName)
+		currentType := common.FoldFilterString(server.Attributes.ServerType)
 
 		if hasType && currentType != serverType {
 			continue
@@ -6,6 +6,8 @@ package device_management_service
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
 )
 
 // deviceManagementServiceIdentityModel captures the fields that make up the
@@ -23,20 +25,69 @@ type MdmServerCertificateModel struct {
 
 // MdmDeviceAssignmentModel describes the Terraform state for an MDM server and its device assignments.
 type MdmDeviceAssignmentModel struct {
-	ID                     types.String               `tfsdk:"id"`
-	Name                   types.String               `tfsdk:"name"`
-	Type                   types.String               `tfsdk:"type"`
-	Status                 types.String               `tfsdk:"status"`
-	DeviceCount            types.Int64                `tfsdk:"device_count"`
-	DefaultProductFamilies types.List                 `tfsdk:"default_product_families"`
-	LastConnectedDateTime  types.String               `tfsdk:"last_connected_date_time"`
-	LastConnectedIp        types.String               `tfsdk:"last_connected_ip"`
-	CreatedDateTime        types.String               `tfsdk:"created_date_time"`
-	UpdatedDateTime        types.String               `tfsdk:"updated_date_time"`
-	AllowRelease           types.Bool                 `tfsdk:"allow_release"`
-	ServerCertificate      *MdmServerCertificateModel `tfsdk:"server_certificate"`
-	Timeouts               timeouts.Value             `tfsdk:"timeouts"`
-	DeviceIDs              types.Set                  `tfsdk:"device_ids"`
+	ID                             types.String               `tfsdk:"id"`
+	Name                           types.String               `tfsdk:"name"`
+	Type                           types.String               `tfsdk:"type"`
+	Status                         types.String               `tfsdk:"status"`
+	DeviceCount                    types.Int64                `tfsdk:"device_count"`
+	DefaultProductFamilies         types.List                 `tfsdk:"default_product_families"`
+	LastConnectedDateTime          types.String               `tfsdk:"last_connected_date_time"`
+	LastConnectedIp                types.String               `tfsdk:"last_connected_ip"`
+	CreatedDateTime                types.String               `tfsdk:"created_date_time"`
+	UpdatedDateTime                types.String               `tfsdk:"updated_date_time"`
+	SelfLink                       types.String               `tfsdk:"self_link"`
+	AllowRelease                   types.Bool                 `tfsdk:"allow_release"`
+	ServerCertificate              *MdmServerCertificateModel `tfsdk:"server_certificate"`
+	Timeouts                       timeouts.Value             `tfsdk:"timeouts"`
+	DeviceIDs                      types.Set                  `tfsdk:"device_ids"`
+	DeviceFilter                   *DeviceFilterModel         `tfsdk:"device_filter"`
+	ExternallyAddedDevices         types.Set                  `tfsdk:"externally_added_devices"`
+	MissingDevices                 types.Set                  `tfsdk:"missing_devices"`
+	DryRun                         types.Bool                 `tfsdk:"dry_run"`
+	BatchDelay                     types.String               `tfsdk:"batch_delay"`
+	ActivityPollInterval           types.String               `tfsdk:"activity_poll_interval"`
+	Retry                          *common.RetryModel         `tfsdk:"retry"`
+	ActivityLogPath                types.String               `tfsdk:"activity_log_path"`
+	ActivityResults                types.List                 `tfsdk:"activity_results"`
+	ActivityResultsMaxRows         types.Int64                `tfsdk:"activity_results_max_rows"`
+	LastActivity                   types.Object               `tfsdk:"last_activity"`
+	VerifyAfterApply               types.Bool                 `tfsdk:"verify_after_apply"`
+	MaxUnassignWithoutConfirmation types.Int64                `tfsdk:"max_unassign_without_confirmation"`
+	ConfirmBulkUnassign            types.Bool                 `tfsdk:"confirm_bulk_unassign"`
+	AllowNonMdmServer              types.Bool                 `tfsdk:"allow_non_mdm_server"`
+	ValidateDevices                types.Bool                 `tfsdk:"validate_devices"`
+}
+
+// ActivityResultModel describes a device that an assignment activity did not process
+// successfully, as reported by the activity log.
+type ActivityResultModel struct {
+	SerialNumber types.String `tfsdk:"serial_number"`
+	Status       types.String `tfsdk:"status"`
+	SubStatus    types.String `tfsdk:"sub_status"`
+	Message      types.String `tfsdk:"message"`
+}
+
+// LastActivityModel describes the last assignment or unassignment activity submitted by a
+// create or update and the per-device outcomes reported by its activity log.
+type LastActivityModel struct {
+	ID                types.String `tfsdk:"id"`
+	Type              types.String `tfsdk:"type"`
+	Status            types.String `tfsdk:"status"`
+	SubStatus         types.String `tfsdk:"sub_status"`
+	CompletedDateTime types.String `tfsdk:"completed_date_time"`
+	DeviceResults     types.Map    `tfsdk:"device_results"`
+}
+
+// DeviceResultModel is the outcome the activity log reports for one device.
+type DeviceResultModel struct {
+	Status    types.String `tfsdk:"status"`
+	SubStatus types.String `tfsdk:"sub_status"`
+}
+
+// DeviceFilterModel selects the inventory devices assigned to an MDM server in place of an
+// explicit device_ids set.
+type DeviceFilterModel struct {
+	ProductFamily types.String `tfsdk:"product_family"`
 }
 
 // DeviceManagementServiceListResourceModel captures filters supported by the list query.
@@ -5,9 +5,15 @@ package device_management_service
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
@@ -18,6 +24,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/neilmartin83/terraform-provider-axm/internal/client"
@@ -27,6 +34,7 @@ import (
 var _ resource.Resource = &DeviceManagementServiceResource{}
 var _ resource.ResourceWithIdentity = &DeviceManagementServiceResource{}
 var _ resource.ResourceWithImportState = &DeviceManagementServiceResource{}
+var _ resource.ResourceWithModifyPlan = &DeviceManagementServiceResource{}
 
 const (
 	defaultCreateTimeout = 10 * time.Minute
@@ -51,7 +59,10 @@ func (r *DeviceManagementServiceResource) Metadata(ctx context.Context, req reso
 // Schema defines the schema for the resource.
 func (r *DeviceManagementServiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages an Apple Business Manager MDM server and its device assignments. Server creation, update, and deletion require business scope.",
+		Description: "Manages an Apple Business Manager MDM server and its device assignments. Server creation, update, and deletion require business scope. " +
+			"When an update or deletion fails after submitting assignment activities, retrying it within an hour reuses the activities that are still " +
+			"in progress or completed instead of resubmitting identical ones. An activity still running when an update or deletion is interrupted " +
+			"or times out continues in Apple Business Manager, and later refreshes account for its devices once it completes.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -121,6 +132,13 @@ func (r *DeviceManagementServiceResource) Schema(ctx context.Context, req resour
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"self_link": schema.StringAttribute{
+				Computed:    true,
+				Description: "The API URL of the device management service resource, as returned in links.self. Null if the API did not return a link.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"allow_release": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -144,6 +162,122 @@ func (r *DeviceManagementServiceResource) Schema(ctx context.Context, req resour
 					},
 				},
 			},
+			"activity_log_path": schema.StringAttribute{
+				Optional: true,
+				Description: "Local file path to which the activity result CSV is written for each assignment activity performed during Create or Update, " +
+					"whether it completed, failed or was stopped. The placeholder {activity_id} is replaced with the activity ID, e.g. \"${path.module}/activity-logs/{activity_id}.csv\"; " +
+					"without it, the activity ID is appended to the file name so that each activity is written to its own file. " +
+					"Parent directories are created as needed. Failures to download or write the log are reported as warnings.",
+			},
+			"activity_results": schema.ListNestedAttribute{
+				Computed: true,
+				Description: "Devices that the assignment and unassignment activities submitted by the last create or update did not process successfully, " +
+					"as reported by their activity logs, in log order. Empty when every device succeeded or no activity was submitted. " +
+					"At most activity_results_max_rows devices are recorded; a warning reports how many more were omitted.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Computed:    true,
+							Description: "The serial number of the device.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The outcome for the device, such as FAILED.",
+						},
+						"sub_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The detailed outcome for the device, such as DEVICE_NOT_FOUND, if any.",
+						},
+						"message": schema.StringAttribute{
+							Computed:    true,
+							Description: "A description of the outcome naming the activity that reported it.",
+						},
+					},
+				},
+			},
+			"last_activity": schema.SingleNestedAttribute{
+				Computed: true,
+				Description: "The last assignment or unassignment activity to finish during the last create or update that submitted one, " +
+					"kept until a later apply submits another. Null until an activity has finished.",
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: "The activity ID.",
+					},
+					"type": schema.StringAttribute{
+						Computed:    true,
+						Description: "The activity type: ASSIGN_DEVICES or UNASSIGN_DEVICES.",
+					},
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "The final status of the activity, such as COMPLETED or FAILED.",
+					},
+					"sub_status": schema.StringAttribute{
+						Computed:    true,
+						Description: "The detailed status of the activity, such as COMPLETED_WITH_SUCCESS or COMPLETED_WITH_ERRORS, if any.",
+					},
+					"completed_date_time": schema.StringAttribute{
+						Computed:    true,
+						Description: "The date and time the activity finished.",
+					},
+					"device_results": schema.MapNestedAttribute{
+						Computed: true,
+						Description: "The outcome of each device in the activity, keyed by serial number, as reported by its activity log. " +
+							"Null when the activity provided no log or the log could not be read.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"status": schema.StringAttribute{
+									Computed:    true,
+									Description: "The outcome for the device, such as SUCCESS or FAILED.",
+								},
+								"sub_status": schema.StringAttribute{
+									Computed:    true,
+									Description: "The detailed outcome for the device, such as DEVICE_NOT_FOUND, if any.",
+								},
+							},
+						},
+					},
+				},
+			},
+			"activity_results_max_rows": schema.Int64Attribute{
+				Optional: true,
+				Description: "Maximum number of devices recorded in activity_results, keeping state small when an activity fails for a large fleet. " +
+					"Set to 0 to record none. Defaults to 100.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"verify_after_apply": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, the server's device relationship is re-read after assignment activities complete until every planned device is assigned, " +
+					"waiting longer between reads while Apple propagates the change, and the apply fails if any planned device is still missing when the operation timeout elapses. " +
+					"Devices assigned to the server outside Terraform are not checked.",
+			},
+			"max_unassign_without_confirmation": schema.Int64Attribute{
+				Optional: true,
+				Description: "Maximum number of devices a single plan may unassign from this server, including by destroying it, " +
+					"before confirm_bulk_unassign must be set. Guards against a configuration mistake orphaning a fleet. The limit is checked when planning and again when applying, " +
+					"against the devices actually unassigned, so it also covers device_filter and values known only at apply time. Unset means no limit.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"confirm_bulk_unassign": schema.BoolAttribute{
+				Optional: true,
+				Description: "Set to true to allow a plan that unassigns more devices than max_unassign_without_confirmation. " +
+					"Remove it again after the apply so later plans remain guarded.",
+			},
+			"allow_non_mdm_server": schema.BoolAttribute{
+				Optional: true,
+				Description: "Set to true to allow assigning devices to a server whose type is APPLE_CONFIGURATOR. " +
+					"Such assignments are almost always a mistake, so by default the plan and the apply fail when devices would be assigned to one.",
+			},
+			"validate_devices": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, the plan reads each device newly added to device_ids from the organization and fails if any does not exist, " +
+					"so that a mistyped serial number is reported before an assignment activity is submitted. Ignored when device_filter is set.",
+			},
+			"retry": common.RetryAttribute(),
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
@@ -154,11 +288,70 @@ func (r *DeviceManagementServiceResource) Schema(ctx context.Context, req resour
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
-				Description: "Set of device serial numbers to assign to this MDM server.",
+				Description: "Set of devices to assign to this MDM server. Each entry may be a device serial number or an opaque organization device ID; entries are resolved to canonical device IDs at apply time and state keeps the form used in configuration. " +
+					"When device_filter is set instead, this holds the devices assigned to the server and is planned as known after apply whenever matching devices are not yet assigned. " +
+					"Listed devices that an apply finds are no longer in the organization stay in device_ids, because state must match the configuration, and are reported in missing_devices until they are removed; " +
+					"devices matched by device_filter that are no longer found are left out of device_ids.",
 				PlanModifiers: []planmodifier.Set{
 					setplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"device_filter": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "Assigns every organization device matching these criteria to this MDM server, as an alternative to listing device_ids. " +
+					"The filter is resolved against the device inventory during each plan and again at apply time, so devices added to the organization later are assigned by the next apply. " +
+					"Matching devices assigned to other servers are moved to this one, and devices of other product families already assigned to this server are left in place. " +
+					"Conflicts with device_ids.",
+				Attributes: map[string]schema.Attribute{
+					"product_family": schema.StringAttribute{
+						Required:    true,
+						Description: "Assign every device of this product family: iPhone, iPad, Mac, AppleTV, Watch or Vision. Case-insensitive.",
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(client.OrgDeviceProductFamilyValues()...),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("device_ids")),
+				},
+			},
+			"externally_added_devices": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Canonical IDs of devices assigned to this MDM server that device_ids did not include when Terraform last applied it, such as devices assigned in the Apple Business Manager portal. " +
+					"Populated during refresh; null until the resource has been created or updated by this provider version. " +
+					"They also appear in device_ids, and are unassigned by the next apply, unless the provider's features block sets authoritative_assignments = false.",
+			},
+			"missing_devices": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Canonical IDs of devices that device_ids included when Terraform last applied it but that are no longer assigned to this MDM server, " +
+					"including devices listed in device_ids that an apply skipped because they were no longer found in the organization. Populated during refresh; null until the resource has been created or updated by this provider version.",
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, the device assignments and unassignments required to reconcile device_ids are computed and reported as warnings, " +
+					"but no assignment activities are submitted to Apple. Server attributes are still managed. " +
+					"Because the assignments are not applied, the difference remains visible on every subsequent plan until dry_run is disabled. " +
+					"A new MDM server cannot be created with device_ids in a dry run; with device_filter, a dry-run create leaves device_ids empty.",
+			},
+			"batch_delay": schema.StringAttribute{
+				Optional: true,
+				Description: "Delay to wait between consecutive assignment activity submissions within a single apply, " +
+					`expressed as a duration such as "30s" or "2m". Use this to pace large migrations under Apple's rate limits. Defaults to no delay.`,
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
+			"activity_poll_interval": schema.StringAttribute{
+				Optional: true,
+				Description: `Interval between status checks while waiting for an assignment activity to finish, expressed as a duration such as "30s". ` +
+					`Defaults to "5s". Activities are waited for until they finish or the create, update, or delete timeout in the timeouts block elapses, ` +
+					"so raise those timeouts rather than this interval for very large assignments.",
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
 		},
 	}
 }
@@ -183,6 +376,182 @@ func (r *DeviceManagementServiceResource) Configure(ctx context.Context, req res
 	r.client = c
 }
 
+// ImportState imports a device management service by its ID, or by its name when the import ID
+// has the form serverName=<name>.
 func (r *DeviceManagementServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	name, byName := strings.CutPrefix(req.ID, serverNameImportPrefix)
+	if !byName {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	servers, err := r.client.GetDeviceManagementServices(ctx, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Import Device Management Service",
+			fmt.Sprintf("Failed to list device management services to resolve %q: %s", name, err),
+		)
+		return
+	}
+
+	id, err := serverIDByName(servers, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Import Device Management Service", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// ModifyPlan enforces the bulk unassignment, server type and serial lock guardrails, resolves
+// device_filter against the inventory, checks that newly planned devices exist when
+// validate_devices is set, and reports the device assignment changes that a dry run
+// would submit.
+func (r *DeviceManagementServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var state *MdmDeviceAssignmentModel
+	if !req.State.Raw.IsNull() {
+		state = &MdmDeviceAssignmentModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if req.Plan.Raw.IsNull() {
+		if state != nil && r.client.Features().DeleteUnassignsDevices {
+			current := extractStrings(state.DeviceIDs)
+			if exceedsUnassignLimit(state.MaxUnassignWithoutConfirmation, state.ConfirmBulkUnassign, len(current)) {
+				resp.Diagnostics.AddError(
+					"Bulk unassignment requires confirmation",
+					bulkUnassignDetail(len(current), state.MaxUnassignWithoutConfirmation.ValueInt64()),
+				)
+			}
+		}
+		return
+	}
+
+	var plan MdmDeviceAssignmentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var current []string
+	if state != nil {
+		current = extractStrings(state.DeviceIDs)
+	}
+
+	var toAssign, toUnassign []string
+	if plan.DeviceFilter != nil {
+		var ok bool
+		if toAssign, ok = r.planDeviceFilter(ctx, plan.DeviceFilter, state, resp); !ok {
+			return
+		}
+	} else {
+		if plan.DeviceIDs.IsUnknown() {
+			return
+		}
+		toAssign, toUnassign = diffDeviceIDs(current, extractStrings(plan.DeviceIDs))
+	}
+	if exceedsUnassignLimit(plan.MaxUnassignWithoutConfirmation, plan.ConfirmBulkUnassign, len(toUnassign)) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("device_ids"),
+			"Bulk unassignment requires confirmation",
+			bulkUnassignDetail(len(toUnassign), plan.MaxUnassignWithoutConfirmation.ValueInt64()),
+		)
+		return
+	}
+
+	if state != nil && len(toAssign) > 0 && blocksAssignment(state.Type.ValueString(), plan.AllowNonMdmServer) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("device_ids"),
+			"Device assignment to a non-MDM server",
+			nonMdmServerDetail(state.Type.ValueString(), len(toAssign)),
+		)
+		return
+	}
+
+	if r.client != nil {
+		planned := append(slices.Clone(withoutDevices(current, toUnassign)), toAssign...)
+		conflicts, err := r.client.SerialLockConflicts(ctx, planned)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("device_ids"), "Failed to check serial locks", err.Error())
+			return
+		}
+		if len(conflicts) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("device_ids"),
+				"Devices managed by another workspace",
+				common.SerialLockConflictDetail(conflicts),
+			)
+			return
+		}
+	}
+
+	if r.client != nil && plan.DeviceFilter == nil && plan.ValidateDevices.ValueBool() && len(toAssign) > 0 {
+		missing, err := r.client.MissingOrgDevices(ctx, toAssign)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("device_ids"), "Failed to validate devices", err.Error())
+			return
+		}
+		if len(missing) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("device_ids"),
+				"Devices not found in the organization",
+				missingDevicesDetail(missing),
+			)
+			return
+		}
+	}
+
+	if !plan.DryRun.ValueBool() || (len(toAssign) == 0 && len(toUnassign) == 0) {
+		return
+	}
+
+	if state == nil && plan.DeviceFilter == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("device_ids"), "Dry run of a new MDM server with devices", dryRunCreateDetail(len(toAssign)))
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Dry run: device assignment activities will not be submitted",
+		dryRunSummary(toAssign, toUnassign),
+	)
+}
+
+// bulkUnassignDetail describes a blocked bulk unassignment.
+func bulkUnassignDetail(count int, limit int64) string {
+	return fmt.Sprintf("This plan would unassign %d devices, which exceeds max_unassign_without_confirmation (%d). "+
+		"Review the change and set confirm_bulk_unassign = true to proceed.", count, limit)
+}
+
+// dryRunCreateDetail describes why a new MDM server cannot be created with devices in dry_run.
+func dryRunCreateDetail(count int) string {
+	return fmt.Sprintf("dry_run is set and device_ids lists %d device(s) for a new MDM server. A dry run assigns nothing, "+
+		"but Terraform requires the device_ids stored in state to match the configuration, so the devices would be recorded as assigned. "+
+		"Create the server without device_ids, or with dry_run = false, and add the devices in a later apply.", count)
+}
+
+// planDeviceFilter resolves device_filter against the inventory and returns the matching devices not
+// yet assigned to the server. When there are any, device_ids is planned as unknown so that the apply
+// resolves the filter again; otherwise it keeps its prior value. It returns false when the plan
+// cannot be checked yet, such as before the server exists or while the filter is unknown.
+func (r *DeviceManagementServiceResource) planDeviceFilter(ctx context.Context, filter *DeviceFilterModel, state *MdmDeviceAssignmentModel, resp *resource.ModifyPlanResponse) ([]string, bool) {
+	family, ok := filterProductFamily(filter)
+	if !ok || state == nil || r.client == nil {
+		return nil, false
+	}
+
+	matching, err := r.filteredDeviceIDs(ctx, family)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("device_filter"), "Failed to resolve device_filter", err.Error())
+		return nil, false
+	}
+
+	toAssign, _ := diffDeviceIDs(extractStrings(state.DeviceIDs), matching)
+	if len(toAssign) > 0 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("device_ids"), types.SetUnknown(types.StringType))...)
+	}
+	return toAssign, !resp.Diagnostics.HasError()
 }
@@ -152,8 +152,25 @@ func TestResourceSchema(t *testing.T) {
 		{"last_connected_ip", false, false, true},
 		{"created_date_time", false, false, true},
 		{"updated_date_time", false, false, true},
+		{"self_link", false, false, true},
 		{"allow_release", false, true, true},
 		{"device_ids", false, true, true},
+		{"device_filter", false, true, false},
+		{"externally_added_devices", false, false, true},
+		{"missing_devices", false, false, true},
+		{"dry_run", false, true, false},
+		{"batch_delay", false, true, false},
+		{"activity_poll_interval", false, true, false},
+		{"retry", false, true, false},
+		{"activity_log_path", false, true, false},
+		{"activity_results", false, false, true},
+		{"last_activity", false, false, true},
+		{"activity_results_max_rows", false, true, false},
+		{"verify_after_apply", false, true, false},
+		{"max_unassign_without_confirmation", false, true, false},
+		{"confirm_bulk_unassign", false, true, false},
+		{"allow_non_mdm_server", false, true, false},
+		{"validate_devices", false, true, false},
 		{"timeouts", false, true, false},
 	}
 
@@ -5,10 +5,15 @@ package device_management_service_serialnumbers
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -33,6 +38,8 @@ type DeviceManagementServiceSerialNumbersDataSourceModel struct {
 	ID            types.String   `tfsdk:"id"`
 	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 	ServerID      types.String   `tfsdk:"server_id"`
+	ProductFamily types.String   `tfsdk:"product_family"`
+	Status        types.String   `tfsdk:"status"`
 	SerialNumbers []types.String `tfsdk:"serial_numbers"`
 }
 
@@ -53,6 +60,17 @@ func (d *DeviceManagementServiceSerialNumbersDataSource) Schema(ctx context.Cont
 				Description: "The opaque resource ID that uniquely identifies the device management service to get serial numbers for.",
 				Required:    true,
 			},
+			"product_family": schema.StringAttribute{
+				Description: "Only return serial numbers of devices in this product family (e.g. iPhone, iPad, Mac, AppleTV, Watch, Vision). Matching is case-insensitive. Setting a filter looks up each device in the organization inventory.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only return serial numbers of devices with this status: ASSIGNED or UNASSIGNED. Setting a filter looks up each device in the organization inventory.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.OrgDeviceStatusValues()...),
+				},
+			},
 			"serial_numbers": schema.ListAttribute{
 				Description: "List of device serial numbers assigned to this device management service.",
 				Computed:    true,
@@ -72,6 +90,8 @@ func (d *DeviceManagementServiceSerialNumbersDataSource) Configure(ctx context.C
 }
 
 func (d *DeviceManagementServiceSerialNumbersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data DeviceManagementServiceSerialNumbersDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -97,13 +117,88 @@ func (d *DeviceManagementServiceSerialNumbersDataSource) Read(ctx context.Contex
 		return
 	}
 
+	filter := newDeviceFilter(data)
+	if filter.active() {
+		serialNumbers, err = d.filterSerialNumbers(readCtx, serialNumbers, filter)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Filter Device Management Service Serial Numbers",
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	data.SerialNumbers = common.StringsToTypesStrings(serialNumbers)
 	data.ID = data.ServerID
 
 	tflog.Debug(ctx, "Read device management service serial numbers", map[string]any{
 		"server_id":      data.ServerID.ValueString(),
+		"product_family": filter.productFamily,
+		"status":         filter.status,
 		"serial_numbers": serialNumbers,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// deviceFilter selects devices by the inventory details of the optional filter attributes.
+type deviceFilter struct {
+	productFamily string
+	status        string
+}
+
+// newDeviceFilter builds a filter from the model's optional filter attributes.
+func newDeviceFilter(data DeviceManagementServiceSerialNumbersDataSourceModel) deviceFilter {
+	var filter deviceFilter
+	if family, ok := common.NormalizedFilterString(data.ProductFamily); ok {
+		filter.productFamily = family
+	}
+	if status, ok := common.NormalizedFilterString(data.Status); ok {
+		filter.status = status
+	}
+	return filter
+}
+
+// active reports whether any filter attribute is set.
+func (f deviceFilter) active() bool {
+	return f.productFamily != "" || f.status != ""
+}
+
+// matches reports whether device passes the filter.
+func (f deviceFilter) matches(device client.OrgDevice) bool {
+	if f.productFamily != "" && !strings.EqualFold(f.productFamily, device.Attributes.ProductFamily) {
+		return false
+	}
+	if f.status != "" && !strings.EqualFold(f.status, string(device.Attributes.Status)) {
+		return false
+	}
+	return true
+}
+
+// filterSerialNumbers joins serialNumbers against the organization inventory and returns those
+// whose device passes filter, preserving their order. The inventory is fetched once with only
+// the fields the filter needs, rather than looking up each device individually.
+func (d *DeviceManagementServiceSerialNumbersDataSource) filterSerialNumbers(ctx context.Context, serialNumbers []string, filter deviceFilter) ([]string, error) {
+	if len(serialNumbers) == 0 {
+		return serialNumbers, nil
+	}
+
+	devices, err := d.client.GetOrgDevices(ctx, url.Values{"fields[orgDevices]": {"serialNumber,productFamily,status"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up organization devices: %w", err)
+	}
+
+	byID := make(map[string]client.OrgDevice, len(devices))
+	for _, device := range devices {
+		byID[device.ID] = device
+	}
+
+	matched := make([]string, 0, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		if device, ok := byID[serialNumber]; ok && filter.matches(device) {
+			matched = append(matched, serialNumber)
+		}
+	}
+	return matched, nil
+}
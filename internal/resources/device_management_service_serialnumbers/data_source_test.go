@@ -65,6 +65,16 @@ func TestDeviceManagementServiceSerialNumbersDataSourceSchema(t *testing.T) {
 		t.Error("expected 'server_id' to be Required")
 	}
 
+	for _, name := range []string{"product_family", "status"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("attribute %q not found", name)
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected %q to be Optional", name)
+		}
+	}
+
 	idAttr, ok := resp.Schema.Attributes["id"]
 	if !ok {
 		t.Fatal("attribute 'id' not found")
@@ -30,9 +30,10 @@ type DeviceManagementServicesDataSource struct {
 
 // DeviceManagementServicesDataSourceModel describes the data source data model.
 type DeviceManagementServicesDataSourceModel struct {
-	ID       types.String                   `tfsdk:"id"`
-	Timeouts timeouts.Value                 `tfsdk:"timeouts"`
-	Servers  []DeviceManagementServiceModel `tfsdk:"servers"`
+	ID                 types.String                   `tfsdk:"id"`
+	Timeouts           timeouts.Value                 `tfsdk:"timeouts"`
+	CredentialsProfile types.String                   `tfsdk:"credentials_profile"`
+	Servers            []DeviceManagementServiceModel `tfsdk:"servers"`
 }
 
 // DeviceManagementServiceModel describes a device management service.
@@ -43,6 +44,7 @@ type DeviceManagementServiceModel struct {
 	ServerType      types.String `tfsdk:"server_type"`
 	CreatedDateTime types.String `tfsdk:"created_date_time"`
 	UpdatedDateTime types.String `tfsdk:"updated_date_time"`
+	SelfLink        types.String `tfsdk:"self_link"`
 }
 
 func (d *DeviceManagementServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -57,7 +59,8 @@ func (d *DeviceManagementServicesDataSource) Schema(ctx context.Context, req dat
 				Description: "Identifier for this data source.",
 				Computed:    true,
 			},
-			"timeouts": timeouts.Attributes(ctx),
+			"timeouts":            timeouts.Attributes(ctx),
+			"credentials_profile": common.CredentialsProfileAttribute(),
 			"servers": schema.ListNestedAttribute{
 				Description: "List of device management services.",
 				Computed:    true,
@@ -87,6 +90,10 @@ func (d *DeviceManagementServicesDataSource) Schema(ctx context.Context, req dat
 							Description: "The date and time of the most-recent update for the resource.",
 							Computed:    true,
 						},
+						"self_link": schema.StringAttribute{
+							Description: "The API URL of the device management service resource, as returned in links.self. Null if the API did not return a link.",
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -119,7 +126,15 @@ func (d *DeviceManagementServicesDataSource) Read(ctx context.Context, req datas
 	}
 	defer cancel()
 
-	servers, err := d.client.GetDeviceManagementServices(readCtx, nil)
+	c, profileDiags := common.ClientForProfile(d.client, data.CredentialsProfile)
+	resp.Diagnostics.Append(profileDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, reportSkipped := common.CollectSkippedRecords(readCtx)
+
+	servers, err := c.GetDeviceManagementServices(readCtx, nil)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -128,6 +143,7 @@ func (d *DeviceManagementServicesDataSource) Read(ctx context.Context, req datas
 		)
 		return
 	}
+	reportSkipped(&resp.Diagnostics)
 
 	data.Servers = make([]DeviceManagementServiceModel, 0, len(servers))
 	for _, server := range servers {
@@ -138,6 +154,7 @@ func (d *DeviceManagementServicesDataSource) Read(ctx context.Context, req datas
 			ServerType:      types.StringValue(server.Attributes.ServerType),
 			CreatedDateTime: types.StringValue(server.Attributes.CreatedDateTime),
 			UpdatedDateTime: types.StringValue(server.Attributes.UpdatedDateTime),
+			SelfLink:        common.OptionalString(server.Links.Self),
 		}
 		data.Servers = append(data.Servers, serverModel)
 	}
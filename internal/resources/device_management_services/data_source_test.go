@@ -72,7 +72,7 @@ func TestDeviceManagementServicesDataSourceSchema(t *testing.T) {
 		t.Fatal("expected 'servers' to be a ListNestedAttribute")
 	}
 
-	expectedNested := []string{"id", "type", "server_name", "server_type", "created_date_time", "updated_date_time"}
+	expectedNested := []string{"id", "type", "server_name", "server_type", "created_date_time", "updated_date_time", "self_link"}
 	nestedAttrs := listNested.NestedObject.Attributes
 	for _, name := range expectedNested {
 		attr, ok := nestedAttrs[name]
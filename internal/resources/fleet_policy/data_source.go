@@ -0,0 +1,329 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fleet_policy
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &FleetPolicyDataSource{}
+
+// defaultMaxViolations is the number of violations listed per rule when max_violations is unset.
+const defaultMaxViolations = 100
+
+// fleetPolicyFields lists the device attributes rules can reference.
+const fleetPolicyFields = "serialNumber,productFamily,status,deviceModel,color,purchaseSourceType,purchaseSourceId"
+
+// NewFleetPolicyDataSource returns a new data source for evaluating fleet policy rules.
+func NewFleetPolicyDataSource() datasource.DataSource {
+	return &FleetPolicyDataSource{}
+}
+
+// FleetPolicyDataSource defines the data source implementation.
+type FleetPolicyDataSource struct {
+	client *client.Client
+}
+
+// FleetPolicyDataSourceModel describes the data source data model.
+type FleetPolicyDataSourceModel struct {
+	ID             types.String           `tfsdk:"id"`
+	Timeouts       timeouts.Value         `tfsdk:"timeouts"`
+	Rules          []FleetPolicyRuleModel `tfsdk:"rules"`
+	MaxViolations  types.Int64            `tfsdk:"max_violations"`
+	Compliant      types.Bool             `tfsdk:"compliant"`
+	DeviceCount    types.Int64            `tfsdk:"device_count"`
+	ViolationCount types.Int64            `tfsdk:"violation_count"`
+	Results        []RuleResultModel      `tfsdk:"results"`
+}
+
+// FleetPolicyRuleModel describes one configured rule.
+type FleetPolicyRuleModel struct {
+	Name    types.String           `tfsdk:"name"`
+	Where   *DeviceConditionsModel `tfsdk:"where"`
+	Require *DeviceConditionsModel `tfsdk:"require"`
+	Forbid  types.Bool             `tfsdk:"forbid"`
+}
+
+// DeviceConditionsModel describes device attribute values that must all match.
+type DeviceConditionsModel struct {
+	ProductFamily      types.String `tfsdk:"product_family"`
+	Status             types.String `tfsdk:"status"`
+	DeviceModel        types.String `tfsdk:"device_model"`
+	Color              types.String `tfsdk:"color"`
+	PurchaseSourceType types.String `tfsdk:"purchase_source_type"`
+	PurchaseSourceID   types.String `tfsdk:"purchase_source_id"`
+}
+
+// RuleResultModel describes the outcome of evaluating one rule.
+type RuleResultModel struct {
+	Name               types.String     `tfsdk:"name"`
+	Compliant          types.Bool       `tfsdk:"compliant"`
+	MatchedDeviceCount types.Int64      `tfsdk:"matched_device_count"`
+	ViolationCount     types.Int64      `tfsdk:"violation_count"`
+	Violations         []ViolationModel `tfsdk:"violations"`
+}
+
+// ViolationModel describes a device that breaks a rule.
+type ViolationModel struct {
+	DeviceID     types.String `tfsdk:"device_id"`
+	SerialNumber types.String `tfsdk:"serial_number"`
+	Reason       types.String `tfsdk:"reason"`
+}
+
+func (d *FleetPolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fleet_policy"
+}
+
+// deviceConditionsAttributes returns the attributes of a where or require block.
+func deviceConditionsAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"product_family": schema.StringAttribute{
+			Description: "Product family the device must have: iPhone, iPad, Mac, AppleTV, Watch or Vision.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOfCaseInsensitive(client.OrgDeviceProductFamilyValues()...),
+			},
+		},
+		"status": schema.StringAttribute{
+			Description: "Assignment status the device must have: ASSIGNED or UNASSIGNED.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOfCaseInsensitive(client.OrgDeviceStatusValues()...),
+			},
+		},
+		"device_model": schema.StringAttribute{
+			Description: "Device model the device must have, such as \"iPhone 15\".",
+			Optional:    true,
+		},
+		"color": schema.StringAttribute{
+			Description: "Color the device must have.",
+			Optional:    true,
+		},
+		"purchase_source_type": schema.StringAttribute{
+			Description: "Purchase source type the device must have: APPLE, RESELLER or MANUALLY_ADDED.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOfCaseInsensitive(client.PurchaseSourceTypeValues()...),
+			},
+		},
+		"purchase_source_id": schema.StringAttribute{
+			Description: "Purchase source ID the device must have, such as a reseller or Apple customer number.",
+			Optional:    true,
+		},
+	}
+}
+
+func (d *FleetPolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Evaluates declared rules against every device in the organization and reports the devices that break them, " +
+			"such as iPhones that are not assigned or devices bought from a particular reseller. Each rule selects devices with where and " +
+			"either lists the attribute values they must have in require, or sets forbid to report every selected device. Attribute values " +
+			"are compared case-insensitively. Designed to be used in a Terraform check block, e.g. assert { condition = data.axm_fleet_policy.this.compliant }.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"rules": schema.SetNestedAttribute{
+				Description: "The rules to evaluate. Rule names must be unique.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name identifying the rule in results.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"where": schema.SingleNestedAttribute{
+							Description: "Selects the devices the rule applies to. Every attribute set must match. Unset applies the rule to every device.",
+							Optional:    true,
+							Attributes:  deviceConditionsAttributes(),
+						},
+						"require": schema.SingleNestedAttribute{
+							Description: "Attribute values every selected device must have. Conflicts with forbid; one of them must be set.",
+							Optional:    true,
+							Attributes:  deviceConditionsAttributes(),
+						},
+						"forbid": schema.BoolAttribute{
+							Description: "When true, every selected device is a violation. Conflicts with require. Defaults to false.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"max_violations": schema.Int64Attribute{
+				Description: "Maximum number of violations listed per rule, to keep state small for large fleets. Violation counts always include every violation. Defaults to 100.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"compliant": schema.BoolAttribute{
+				Description: "True when no rule has a violation.",
+				Computed:    true,
+			},
+			"device_count": schema.Int64Attribute{
+				Description: "The number of devices in the organization that were evaluated.",
+				Computed:    true,
+			},
+			"violation_count": schema.Int64Attribute{
+				Description: "The total number of violations across all rules.",
+				Computed:    true,
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "Per-rule results, ordered by rule name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the rule.",
+							Computed:    true,
+						},
+						"compliant": schema.BoolAttribute{
+							Description: "True when no device breaks the rule.",
+							Computed:    true,
+						},
+						"matched_device_count": schema.Int64Attribute{
+							Description: "The number of devices selected by the rule's where.",
+							Computed:    true,
+						},
+						"violation_count": schema.Int64Attribute{
+							Description: "The number of devices breaking the rule.",
+							Computed:    true,
+						},
+						"violations": schema.ListNestedAttribute{
+							Description: "Devices breaking the rule, ordered by serial number and limited to max_violations.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"device_id": schema.StringAttribute{
+										Description: "The opaque organization device ID.",
+										Computed:    true,
+									},
+									"serial_number": schema.StringAttribute{
+										Description: "The device's serial number.",
+										Computed:    true,
+									},
+									"reason": schema.StringAttribute{
+										Description: "Why the device breaks the rule, such as `status is \"UNASSIGNED\", expected \"ASSIGNED\"`.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FleetPolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *FleetPolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data FleetPolicyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := newRules(data.Rules)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("rules"), "Invalid Fleet Policy Rule", err.Error())
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	devices, err := d.client.GetOrgDevices(readCtx, url.Values{"fields[orgDevices]": {fleetPolicyFields}})
+	if cached, ok := common.OfflineOrgDevices(d.client, err, &resp.Diagnostics); ok {
+		devices, err = cached, nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Devices",
+			err.Error(),
+		)
+		return
+	}
+
+	maxViolations := defaultMaxViolations
+	if !data.MaxViolations.IsNull() {
+		maxViolations = int(data.MaxViolations.ValueInt64())
+	}
+
+	violationCount := 0
+	data.Results = make([]RuleResultModel, 0, len(rules))
+	for _, rule := range rules {
+		result := rule.evaluate(devices)
+		violationCount += len(result.violations)
+
+		listed := result.violations[:min(len(result.violations), maxViolations)]
+		violations := make([]ViolationModel, 0, len(listed))
+		for _, v := range listed {
+			violations = append(violations, ViolationModel{
+				DeviceID:     types.StringValue(v.deviceID),
+				SerialNumber: types.StringValue(v.serialNumber),
+				Reason:       types.StringValue(v.reason),
+			})
+		}
+		data.Results = append(data.Results, RuleResultModel{
+			Name:               types.StringValue(result.name),
+			Compliant:          types.BoolValue(len(result.violations) == 0),
+			MatchedDeviceCount: types.Int64Value(int64(result.matched)),
+			ViolationCount:     types.Int64Value(int64(len(result.violations))),
+			Violations:         violations,
+		})
+	}
+
+	data.ID = types.StringValue("fleet_policy")
+	data.Compliant = types.BoolValue(violationCount == 0)
+	data.DeviceCount = types.Int64Value(int64(len(devices)))
+	data.ViolationCount = types.Int64Value(int64(violationCount))
+
+	tflog.Debug(ctx, "Evaluated fleet policy", map[string]any{
+		"rule_count":      len(rules),
+		"device_count":    len(devices),
+		"violation_count": violationCount,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,111 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fleet_policy_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/fleet_policy"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestFleetPolicyDataSourceMetadata(t *testing.T) {
+	ds := fleet_policy.NewFleetPolicyDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_fleet_policy" {
+		t.Errorf("expected TypeName %q, got %q", "axm_fleet_policy", resp.TypeName)
+	}
+}
+
+func TestFleetPolicyDataSourceSchema(t *testing.T) {
+	ds := fleet_policy.NewFleetPolicyDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	rules, ok := resp.Schema.Attributes["rules"]
+	if !ok {
+		t.Fatal("attribute 'rules' not found")
+	}
+	if !rules.IsRequired() {
+		t.Error("expected 'rules' to be Required")
+	}
+
+	maxViolations, ok := resp.Schema.Attributes["max_violations"]
+	if !ok {
+		t.Fatal("attribute 'max_violations' not found")
+	}
+	if !maxViolations.IsOptional() {
+		t.Error("expected 'max_violations' to be Optional")
+	}
+
+	for _, name := range []string{"id", "compliant", "device_count", "violation_count", "results"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Errorf("attribute %q not found", name)
+			continue
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected %q to be Computed", name)
+		}
+	}
+}
+
+func TestAccFleetPolicyDataSource(t *testing.T) {
+	testAccPreCheck(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "axm_fleet_policy" "test" {
+  rules = [
+    {
+      name    = "all-assigned"
+      require = { status = "ASSIGNED" }
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_fleet_policy.test", "id", "fleet_policy"),
+					resource.TestCheckResourceAttr("data.axm_fleet_policy.test", "results.#", "1"),
+					resource.TestCheckResourceAttrSet("data.axm_fleet_policy.test", "device_count"),
+					resource.TestCheckResourceAttrSet("data.axm_fleet_policy.test", "compliant"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fleet_policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+// deviceCondition is one attribute a device is compared against, with the value it must have.
+type deviceCondition struct {
+	attribute string
+	value     string
+	actual    func(client.DeviceAttribute) string
+}
+
+// deviceConditions is a set of conditions that must all hold for a device.
+type deviceConditions []deviceCondition
+
+// rule is a fleet policy rule in evaluable form. Devices matching where must satisfy require,
+// or are violations outright when forbid is true.
+type rule struct {
+	name    string
+	where   deviceConditions
+	require deviceConditions
+	forbid  bool
+}
+
+// violation records a device that breaks a rule.
+type violation struct {
+	deviceID     string
+	serialNumber string
+	reason       string
+}
+
+// ruleResult holds the outcome of evaluating a rule against the fleet.
+type ruleResult struct {
+	name       string
+	matched    int
+	violations []violation
+}
+
+// newDeviceConditions builds the conditions for the attributes set in model. A nil model has
+// no conditions, matching every device.
+func newDeviceConditions(model *DeviceConditionsModel) deviceConditions {
+	if model == nil {
+		return nil
+	}
+	var conditions deviceConditions
+	add := func(attribute string, value string, ok bool, actual func(client.DeviceAttribute) string) {
+		if ok {
+			conditions = append(conditions, deviceCondition{attribute: attribute, value: value, actual: actual})
+		}
+	}
+	value, ok := common.NormalizedFilterString(model.ProductFamily)
+	add("product_family", value, ok, func(a client.DeviceAttribute) string { return a.ProductFamily })
+	value, ok = common.NormalizedFilterString(model.Status)
+	add("status", value, ok, func(a client.DeviceAttribute) string { return string(a.Status) })
+	value, ok = common.NormalizedFilterString(model.DeviceModel)
+	add("device_model", value, ok, func(a client.DeviceAttribute) string { return a.DeviceModel })
+	value, ok = common.NormalizedFilterString(model.Color)
+	add("color", value, ok, func(a client.DeviceAttribute) string { return a.Color })
+	value, ok = common.NormalizedFilterString(model.PurchaseSourceType)
+	add("purchase_source_type", value, ok, func(a client.DeviceAttribute) string { return string(a.PurchaseSourceType) })
+	value, ok = common.NormalizedFilterString(model.PurchaseSourceID)
+	add("purchase_source_id", value, ok, func(a client.DeviceAttribute) string { return a.PurchaseSourceID })
+	return conditions
+}
+
+// matches reports whether every condition holds for attrs.
+func (c deviceConditions) matches(attrs client.DeviceAttribute) bool {
+	return len(c.failures(attrs)) == 0
+}
+
+// failures describes each condition that does not hold for attrs. Values are compared
+// case-insensitively.
+func (c deviceConditions) failures(attrs client.DeviceAttribute) []string {
+	var failures []string
+	for _, condition := range c {
+		actual := condition.actual(attrs)
+		if common.FoldFilterString(actual) != common.FoldFilterString(condition.value) {
+			failures = append(failures, fmt.Sprintf("%s is %q, expected %q", condition.attribute, actual, condition.value))
+		}
+	}
+	return failures
+}
+
+// evaluate applies r to devices, returning the violations ordered by serial number.
+func (r rule) evaluate(devices []client.OrgDevice) ruleResult {
+	result := ruleResult{name: r.name, violations: []violation{}}
+	for _, device := range devices {
+		if !r.where.matches(device.Attributes) {
+			continue
+		}
+		result.matched++
+
+		var reason string
+		if r.forbid {
+			reason = "device matches a forbidden selector"
+		} else if failures := r.require.failures(device.Attributes); len(failures) > 0 {
+			reason = strings.Join(failures, "; ")
+		} else {
+			continue
+		}
+		result.violations = append(result.violations, violation{
+			deviceID:     device.ID,
+			serialNumber: device.Attributes.SerialNumber,
+			reason:       reason,
+		})
+	}
+	slices.SortFunc(result.violations, func(a, b violation) int {
+		return strings.Compare(a.serialNumber, b.serialNumber)
+	})
+	return result
+}
+
+// newRules converts the configured rules into evaluable form, ordered by name. It returns an
+// error for duplicate names and for rules that set both or neither of require and forbid.
+func newRules(models []FleetPolicyRuleModel) ([]rule, error) {
+	seen := make(map[string]bool, len(models))
+	rules := make([]rule, 0, len(models))
+	for _, model := range models {
+		name := strings.TrimSpace(model.Name.ValueString())
+		if seen[name] {
+			return nil, fmt.Errorf("rule name %q is used more than once", name)
+		}
+		seen[name] = true
+
+		forbid := model.Forbid.ValueBool()
+		require := newDeviceConditions(model.Require)
+		switch {
+		case forbid && len(require) > 0:
+			return nil, fmt.Errorf("rule %q sets both require and forbid", name)
+		case !forbid && len(require) == 0:
+			return nil, fmt.Errorf("rule %q must set at least one require attribute, or forbid = true", name)
+		}
+
+		rules = append(rules, rule{
+			name:    name,
+			where:   newDeviceConditions(model.Where),
+			require: require,
+			forbid:  forbid,
+		})
+	}
+	slices.SortFunc(rules, func(a, b rule) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return rules, nil
+}
@@ -0,0 +1,130 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package fleet_policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func testDevice(id, serial, family string, status client.OrgDeviceStatus, sourceID string) client.OrgDevice {
+	return client.OrgDevice{
+		ID: id,
+		Attributes: client.DeviceAttribute{
+			SerialNumber:     serial,
+			ProductFamily:    family,
+			Status:           status,
+			PurchaseSourceID: sourceID,
+		},
+	}
+}
+
+func TestRuleEvaluate(t *testing.T) {
+	devices := []client.OrgDevice{
+		testDevice("d3", "SN003", "iPhone", client.OrgDeviceStatusUnassigned, "R1"),
+		testDevice("d1", "SN001", "iPhone", client.OrgDeviceStatusAssigned, "R2"),
+		testDevice("d2", "SN002", "Mac", client.OrgDeviceStatusUnassigned, "R1"),
+	}
+
+	rules, err := newRules([]FleetPolicyRuleModel{
+		{
+			Name:    types.StringValue("iphones-assigned"),
+			Where:   &DeviceConditionsModel{ProductFamily: types.StringValue("iphone")},
+			Require: &DeviceConditionsModel{Status: types.StringValue("ASSIGNED")},
+		},
+		{
+			Name:   types.StringValue("no-reseller-r1"),
+			Where:  &DeviceConditionsModel{PurchaseSourceID: types.StringValue("R1")},
+			Forbid: types.BoolValue(true),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 || rules[0].name != "iphones-assigned" {
+		t.Fatalf("expected rules ordered by name, got %+v", rules)
+	}
+
+	assigned := rules[0].evaluate(devices)
+	if assigned.matched != 2 || len(assigned.violations) != 1 {
+		t.Fatalf("expected 2 matches and 1 violation, got %+v", assigned)
+	}
+	if v := assigned.violations[0]; v.deviceID != "d3" || v.reason != `status is "UNASSIGNED", expected "ASSIGNED"` {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+
+	forbidden := rules[1].evaluate(devices)
+	if forbidden.matched != 2 || len(forbidden.violations) != 2 {
+		t.Fatalf("expected 2 matches and 2 violations, got %+v", forbidden)
+	}
+	if forbidden.violations[0].serialNumber != "SN002" || forbidden.violations[1].serialNumber != "SN003" {
+		t.Errorf("expected violations ordered by serial number, got %+v", forbidden.violations)
+	}
+}
+
+func TestRuleEvaluate_NoWhereMatchesEveryDevice(t *testing.T) {
+	devices := []client.OrgDevice{
+		testDevice("d1", "SN001", "iPhone", client.OrgDeviceStatusAssigned, ""),
+		testDevice("d2", "SN002", "Mac", client.OrgDeviceStatusAssigned, ""),
+	}
+	rules, err := newRules([]FleetPolicyRuleModel{{
+		Name:    types.StringValue("all-assigned"),
+		Require: &DeviceConditionsModel{Status: types.StringValue("ASSIGNED")},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := rules[0].evaluate(devices)
+	if result.matched != 2 || len(result.violations) != 0 {
+		t.Errorf("expected every device to match without violations, got %+v", result)
+	}
+}
+
+func TestNewRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		models  []FleetPolicyRuleModel
+		wantErr string
+	}{
+		{
+			name: "duplicate names",
+			models: []FleetPolicyRuleModel{
+				{Name: types.StringValue("a"), Forbid: types.BoolValue(true)},
+				{Name: types.StringValue("a"), Forbid: types.BoolValue(true)},
+			},
+			wantErr: "used more than once",
+		},
+		{
+			name: "require and forbid",
+			models: []FleetPolicyRuleModel{{
+				Name:    types.StringValue("a"),
+				Require: &DeviceConditionsModel{Status: types.StringValue("ASSIGNED")},
+				Forbid:  types.BoolValue(true),
+			}},
+			wantErr: "both require and forbid",
+		},
+		{
+			name: "neither require nor forbid",
+			models: []FleetPolicyRuleModel{{
+				Name:    types.StringValue("a"),
+				Require: &DeviceConditionsModel{},
+			}},
+			wantErr: "at least one require attribute",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRules(tt.models)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package inventory_snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+// Create captures the inventory and writes the first snapshot file.
+func (r *InventorySnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var data InventorySnapshotModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.writeSnapshot(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read clears the recorded hash when the snapshot file is missing or has been modified,
+// so the next plan rewrites it.
+func (r *InventorySnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var data InventorySnapshotModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read inventory snapshot", err.Error())
+		return
+	}
+	if hash != data.ContentHash.ValueString() {
+		tflog.Info(ctx, "Inventory snapshot file missing or modified outside Terraform", map[string]any{
+			"path": data.Path.ValueString(),
+		})
+		data.ContentHash = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update captures the inventory and rewrites the snapshot file.
+func (r *InventorySnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	var data InventorySnapshotModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.writeSnapshot(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from state. The snapshot file is retained.
+func (r *InventorySnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+}
+
+// writeSnapshot captures the inventory for data, writes it to data.Path and records the result in data.
+func (r *InventorySnapshotResource) writeSnapshot(ctx context.Context, data *InventorySnapshotModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	records, content, err := r.captureSnapshot(ctx, *data)
+	if err != nil {
+		diags.AddError("Failed to capture inventory snapshot", err.Error())
+		return diags
+	}
+
+	data.AddedDevices = types.SetNull(types.StringType)
+	data.RemovedDevices = types.SetNull(types.StringType)
+	data.ChangedDevices = types.SetNull(types.StringType)
+	if data.TrackChanges.ValueBool() {
+		diags.Append(recordChanges(ctx, data, records)...)
+	}
+
+	if err := writeSnapshot(data.Path.ValueString(), content); err != nil {
+		diags.AddError("Failed to write inventory snapshot", err.Error())
+		return diags
+	}
+
+	data.ID = data.Path
+	data.ContentHash = types.StringValue(contentHash(content))
+	data.DeviceCount = types.Int64Value(int64(len(records)))
+	data.CapturedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	tflog.Debug(ctx, "Wrote inventory snapshot", map[string]any{
+		"path":         data.Path.ValueString(),
+		"device_count": len(records),
+		"content_hash": data.ContentHash.ValueString(),
+	})
+	return diags
+}
+
+// recordChanges compares records with the snapshot file about to be replaced and stores the
+// differences in data. A previous file that cannot be read is reported as a warning.
+func recordChanges(ctx context.Context, data *InventorySnapshotModel, records []snapshotDevice) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	previous, ok, err := readPreviousSnapshot(data.Path.ValueString())
+	if err != nil {
+		diags.AddWarning(
+			"Unable to compare with previous inventory snapshot",
+			fmt.Sprintf("Path: %s\n\n%v\n\nadded_devices, removed_devices, and changed_devices are left null.", data.Path.ValueString(), err),
+		)
+		return diags
+	}
+	if !ok {
+		return diags
+	}
+
+	changes := diffSnapshots(previous, records)
+	var setDiags diag.Diagnostics
+	data.AddedDevices, setDiags = types.SetValueFrom(ctx, types.StringType, changes.added)
+	diags.Append(setDiags...)
+	data.RemovedDevices, setDiags = types.SetValueFrom(ctx, types.StringType, changes.removed)
+	diags.Append(setDiags...)
+	data.ChangedDevices, setDiags = types.SetValueFrom(ctx, types.StringType, changes.changed)
+	diags.Append(setDiags...)
+
+	tflog.Debug(ctx, "Compared inventory snapshot with previous snapshot", map[string]any{
+		"path":    data.Path.ValueString(),
+		"added":   len(changes.added),
+		"removed": len(changes.removed),
+		"changed": len(changes.changed),
+	})
+	return diags
+}
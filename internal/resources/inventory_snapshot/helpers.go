@@ -0,0 +1,286 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package inventory_snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+	"github.com/neilmartin83/terraform-provider-axm/internal/export"
+)
+
+// snapshotCSVHeader lists the CSV columns in output order.
+var snapshotCSVHeader = []string{
+	"id", "serial_number", "product_family", "product_type", "device_model", "device_capacity",
+	"color", "status", "added_to_org_date_time", "released_from_org_date_time", "updated_date_time",
+	"order_number", "order_date_time", "part_number", "purchase_source_type", "purchase_source_id",
+	"imei", "meid", "eid", "wifi_mac_address", "bluetooth_mac_address", "ethernet_mac_address",
+}
+
+// snapshotFilter restricts which devices are included in a snapshot.
+type snapshotFilter struct {
+	productFamilies []string
+	status          string
+}
+
+// newSnapshotFilter builds a filter from the model's optional filter attributes.
+func newSnapshotFilter(data InventorySnapshotModel) snapshotFilter {
+	filter := snapshotFilter{productFamilies: common.SetToStrings(data.ProductFamilies)}
+	if status, ok := common.NormalizedFilterString(data.Status); ok {
+		filter.status = status
+	}
+	return filter
+}
+
+// matches reports whether device passes the filter.
+func (f snapshotFilter) matches(device client.OrgDevice) bool {
+	if len(f.productFamilies) > 0 && !slices.ContainsFunc(f.productFamilies, func(family string) bool {
+		return strings.EqualFold(family, device.Attributes.ProductFamily)
+	}) {
+		return false
+	}
+	if f.status != "" && !strings.EqualFold(f.status, string(device.Attributes.Status)) {
+		return false
+	}
+	return true
+}
+
+// captureSnapshot fetches the device inventory and renders the filtered devices in format.
+func (r *InventorySnapshotResource) captureSnapshot(ctx context.Context, data InventorySnapshotModel) ([]snapshotDevice, []byte, error) {
+	devices, err := r.client.GetOrgDevices(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read organization devices: %w", err)
+	}
+	records := snapshotRecords(devices, newSnapshotFilter(data))
+	content, err := renderSnapshot(records, data.Format.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+	return records, content, nil
+}
+
+// snapshotRecords converts the devices passing filter to snapshot records sorted by ID.
+func snapshotRecords(devices []client.OrgDevice, filter snapshotFilter) []snapshotDevice {
+	records := make([]snapshotDevice, 0, len(devices))
+	for _, device := range devices {
+		if !filter.matches(device) {
+			continue
+		}
+		attrs := device.Attributes
+		records = append(records, snapshotDevice{
+			ID:                      device.ID,
+			SerialNumber:            attrs.SerialNumber,
+			ProductFamily:           attrs.ProductFamily,
+			ProductType:             attrs.ProductType,
+			DeviceModel:             attrs.DeviceModel,
+			DeviceCapacity:          attrs.DeviceCapacity,
+			Color:                   attrs.Color,
+			Status:                  string(attrs.Status),
+			AddedToOrgDateTime:      attrs.AddedToOrgDateTime,
+			ReleasedFromOrgDateTime: attrs.ReleasedFromOrgDateTime,
+			UpdatedDateTime:         attrs.UpdatedDateTime,
+			OrderNumber:             attrs.OrderNumber,
+			OrderDateTime:           attrs.OrderDateTime,
+			PartNumber:              attrs.PartNumber,
+			PurchaseSourceType:      string(attrs.PurchaseSourceType),
+			PurchaseSourceID:        attrs.PurchaseSourceID,
+			IMEI:                    attrs.IMEI,
+			MEID:                    attrs.MEID,
+			EID:                     attrs.EID,
+			WifiMacAddress:          attrs.WifiMacAddress,
+			BluetoothMacAddress:     attrs.BluetoothMacAddress,
+			EthernetMacAddress:      attrs.EthernetMacAddress,
+		})
+	}
+	slices.SortFunc(records, func(a, b snapshotDevice) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return records
+}
+
+// snapshotColumns flattens snapshot records into rows for tabular export formats. List values
+// are joined with semicolons.
+var snapshotColumns = export.Columns[snapshotDevice]{
+	Header: snapshotCSVHeader,
+	Row:    csvRecord,
+	Parse:  parseCSVRecord,
+}
+
+// renderSnapshot serializes records in format, one of export.Formats.
+func renderSnapshot(records []snapshotDevice, format string) ([]byte, error) {
+	encoder, err := export.New(format, snapshotColumns)
+	if err != nil {
+		return nil, err
+	}
+	content, err := encoder.Encode(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return content, nil
+}
+
+// csvRecord returns the CSV columns of d in snapshotCSVHeader order.
+func csvRecord(d snapshotDevice) []string {
+	return []string{
+		d.ID, d.SerialNumber, d.ProductFamily, d.ProductType, d.DeviceModel, d.DeviceCapacity,
+		d.Color, d.Status, d.AddedToOrgDateTime, d.ReleasedFromOrgDateTime, d.UpdatedDateTime,
+		d.OrderNumber, d.OrderDateTime, d.PartNumber, d.PurchaseSourceType, d.PurchaseSourceID,
+		strings.Join(d.IMEI, ";"), strings.Join(d.MEID, ";"), d.EID, d.WifiMacAddress,
+		d.BluetoothMacAddress, strings.Join(d.EthernetMacAddress, ";"),
+	}
+}
+
+// parseCSVRecord builds a snapshot record from a row in snapshotCSVHeader order.
+func parseCSVRecord(row []string) snapshotDevice {
+	list := func(value string) []string {
+		if value == "" {
+			return nil
+		}
+		return strings.Split(value, ";")
+	}
+	return snapshotDevice{
+		ID:                      row[0],
+		SerialNumber:            row[1],
+		ProductFamily:           row[2],
+		ProductType:             row[3],
+		DeviceModel:             row[4],
+		DeviceCapacity:          row[5],
+		Color:                   row[6],
+		Status:                  row[7],
+		AddedToOrgDateTime:      row[8],
+		ReleasedFromOrgDateTime: row[9],
+		UpdatedDateTime:         row[10],
+		OrderNumber:             row[11],
+		OrderDateTime:           row[12],
+		PartNumber:              row[13],
+		PurchaseSourceType:      row[14],
+		PurchaseSourceID:        row[15],
+		IMEI:                    list(row[16]),
+		MEID:                    list(row[17]),
+		EID:                     row[18],
+		WifiMacAddress:          row[19],
+		BluetoothMacAddress:     row[20],
+		EthernetMacAddress:      list(row[21]),
+	}
+}
+
+// parseSnapshot decodes snapshot file content written in any export format, detected from
+// the content so that a file written before the format was changed can still be compared.
+func parseSnapshot(content []byte) ([]snapshotDevice, error) {
+	records, err := export.Decode(content, snapshotColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return records, nil
+}
+
+// snapshotChanges holds the IDs of the devices that differ between two snapshots.
+type snapshotChanges struct {
+	added   []string
+	removed []string
+	changed []string
+}
+
+// diffSnapshots compares current with previous by device ID. A device is changed when any of
+// its recorded attributes differ. Each list is sorted.
+func diffSnapshots(previous, current []snapshotDevice) snapshotChanges {
+	fingerprint := func(d snapshotDevice) string {
+		return strings.Join(csvRecord(d), "\x1f")
+	}
+	before := make(map[string]string, len(previous))
+	for _, d := range previous {
+		before[d.ID] = fingerprint(d)
+	}
+
+	changes := snapshotChanges{added: []string{}, removed: []string{}, changed: []string{}}
+	seen := make(map[string]bool, len(current))
+	for _, d := range current {
+		seen[d.ID] = true
+		prior, ok := before[d.ID]
+		switch {
+		case !ok:
+			changes.added = append(changes.added, d.ID)
+		case prior != fingerprint(d):
+			changes.changed = append(changes.changed, d.ID)
+		}
+	}
+	for id := range before {
+		if !seen[id] {
+			changes.removed = append(changes.removed, id)
+		}
+	}
+	slices.Sort(changes.added)
+	slices.Sort(changes.removed)
+	slices.Sort(changes.changed)
+	return changes
+}
+
+// readPreviousSnapshot parses the snapshot file at path, reporting false when none exists.
+func readPreviousSnapshot(path string) ([]snapshotDevice, bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read previous snapshot file: %w", err)
+	}
+	records, err := parseSnapshot(content)
+	if err != nil {
+		return nil, false, err
+	}
+	return records, true, nil
+}
+
+// contentHash returns the hex-encoded SHA-256 digest of content.
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeSnapshot atomically writes content to path, creating parent directories as needed.
+func writeSnapshot(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move snapshot file into place: %w", err)
+	}
+	return nil
+}
+
+// fileHash returns the content hash of the file at path, or "" if it does not exist.
+func fileHash(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	return contentHash(content), nil
+}
@@ -0,0 +1,230 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package inventory_snapshot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/export"
+)
+
+func testDevices() []client.OrgDevice {
+	return []client.OrgDevice{
+		{ID: "C", Attributes: client.DeviceAttribute{SerialNumber: "SER-C", ProductFamily: "Mac", Status: "ASSIGNED", EthernetMacAddress: []string{"aa", "bb"}}},
+		{ID: "A", Attributes: client.DeviceAttribute{SerialNumber: "SER-A", ProductFamily: "iPhone", Status: "UNASSIGNED"}},
+		{ID: "B", Attributes: client.DeviceAttribute{SerialNumber: "SER-B", ProductFamily: "iPad", Status: "ASSIGNED"}},
+	}
+}
+
+func TestSnapshotRecords_FiltersAndSorts(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter snapshotFilter
+		want   []string
+	}{
+		{name: "no_filter", filter: snapshotFilter{}, want: []string{"A", "B", "C"}},
+		{name: "product_family_case_insensitive", filter: snapshotFilter{productFamilies: []string{"mac", "IPAD"}}, want: []string{"B", "C"}},
+		{name: "status", filter: snapshotFilter{status: "ASSIGNED"}, want: []string{"B", "C"}},
+		{name: "combined", filter: snapshotFilter{productFamilies: []string{"iPhone"}, status: "ASSIGNED"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := snapshotRecords(testDevices(), tt.filter)
+			got := make([]string, 0, len(records))
+			for _, r := range records {
+				got = append(got, r.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewSnapshotFilter(t *testing.T) {
+	families, _ := types.SetValueFrom(t.Context(), types.StringType, []string{"Mac"})
+	filter := newSnapshotFilter(InventorySnapshotModel{
+		ProductFamilies: families,
+		Status:          types.StringValue(" UNASSIGNED "),
+	})
+	if len(filter.productFamilies) != 1 || filter.productFamilies[0] != "Mac" {
+		t.Errorf("unexpected product families %v", filter.productFamilies)
+	}
+	if filter.status != "UNASSIGNED" {
+		t.Errorf("expected status %q, got %q", "UNASSIGNED", filter.status)
+	}
+}
+
+func TestRenderSnapshot_JSON(t *testing.T) {
+	records := snapshotRecords(testDevices(), snapshotFilter{})
+	content, err := renderSnapshot(records, export.FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded []snapshotDevice
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("snapshot is not valid JSON: %v", err)
+	}
+	if len(decoded) != 3 || decoded[2].SerialNumber != "SER-C" || len(decoded[2].EthernetMacAddress) != 2 {
+		t.Errorf("unexpected decoded snapshot: %+v", decoded)
+	}
+}
+
+func TestRenderSnapshot_CSV(t *testing.T) {
+	records := snapshotRecords(testDevices(), snapshotFilter{})
+	content, err := renderSnapshot(records, export.FormatCSV)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil {
+		t.Fatalf("snapshot is not valid CSV: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected header plus 3 rows, got %d", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(snapshotCSVHeader, ",") {
+		t.Errorf("unexpected header %v", rows[0])
+	}
+	last := rows[3]
+	if last[0] != "C" || last[len(last)-1] != "aa;bb" {
+		t.Errorf("unexpected row %v", last)
+	}
+}
+
+func TestRenderSnapshot_UnsupportedFormat(t *testing.T) {
+	if _, err := renderSnapshot(nil, "xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestRenderSnapshot_StableHash(t *testing.T) {
+	first, _ := renderSnapshot(snapshotRecords(testDevices(), snapshotFilter{}), export.FormatJSON)
+	devices := testDevices()
+	devices[0], devices[2] = devices[2], devices[0]
+	second, _ := renderSnapshot(snapshotRecords(devices, snapshotFilter{}), export.FormatJSON)
+
+	if contentHash(first) != contentHash(second) {
+		t.Error("expected content hash to be independent of API ordering")
+	}
+}
+
+func TestWriteSnapshotAndFileHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "inventory.json")
+
+	hash, err := fileHash(path)
+	if err != nil || hash != "" {
+		t.Fatalf("expected empty hash for missing file, got %q, %v", hash, err)
+	}
+
+	content := []byte("[]\n")
+	if err := writeSnapshot(path, content); err != nil {
+		t.Fatalf("writeSnapshot returned error: %v", err)
+	}
+
+	hash, err = fileHash(path)
+	if err != nil {
+		t.Fatalf("fileHash returned error: %v", err)
+	}
+	if hash != contentHash(content) {
+		t.Errorf("expected hash %q, got %q", contentHash(content), hash)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("failed to list snapshot directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the snapshot file to remain, got %d entries", len(entries))
+	}
+}
+
+func TestParseSnapshot_RoundTrip(t *testing.T) {
+	records := snapshotRecords(testDevices(), snapshotFilter{})
+	for _, format := range export.Formats() {
+		t.Run(format, func(t *testing.T) {
+			content, err := renderSnapshot(records, format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			parsed, err := parseSnapshot(content)
+			if err != nil {
+				t.Fatalf("parseSnapshot returned error: %v", err)
+			}
+			changes := diffSnapshots(parsed, records)
+			if len(changes.added)+len(changes.removed)+len(changes.changed) != 0 {
+				t.Errorf("expected parsed snapshot to match the rendered records, got %+v", changes)
+			}
+		})
+	}
+}
+
+func TestParseSnapshot_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"json":       "[{",
+		"csv_header": "serial,id\nA,B\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseSnapshot([]byte(content)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	records, err := parseSnapshot([]byte("  \n"))
+	if err != nil || records != nil {
+		t.Errorf("expected no records for empty content, got %v, %v", records, err)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	previous := snapshotRecords(testDevices(), snapshotFilter{})
+
+	devices := testDevices()
+	devices[0].Attributes.Status = "UNASSIGNED"
+	devices[1] = client.OrgDevice{ID: "D", Attributes: client.DeviceAttribute{SerialNumber: "SER-D"}}
+	current := snapshotRecords(devices, snapshotFilter{})
+
+	changes := diffSnapshots(previous, current)
+	if strings.Join(changes.added, ",") != "D" {
+		t.Errorf("expected added [D], got %v", changes.added)
+	}
+	if strings.Join(changes.removed, ",") != "A" {
+		t.Errorf("expected removed [A], got %v", changes.removed)
+	}
+	if strings.Join(changes.changed, ",") != "C" {
+		t.Errorf("expected changed [C], got %v", changes.changed)
+	}
+}
+
+func TestReadPreviousSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.csv")
+
+	if _, ok, err := readPreviousSnapshot(path); ok || err != nil {
+		t.Fatalf("expected no previous snapshot for missing file, got %v, %v", ok, err)
+	}
+
+	content, _ := renderSnapshot(snapshotRecords(testDevices(), snapshotFilter{}), export.FormatCSV)
+	if err := writeSnapshot(path, content); err != nil {
+		t.Fatalf("writeSnapshot returned error: %v", err)
+	}
+	records, ok, err := readPreviousSnapshot(path)
+	if err != nil || !ok {
+		t.Fatalf("expected previous snapshot, got %v, %v", ok, err)
+	}
+	if len(records) != 3 {
+		t.Errorf("expected 3 records, got %d", len(records))
+	}
+}
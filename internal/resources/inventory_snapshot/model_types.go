@@ -0,0 +1,48 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package inventory_snapshot
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// InventorySnapshotModel describes the Terraform state for an inventory snapshot.
+type InventorySnapshotModel struct {
+	ID              types.String `tfsdk:"id"`
+	Path            types.String `tfsdk:"path"`
+	Format          types.String `tfsdk:"format"`
+	ProductFamilies types.Set    `tfsdk:"product_families"`
+	Status          types.String `tfsdk:"status"`
+	ContentHash     types.String `tfsdk:"content_hash"`
+	DeviceCount     types.Int64  `tfsdk:"device_count"`
+	CapturedAt      types.String `tfsdk:"captured_at"`
+	TrackChanges    types.Bool   `tfsdk:"track_changes"`
+	AddedDevices    types.Set    `tfsdk:"added_devices"`
+	RemovedDevices  types.Set    `tfsdk:"removed_devices"`
+	ChangedDevices  types.Set    `tfsdk:"changed_devices"`
+}
+
+// snapshotDevice is the serialized form of a device in a snapshot file.
+type snapshotDevice struct {
+	ID                      string   `json:"id"`
+	SerialNumber            string   `json:"serial_number"`
+	ProductFamily           string   `json:"product_family"`
+	ProductType             string   `json:"product_type"`
+	DeviceModel             string   `json:"device_model"`
+	DeviceCapacity          string   `json:"device_capacity"`
+	Color                   string   `json:"color"`
+	Status                  string   `json:"status"`
+	AddedToOrgDateTime      string   `json:"added_to_org_date_time"`
+	ReleasedFromOrgDateTime string   `json:"released_from_org_date_time,omitempty"`
+	UpdatedDateTime         string   `json:"updated_date_time"`
+	OrderNumber             string   `json:"order_number,omitempty"`
+	OrderDateTime           string   `json:"order_date_time,omitempty"`
+	PartNumber              string   `json:"part_number,omitempty"`
+	PurchaseSourceType      string   `json:"purchase_source_type"`
+	PurchaseSourceID        string   `json:"purchase_source_id"`
+	IMEI                    []string `json:"imei,omitempty"`
+	MEID                    []string `json:"meid,omitempty"`
+	EID                     string   `json:"eid,omitempty"`
+	WifiMacAddress          string   `json:"wifi_mac_address,omitempty"`
+	BluetoothMacAddress     string   `json:"bluetooth_mac_address,omitempty"`
+	EthernetMacAddress      []string `json:"ethernet_mac_address,omitempty"`
+}
@@ -0,0 +1,170 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package inventory_snapshot
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+	"github.com/neilmartin83/terraform-provider-axm/internal/export"
+)
+
+var _ resource.Resource = &InventorySnapshotResource{}
+var _ resource.ResourceWithModifyPlan = &InventorySnapshotResource{}
+
+// NewInventorySnapshotResource returns a new resource that writes device inventory snapshots to disk.
+func NewInventorySnapshotResource() resource.Resource {
+	return &InventorySnapshotResource{}
+}
+
+// InventorySnapshotResource writes a snapshot of the organization device inventory to a local file.
+type InventorySnapshotResource struct {
+	client *client.Client
+}
+
+func (r *InventorySnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inventory_snapshot"
+}
+
+// Schema defines the schema for the resource.
+func (r *InventorySnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Writes a snapshot of the organization device inventory, optionally filtered, to a local JSON or CSV file. " +
+			"The inventory is fetched during every plan and the SHA-256 hash of the rendered snapshot is kept in state, " +
+			"so inventory changes appear as an update to content_hash and the file is rewritten on apply. " +
+			"The file is left in place when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The snapshot file path. Identifies this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local file path to which the snapshot is written. Parent directories are created as needed.",
+			},
+			"format": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(export.FormatJSON),
+				Description: "Snapshot file format: json, csv or ndjson (one JSON object per line). Defaults to json. In CSV output, list values are joined with semicolons.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(export.Formats()...),
+				},
+			},
+			"product_families": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only include devices in these product families (e.g. iPhone, iPad, Mac, AppleTV, Watch, Vision). Matching is case-insensitive.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include devices with this status: ASSIGNED or UNASSIGNED.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.OrgDeviceStatusValues()...),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex-encoded SHA-256 hash of the snapshot file contents.",
+			},
+			"device_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of devices in the snapshot.",
+			},
+			"captured_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 time at which the snapshot was captured.",
+			},
+			"track_changes": schema.BoolAttribute{
+				Optional: true,
+				Description: "When true, each time the snapshot is written the new inventory is compared with the snapshot file it replaces, " +
+					"and the differences are exposed in added_devices, removed_devices, and changed_devices. Devices are matched by ID, " +
+					"so changing the filters also shows devices as added or removed. Defaults to false.",
+			},
+			"added_devices": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of devices in the snapshot that were not in the previous snapshot file. Null unless track_changes is true and a previous snapshot file existed.",
+			},
+			"removed_devices": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of devices in the previous snapshot file that are no longer in the snapshot. Null unless track_changes is true and a previous snapshot file existed.",
+			},
+			"changed_devices": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of devices in both snapshots whose recorded attributes, such as status or updated_date_time, differ. Null unless track_changes is true and a previous snapshot file existed.",
+			},
+		},
+	}
+}
+
+func (r *InventorySnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Resource")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.client = c
+}
+
+// ModifyPlan captures the current inventory and marks the snapshot for rewrite when its
+// content no longer matches the hash recorded in state.
+func (r *InventorySnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer common.RedactDiagnostics(r.client, &resp.Diagnostics)
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state InventorySnapshotModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Path.IsUnknown() || plan.Format.IsUnknown() || plan.ProductFamilies.IsUnknown() || plan.Status.IsUnknown() {
+		return
+	}
+
+	_, content, err := r.captureSnapshot(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to capture inventory snapshot during plan",
+			err.Error()+"\n\nThe snapshot will be refreshed on the next apply that changes its configuration.",
+		)
+		return
+	}
+	if contentHash(content) == state.ContentHash.ValueString() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("device_count"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("captured_at"), types.StringUnknown())...)
+	if plan.TrackChanges.ValueBool() {
+		for _, name := range []string{"added_devices", "removed_devices", "changed_devices"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.SetUnknown(types.StringType))...)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package inventory_snapshot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/inventory_snapshot"
+)
+
+func TestInventorySnapshotResourceMetadata(t *testing.T) {
+	r := inventory_snapshot.NewInventorySnapshotResource()
+	resp := tfresource.MetadataResponse{}
+	r.Metadata(context.Background(), tfresource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_inventory_snapshot" {
+		t.Errorf("expected TypeName %q, got %q", "axm_inventory_snapshot", resp.TypeName)
+	}
+}
+
+func TestInventorySnapshotResourceSchema(t *testing.T) {
+	r := inventory_snapshot.NewInventorySnapshotResource()
+	resp := tfresource.SchemaResponse{}
+	r.Schema(context.Background(), tfresource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	tests := []struct {
+		name     string
+		required bool
+		optional bool
+		computed bool
+	}{
+		{"id", false, false, true},
+		{"path", true, false, false},
+		{"format", false, true, true},
+		{"product_families", false, true, false},
+		{"status", false, true, false},
+		{"content_hash", false, false, true},
+		{"device_count", false, false, true},
+		{"captured_at", false, false, true},
+		{"track_changes", false, true, false},
+		{"added_devices", false, false, true},
+		{"removed_devices", false, false, true},
+		{"changed_devices", false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, ok := resp.Schema.Attributes[tt.name]
+			if !ok {
+				t.Fatalf("attribute %q not found in schema", tt.name)
+			}
+			if attr.IsRequired() != tt.required {
+				t.Errorf("expected Required=%v, got %v", tt.required, attr.IsRequired())
+			}
+			if attr.IsOptional() != tt.optional {
+				t.Errorf("expected Optional=%v, got %v", tt.optional, attr.IsOptional())
+			}
+			if attr.IsComputed() != tt.computed {
+				t.Errorf("expected Computed=%v, got %v", tt.computed, attr.IsComputed())
+			}
+		})
+	}
+
+	if len(resp.Schema.Attributes) != len(tests) {
+		t.Errorf("expected %d attributes, got %d", len(tests), len(resp.Schema.Attributes))
+	}
+}
+
+func TestInventorySnapshotResourceImplementsModifyPlan(t *testing.T) {
+	r := inventory_snapshot.NewInventorySnapshotResource()
+	if _, ok := r.(tfresource.ResourceWithModifyPlan); !ok {
+		t.Error("expected resource to implement ResourceWithModifyPlan")
+	}
+}
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestAccInventorySnapshotResource_basic(t *testing.T) {
+	testAccPreCheck(t)
+
+	snapshotPath := filepath.Join(t.TempDir(), "inventory.csv")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "axm_inventory_snapshot" "this" {
+  path   = "` + snapshotPath + `"
+  format = "csv"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("axm_inventory_snapshot.this", "id", snapshotPath),
+					resource.TestCheckResourceAttrSet("axm_inventory_snapshot.this", "content_hash"),
+					resource.TestCheckResourceAttrSet("axm_inventory_snapshot.this", "device_count"),
+				),
+			},
+		},
+	})
+}
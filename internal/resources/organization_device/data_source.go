@@ -5,6 +5,7 @@ package organization_device
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -30,32 +31,11 @@ type OrganizationDeviceDataSource struct {
 
 // OrganizationDeviceDataSourceModel describes the data source data model.
 type OrganizationDeviceDataSourceModel struct {
-	ID                      types.String   `tfsdk:"id"`
-	Timeouts                timeouts.Value `tfsdk:"timeouts"`
-	Type                    types.String   `tfsdk:"type"`
-	SerialNumber            types.String   `tfsdk:"serial_number"`
-	AddedToOrgDateTime      types.String   `tfsdk:"added_to_org_date_time"`
-	ReleasedFromOrgDateTime types.String   `tfsdk:"released_from_org_date_time"`
-	UpdatedDateTime         types.String   `tfsdk:"updated_date_time"`
-	DeviceModel             types.String   `tfsdk:"device_model"`
-	ProductFamily           types.String   `tfsdk:"product_family"`
-	ProductType             types.String   `tfsdk:"product_type"`
-	DeviceCapacity          types.String   `tfsdk:"device_capacity"`
-	PartNumber              types.String   `tfsdk:"part_number"`
-	OrderNumber             types.String   `tfsdk:"order_number"`
-	Color                   types.String   `tfsdk:"color"`
-	Status                  types.String   `tfsdk:"status"`
-	OrderDateTime           types.String   `tfsdk:"order_date_time"`
-	IMEI                    []types.String `tfsdk:"imei"`
-	MEID                    []types.String `tfsdk:"meid"`
-	EID                     types.String   `tfsdk:"eid"`
-	PurchaseSourceID        types.String   `tfsdk:"purchase_source_id"`
-	PurchaseSourceType      types.String   `tfsdk:"purchase_source_type"`
-	WifiMacAddress          types.String   `tfsdk:"wifi_mac_address"`
-	BluetoothMacAddress     types.String   `tfsdk:"bluetooth_mac_address"`
-	EthernetMacAddress      []types.String `tfsdk:"ethernet_mac_address"`
-	ReleaserEntityType      types.String   `tfsdk:"releaser_entity_type"`
-	ReleaserID              types.String   `tfsdk:"releaser_id"`
+	ID                 types.String   `tfsdk:"id"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+	CredentialsProfile types.String   `tfsdk:"credentials_profile"`
+	Fields             types.Set      `tfsdk:"fields"`
+	common.OrgDeviceModel
 }
 
 func (d *OrganizationDeviceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,114 +43,18 @@ func (d *OrganizationDeviceDataSource) Metadata(ctx context.Context, req datasou
 }
 
 func (d *OrganizationDeviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := common.OrgDeviceSchemaAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The opaque resource ID that uniquely identifies the resource.",
+	}
+	attributes["timeouts"] = timeouts.Attributes(ctx)
+	attributes["credentials_profile"] = common.CredentialsProfileAttribute()
+	attributes["fields"] = common.OrgDeviceFieldsAttribute()
+
 	resp.Schema = schema.Schema{
 		Description: "Fetches information about a specific device from Apple Business or School Manager.",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Required:    true,
-				Description: "The opaque resource ID that uniquely identifies the resource.",
-			},
-			"timeouts": timeouts.Attributes(ctx),
-			"type": schema.StringAttribute{
-				Computed:    true,
-				Description: "The type of the device.",
-			},
-			"serial_number": schema.StringAttribute{
-				Computed:    true,
-				Description: "The device's serial number.",
-			},
-			"added_to_org_date_time": schema.StringAttribute{
-				Computed:    true,
-				Description: "The date and time of adding the device to an organization.",
-			},
-			"released_from_org_date_time": schema.StringAttribute{
-				Computed:    true,
-				Description: "The date and time the device was released from an organization. This will be null if the device hasn't been released. Currently only querying by a single device is supported. Batch device queries aren't currently supported for this property.",
-			},
-			"updated_date_time": schema.StringAttribute{
-				Computed:    true,
-				Description: "The date and time of the most-recent update for the device.",
-			},
-			"device_model": schema.StringAttribute{
-				Computed:    true,
-				Description: "The model name.",
-			},
-			"product_family": schema.StringAttribute{
-				Computed:    true,
-				Description: "The device's Apple product family: iPhone, iPad,Mac, AppleTV, Watch, or Vision.",
-			},
-			"product_type": schema.StringAttribute{
-				Computed:    true,
-				Description: "The device's product type: (examples: iPhone14,3, iPad13,4, MacBookPro14,2).",
-			},
-			"device_capacity": schema.StringAttribute{
-				Computed:    true,
-				Description: "The capacity of the device.",
-			},
-			"part_number": schema.StringAttribute{
-				Computed:    true,
-				Description: "The part number of the device.",
-			},
-			"order_number": schema.StringAttribute{
-				Computed:    true,
-				Description: "The order number of the device.",
-			},
-			"color": schema.StringAttribute{
-				Computed:    true,
-				Description: "The color of the device.",
-			},
-			"status": schema.StringAttribute{
-				Computed:    true,
-				Description: "The device's status: ASSIGNED or UNASSIGNED. If ASSIGNED, use a separate API to get the information of the assigned server.",
-			},
-			"order_date_time": schema.StringAttribute{
-				Computed:    true,
-				Description: "The date and time of placing the device's order.",
-			},
-			"imei": schema.ListAttribute{
-				ElementType: types.StringType,
-				Computed:    true,
-				Description: "The device's IMEI (if available).",
-			},
-			"meid": schema.ListAttribute{
-				ElementType: types.StringType,
-				Computed:    true,
-				Description: "The device's MEID (if available).",
-			},
-			"eid": schema.StringAttribute{
-				Computed:    true,
-				Description: "The device's EID (if available).",
-			},
-			"purchase_source_id": schema.StringAttribute{
-				Computed:    true,
-				Description: "The unique ID of the purchase source type: Apple Customer Number or Reseller Number.",
-			},
-			"purchase_source_type": schema.StringAttribute{
-				Computed:    true,
-				Description: "The type of the purchase source.",
-			},
-			"wifi_mac_address": schema.StringAttribute{
-				Description: "The device's Wi-Fi MAC address.",
-				Computed:    true,
-			},
-			"bluetooth_mac_address": schema.StringAttribute{
-				Description: "The device's Bluetooth MAC address.",
-				Computed:    true,
-			},
-			"ethernet_mac_address": schema.ListAttribute{
-				ElementType: types.StringType,
-				Computed:    true,
-				Description: "The device's built-in Ethernet MAC addresses.",
-			},
-			"releaser_entity_type": schema.StringAttribute{
-				Computed:    true,
-				Description: "The type of entity that released the device from the organization.",
-			},
-			"releaser_id": schema.StringAttribute{
-				Computed:    true,
-				Description: "The ID of the entity that released the device from the organization.",
-			},
-		},
+		Attributes:  attributes,
 	}
 }
 
@@ -184,6 +68,8 @@ func (d *OrganizationDeviceDataSource) Configure(ctx context.Context, req dataso
 }
 
 func (d *OrganizationDeviceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data OrganizationDeviceDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -199,7 +85,24 @@ func (d *OrganizationDeviceDataSource) Read(ctx context.Context, req datasource.
 	}
 	defer cancel()
 
-	device, err := d.client.GetOrgDevice(readCtx, data.ID.ValueString(), nil)
+	c, profileDiags := common.ClientForProfile(d.client, data.CredentialsProfile)
+	resp.Diagnostics.Append(profileDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fields := common.SetToStrings(data.Fields)
+	var params url.Values
+	if len(fields) > 0 {
+		params = url.Values{common.OrgDeviceFieldsParam: {common.OrgDeviceFieldsValue(fields...)}}
+	}
+
+	device, err := c.GetOrgDevice(readCtx, data.ID.ValueString(), params)
+	if cached, ok := common.OfflineOrgDevices(c, err, &resp.Diagnostics); ok {
+		if match, found := common.FindOrgDevice(cached, data.ID.ValueString()); found {
+			device, err = &match, nil
+		}
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -210,35 +113,11 @@ func (d *OrganizationDeviceDataSource) Read(ctx context.Context, req datasource.
 	}
 
 	data.ID = types.StringValue(device.ID)
-	data.Type = types.StringValue(device.Type)
-	data.SerialNumber = types.StringValue(device.Attributes.SerialNumber)
-	data.AddedToOrgDateTime = types.StringValue(device.Attributes.AddedToOrgDateTime)
-	data.ReleasedFromOrgDateTime = types.StringPointerValue(common.StringPointerOrNil(device.Attributes.ReleasedFromOrgDateTime))
-	data.UpdatedDateTime = types.StringValue(device.Attributes.UpdatedDateTime)
-	data.DeviceModel = types.StringValue(device.Attributes.DeviceModel)
-	data.ProductFamily = types.StringValue(device.Attributes.ProductFamily)
-	data.ProductType = types.StringValue(device.Attributes.ProductType)
-	data.DeviceCapacity = types.StringValue(device.Attributes.DeviceCapacity)
-	data.PartNumber = types.StringValue(device.Attributes.PartNumber)
-	data.OrderNumber = types.StringValue(device.Attributes.OrderNumber)
-	data.Color = types.StringValue(device.Attributes.Color)
-	data.Status = types.StringValue(device.Attributes.Status)
-	data.OrderDateTime = types.StringValue(device.Attributes.OrderDateTime)
-	data.EID = types.StringValue(device.Attributes.EID)
-	data.PurchaseSourceID = types.StringValue(device.Attributes.PurchaseSourceID)
-	data.PurchaseSourceType = types.StringValue(device.Attributes.PurchaseSourceType)
-	data.WifiMacAddress = types.StringValue(device.Attributes.WifiMacAddress)
-	data.BluetoothMacAddress = types.StringValue(device.Attributes.BluetoothMacAddress)
-
-	data.EthernetMacAddress = common.StringsToTypesStrings(device.Attributes.EthernetMacAddress)
-	data.IMEI = common.StringsToTypesStrings(device.Attributes.IMEI)
-	data.MEID = common.StringsToTypesStrings(device.Attributes.MEID)
-	data.ReleaserEntityType = types.StringPointerValue(common.StringPointerOrNil(device.Attributes.ReleaserEntityType))
-	data.ReleaserID = types.StringPointerValue(common.StringPointerOrNil(device.Attributes.ReleaserID))
+	data.OrgDeviceModel = common.NewOrgDeviceModel(*device, &resp.Diagnostics).WithFields(fields)
 
 	tflog.Debug(ctx, "Read organization device", map[string]any{
-		"device_id":     data.ID.ValueString(),
-		"serial_number": data.SerialNumber.ValueString(),
+		"device_id":     c.RedactSerial(data.ID.ValueString()),
+		"serial_number": c.RedactSerial(data.SerialNumber.ValueString()),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -87,6 +87,17 @@ func TestOrganizationDeviceDataSourceSchema(t *testing.T) {
 		}
 	}
 
+	fieldsAttr, ok := resp.Schema.Attributes["fields"].(dsschema.SetAttribute)
+	if !ok {
+		t.Fatal("expected 'fields' to be a SetAttribute")
+	}
+	if !fieldsAttr.IsOptional() {
+		t.Error("expected 'fields' to be Optional")
+	}
+	if attr, ok := resp.Schema.Attributes["credentials_profile"]; !ok || !attr.IsOptional() {
+		t.Error("expected 'credentials_profile' to be Optional")
+	}
+
 	listAttrs := []string{"imei", "meid", "ethernet_mac_address"}
 	for _, name := range listAttrs {
 		attr, ok := resp.Schema.Attributes[name]
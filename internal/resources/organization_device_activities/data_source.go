@@ -0,0 +1,306 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &OrganizationDeviceActivitiesDataSource{}
+
+// NewOrganizationDeviceActivitiesDataSource returns a new data source for the activity history of a device.
+func NewOrganizationDeviceActivitiesDataSource() datasource.DataSource {
+	return &OrganizationDeviceActivitiesDataSource{}
+}
+
+// OrganizationDeviceActivitiesDataSource defines the data source implementation.
+type OrganizationDeviceActivitiesDataSource struct {
+	client *client.Client
+}
+
+// OrganizationDeviceActivitiesDataSourceModel describes the data source data model.
+type OrganizationDeviceActivitiesDataSourceModel struct {
+	ID          types.String                      `tfsdk:"id"`
+	Timeouts    timeouts.Value                    `tfsdk:"timeouts"`
+	ActivityIDs types.Set                         `tfsdk:"activity_ids"`
+	Activities  []OrganizationDeviceActivityModel `tfsdk:"activities"`
+}
+
+// OrganizationDeviceActivityModel describes one activity affecting the device.
+type OrganizationDeviceActivityModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ActivityType       types.String `tfsdk:"activity_type"`
+	ServerID           types.String `tfsdk:"server_id"`
+	Status             types.String `tfsdk:"status"`
+	SubStatus          types.String `tfsdk:"sub_status"`
+	CreatedDateTime    types.String `tfsdk:"created_date_time"`
+	CompletedDateTime  types.String `tfsdk:"completed_date_time"`
+	OperationStatus    types.String `tfsdk:"operation_status"`
+	OperationSubStatus types.String `tfsdk:"operation_sub_status"`
+}
+
+func (d *OrganizationDeviceActivitiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_device_activities"
+}
+
+func (d *OrganizationDeviceActivitiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the assignment and unassignment activities that affected a specific device, most recent first. " +
+			"The API does not list past activities, so the candidates are the activities recorded in the provider's audit_log_path together with any activity_ids given. " +
+			"The activity log of each candidate is downloaded and searched for the device's serial number.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Device serial number, which is also its identifier.",
+				Required:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"activity_ids": schema.SetAttribute{
+				Description: "Additional organization device activity IDs to search, such as activities performed outside Terraform. Unlike activities from the audit log, an activity that cannot be read causes an error.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"activities": schema.ListNestedAttribute{
+				Description: "Activities whose activity log lists the device, ordered by creation time, most recent first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The opaque resource ID that uniquely identifies the activity.",
+							Computed:    true,
+						},
+						"activity_type": schema.StringAttribute{
+							Description: "ASSIGN_DEVICES or UNASSIGN_DEVICES. Known only for activities recorded in the audit log.",
+							Computed:    true,
+						},
+						"server_id": schema.StringAttribute{
+							Description: "ID of the device management service the activity targeted. Known only for activities recorded in the audit log.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the activity. Possible values: 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'STOPPED'.",
+							Computed:    true,
+						},
+						"sub_status": schema.StringAttribute{
+							Description: "The sub-status of the activity.",
+							Computed:    true,
+						},
+						"created_date_time": schema.StringAttribute{
+							Description: "The date and time the activity was created. Normalized to RFC 3339 in UTC.",
+							Computed:    true,
+						},
+						"completed_date_time": schema.StringAttribute{
+							Description: "The date and time the activity completed. Normalized to RFC 3339 in UTC.",
+							Computed:    true,
+						},
+						"operation_status": schema.StringAttribute{
+							Description: "The outcome for this device recorded in the activity log, such as SUCCESS.",
+							Computed:    true,
+						},
+						"operation_sub_status": schema.StringAttribute{
+							Description: "The detailed outcome for this device recorded in the activity log, if any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationDeviceActivitiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+// candidateActivity is an activity to search for the device. explicit is true when the
+// activity was given in activity_ids.
+type candidateActivity struct {
+	id           string
+	activityType string
+	serverID     string
+	explicit     bool
+}
+
+// activityResult holds the outcome of searching one candidate activity.
+type activityResult struct {
+	activity *client.OrgDeviceActivity
+	row      map[string]string
+	err      error
+}
+
+func (d *OrganizationDeviceActivitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data OrganizationDeviceActivitiesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	audited, err := d.client.AuditedActivities()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Audit Log",
+			err.Error(),
+		)
+		return
+	}
+	candidates := candidateActivities(audited, common.SetToStrings(data.ActivityIDs))
+
+	serialNumber := strings.TrimSpace(data.ID.ValueString())
+	results := make([]activityResult, len(candidates))
+	err = client.ForEachConcurrent(readCtx, d.client.MaxConcurrency(), len(candidates), func(ctx context.Context, i int) error {
+		results[i] = d.searchActivity(ctx, candidates[i].id, serialNumber)
+		return ctx.Err()
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Device Activities",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Activities = make([]OrganizationDeviceActivityModel, 0)
+	for i, result := range results {
+		candidate := candidates[i]
+		if result.err != nil {
+			if candidate.explicit {
+				resp.Diagnostics.AddError(
+					"Unable to Read Organization Device Activity",
+					fmt.Sprintf("Activity ID: %s\n\n%v", candidate.id, result.err),
+				)
+			} else {
+				resp.Diagnostics.AddWarning(
+					"Skipped Audited Activity",
+					fmt.Sprintf("Activity %s from the audit log could not be searched for the device: %v", candidate.id, result.err),
+				)
+			}
+			continue
+		}
+		if result.row == nil {
+			continue
+		}
+
+		attrs := result.activity.Attributes
+		data.Activities = append(data.Activities, OrganizationDeviceActivityModel{
+			ID:                 types.StringValue(candidate.id),
+			ActivityType:       common.OptionalString(candidate.activityType),
+			ServerID:           common.OptionalString(candidate.serverID),
+			Status:             types.StringValue(attrs.Status),
+			SubStatus:          common.OptionalString(attrs.SubStatus),
+			CreatedDateTime:    common.TimestampValue(attrs.CreatedDateTime, "created_date_time", &resp.Diagnostics),
+			CompletedDateTime:  common.TimestampValue(attrs.CompletedDateTime, "completed_date_time", &resp.Diagnostics),
+			OperationStatus:    common.OptionalString(result.row["operation_status"]),
+			OperationSubStatus: common.OptionalString(result.row["operation_substatus"]),
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sortActivities(data.Activities)
+
+	tflog.Debug(ctx, "Read organization device activities", map[string]any{
+		"device_id":       d.client.RedactSerial(serialNumber),
+		"candidate_count": len(candidates),
+		"activity_count":  len(data.Activities),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sortActivities orders activities by creation time, most recent first. Normalized RFC 3339
+// timestamps in UTC sort chronologically as strings.
+func sortActivities(activities []OrganizationDeviceActivityModel) {
+	slices.SortStableFunc(activities, func(a, b OrganizationDeviceActivityModel) int {
+		return strings.Compare(b.CreatedDateTime.ValueString(), a.CreatedDateTime.ValueString())
+	})
+}
+
+// candidateActivities merges the audited activities with the explicit activity IDs, keeping
+// the first occurrence of each ID.
+func candidateActivities(audited []client.AuditRecord, activityIDs []string) []candidateActivity {
+	seen := make(map[string]bool, len(audited)+len(activityIDs))
+	var candidates []candidateActivity
+	for _, id := range activityIDs {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			candidates = append(candidates, candidateActivity{id: id, explicit: true})
+		}
+	}
+	for _, record := range audited {
+		if seen[record.ActivityID] {
+			for i := range candidates {
+				if candidates[i].id == record.ActivityID && candidates[i].activityType == "" {
+					candidates[i].activityType = record.ActivityType
+					candidates[i].serverID = record.ServerID
+				}
+			}
+			continue
+		}
+		seen[record.ActivityID] = true
+		candidates = append(candidates, candidateActivity{
+			id:           record.ActivityID,
+			activityType: record.ActivityType,
+			serverID:     record.ServerID,
+		})
+	}
+	return candidates
+}
+
+// searchActivity reads the activity and returns the row of its activity log for serialNumber,
+// leaving the row nil when the device is not listed.
+func (d *OrganizationDeviceActivitiesDataSource) searchActivity(ctx context.Context, activityID, serialNumber string) activityResult {
+	activity, err := d.client.GetOrgDeviceActivity(ctx, activityID, nil)
+	if err != nil {
+		return activityResult{err: err}
+	}
+	if activity.Attributes.DownloadURL == "" {
+		return activityResult{err: fmt.Errorf("activity has no downloadable activity log (status %s)", activity.Attributes.Status)}
+	}
+
+	logData, err := client.DownloadActivityLog(ctx, activity.Attributes.DownloadURL)
+	if err != nil {
+		return activityResult{err: err}
+	}
+	rows, err := client.ParseActivityLogRows(logData)
+	if err != nil {
+		return activityResult{err: fmt.Errorf("failed to parse activity log: %w", err)}
+	}
+
+	result := activityResult{activity: activity}
+	for _, row := range rows {
+		if strings.EqualFold(row["serial_number"], serialNumber) {
+			result.row = row
+			break
+		}
+	}
+	return result
+}
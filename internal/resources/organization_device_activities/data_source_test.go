@@ -0,0 +1,123 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activities_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_activities"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestOrganizationDeviceActivitiesDataSourceMetadata(t *testing.T) {
+	ds := organization_device_activities.NewOrganizationDeviceActivitiesDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_organization_device_activities" {
+		t.Errorf("expected TypeName %q, got %q", "axm_organization_device_activities", resp.TypeName)
+	}
+}
+
+func TestOrganizationDeviceActivitiesDataSourceSchema(t *testing.T) {
+	ds := organization_device_activities.NewOrganizationDeviceActivitiesDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	idAttr, ok := resp.Schema.Attributes["id"]
+	if !ok {
+		t.Fatal("attribute 'id' not found")
+	}
+	if !idAttr.IsRequired() {
+		t.Error("expected 'id' to be Required")
+	}
+
+	activityIDsAttr, ok := resp.Schema.Attributes["activity_ids"]
+	if !ok {
+		t.Fatal("attribute 'activity_ids' not found")
+	}
+	if !activityIDsAttr.IsOptional() {
+		t.Error("expected 'activity_ids' to be Optional")
+	}
+
+	activitiesAttr, ok := resp.Schema.Attributes["activities"]
+	if !ok {
+		t.Fatal("attribute 'activities' not found")
+	}
+	listNested, ok := activitiesAttr.(dsschema.ListNestedAttribute)
+	if !ok {
+		t.Fatal("expected 'activities' to be a ListNestedAttribute")
+	}
+	if !activitiesAttr.IsComputed() {
+		t.Error("expected 'activities' to be Computed")
+	}
+
+	expectedNested := []string{
+		"id", "activity_type", "server_id", "status", "sub_status", "created_date_time",
+		"completed_date_time", "operation_status", "operation_sub_status",
+	}
+	for _, name := range expectedNested {
+		if _, ok := listNested.NestedObject.Attributes[name]; !ok {
+			t.Errorf("nested attribute %q not found in activities", name)
+		}
+	}
+}
+
+func TestAccOrganizationDeviceActivitiesDataSource(t *testing.T) {
+	deviceID := os.Getenv("AXM_TEST_DEVICE_ID")
+	activityID := os.Getenv("AXM_TEST_ACTIVITY_ID")
+	if deviceID == "" || activityID == "" {
+		t.Skip("AXM_TEST_DEVICE_ID and AXM_TEST_ACTIVITY_ID must be set for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "axm_organization_device_activities" "test" {
+						id           = %q
+						activity_ids = [%q]
+					}
+				`, deviceID, activityID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_organization_device_activities.test", "id", deviceID),
+					resource.TestCheckResourceAttrSet("data.axm_organization_device_activities.test", "activities.#"),
+				),
+			},
+		},
+	})
+}
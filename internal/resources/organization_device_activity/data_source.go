@@ -0,0 +1,253 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &OrganizationDeviceActivityDataSource{}
+
+// NewOrganizationDeviceActivityDataSource returns a new data source for a single organization device activity.
+func NewOrganizationDeviceActivityDataSource() datasource.DataSource {
+	return &OrganizationDeviceActivityDataSource{}
+}
+
+// OrganizationDeviceActivityDataSource defines the data source implementation.
+type OrganizationDeviceActivityDataSource struct {
+	client *client.Client
+}
+
+// OrganizationDeviceActivityDataSourceModel describes the data source data model.
+type OrganizationDeviceActivityDataSourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+	WaitForCompletion types.Bool     `tfsdk:"wait_for_completion"`
+	PollInterval      types.String   `tfsdk:"poll_interval"`
+	Status            types.String   `tfsdk:"status"`
+	SubStatus         types.String   `tfsdk:"sub_status"`
+	CreatedDateTime   types.String   `tfsdk:"created_date_time"`
+	CompletedDateTime types.String   `tfsdk:"completed_date_time"`
+	DownloadURL       types.String   `tfsdk:"download_url"`
+	IncludeLog        types.Bool     `tfsdk:"include_log"`
+	Log               types.List     `tfsdk:"log"`
+}
+
+// activityLogEntryAttrTypes describes one row of the activity log.
+var activityLogEntryAttrTypes = map[string]attr.Type{
+	"serial_number":        types.StringType,
+	"operation_status":     types.StringType,
+	"operation_sub_status": types.StringType,
+}
+
+func (d *OrganizationDeviceActivityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_device_activity"
+}
+
+func (d *OrganizationDeviceActivityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves an organization device activity, such as an assignment submitted by the device management service resource or by external tooling, " +
+			"optionally waiting until it reaches a terminal status so that dependent configuration is evaluated after the activity finishes, and optionally " +
+			"parsing its activity log into per-device outcomes for auditing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The opaque resource ID that uniquely identifies the activity.",
+				Required:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"wait_for_completion": schema.BoolAttribute{
+				Description: "When true, the activity is polled until its status is COMPLETED, FAILED, or STOPPED. " +
+					"The read fails if the activity is still IN_PROGRESS when the read timeout elapses. Defaults to false.",
+				Optional: true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: `Interval between status checks while waiting, expressed as a duration such as "10s". Defaults to "5s".`,
+				Optional:    true,
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the activity. Possible values: 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'STOPPED'.",
+				Computed:    true,
+			},
+			"sub_status": schema.StringAttribute{
+				Description: "The sub-status of the activity.",
+				Computed:    true,
+			},
+			"created_date_time": schema.StringAttribute{
+				Description: "The date and time the activity was created. Normalized to RFC 3339 in UTC.",
+				Computed:    true,
+			},
+			"completed_date_time": schema.StringAttribute{
+				Description: "The date and time the activity completed. Normalized to RFC 3339 in UTC.",
+				Computed:    true,
+			},
+			"download_url": schema.StringAttribute{
+				Description: "A pre-signed URL from which the activity log CSV can be downloaded, once the activity has finished.",
+				Computed:    true,
+			},
+			"include_log": schema.BoolAttribute{
+				Description: "When true, the activity log CSV is downloaded from download_url and parsed into log. " +
+					"The log is large for activities covering many devices, so it is only downloaded on request. Defaults to false.",
+				Optional: true,
+			},
+			"log": schema.ListNestedAttribute{
+				Description: "The per-device rows of the activity log, in log order. Null unless include_log is true and the activity has finished with a downloadable log.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Description: "The serial number of the device.",
+							Computed:    true,
+						},
+						"operation_status": schema.StringAttribute{
+							Description: "The outcome for the device, such as SUCCESS or FAILED.",
+							Computed:    true,
+						},
+						"operation_sub_status": schema.StringAttribute{
+							Description: "The detailed outcome for the device, such as DEVICE_NOT_FOUND, if any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationDeviceActivityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *OrganizationDeviceActivityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data OrganizationDeviceActivityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	activityID := strings.TrimSpace(data.ID.ValueString())
+	activity, err := d.client.GetOrgDeviceActivity(readCtx, activityID, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Device Activity",
+			fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+		)
+		return
+	}
+
+	if data.WaitForCompletion.ValueBool() && activity.Attributes.Status == client.ActivityStatusInProgress {
+		interval := common.DurationValue(data.PollInterval, client.DefaultActivityPollInterval)
+		activity, err = d.client.WaitForActivity(readCtx, activityID, interval, func(activity *client.OrgDeviceActivity) {
+			tflog.Debug(ctx, "Checked organization device activity status", map[string]any{
+				"activity_id": activityID,
+				"status":      activity.Attributes.Status,
+			})
+		})
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("the read timeout elapsed before the activity finished: %w", err)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Wait for Organization Device Activity",
+				fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+			)
+			return
+		}
+	}
+
+	attrs := activity.Attributes
+	data.Status = types.StringValue(attrs.Status)
+	data.SubStatus = common.OptionalString(attrs.SubStatus)
+	data.CreatedDateTime = common.TimestampValue(attrs.CreatedDateTime, "created_date_time", &resp.Diagnostics)
+	data.CompletedDateTime = common.TimestampValue(attrs.CompletedDateTime, "completed_date_time", &resp.Diagnostics)
+	data.DownloadURL = common.OptionalString(attrs.DownloadURL)
+	data.Log = types.ListNull(types.ObjectType{AttrTypes: activityLogEntryAttrTypes})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.IncludeLog.ValueBool() && attrs.DownloadURL != "" {
+		logData, err := client.DownloadActivityLog(readCtx, attrs.DownloadURL)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Download Activity Log",
+				fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+			)
+			return
+		}
+		rows, err := client.ParseActivityLogRows(logData)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Parse Activity Log",
+				fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+			)
+			return
+		}
+		var diags diag.Diagnostics
+		data.Log, diags = activityLogEntries(rows)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Read organization device activity", map[string]any{
+		"activity_id": activityID,
+		"status":      attrs.Status,
+		"sub_status":  attrs.SubStatus,
+		"log_rows":    len(data.Log.Elements()),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// activityLogEntries converts parsed activity log rows into the log attribute value.
+func activityLogEntries(rows []map[string]string) (types.List, diag.Diagnostics) {
+	entries := make([]attr.Value, 0, len(rows))
+	for _, row := range rows {
+		entry, diags := types.ObjectValue(activityLogEntryAttrTypes, map[string]attr.Value{
+			"serial_number":        types.StringValue(row["serial_number"]),
+			"operation_status":     common.OptionalString(row["operation_status"]),
+			"operation_sub_status": common.OptionalString(row["operation_substatus"]),
+		})
+		if diags.HasError() {
+			return types.ListNull(types.ObjectType{AttrTypes: activityLogEntryAttrTypes}), diags
+		}
+		entries = append(entries, entry)
+	}
+	return types.ListValue(types.ObjectType{AttrTypes: activityLogEntryAttrTypes}, entries)
+}
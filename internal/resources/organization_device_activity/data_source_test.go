@@ -0,0 +1,111 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activity_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_device_activity"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestOrganizationDeviceActivityDataSourceMetadata(t *testing.T) {
+	ds := organization_device_activity.NewOrganizationDeviceActivityDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_organization_device_activity" {
+		t.Errorf("expected TypeName %q, got %q", "axm_organization_device_activity", resp.TypeName)
+	}
+}
+
+func TestOrganizationDeviceActivityDataSourceSchema(t *testing.T) {
+	ds := organization_device_activity.NewOrganizationDeviceActivityDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	idAttr, ok := resp.Schema.Attributes["id"]
+	if !ok {
+		t.Fatal("attribute 'id' not found")
+	}
+	if !idAttr.IsRequired() {
+		t.Error("expected 'id' to be Required")
+	}
+
+	for _, name := range []string{"wait_for_completion", "poll_interval", "include_log"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("attribute %q not found", name)
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected %q to be Optional", name)
+		}
+	}
+
+	for _, name := range []string{"status", "sub_status", "created_date_time", "completed_date_time", "download_url", "log"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("attribute %q not found", name)
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected %q to be Computed", name)
+		}
+	}
+}
+
+func TestAccOrganizationDeviceActivityDataSource(t *testing.T) {
+	activityID := os.Getenv("AXM_TEST_ACTIVITY_ID")
+	if activityID == "" {
+		t.Skip("AXM_TEST_ACTIVITY_ID must be set for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "axm_organization_device_activity" "test" {
+						id                  = %q
+						wait_for_completion = true
+					}
+				`, activityID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_organization_device_activity.test", "id", activityID),
+					resource.TestCheckResourceAttrSet("data.axm_organization_device_activity.test", "status"),
+				),
+			},
+		},
+	})
+}
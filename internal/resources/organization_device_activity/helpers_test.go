@@ -0,0 +1,46 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activity
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestActivityLogEntries(t *testing.T) {
+	rows := []map[string]string{
+		{"serial_number": "SN001", "operation_status": "SUCCESS", "operation_substatus": ""},
+		{"serial_number": "SN002", "operation_status": "FAILED", "operation_substatus": "DEVICE_NOT_FOUND"},
+	}
+
+	log, diags := activityLogEntries(rows)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	entries := log.Elements()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	first := entries[0].(types.Object).Attributes()
+	if first["serial_number"].(types.String).ValueString() != "SN001" || !first["operation_sub_status"].IsNull() {
+		t.Errorf("unexpected first entry: %v", first)
+	}
+	second := entries[1].(types.Object).Attributes()
+	if second["operation_status"].(types.String).ValueString() != "FAILED" ||
+		second["operation_sub_status"].(types.String).ValueString() != "DEVICE_NOT_FOUND" {
+		t.Errorf("unexpected second entry: %v", second)
+	}
+}
+
+func TestActivityLogEntries_Empty(t *testing.T) {
+	log, diags := activityLogEntries(nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if log.IsNull() || len(log.Elements()) != 0 {
+		t.Errorf("expected an empty, non-null list, got %v", log)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activity_log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &OrganizationDeviceActivityLogDataSource{}
+
+// NewOrganizationDeviceActivityLogDataSource returns a new data source for the activity log of an organization device activity.
+func NewOrganizationDeviceActivityLogDataSource() datasource.DataSource {
+	return &OrganizationDeviceActivityLogDataSource{}
+}
+
+// OrganizationDeviceActivityLogDataSource defines the data source implementation.
+type OrganizationDeviceActivityLogDataSource struct {
+	client *client.Client
+}
+
+// OrganizationDeviceActivityLogDataSourceModel describes the data source data model.
+type OrganizationDeviceActivityLogDataSourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+	Status    types.String   `tfsdk:"status"`
+	SubStatus types.String   `tfsdk:"sub_status"`
+	Rows      types.List     `tfsdk:"rows"`
+}
+
+// rowAttrTypes describes one row of the activity log.
+var rowAttrTypes = map[string]attr.Type{
+	"serial_number": types.StringType,
+	"status":        types.StringType,
+	"sub_status":    types.StringType,
+}
+
+func (d *OrganizationDeviceActivityLogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_device_activity_log"
+}
+
+func (d *OrganizationDeviceActivityLogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Downloads and parses the activity log of a finished organization device activity into one row per device, " +
+			"so that the outcome for each device can be passed on to other systems, such as compliance tooling. " +
+			"The read fails when the activity has not finished and has no activity log yet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The opaque resource ID that uniquely identifies the activity.",
+				Required:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"status": schema.StringAttribute{
+				Description: "The status of the activity. Possible values: 'IN_PROGRESS', 'COMPLETED', 'FAILED', 'STOPPED'.",
+				Computed:    true,
+			},
+			"sub_status": schema.StringAttribute{
+				Description: "The sub-status of the activity.",
+				Computed:    true,
+			},
+			"rows": schema.ListNestedAttribute{
+				Description: "The per-device rows of the activity log, in log order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Description: "The serial number of the device.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The outcome for the device, such as SUCCESS or FAILED.",
+							Computed:    true,
+						},
+						"sub_status": schema.StringAttribute{
+							Description: "The detailed outcome for the device, such as DEVICE_NOT_FOUND, if any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationDeviceActivityLogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *OrganizationDeviceActivityLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data OrganizationDeviceActivityLogDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	activityID := strings.TrimSpace(data.ID.ValueString())
+	activity, rows, err := d.client.GetOrgDeviceActivityLog(readCtx, activityID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Device Activity Log",
+			fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+		)
+		return
+	}
+
+	data.Status = types.StringValue(activity.Attributes.Status)
+	data.SubStatus = common.OptionalString(activity.Attributes.SubStatus)
+	var diags diag.Diagnostics
+	data.Rows, diags = logRows(rows)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Read organization device activity log", map[string]any{
+		"activity_id": activityID,
+		"status":      activity.Attributes.Status,
+		"rows":        len(rows),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// logRows converts parsed activity log rows into the rows attribute value.
+func logRows(rows []client.ActivityLogRow) (types.List, diag.Diagnostics) {
+	values := make([]attr.Value, 0, len(rows))
+	for _, row := range rows {
+		value, diags := types.ObjectValue(rowAttrTypes, map[string]attr.Value{
+			"serial_number": types.StringValue(row.SerialNumber),
+			"status":        common.OptionalString(row.Status),
+			"sub_status":    common.OptionalString(row.SubStatus),
+		})
+		if diags.HasError() {
+			return types.ListNull(types.ObjectType{AttrTypes: rowAttrTypes}), diags
+		}
+		values = append(values, value)
+	}
+	return types.ListValue(types.ObjectType{AttrTypes: rowAttrTypes}, values)
+}
@@ -0,0 +1,48 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_device_activity_log
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestLogRows(t *testing.T) {
+	rows := []client.ActivityLogRow{
+		{SerialNumber: "SN001", Status: "SUCCESS"},
+		{SerialNumber: "SN002", Status: "FAILED", SubStatus: "DEVICE_NOT_FOUND"},
+	}
+
+	list, diags := logRows(rows)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	values := list.Elements()
+	if len(values) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(values))
+	}
+
+	first := values[0].(types.Object).Attributes()
+	if first["serial_number"].(types.String).ValueString() != "SN001" || !first["sub_status"].IsNull() {
+		t.Errorf("unexpected first row: %v", first)
+	}
+	second := values[1].(types.Object).Attributes()
+	if second["status"].(types.String).ValueString() != "FAILED" ||
+		second["sub_status"].(types.String).ValueString() != "DEVICE_NOT_FOUND" {
+		t.Errorf("unexpected second row: %v", second)
+	}
+}
+
+func TestLogRows_Empty(t *testing.T) {
+	list, diags := logRows(nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if list.IsNull() || len(list.Elements()) != 0 {
+		t.Errorf("expected an empty, non-null list, got %v", list)
+	}
+}
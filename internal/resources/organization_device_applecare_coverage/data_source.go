@@ -76,7 +76,7 @@ func (d *OrganizationDeviceAppleCareCoverageDataSource) Schema(ctx context.Conte
 							Computed:    true,
 						},
 						"contract_cancel_date_time": schema.StringAttribute{
-							Description: "UTC date when coverage was canceled for the device. This field isn't applicable for Limited Warranty and AppleCare+ for Business Essentials.",
+							Description: "UTC date when coverage was canceled for the device. This field isn't applicable for Limited Warranty and AppleCare+ for Business Essentials. Normalized to RFC 3339 in UTC.",
 							Computed:    true,
 						},
 						"description": schema.StringAttribute{
@@ -84,7 +84,7 @@ func (d *OrganizationDeviceAppleCareCoverageDataSource) Schema(ctx context.Conte
 							Computed:    true,
 						},
 						"end_date_time": schema.StringAttribute{
-							Description: "UTC date when coverage period ends for the device. This field isn't applicable for AppleCare+ for Business Essentials.",
+							Description: "UTC date when coverage period ends for the device. This field isn't applicable for AppleCare+ for Business Essentials. Normalized to RFC 3339 in UTC.",
 							Computed:    true,
 						},
 						"is_canceled": schema.BoolAttribute{
@@ -100,11 +100,11 @@ func (d *OrganizationDeviceAppleCareCoverageDataSource) Schema(ctx context.Conte
 							Computed:    true,
 						},
 						"start_date_time": schema.StringAttribute{
-							Description: "UTC date when coverage period commenced. For AppleCare+ for Business Essentials, it's UTC date when a device enrolls into the plan.",
+							Description: "UTC date when coverage period commenced. For AppleCare+ for Business Essentials, it's UTC date when a device enrolls into the plan. Normalized to RFC 3339 in UTC.",
 							Computed:    true,
 						},
 						"status": schema.StringAttribute{
-							Description: "The current status of device coverage. Possible values: 'ACTIVE', 'INACTIVE'",
+							Description: "The current status of device coverage. Possible values: 'ACTIVE', 'INACTIVE'.",
 							Computed:    true,
 						},
 					},
@@ -124,6 +124,8 @@ func (d *OrganizationDeviceAppleCareCoverageDataSource) Configure(ctx context.Co
 }
 
 func (d *OrganizationDeviceAppleCareCoverageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data OrganizationDeviceAppleCareCoverageDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -152,15 +154,15 @@ func (d *OrganizationDeviceAppleCareCoverageDataSource) Read(ctx context.Context
 	for _, coverage := range applecarecoverage {
 		coverageModel := OrganizationDeviceAppleCareCoverageModel{
 			ID:                     types.StringValue(coverage.ID),
-			AgreementNumber:        types.StringValue(coverage.Attributes.AgreementNumber),
-			ContractCancelDateTime: types.StringValue(coverage.Attributes.ContractCancelDateTime),
+			AgreementNumber:        common.OptionalString(coverage.Attributes.AgreementNumber),
+			ContractCancelDateTime: common.TimestampValue(coverage.Attributes.ContractCancelDateTime, "contract_cancel_date_time", &resp.Diagnostics),
 			Description:            types.StringValue(coverage.Attributes.Description),
-			EndDateTime:            types.StringValue(coverage.Attributes.EndDateTime),
+			EndDateTime:            common.TimestampValue(coverage.Attributes.EndDateTime, "end_date_time", &resp.Diagnostics),
 			IsCanceled:             types.BoolValue(coverage.Attributes.IsCanceled),
 			IsRenewable:            types.BoolValue(coverage.Attributes.IsRenewable),
 			PaymentType:            types.StringValue(coverage.Attributes.PaymentType),
-			StartDateTime:          types.StringValue(coverage.Attributes.StartDateTime),
-			Status:                 types.StringValue(coverage.Attributes.Status),
+			StartDateTime:          common.TimestampValue(coverage.Attributes.StartDateTime, "start_date_time", &resp.Diagnostics),
+			Status:                 types.StringValue(string(coverage.Attributes.Status)),
 		}
 
 		data.AppleCareCoverageResources = append(data.AppleCareCoverageResources, coverageModel)
@@ -91,6 +91,8 @@ func (d *OrganizationDeviceAssignedServerInformationDataSource) Configure(ctx co
 }
 
 func (d *OrganizationDeviceAssignedServerInformationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data OrganizationDeviceAssignedServerInformationDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
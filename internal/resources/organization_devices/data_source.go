@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -31,38 +33,32 @@ type OrganizationDevicesDataSource struct {
 
 // OrganizationDevicesDataSourceModel describes the data source data model.
 type OrganizationDevicesDataSourceModel struct {
-	ID       types.String              `tfsdk:"id"`
-	Timeouts timeouts.Value            `tfsdk:"timeouts"`
-	Devices  []OrganizationDeviceModel `tfsdk:"devices"`
+	ID                 types.String              `tfsdk:"id"`
+	Timeouts           timeouts.Value            `tfsdk:"timeouts"`
+	CredentialsProfile types.String              `tfsdk:"credentials_profile"`
+	Incremental        types.Bool                `tfsdk:"incremental"`
+	PurchaseSourceType types.String              `tfsdk:"purchase_source_type"`
+	SyncMode           types.String              `tfsdk:"sync_mode"`
+	Fields             types.Set                 `tfsdk:"fields"`
+	Filter             *DeviceFilterModel        `tfsdk:"filter"`
+	Devices            []OrganizationDeviceModel `tfsdk:"devices"`
+}
+
+// DeviceFilterModel describes the criteria devices must meet to be included.
+type DeviceFilterModel struct {
+	ProductFamily types.String `tfsdk:"product_family"`
+	Status        types.String `tfsdk:"status"`
+	DeviceModel   types.String `tfsdk:"device_model"`
+	Color         types.String `tfsdk:"color"`
+	SerialNumbers types.Set    `tfsdk:"serial_numbers"`
+	AddedAfter    types.String `tfsdk:"added_after"`
+	AddedBefore   types.String `tfsdk:"added_before"`
 }
 
 // OrganizationDeviceModel describes an organization device.
 type OrganizationDeviceModel struct {
-	ID                  types.String   `tfsdk:"id"`
-	Type                types.String   `tfsdk:"type"`
-	SerialNumber        types.String   `tfsdk:"serial_number"`
-	AddedDateTime       types.String   `tfsdk:"added_to_org_date_time"`
-	ReleasedDateTime    types.String   `tfsdk:"released_from_org_date_time"`
-	UpdatedDateTime     types.String   `tfsdk:"updated_date_time"`
-	DeviceModel         types.String   `tfsdk:"device_model"`
-	ProductFamily       types.String   `tfsdk:"product_family"`
-	ProductType         types.String   `tfsdk:"product_type"`
-	DeviceCapacity      types.String   `tfsdk:"device_capacity"`
-	PartNumber          types.String   `tfsdk:"part_number"`
-	OrderNumber         types.String   `tfsdk:"order_number"`
-	Color               types.String   `tfsdk:"color"`
-	Status              types.String   `tfsdk:"status"`
-	OrderDateTime       types.String   `tfsdk:"order_date_time"`
-	IMEI                []types.String `tfsdk:"imei"`
-	MEID                []types.String `tfsdk:"meid"`
-	EID                 types.String   `tfsdk:"eid"`
-	PurchaseSourceID    types.String   `tfsdk:"purchase_source_id"`
-	PurchaseSourceType  types.String   `tfsdk:"purchase_source_type"`
-	WifiMacAddress      types.String   `tfsdk:"wifi_mac_address"`
-	BluetoothMacAddress types.String   `tfsdk:"bluetooth_mac_address"`
-	EthernetMacAddress  []types.String `tfsdk:"ethernet_mac_address"`
-	ReleaserEntityType  types.String   `tfsdk:"releaser_entity_type"`
-	ReleaserID          types.String   `tfsdk:"releaser_id"`
+	ID types.String `tfsdk:"id"`
+	common.OrgDeviceModel
 }
 
 func (d *OrganizationDevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -70,125 +66,99 @@ func (d *OrganizationDevicesDataSource) Metadata(ctx context.Context, req dataso
 }
 
 func (d *OrganizationDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	deviceAttributes := common.OrgDeviceSchemaAttributes()
+	deviceAttributes["id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The opaque resource ID that uniquely identifies the resource.",
+	}
+	fieldsAttribute := common.OrgDeviceFieldsAttribute()
+	fieldsAttribute.Description += " Attributes used by filter and purchase_source_type are also requested, but stay null unless listed. " +
+		"Incremental reads fetch every attribute."
+
 	resp.Schema = schema.Schema{
-		Description: "Fetches the list of devices from Apple Business or School Manager.",
+		Description: "Fetches the list of devices from Apple Business or School Manager. A warning is shown when more devices than the provider's device_warning_threshold would be written into state.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Identifier of the data source.",
 				Computed:    true,
 			},
-			"timeouts": timeouts.Attributes(ctx),
-			"devices": schema.ListNestedAttribute{
-				Description: "List of organization devices.",
-				Computed:    true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Required:    true,
-							Description: "The opaque resource ID that uniquely identifies the resource.",
-						},
-						"type": schema.StringAttribute{
-							Computed:    true,
-							Description: "The type of the device.",
-						},
-						"serial_number": schema.StringAttribute{
-							Computed:    true,
-							Description: "The device's serial number.",
-						},
-						"added_to_org_date_time": schema.StringAttribute{
-							Computed:    true,
-							Description: "The date and time of adding the device to an organization.",
-						},
-						"released_from_org_date_time": schema.StringAttribute{
-							Computed:    true,
-							Description: "The date and time the device was released from an organization. This will be null if the device hasn't been released. Currently only querying by a single device is supported. Batch device queries aren't currently supported for this property.",
-						},
-						"updated_date_time": schema.StringAttribute{
-							Computed:    true,
-							Description: "The date and time of the most-recent update for the device.",
-						},
-						"device_model": schema.StringAttribute{
-							Computed:    true,
-							Description: "The model name.",
-						},
-						"product_family": schema.StringAttribute{
-							Computed:    true,
-							Description: "The device's Apple product family: iPhone, iPad,Mac, AppleTV, Watch, or Vision.",
-						},
-						"product_type": schema.StringAttribute{
-							Computed:    true,
-							Description: "The device's product type: (examples: iPhone14,3, iPad13,4, MacBookPro14,2).",
-						},
-						"device_capacity": schema.StringAttribute{
-							Computed:    true,
-							Description: "The capacity of the device.",
-						},
-						"part_number": schema.StringAttribute{
-							Computed:    true,
-							Description: "The part number of the device.",
-						},
-						"order_number": schema.StringAttribute{
-							Computed:    true,
-							Description: "The order number of the device.",
-						},
-						"color": schema.StringAttribute{
-							Computed:    true,
-							Description: "The color of the device.",
-						},
-						"status": schema.StringAttribute{
-							Computed:    true,
-							Description: "The device's status: ASSIGNED or UNASSIGNED. If ASSIGNED, use a separate API to get the information of the assigned server.",
-						},
-						"order_date_time": schema.StringAttribute{
-							Computed:    true,
-							Description: "The date and time of placing the device's order.",
-						},
-						"imei": schema.ListAttribute{
-							ElementType: types.StringType,
-							Computed:    true,
-							Description: "The device's IMEI (if available).",
-						},
-						"meid": schema.ListAttribute{
-							ElementType: types.StringType,
-							Computed:    true,
-							Description: "The device's MEID (if available).",
-						},
-						"eid": schema.StringAttribute{
-							Computed:    true,
-							Description: "The device's EID (if available).",
-						},
-						"purchase_source_id": schema.StringAttribute{
-							Computed:    true,
-							Description: "The unique ID of the purchase source type: Apple Customer Number or Reseller Number.",
-						},
-						"purchase_source_type": schema.StringAttribute{
-							Computed:    true,
-							Description: "The type of the purchase source.",
-						},
-						"wifi_mac_address": schema.StringAttribute{
-							Description: "The device's Wi-Fi MAC address.",
-							Computed:    true,
-						},
-						"bluetooth_mac_address": schema.StringAttribute{
-							Description: "The device's Bluetooth MAC address.",
-							Computed:    true,
+			"timeouts":            timeouts.Attributes(ctx),
+			"credentials_profile": common.CredentialsProfileAttribute(),
+			"incremental": schema.BoolAttribute{
+				Description: "When true, the device list from the last successful read is kept as a checkpoint in the provider cache directory, " +
+					"and later reads list only device IDs and update timestamps and fetch full records for new or updated devices. " +
+					"A full sync is performed when no usable checkpoint exists, the listing omits update timestamps, or more than 100 devices changed. Defaults to false.",
+				Optional: true,
+			},
+			"purchase_source_type": schema.StringAttribute{
+				Description: "Only include devices acquired through this purchase source type: APPLE, RESELLER or MANUALLY_ADDED. " +
+					"Useful for separating devices purchased from resellers from those purchased directly from Apple.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.PurchaseSourceTypeValues()...),
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				Description: "Only include devices meeting every criterion set in this block. product_family, status and serial_numbers are " +
+					"sent to the API as filter query parameters so that fewer devices are transferred; every criterion is also applied by the provider, " +
+					"and the provider falls back to listing all devices if the API rejects the parameters. Incremental reads list all devices and filter them locally.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"product_family": schema.StringAttribute{
+						Description: "Only include devices of this product family: iPhone, iPad, Mac, AppleTV, Watch or Vision. Case-insensitive.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(client.OrgDeviceProductFamilyValues()...),
 						},
-						"ethernet_mac_address": schema.ListAttribute{
-							ElementType: types.StringType,
-							Computed:    true,
-							Description: "The device's built-in Ethernet MAC addresses.",
+					},
+					"status": schema.StringAttribute{
+						Description: "Only include devices with this status: ASSIGNED or UNASSIGNED.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.OrgDeviceStatusValues()...),
 						},
-						"releaser_entity_type": schema.StringAttribute{
-							Computed:    true,
-							Description: "The type of entity that released the device from the organization.",
+					},
+					"device_model": schema.StringAttribute{
+						Description: `Only include devices whose model name, such as "MacBook Air 13-inch (M2, 2022)", matches this value. Case-insensitive.`,
+						Optional:    true,
+					},
+					"color": schema.StringAttribute{
+						Description: "Only include devices of this color. Case-insensitive.",
+						Optional:    true,
+					},
+					"serial_numbers": schema.SetAttribute{
+						Description: "Only include devices with one of these serial numbers. Case-insensitive.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"added_after": schema.StringAttribute{
+						Description: `Only include devices added to the organization after this time, expressed as an RFC 3339 timestamp such as "2025-01-31T09:00:00Z" or a date such as "2025-01-31" (midnight UTC).`,
+						Optional:    true,
+						Validators: []validator.String{
+							common.Timestamp(),
 						},
-						"releaser_id": schema.StringAttribute{
-							Computed:    true,
-							Description: "The ID of the entity that released the device from the organization.",
+					},
+					"added_before": schema.StringAttribute{
+						Description: `Only include devices added to the organization before this time, expressed as an RFC 3339 timestamp such as "2025-01-31T09:00:00Z" or a date such as "2025-01-31" (midnight UTC).`,
+						Optional:    true,
+						Validators: []validator.String{
+							common.Timestamp(),
 						},
 					},
 				},
 			},
+			"fields": fieldsAttribute,
+			"sync_mode": schema.StringAttribute{
+				Description: "How the device list was obtained: full, incremental, or offline when the API could not be reached and the provider's offline_fallback setting read the cached inventory.",
+				Computed:    true,
+			},
+			"devices": schema.ListNestedAttribute{
+				Description: "List of organization devices.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: deviceAttributes,
+				},
+			},
 		},
 	}
 }
@@ -203,6 +173,8 @@ func (d *OrganizationDevicesDataSource) Configure(ctx context.Context, req datas
 }
 
 func (d *OrganizationDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
 	var data OrganizationDevicesDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -218,7 +190,47 @@ func (d *OrganizationDevicesDataSource) Read(ctx context.Context, req datasource
 	}
 	defer cancel()
 
-	devices, err := d.client.GetOrgDevices(readCtx, nil)
+	c, profileDiags := common.ClientForProfile(d.client, data.CredentialsProfile)
+	resp.Diagnostics.Append(profileDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, reportSkipped := common.CollectSkippedRecords(readCtx)
+	filter := newDeviceFilter(data.Filter)
+	fields := common.SetToStrings(data.Fields)
+	sourceType, filterBySourceType := common.NormalizedFilterString(data.PurchaseSourceType)
+	fieldsValue := requestedFields(fields, filter, filterBySourceType)
+
+	var devices []client.OrgDevice
+	var err error
+	if data.Incremental.ValueBool() {
+		var result client.DeviceSyncResult
+		devices, result, err = c.SyncOrgDevices(readCtx)
+		if err == nil {
+			data.SyncMode = types.StringValue(result.Mode)
+			tflog.Debug(ctx, "Synchronized organization devices", map[string]any{
+				"mode":            result.Mode,
+				"fallback_reason": result.FallbackReason,
+				"changed_count":   result.ChangedCount,
+				"removed_count":   result.RemovedCount,
+				"checkpoint":      result.Checkpoint,
+			})
+		}
+	} else {
+		devices, err = c.GetOrgDevices(readCtx, withFields(filter.queryParams(), fieldsValue))
+		if err != nil && filter.queryParams() != nil && client.IsParameterError(err) {
+			tflog.Warn(ctx, "API rejected device filter parameters; filtering all devices locally", map[string]any{
+				"error": err.Error(),
+			})
+			devices, err = c.GetOrgDevices(readCtx, withFields(nil, fieldsValue))
+		}
+		data.SyncMode = types.StringValue(client.DeviceSyncModeFull)
+	}
+	if cached, ok := common.OfflineOrgDevices(c, err, &resp.Diagnostics); ok {
+		devices, err = cached, nil
+		data.SyncMode = types.StringValue(client.DeviceSyncModeOffline)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Organization Devices",
@@ -226,35 +238,19 @@ func (d *OrganizationDevicesDataSource) Read(ctx context.Context, req datasource
 		)
 		return
 	}
+	reportSkipped(&resp.Diagnostics)
+
+	if filterBySourceType {
+		devices = filterByPurchaseSourceType(devices, client.PurchaseSourceType(sourceType))
+	}
+	devices = filterDevices(devices, filter)
+	warnLargeInventory(&resp.Diagnostics, len(devices), c.DeviceWarningThreshold())
 
 	data.Devices = make([]OrganizationDeviceModel, 0, len(devices))
 	for _, device := range devices {
 		deviceModel := OrganizationDeviceModel{
-			ID:                  types.StringValue(device.ID),
-			Type:                types.StringValue(device.Type),
-			SerialNumber:        types.StringValue(device.Attributes.SerialNumber),
-			AddedDateTime:       types.StringValue(device.Attributes.AddedToOrgDateTime),
-			ReleasedDateTime:    types.StringValue(device.Attributes.ReleasedFromOrgDateTime),
-			UpdatedDateTime:     types.StringValue(device.Attributes.UpdatedDateTime),
-			DeviceModel:         types.StringValue(device.Attributes.DeviceModel),
-			ProductFamily:       types.StringValue(device.Attributes.ProductFamily),
-			ProductType:         types.StringValue(device.Attributes.ProductType),
-			DeviceCapacity:      types.StringValue(device.Attributes.DeviceCapacity),
-			PartNumber:          types.StringValue(device.Attributes.PartNumber),
-			OrderNumber:         types.StringValue(device.Attributes.OrderNumber),
-			Color:               types.StringValue(device.Attributes.Color),
-			Status:              types.StringValue(device.Attributes.Status),
-			OrderDateTime:       types.StringValue(device.Attributes.OrderDateTime),
-			EID:                 types.StringValue(device.Attributes.EID),
-			PurchaseSourceID:    types.StringValue(device.Attributes.PurchaseSourceID),
-			PurchaseSourceType:  types.StringValue(device.Attributes.PurchaseSourceType),
-			WifiMacAddress:      types.StringValue(device.Attributes.WifiMacAddress),
-			BluetoothMacAddress: types.StringValue(device.Attributes.BluetoothMacAddress),
-			EthernetMacAddress:  common.StringsToTypesStrings(device.Attributes.EthernetMacAddress),
-			IMEI:                common.StringsToTypesStrings(device.Attributes.IMEI),
-			MEID:                common.StringsToTypesStrings(device.Attributes.MEID),
-			ReleaserEntityType:  types.StringPointerValue(common.StringPointerOrNil(device.Attributes.ReleaserEntityType)),
-			ReleaserID:          types.StringPointerValue(common.StringPointerOrNil(device.Attributes.ReleaserID)),
+			ID:             types.StringValue(device.ID),
+			OrgDeviceModel: common.NewOrgDeviceModel(device, &resp.Diagnostics).WithFields(fields),
 		}
 
 		data.Devices = append(data.Devices, deviceModel)
@@ -268,3 +264,14 @@ func (d *OrganizationDevicesDataSource) Read(ctx context.Context, req datasource
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// filterByPurchaseSourceType returns the devices acquired through sourceType.
+func filterByPurchaseSourceType(devices []client.OrgDevice, sourceType client.PurchaseSourceType) []client.OrgDevice {
+	filtered := make([]client.OrgDevice, 0, len(devices))
+	for _, device := range devices {
+		if device.Attributes.PurchaseSourceType == sourceType {
+			filtered = append(filtered, device)
+		}
+	}
+	return filtered
+}
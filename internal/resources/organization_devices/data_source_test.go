@@ -64,6 +64,55 @@ func TestOrganizationDevicesDataSourceSchema(t *testing.T) {
 		t.Error("expected 'id' to be Computed")
 	}
 
+	incrementalAttr, ok := resp.Schema.Attributes["incremental"]
+	if !ok {
+		t.Fatal("attribute 'incremental' not found")
+	}
+	if !incrementalAttr.IsOptional() || incrementalAttr.IsComputed() {
+		t.Error("expected 'incremental' to be Optional and not Computed")
+	}
+
+	filterAttr, ok := resp.Schema.Attributes["filter"]
+	if !ok {
+		t.Fatal("attribute 'filter' not found")
+	}
+	filterNested, ok := filterAttr.(dsschema.SingleNestedAttribute)
+	if !ok {
+		t.Fatal("expected 'filter' to be a SingleNestedAttribute")
+	}
+	if !filterAttr.IsOptional() || filterAttr.IsComputed() {
+		t.Error("expected 'filter' to be Optional and not Computed")
+	}
+	for _, name := range []string{"product_family", "status", "device_model", "color", "serial_numbers", "added_after", "added_before"} {
+		attr, ok := filterNested.Attributes[name]
+		if !ok {
+			t.Errorf("nested attribute %q not found in filter", name)
+			continue
+		}
+		if !attr.IsOptional() {
+			t.Errorf("expected filter %q to be Optional", name)
+		}
+	}
+	if _, ok := filterNested.Attributes["serial_numbers"].(dsschema.SetAttribute); !ok {
+		t.Error("expected filter 'serial_numbers' to be a SetAttribute")
+	}
+
+	fieldsAttr, ok := resp.Schema.Attributes["fields"].(dsschema.SetAttribute)
+	if !ok {
+		t.Fatal("expected 'fields' to be a SetAttribute")
+	}
+	if !fieldsAttr.IsOptional() {
+		t.Error("expected 'fields' to be Optional")
+	}
+
+	syncModeAttr, ok := resp.Schema.Attributes["sync_mode"]
+	if !ok {
+		t.Fatal("attribute 'sync_mode' not found")
+	}
+	if !syncModeAttr.IsComputed() {
+		t.Error("expected 'sync_mode' to be Computed")
+	}
+
 	devicesAttr, ok := resp.Schema.Attributes["devices"]
 	if !ok {
 		t.Fatal("attribute 'devices' not found")
@@ -84,7 +133,7 @@ func TestOrganizationDevicesDataSourceSchema(t *testing.T) {
 		"order_number", "color", "status", "order_date_time", "imei", "meid",
 		"eid", "purchase_source_id", "purchase_source_type", "wifi_mac_address",
 		"bluetooth_mac_address", "ethernet_mac_address",
-		"releaser_entity_type", "releaser_id",
+		"releaser_entity_type", "releaser_id", "self_link",
 	}
 	for _, name := range allExpectedNested {
 		if _, ok := nestedAttrs[name]; !ok {
@@ -0,0 +1,205 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_devices
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+// deviceFilter holds the normalized criteria of a filter block. Zero-valued fields match
+// every device.
+type deviceFilter struct {
+	productFamily string
+	status        string
+	deviceModel   string
+	color         string
+	serialNumbers []string
+	addedAfter    time.Time
+	addedBefore   time.Time
+}
+
+// newDeviceFilter normalizes the configured filter block.
+func newDeviceFilter(model *DeviceFilterModel) deviceFilter {
+	var f deviceFilter
+	if model == nil {
+		return f
+	}
+
+	if value, ok := common.NormalizedFilterString(model.ProductFamily); ok {
+		for _, family := range client.OrgDeviceProductFamilyValues() {
+			if strings.EqualFold(family, value) {
+				value = family
+				break
+			}
+		}
+		f.productFamily = value
+	}
+	if value, ok := common.NormalizedFilterString(model.Status); ok {
+		f.status = strings.ToUpper(value)
+	}
+	if value, ok := common.NormalizedFilterString(model.DeviceModel); ok {
+		f.deviceModel = common.FoldFilterString(value)
+	}
+	if value, ok := common.NormalizedFilterString(model.Color); ok {
+		f.color = common.FoldFilterString(value)
+	}
+	for _, serial := range common.SetToStrings(model.SerialNumbers) {
+		if serial = strings.ToUpper(strings.TrimSpace(serial)); serial != "" && !slices.Contains(f.serialNumbers, serial) {
+			f.serialNumbers = append(f.serialNumbers, serial)
+		}
+	}
+	slices.Sort(f.serialNumbers)
+	if value, ok := common.NormalizedFilterString(model.AddedAfter); ok {
+		f.addedAfter, _ = common.ParseTimestamp(value)
+	}
+	if value, ok := common.NormalizedFilterString(model.AddedBefore); ok {
+		f.addedBefore, _ = common.ParseTimestamp(value)
+	}
+	return f
+}
+
+// queryParams returns the filter[...] query parameters for the criteria the API can apply
+// itself. Criteria without an API filter are applied by matches alone.
+func (f deviceFilter) queryParams() url.Values {
+	params := url.Values{}
+	if f.productFamily != "" {
+		params.Set("filter[productFamily]", f.productFamily)
+	}
+	if f.status != "" {
+		params.Set("filter[status]", f.status)
+	}
+	if len(f.serialNumbers) > 0 {
+		params.Set("filter[serialNumber]", strings.Join(f.serialNumbers, ","))
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+// matches reports whether device meets every criterion of the filter.
+func (f deviceFilter) matches(device client.OrgDevice) bool {
+	attrs := device.Attributes
+	if f.productFamily != "" && !strings.EqualFold(attrs.ProductFamily, f.productFamily) {
+		return false
+	}
+	if f.status != "" && !strings.EqualFold(string(attrs.Status), f.status) {
+		return false
+	}
+	if f.deviceModel != "" && common.FoldFilterString(attrs.DeviceModel) != f.deviceModel {
+		return false
+	}
+	if f.color != "" && common.FoldFilterString(attrs.Color) != f.color {
+		return false
+	}
+	if len(f.serialNumbers) > 0 && !slices.Contains(f.serialNumbers, strings.ToUpper(attrs.SerialNumber)) {
+		return false
+	}
+	if !f.addedAfter.IsZero() || !f.addedBefore.IsZero() {
+		added, err := common.ParseTimestamp(attrs.AddedToOrgDateTime)
+		if err != nil {
+			return false
+		}
+		if !f.addedAfter.IsZero() && !added.After(f.addedAfter) {
+			return false
+		}
+		if !f.addedBefore.IsZero() && !added.Before(f.addedBefore) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterDevices returns the devices matched by f, in their original order.
+func filterDevices(devices []client.OrgDevice, f deviceFilter) []client.OrgDevice {
+	if f.isZero() {
+		return devices
+	}
+	filtered := make([]client.OrgDevice, 0, len(devices))
+	for _, device := range devices {
+		if f.matches(device) {
+			filtered = append(filtered, device)
+		}
+	}
+	return filtered
+}
+
+// requestedFields returns the fields[orgDevices] value for a read selecting fields, adding the
+// attributes the filter and the purchase source type filter are applied to. It returns an empty
+// string when no fields are selected.
+func requestedFields(fields []string, f deviceFilter, bySourceType bool) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	attributes := slices.Clone(fields)
+	if f.productFamily != "" {
+		attributes = append(attributes, "product_family")
+	}
+	if f.status != "" {
+		attributes = append(attributes, "status")
+	}
+	if f.deviceModel != "" {
+		attributes = append(attributes, "device_model")
+	}
+	if f.color != "" {
+		attributes = append(attributes, "color")
+	}
+	if len(f.serialNumbers) > 0 {
+		attributes = append(attributes, "serial_number")
+	}
+	if !f.addedAfter.IsZero() || !f.addedBefore.IsZero() {
+		attributes = append(attributes, "added_to_org_date_time")
+	}
+	if bySourceType {
+		attributes = append(attributes, "purchase_source_type")
+	}
+	return common.OrgDeviceFieldsValue(attributes...)
+}
+
+// withFields returns params with the fields[orgDevices] query parameter set to fields, or
+// params unchanged when fields is empty.
+func withFields(params url.Values, fields string) url.Values {
+	if fields == "" {
+		return params
+	}
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set(common.OrgDeviceFieldsParam, fields)
+	return params
+}
+
+// isZero reports whether the filter has no criteria.
+func (f deviceFilter) isZero() bool {
+	return f.productFamily == "" && f.status == "" && f.deviceModel == "" && f.color == "" &&
+		len(f.serialNumbers) == 0 && f.addedAfter.IsZero() && f.addedBefore.IsZero()
+}
+
+// warnLargeInventory adds a warning when more than threshold devices are about to be written
+// to state, recommending ways to keep the state small. A threshold of zero disables it.
+func warnLargeInventory(diags *diag.Diagnostics, count, threshold int) {
+	if threshold <= 0 || count <= threshold {
+		return
+	}
+	diags.AddWarning(
+		"Large Device Inventory in State",
+		fmt.Sprintf("axm_organization_devices is writing %d devices into state, more than the device_warning_threshold of %d. "+
+			"Every device is stored in the state file and re-read on each refresh, which slows plans and enlarges the state.\n\n"+
+			"To keep the state small:\n"+
+			"  - Set filter or purchase_source_type to read only the devices this configuration needs.\n"+
+			"  - Use axm_organization_device to read individual devices by ID.\n"+
+			"  - Use axm_inventory_snapshot to write the inventory to a local file instead of state.\n\n"+
+			"Set device_warning_threshold in the provider configuration to raise the threshold, or to 0 to disable this warning.",
+			count, threshold),
+	)
+}
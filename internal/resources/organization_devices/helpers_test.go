@@ -0,0 +1,176 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_devices
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestFilterByPurchaseSourceType(t *testing.T) {
+	devices := []client.OrgDevice{
+		{ID: "dev-1", Attributes: client.DeviceAttribute{PurchaseSourceType: client.PurchaseSourceTypeApple}},
+		{ID: "dev-2", Attributes: client.DeviceAttribute{PurchaseSourceType: client.PurchaseSourceTypeReseller}},
+		{ID: "dev-3", Attributes: client.DeviceAttribute{PurchaseSourceType: client.PurchaseSourceTypeManuallyAdded}},
+		{ID: "dev-4", Attributes: client.DeviceAttribute{PurchaseSourceType: client.PurchaseSourceTypeReseller}},
+	}
+
+	tests := []struct {
+		name       string
+		sourceType client.PurchaseSourceType
+		wantIDs    []string
+	}{
+		{name: "apple", sourceType: client.PurchaseSourceTypeApple, wantIDs: []string{"dev-1"}},
+		{name: "reseller", sourceType: client.PurchaseSourceTypeReseller, wantIDs: []string{"dev-2", "dev-4"}},
+		{name: "manually_added", sourceType: client.PurchaseSourceTypeManuallyAdded, wantIDs: []string{"dev-3"}},
+		{name: "no_match", sourceType: "UNKNOWN", wantIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterByPurchaseSourceType(devices, tt.sourceType)
+			if len(filtered) != len(tt.wantIDs) {
+				t.Fatalf("expected %d devices, got %d", len(tt.wantIDs), len(filtered))
+			}
+			for i, wantID := range tt.wantIDs {
+				if filtered[i].ID != wantID {
+					t.Errorf("device[%d]: expected ID %s, got %s", i, wantID, filtered[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceFilterMatches(t *testing.T) {
+	devices := []client.OrgDevice{
+		{ID: "dev-1", Attributes: client.DeviceAttribute{SerialNumber: "C02AAA", ProductFamily: "Mac", Status: client.OrgDeviceStatusUnassigned, DeviceModel: "MacBook Air", Color: "Midnight", AddedToOrgDateTime: "2025-03-01T10:00:00Z"}},
+		{ID: "dev-2", Attributes: client.DeviceAttribute{SerialNumber: "C02BBB", ProductFamily: "Mac", Status: client.OrgDeviceStatusAssigned, DeviceModel: "MacBook Pro", Color: "Silver", AddedToOrgDateTime: "2024-06-01T10:00:00Z"}},
+		{ID: "dev-3", Attributes: client.DeviceAttribute{SerialNumber: "DMPCCC", ProductFamily: "iPad", Status: client.OrgDeviceStatusUnassigned, DeviceModel: "iPad Air", Color: "Silver", AddedToOrgDateTime: "2025-05-01T10:00:00Z"}},
+		{ID: "dev-4", Attributes: client.DeviceAttribute{SerialNumber: "DMPDDD", ProductFamily: "iPad", Status: client.OrgDeviceStatusUnassigned, DeviceModel: "iPad Air", Color: "Silver"}},
+	}
+
+	tests := []struct {
+		name    string
+		model   DeviceFilterModel
+		wantIDs []string
+	}{
+		{name: "no_criteria", model: DeviceFilterModel{}, wantIDs: []string{"dev-1", "dev-2", "dev-3", "dev-4"}},
+		{name: "product_family_case_insensitive", model: DeviceFilterModel{ProductFamily: types.StringValue("mac")}, wantIDs: []string{"dev-1", "dev-2"}},
+		{name: "status", model: DeviceFilterModel{Status: types.StringValue("ASSIGNED")}, wantIDs: []string{"dev-2"}},
+		{name: "device_model", model: DeviceFilterModel{DeviceModel: types.StringValue("ipad air")}, wantIDs: []string{"dev-3", "dev-4"}},
+		{name: "color", model: DeviceFilterModel{Color: types.StringValue("SILVER")}, wantIDs: []string{"dev-2", "dev-3", "dev-4"}},
+		{
+			name:    "serial_numbers",
+			model:   DeviceFilterModel{SerialNumbers: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("c02bbb"), types.StringValue("DMPCCC")})},
+			wantIDs: []string{"dev-2", "dev-3"},
+		},
+		{name: "added_after_date", model: DeviceFilterModel{AddedAfter: types.StringValue("2025-01-01")}, wantIDs: []string{"dev-1", "dev-3"}},
+		{name: "added_range", model: DeviceFilterModel{AddedAfter: types.StringValue("2025-01-01"), AddedBefore: types.StringValue("2025-04-01T00:00:00Z")}, wantIDs: []string{"dev-1"}},
+		{name: "combined", model: DeviceFilterModel{ProductFamily: types.StringValue("iPad"), Color: types.StringValue("silver"), AddedBefore: types.StringValue("2026-01-01")}, wantIDs: []string{"dev-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterDevices(devices, newDeviceFilter(&tt.model))
+			gotIDs := make([]string, 0, len(filtered))
+			for _, device := range filtered {
+				gotIDs = append(gotIDs, device.ID)
+			}
+			if !slices.Equal(gotIDs, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, gotIDs)
+			}
+		})
+	}
+}
+
+func TestDeviceFilterQueryParams(t *testing.T) {
+	if params := newDeviceFilter(nil).queryParams(); params != nil {
+		t.Errorf("expected no query parameters without a filter, got %v", params)
+	}
+
+	f := newDeviceFilter(&DeviceFilterModel{
+		ProductFamily: types.StringValue("appletv"),
+		Status:        types.StringValue("UNASSIGNED"),
+		DeviceModel:   types.StringValue("Apple TV 4K"),
+		SerialNumbers: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dmpbbb"), types.StringValue("C02AAA")}),
+		AddedAfter:    types.StringValue("2025-01-01"),
+	})
+	params := f.queryParams()
+	want := map[string]string{
+		"filter[productFamily]": "AppleTV",
+		"filter[status]":        "UNASSIGNED",
+		"filter[serialNumber]":  "C02AAA,DMPBBB",
+	}
+	if len(params) != len(want) {
+		t.Errorf("expected %d query parameters, got %v", len(want), params)
+	}
+	for key, value := range want {
+		if got := params.Get(key); got != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestWarnLargeInventory(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		threshold int
+		wantWarn  bool
+	}{
+		{name: "below", count: 10, threshold: 100, wantWarn: false},
+		{name: "equal", count: 100, threshold: 100, wantWarn: false},
+		{name: "above", count: 101, threshold: 100, wantWarn: true},
+		{name: "disabled", count: 50000, threshold: 0, wantWarn: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			warnLargeInventory(&diags, tt.count, tt.threshold)
+			if got := diags.WarningsCount() > 0; got != tt.wantWarn {
+				t.Fatalf("expected warning=%v, got %v", tt.wantWarn, diags)
+			}
+			if tt.wantWarn {
+				detail := diags.Warnings()[0].Detail()
+				for _, want := range []string{"101 devices", "device_warning_threshold of 100", "filter", "axm_inventory_snapshot"} {
+					if !strings.Contains(detail, want) {
+						t.Errorf("expected warning detail to contain %q, got %q", want, detail)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRequestedFields(t *testing.T) {
+	if got := requestedFields(nil, deviceFilter{status: "ASSIGNED"}, true); got != "" {
+		t.Errorf("expected no fields when none are selected, got %q", got)
+	}
+
+	got := requestedFields([]string{"serial_number"}, deviceFilter{color: "silver", addedAfter: time.Unix(0, 0)}, true)
+	if want := "addedToOrgDateTime,color,purchaseSourceType,serialNumber"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	if got := withFields(nil, ""); got != nil {
+		t.Errorf("expected nil params, got %v", got)
+	}
+
+	got := withFields(url.Values{"filter[status]": {"ASSIGNED"}}, "serialNumber,status")
+	if got.Get("fields[orgDevices]") != "serialNumber,status" || got.Get("filter[status]") != "ASSIGNED" {
+		t.Errorf("unexpected params: %v", got)
+	}
+}
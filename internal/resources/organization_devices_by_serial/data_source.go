@@ -0,0 +1,164 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_devices_by_serial
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &OrganizationDevicesBySerialDataSource{}
+
+// NewOrganizationDevicesBySerialDataSource returns a new data source for looking up organization
+// devices by serial number.
+func NewOrganizationDevicesBySerialDataSource() datasource.DataSource {
+	return &OrganizationDevicesBySerialDataSource{}
+}
+
+// OrganizationDevicesBySerialDataSource defines the data source implementation.
+type OrganizationDevicesBySerialDataSource struct {
+	client *client.Client
+}
+
+// OrganizationDevicesBySerialDataSourceModel describes the data source data model.
+type OrganizationDevicesBySerialDataSourceModel struct {
+	ID                 types.String              `tfsdk:"id"`
+	Timeouts           timeouts.Value            `tfsdk:"timeouts"`
+	CredentialsProfile types.String              `tfsdk:"credentials_profile"`
+	SerialNumbers      types.Set                 `tfsdk:"serial_numbers"`
+	Fields             types.Set                 `tfsdk:"fields"`
+	Devices            []OrganizationDeviceModel `tfsdk:"devices"`
+	NotFound           []types.String            `tfsdk:"not_found"`
+}
+
+// OrganizationDeviceModel describes an organization device.
+type OrganizationDeviceModel struct {
+	ID types.String `tfsdk:"id"`
+	common.OrgDeviceModel
+}
+
+func (d *OrganizationDevicesBySerialDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_devices_by_serial"
+}
+
+func (d *OrganizationDevicesBySerialDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	deviceAttributes := common.OrgDeviceSchemaAttributes()
+	deviceAttributes["id"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The opaque resource ID that uniquely identifies the resource.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Resolves a set of serial numbers, such as those kept in a CMDB, to organization devices from Apple Business or School Manager. " +
+			"Serial numbers are looked up in batches with the API's serial number filter, falling back to reading each device when the API rejects it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the data source.",
+				Computed:    true,
+			},
+			"timeouts":            timeouts.Attributes(ctx),
+			"credentials_profile": common.CredentialsProfileAttribute(),
+			"serial_numbers": schema.SetAttribute{
+				Description: "The serial numbers of the devices to look up. Case-insensitive.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"fields": common.OrgDeviceFieldsAttribute(),
+			"devices": schema.ListNestedAttribute{
+				Description: "The devices found, ordered by serial number.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: deviceAttributes,
+				},
+			},
+			"not_found": schema.ListAttribute{
+				Description: "The configured serial numbers with no device in the organization, sorted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *OrganizationDevicesBySerialDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *OrganizationDevicesBySerialDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data OrganizationDevicesBySerialDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	c, profileDiags := common.ClientForProfile(d.client, data.CredentialsProfile)
+	resp.Diagnostics.Append(profileDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serials := common.SetToStrings(data.SerialNumbers)
+	fields := common.SetToStrings(data.Fields)
+	params := fieldsParams(fields)
+
+	found, err := c.GetOrgDevicesBySerial(readCtx, serials, params)
+	if cached, ok := common.OfflineOrgDevices(c, err, &resp.Diagnostics); ok {
+		found, err = cachedSerials(cached), nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Devices",
+			err.Error(),
+		)
+		return
+	}
+
+	devices, notFound := matchSerials(serials, found)
+	data.Devices = make([]OrganizationDeviceModel, 0, len(devices))
+	for _, device := range devices {
+		data.Devices = append(data.Devices, OrganizationDeviceModel{
+			ID:             types.StringValue(device.ID),
+			OrgDeviceModel: common.NewOrgDeviceModel(device, &resp.Diagnostics).WithFields(fields),
+		})
+	}
+	data.NotFound = common.StringsToTypesStrings(notFound)
+	data.ID = types.StringValue("organization_devices_by_serial")
+
+	tflog.Debug(ctx, "Read organization devices by serial number", map[string]any{
+		"requested_count": len(serials),
+		"device_count":    len(data.Devices),
+		"not_found_count": len(notFound),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,118 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_devices_by_serial_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/organization_devices_by_serial"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestOrganizationDevicesBySerialDataSourceMetadata(t *testing.T) {
+	ds := organization_devices_by_serial.NewOrganizationDevicesBySerialDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_organization_devices_by_serial" {
+		t.Errorf("expected TypeName %q, got %q", "axm_organization_devices_by_serial", resp.TypeName)
+	}
+}
+
+func TestOrganizationDevicesBySerialDataSourceSchema(t *testing.T) {
+	ds := organization_devices_by_serial.NewOrganizationDevicesBySerialDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	serialsAttr, ok := resp.Schema.Attributes["serial_numbers"].(dsschema.SetAttribute)
+	if !ok {
+		t.Fatal("expected 'serial_numbers' to be a SetAttribute")
+	}
+	if !serialsAttr.IsRequired() {
+		t.Error("expected 'serial_numbers' to be Required")
+	}
+
+	if attr, ok := resp.Schema.Attributes["fields"]; !ok || !attr.IsOptional() {
+		t.Error("expected 'fields' to be Optional")
+	}
+
+	devicesAttr, ok := resp.Schema.Attributes["devices"].(dsschema.ListNestedAttribute)
+	if !ok {
+		t.Fatal("expected 'devices' to be a ListNestedAttribute")
+	}
+	if !devicesAttr.IsComputed() {
+		t.Error("expected 'devices' to be Computed")
+	}
+	for _, name := range []string{"id", "serial_number", "status", "product_family"} {
+		if _, ok := devicesAttr.NestedObject.Attributes[name]; !ok {
+			t.Errorf("nested attribute %q not found", name)
+		}
+	}
+
+	notFoundAttr, ok := resp.Schema.Attributes["not_found"].(dsschema.ListAttribute)
+	if !ok {
+		t.Fatal("expected 'not_found' to be a ListAttribute")
+	}
+	if !notFoundAttr.IsComputed() {
+		t.Error("expected 'not_found' to be Computed")
+	}
+}
+
+func TestAccOrganizationDevicesBySerialDataSource(t *testing.T) {
+	serial := os.Getenv("AXM_TEST_DEVICE_SERIAL_1")
+	if serial == "" {
+		t.Skip("AXM_TEST_DEVICE_SERIAL_1 must be set for this test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "axm_organization_devices_by_serial" "test" {
+						serial_numbers = [%q, "NOTAREALSERIAL0"]
+					}
+				`, serial),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.axm_organization_devices_by_serial.test", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.axm_organization_devices_by_serial.test", "devices.0.serial_number", serial),
+					resource.TestCheckResourceAttr("data.axm_organization_devices_by_serial.test", "not_found.#", "1"),
+					resource.TestCheckResourceAttr("data.axm_organization_devices_by_serial.test", "not_found.0", "NOTAREALSERIAL0"),
+				),
+			},
+		},
+	})
+}
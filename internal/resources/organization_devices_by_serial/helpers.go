@@ -0,0 +1,58 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_devices_by_serial
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+// fieldsParams returns the query parameters requesting fields, always including the serial
+// number the devices are matched on, or nil when no fields are selected.
+func fieldsParams(fields []string) url.Values {
+	if len(fields) == 0 {
+		return nil
+	}
+	return url.Values{common.OrgDeviceFieldsParam: {common.OrgDeviceFieldsValue(append(slices.Clone(fields), "serial_number")...)}}
+}
+
+// cachedSerials returns the devices of a cached inventory keyed by upper-cased serial number,
+// as GetOrgDevicesBySerial returns them.
+func cachedSerials(devices []client.OrgDevice) map[string]client.OrgDevice {
+	found := make(map[string]client.OrgDevice, len(devices))
+	for _, device := range devices {
+		found[strings.ToUpper(device.Attributes.SerialNumber)] = device
+	}
+	return found
+}
+
+// matchSerials splits the configured serials into the devices found for them, ordered by
+// serial number and without duplicates, and the serials with no device, sorted as configured.
+func matchSerials(serials []string, found map[string]client.OrgDevice) ([]client.OrgDevice, []string) {
+	sorted := slices.Clone(serials)
+	slices.Sort(sorted)
+
+	var devices []client.OrgDevice
+	var notFound []string
+	seen := make(map[string]bool, len(sorted))
+	for _, serial := range sorted {
+		key := strings.ToUpper(strings.TrimSpace(serial))
+		device, ok := found[key]
+		switch {
+		case !ok:
+			notFound = append(notFound, serial)
+		case !seen[device.ID]:
+			seen[device.ID] = true
+			devices = append(devices, device)
+		}
+	}
+	slices.SortFunc(devices, func(a, b client.OrgDevice) int {
+		return strings.Compare(strings.ToUpper(a.Attributes.SerialNumber), strings.ToUpper(b.Attributes.SerialNumber))
+	})
+	return devices, notFound
+}
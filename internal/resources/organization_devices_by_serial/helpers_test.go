@@ -0,0 +1,51 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package organization_devices_by_serial
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestFieldsParams(t *testing.T) {
+	if got := fieldsParams(nil); got != nil {
+		t.Errorf("expected no params without fields, got %v", got)
+	}
+	got := fieldsParams([]string{"status"})
+	if want := "serialNumber,status"; got.Get("fields[orgDevices]") != want {
+		t.Errorf("expected fields[orgDevices]=%q, got %v", want, got)
+	}
+}
+
+func TestMatchSerials(t *testing.T) {
+	found := map[string]client.OrgDevice{
+		"SN002": {ID: "DEV002", Attributes: client.DeviceAttribute{SerialNumber: "SN002"}},
+		"SN001": {ID: "DEV001", Attributes: client.DeviceAttribute{SerialNumber: "SN001"}},
+	}
+
+	devices, notFound := matchSerials([]string{"sn002", "SN404", "SN001", "SN002", "abc"}, found)
+
+	var ids []string
+	for _, device := range devices {
+		ids = append(ids, device.ID)
+	}
+	if !slices.Equal(ids, []string{"DEV001", "DEV002"}) {
+		t.Errorf("expected devices DEV001 and DEV002 once each, got %v", ids)
+	}
+	if !slices.Equal(notFound, []string{"SN404", "abc"}) {
+		t.Errorf("expected SN404 and abc not found, got %v", notFound)
+	}
+}
+
+func TestCachedSerials(t *testing.T) {
+	found := cachedSerials([]client.OrgDevice{
+		{ID: "DEV1", Attributes: client.DeviceAttribute{SerialNumber: "sn001"}},
+		{ID: "DEV2", Attributes: client.DeviceAttribute{SerialNumber: "SN002"}},
+	})
+	if len(found) != 2 || found["SN001"].ID != "DEV1" || found["SN002"].ID != "DEV2" {
+		t.Errorf("unexpected devices: %+v", found)
+	}
+}
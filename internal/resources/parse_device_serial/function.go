@@ -0,0 +1,82 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package parse_device_serial
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ParseDeviceSerialFunction{}
+
+// Lengths accepted for an Apple serial number. Serial numbers issued since 2021 are 10
+// characters long; earlier ones are 11 or 12.
+const (
+	minSerialLength = 10
+	maxSerialLength = 12
+)
+
+// NewParseDeviceSerialFunction returns a new function that validates and normalizes a device serial number.
+func NewParseDeviceSerialFunction() function.Function {
+	return &ParseDeviceSerialFunction{}
+}
+
+// ParseDeviceSerialFunction defines the function implementation.
+type ParseDeviceSerialFunction struct{}
+
+func (f *ParseDeviceSerialFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_device_serial"
+}
+
+func (f *ParseDeviceSerialFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validates and normalizes an Apple device serial number",
+		MarkdownDescription: "Validates and normalizes an Apple device serial number, such as one supplied by a user or copied from a " +
+			"spreadsheet, so that it can be used in `device_ids`. All whitespace is removed and letters are converted to upper case. " +
+			"The result must be 10 to 12 letters and digits long, otherwise the function fails with an error naming the serial number.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "serial",
+				Description: "Device serial number to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ParseDeviceSerialFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serial string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &serial))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := parseDeviceSerial(serial)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
+
+// parseDeviceSerial removes whitespace from serial, converts it to upper case, and checks that
+// the result is an Apple serial number.
+func parseDeviceSerial(serial string) (string, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(serial), ""))
+	for _, r := range normalized {
+		if r > unicode.MaxASCII || !(unicode.IsUpper(r) || unicode.IsDigit(r)) {
+			return "", fmt.Errorf("serial number %q may contain only letters and digits, got %q", serial, r)
+		}
+	}
+	if len(normalized) < minSerialLength || len(normalized) > maxSerialLength {
+		return "", fmt.Errorf("serial number %q must be %d to %d characters long, got %d", serial, minSerialLength, maxSerialLength, len(normalized))
+	}
+	return normalized, nil
+}
@@ -0,0 +1,84 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package parse_device_serial_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/parse_device_serial"
+)
+
+func TestFunctionMetadata(t *testing.T) {
+	f := parse_device_serial.NewParseDeviceSerialFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+
+	if resp.Name != "parse_device_serial" {
+		t.Errorf("expected Name %q, got %q", "parse_device_serial", resp.Name)
+	}
+}
+
+func TestFunctionDefinition(t *testing.T) {
+	f := parse_device_serial.NewParseDeviceSerialFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+
+	if resp.Definition.Summary == "" {
+		t.Error("expected non-empty Summary")
+	}
+	if len(resp.Definition.Parameters) != 1 {
+		t.Fatalf("expected 1 parameter, got %d", len(resp.Definition.Parameters))
+	}
+	if _, ok := resp.Definition.Parameters[0].(function.StringParameter); !ok {
+		t.Errorf("expected the parameter to be a StringParameter, got %T", resp.Definition.Parameters[0])
+	}
+}
+
+func TestFunctionRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		serial  string
+		want    string
+		wantErr string
+	}{
+		{"already_normalized", "C02XL0GXJGH5", "C02XL0GXJGH5", ""},
+		{"lower_case_and_whitespace", "  c02x l0gx\tjgh5\n", "C02XL0GXJGH5", ""},
+		{"ten_characters", "h7wk2q9vnm", "H7WK2Q9VNM", ""},
+		{"too_short", "C02XL0G", "", "must be 10 to 12 characters long, got 7"},
+		{"too_long", "SC02XL0GXJGH5", "", "must be 10 to 12 characters long, got 13"},
+		{"punctuation", "C02XL0-GXJGH", "", "may contain only letters and digits"},
+		{"non_ascii", "C02XL0GXJGÉ5", "", "may contain only letters and digits"},
+		{"empty", "", "", "got 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.serial)}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			parse_device_serial.NewParseDeviceSerialFunction().Run(context.Background(), req, &resp)
+
+			if tt.wantErr != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, resp.Error)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tt.want)) {
+				t.Errorf("expected %q, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_info
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &ProviderInfoDataSource{}
+
+// NewProviderInfoDataSource returns a new data source exposing provider diagnostic information.
+func NewProviderInfoDataSource() datasource.DataSource {
+	return &ProviderInfoDataSource{}
+}
+
+// ProviderInfoDataSource defines the data source implementation.
+type ProviderInfoDataSource struct {
+	client *client.Client
+}
+
+// ProviderInfoDataSourceModel describes the data source data model.
+type ProviderInfoDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Version        types.String `tfsdk:"version"`
+	Scope          types.String `tfsdk:"scope"`
+	BaseURL        types.String `tfsdk:"base_url"`
+	TokenExpiresAt types.String `tfsdk:"token_expires_at"`
+	CacheDir       types.String `tfsdk:"cache_dir"`
+}
+
+func (d *ProviderInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+func (d *ProviderInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exposes diagnostic information about the configured provider. Credentials and tokens are never included.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "The provider version.",
+				Computed:    true,
+			},
+			"scope": schema.StringAttribute{
+				Description: "The configured API scope: business.api or school.api.",
+				Computed:    true,
+			},
+			"base_url": schema.StringAttribute{
+				Description: "The resolved API base URL used for requests.",
+				Computed:    true,
+			},
+			"token_expires_at": schema.StringAttribute{
+				Description: "The RFC 3339 time at which the current OAuth access token expires. Null if a token could not be obtained.",
+				Computed:    true,
+			},
+			"cache_dir": schema.StringAttribute{
+				Description: "The local directory used to cache client assertions and access tokens.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ProviderInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *ProviderInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProviderInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("provider_info")
+	data.Version = types.StringValue(d.client.ProviderVersion())
+	data.Scope = types.StringValue(d.client.Scope())
+	data.BaseURL = types.StringValue(d.client.BaseURL())
+	data.CacheDir = types.StringValue(d.client.CacheDir())
+	data.TokenExpiresAt = types.StringNull()
+
+	expiry, err := d.client.TokenExpiry()
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Determine Token Expiry",
+			err.Error(),
+		)
+	} else {
+		data.TokenExpiresAt = types.StringValue(expiry.UTC().Format(time.RFC3339))
+	}
+
+	tflog.Debug(ctx, "Read provider info", map[string]any{
+		"data": data,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
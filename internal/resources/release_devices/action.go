@@ -0,0 +1,137 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package release_devices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/action/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_management_service"
+)
+
+var _ action.Action = &ReleaseDevicesAction{}
+var _ action.ActionWithConfigure = &ReleaseDevicesAction{}
+
+const defaultInvokeTimeout = 10 * time.Minute
+
+// NewReleaseDevicesAction returns a new action that releases devices from the organization.
+func NewReleaseDevicesAction() action.Action {
+	return &ReleaseDevicesAction{}
+}
+
+// ReleaseDevicesAction releases devices from the organization. Release activities are awaited
+// and audited by the device management service resource's activity runner, the same way as
+// assignment activities.
+type ReleaseDevicesAction struct {
+	client *client.Client
+}
+
+// ReleaseDevicesModel describes the action configuration.
+type ReleaseDevicesModel struct {
+	DeviceIDs types.Set      `tfsdk:"device_ids"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (a *ReleaseDevicesAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_release_devices"
+}
+
+func (a *ReleaseDevicesAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Releases devices from the organization by submitting RELEASE_DEVICES activities, in chunks of at most the provider's " +
+			"activity_chunk_size devices, and waits for each to complete. The activity log summary of an activity that completes with errors " +
+			"is reported as a warning. Releasing a device cannot be undone: it can only return to the organization by being added again, " +
+			"for example through Apple Configurator or the reseller that sold it.",
+		Attributes: map[string]schema.Attribute{
+			"device_ids": schema.SetAttribute{
+				Description: "Serial numbers or organization device IDs of the devices to release.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (a *ReleaseDevicesAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Action")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	a.client = c
+}
+
+func (a *ReleaseDevicesAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	defer common.RedactDiagnostics(a.client, &resp.Diagnostics)
+
+	var data ReleaseDevicesModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invokeTimeout, timeoutDiags := data.Timeouts.Invoke(ctx, defaultInvokeTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	invokeCtx, cancel := context.WithTimeout(ctx, invokeTimeout)
+	defer cancel()
+
+	deviceIDs := releaseDeviceIDs(common.SetToStrings(data.DeviceIDs))
+	tflog.Debug(ctx, "Releasing devices from the organization", map[string]any{
+		"device_count": len(deviceIDs),
+	})
+
+	var released []string
+	for chunk := range slices.Chunk(deviceIDs, a.client.ActivityChunkSize()) {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Releasing %d device(s) from the organization.", len(chunk)),
+		})
+		activityID, err := device_management_service.ReleaseDevices(invokeCtx, a.client, chunk, &resp.Diagnostics)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Release Devices",
+				fmt.Sprintf("%d of %d device(s) were released before the failure.\n\n%v", len(released), len(deviceIDs), err),
+			)
+			break
+		}
+		released = append(released, chunk...)
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Activity %s released %d device(s).", activityID, len(chunk)),
+		})
+	}
+
+	common.ReleaseSerials(invokeCtx, a.client, released, &resp.Diagnostics)
+}
+
+// releaseDeviceIDs trims, deduplicates and sorts the configured device IDs, dropping empty ones.
+func releaseDeviceIDs(values []string) []string {
+	deviceIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			deviceIDs = append(deviceIDs, value)
+		}
+	}
+	slices.Sort(deviceIDs)
+	return slices.Compact(deviceIDs)
+}
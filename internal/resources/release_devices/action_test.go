@@ -0,0 +1,53 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package release_devices_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/release_devices"
+)
+
+func TestActionMetadata(t *testing.T) {
+	a := release_devices.NewReleaseDevicesAction()
+	resp := action.MetadataResponse{}
+	a.Metadata(context.Background(), action.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_release_devices" {
+		t.Errorf("expected TypeName %q, got %q", "axm_release_devices", resp.TypeName)
+	}
+}
+
+func TestActionSchema(t *testing.T) {
+	a := release_devices.NewReleaseDevicesAction()
+	resp := action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	tests := []struct {
+		name     string
+		required bool
+	}{
+		{"device_ids", true},
+		{"timeouts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, ok := resp.Schema.Attributes[tt.name]
+			if !ok {
+				t.Fatalf("attribute %q not found in schema", tt.name)
+			}
+			if attr.IsRequired() != tt.required {
+				t.Errorf("expected attribute %q Required=%v, got %v", tt.name, tt.required, attr.IsRequired())
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package release_devices
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReleaseDeviceIDs(t *testing.T) {
+	got := releaseDeviceIDs([]string{" SN002", "SN001", "", "SN002 ", "  "})
+	if want := []string{"SN001", "SN002"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package render_inventory_markdown
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &RenderInventoryMarkdownFunction{}
+
+// defaultColumns are the device attributes rendered when no columns are given.
+var defaultColumns = []string{"serial_number", "product_family", "device_model", "status", "purchase_source_type"}
+
+// NewRenderInventoryMarkdownFunction returns a new function that renders a device list as a Markdown table.
+func NewRenderInventoryMarkdownFunction() function.Function {
+	return &RenderInventoryMarkdownFunction{}
+}
+
+// RenderInventoryMarkdownFunction defines the function implementation.
+type RenderInventoryMarkdownFunction struct{}
+
+func (f *RenderInventoryMarkdownFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_inventory_markdown"
+}
+
+func (f *RenderInventoryMarkdownFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Renders a list of devices as a Markdown table",
+		MarkdownDescription: "Renders a list of devices, such as the `devices` of `axm_organization_devices`, as a Markdown table with one row per device, " +
+			"so that human-readable fleet reports can be written with `local_file`. Each column is a device attribute, named in the header row. " +
+			"The columns default to `serial_number`, `product_family`, `device_model`, `status` and `purchase_source_type`; pass attribute names " +
+			"after `devices` to choose others. Null or missing attributes render as empty cells, lists are joined with commas, and pipe characters " +
+			"and line breaks are escaped so that every device stays on one row.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "devices",
+				Description: "List of device objects to render.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "columns",
+			Description: "Names of the device attributes to render as columns, in order.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RenderInventoryMarkdownFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var devices types.Dynamic
+	var columns []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &devices, &columns))
+	if resp.Error != nil {
+		return
+	}
+
+	rows, err := deviceRows(devices.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	if len(columns) == 0 {
+		columns = defaultColumns
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, renderTable(columns, rows)))
+}
+
+// deviceRows returns the attributes of each device in devices, which must be a list, set or
+// tuple of objects or maps.
+func deviceRows(devices attr.Value) ([]map[string]attr.Value, error) {
+	var elements []attr.Value
+	switch v := devices.(type) {
+	case basetypes.ListValue:
+		elements = v.Elements()
+	case basetypes.SetValue:
+		elements = v.Elements()
+	case basetypes.TupleValue:
+		elements = v.Elements()
+	default:
+		return nil, fmt.Errorf("devices must be a list of device objects, got %s", typeName(devices))
+	}
+
+	rows := make([]map[string]attr.Value, 0, len(elements))
+	for i, element := range elements {
+		if dynamic, ok := element.(basetypes.DynamicValue); ok {
+			element = dynamic.UnderlyingValue()
+		}
+		switch v := element.(type) {
+		case basetypes.ObjectValue:
+			rows = append(rows, v.Attributes())
+		case basetypes.MapValue:
+			rows = append(rows, v.Elements())
+		default:
+			return nil, fmt.Errorf("device %d must be an object, got %s", i, typeName(element))
+		}
+	}
+	return rows, nil
+}
+
+// renderTable renders rows as a Markdown table with the given columns.
+func renderTable(columns []string, rows []map[string]attr.Value) string {
+	var b strings.Builder
+	writeRow(&b, columns)
+	separators := make([]string, len(columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeRow(&b, separators)
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = cellText(row[column])
+		}
+		writeRow(&b, cells)
+	}
+	return b.String()
+}
+
+// writeRow writes one table row of cells, escaped for Markdown.
+func writeRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, "|", `\|`)
+		cell = strings.Join(strings.Fields(strings.ReplaceAll(cell, "\n", " ")), " ")
+		fmt.Fprintf(b, " %s |", cell)
+	}
+	b.WriteString("\n")
+}
+
+// cellText returns the text of one attribute value: empty for null, unknown or missing values,
+// and the elements joined with commas for collections.
+func cellText(value attr.Value) string {
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+	switch v := value.(type) {
+	case basetypes.StringValue:
+		return v.ValueString()
+	case basetypes.BoolValue:
+		return fmt.Sprint(v.ValueBool())
+	case basetypes.Int64Value:
+		return fmt.Sprint(v.ValueInt64())
+	case basetypes.Float64Value:
+		return fmt.Sprint(v.ValueFloat64())
+	case basetypes.NumberValue:
+		return v.ValueBigFloat().Text('f', -1)
+	case basetypes.DynamicValue:
+		return cellText(v.UnderlyingValue())
+	case basetypes.ListValue:
+		return joinCells(v.Elements())
+	case basetypes.SetValue:
+		return joinCells(v.Elements())
+	case basetypes.TupleValue:
+		return joinCells(v.Elements())
+	default:
+		return value.String()
+	}
+}
+
+// joinCells returns the text of each of values joined with commas.
+func joinCells(values []attr.Value) string {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		if text := cellText(value); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, ", ")
+}
+
+// typeName describes the Terraform type of value for error messages.
+func typeName(value attr.Value) string {
+	if value == nil {
+		return "null"
+	}
+	return value.Type(context.Background()).String()
+}
@@ -0,0 +1,134 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package render_inventory_markdown_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/render_inventory_markdown"
+)
+
+func TestFunctionMetadata(t *testing.T) {
+	f := render_inventory_markdown.NewRenderInventoryMarkdownFunction()
+	resp := function.MetadataResponse{}
+	f.Metadata(context.Background(), function.MetadataRequest{}, &resp)
+
+	if resp.Name != "render_inventory_markdown" {
+		t.Errorf("expected Name %q, got %q", "render_inventory_markdown", resp.Name)
+	}
+}
+
+func TestFunctionDefinition(t *testing.T) {
+	f := render_inventory_markdown.NewRenderInventoryMarkdownFunction()
+	resp := function.DefinitionResponse{}
+	f.Definition(context.Background(), function.DefinitionRequest{}, &resp)
+
+	if resp.Definition.Summary == "" {
+		t.Error("expected non-empty Summary")
+	}
+	if len(resp.Definition.Parameters) != 1 {
+		t.Fatalf("expected 1 parameter, got %d", len(resp.Definition.Parameters))
+	}
+	if _, ok := resp.Definition.Parameters[0].(function.DynamicParameter); !ok {
+		t.Errorf("expected the parameter to be a DynamicParameter, got %T", resp.Definition.Parameters[0])
+	}
+	if _, ok := resp.Definition.VariadicParameter.(function.StringParameter); !ok {
+		t.Errorf("expected a StringParameter variadic parameter, got %T", resp.Definition.VariadicParameter)
+	}
+}
+
+func TestFunctionRun(t *testing.T) {
+	deviceType := map[string]attr.Type{
+		"serial_number":  types.StringType,
+		"product_family": types.StringType,
+		"device_model":   types.StringType,
+		"status":         types.StringType,
+		"imei":           types.ListType{ElemType: types.StringType},
+	}
+	device := func(serial, family, model string, imei ...string) attr.Value {
+		imeiValues := make([]attr.Value, len(imei))
+		for i, v := range imei {
+			imeiValues[i] = types.StringValue(v)
+		}
+		return types.ObjectValueMust(deviceType, map[string]attr.Value{
+			"serial_number":  types.StringValue(serial),
+			"product_family": types.StringValue(family),
+			"device_model":   types.StringValue(model),
+			"status":         types.StringNull(),
+			"imei":           types.ListValueMust(types.StringType, imeiValues),
+		})
+	}
+	devices := types.DynamicValue(types.ListValueMust(types.ObjectType{AttrTypes: deviceType}, []attr.Value{
+		device("SN1", "Mac", "MacBook Air"),
+		device("SN2", "iPhone", "iPhone 15 | Pro", "1", "2"),
+	}))
+
+	tests := []struct {
+		name    string
+		columns []string
+		want    string
+	}{
+		{
+			name: "default_columns",
+			want: "| serial_number | product_family | device_model | status | purchase_source_type |\n" +
+				"| --- | --- | --- | --- | --- |\n" +
+				"| SN1 | Mac | MacBook Air |  |  |\n" +
+				"| SN2 | iPhone | iPhone 15 \\| Pro |  |  |\n",
+		},
+		{
+			name:    "chosen_columns",
+			columns: []string{"serial_number", "imei"},
+			want: "| serial_number | imei |\n" +
+				"| --- | --- |\n" +
+				"| SN1 |  |\n" +
+				"| SN2 | 1, 2 |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := make([]attr.Value, len(tt.columns))
+			columnTypes := make([]attr.Type, len(tt.columns))
+			for i, column := range tt.columns {
+				columns[i] = types.StringValue(column)
+				columnTypes[i] = types.StringType
+			}
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					devices,
+					types.TupleValueMust(columnTypes, columns),
+				}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			render_inventory_markdown.NewRenderInventoryMarkdownFunction().Run(context.Background(), req, &resp)
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.String).ValueString(); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFunctionRun_NotAList(t *testing.T) {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.DynamicValue(types.StringValue("SN1")),
+			types.TupleValueMust(nil, nil),
+		}),
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	render_inventory_markdown.NewRenderInventoryMarkdownFunction().Run(context.Background(), req, &resp)
+
+	if resp.Error == nil {
+		t.Fatal("expected an error for a devices argument that is not a list")
+	}
+}
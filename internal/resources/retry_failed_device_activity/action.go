@@ -0,0 +1,208 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package retry_failed_device_activity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/action/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/device_management_service"
+)
+
+var _ action.Action = &RetryFailedDeviceActivityAction{}
+var _ action.ActionWithConfigure = &RetryFailedDeviceActivityAction{}
+
+const defaultInvokeTimeout = 10 * time.Minute
+
+// NewRetryFailedDeviceActivityAction returns a new action that resubmits the failed devices of an activity.
+func NewRetryFailedDeviceActivityAction() action.Action {
+	return &RetryFailedDeviceActivityAction{}
+}
+
+// RetryFailedDeviceActivityAction resubmits the devices an assignment or unassignment activity
+// failed to process.
+type RetryFailedDeviceActivityAction struct {
+	client *client.Client
+}
+
+// RetryFailedDeviceActivityModel describes the action configuration.
+type RetryFailedDeviceActivityModel struct {
+	ActivityID   types.String   `tfsdk:"activity_id"`
+	ActivityType types.String   `tfsdk:"activity_type"`
+	ServerID     types.String   `tfsdk:"server_id"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (a *RetryFailedDeviceActivityAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_retry_failed_device_activity"
+}
+
+func (a *RetryFailedDeviceActivityAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resubmits only the devices that failed in a previous assignment or unassignment activity. " +
+			"The activity log of the activity is downloaded, and every serial number whose operation status is not SUCCESS is " +
+			"assigned to, or unassigned from, the same device management service in a new activity.",
+		Attributes: map[string]schema.Attribute{
+			"activity_id": schema.StringAttribute{
+				Description: "ID of the completed organization device activity whose failed devices are retried.",
+				Required:    true,
+			},
+			"activity_type": schema.StringAttribute{
+				Description: "ASSIGN_DEVICES or UNASSIGN_DEVICES. The API does not report the type of an activity, so when omitted " +
+					"it is taken from the activity's record in the provider's audit_log_path.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.ActivityTypeAssignDevices, client.ActivityTypeUnassignDevices),
+				},
+			},
+			"server_id": schema.StringAttribute{
+				Description: "ID of the device management service the activity targeted. When omitted it is taken from the " +
+					"activity's record in the provider's audit_log_path.",
+				Optional: true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (a *RetryFailedDeviceActivityAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Action")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	a.client = c
+}
+
+func (a *RetryFailedDeviceActivityAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	defer common.RedactDiagnostics(a.client, &resp.Diagnostics)
+
+	var data RetryFailedDeviceActivityModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invokeTimeout, timeoutDiags := data.Timeouts.Invoke(ctx, defaultInvokeTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	invokeCtx, cancel := context.WithTimeout(ctx, invokeTimeout)
+	defer cancel()
+
+	activityID := strings.TrimSpace(data.ActivityID.ValueString())
+	activityType, serverID := data.ActivityType.ValueString(), strings.TrimSpace(data.ServerID.ValueString())
+	if activityType == "" || serverID == "" {
+		audited, err := a.client.AuditedActivities()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Audit Log", err.Error())
+			return
+		}
+		activityType, serverID = auditedActivityTarget(audited, activityID, activityType, serverID)
+		if activityType == "" || serverID == "" {
+			resp.Diagnostics.AddError(
+				"Unable to Determine Activity Target",
+				fmt.Sprintf("Activity %s is not recorded in the audit log. Set activity_type and server_id to retry it.", activityID),
+			)
+			return
+		}
+	}
+
+	activity, err := a.client.GetOrgDeviceActivity(invokeCtx, activityID, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Device Activity",
+			fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+		)
+		return
+	}
+	if activity.Attributes.Status == client.ActivityStatusInProgress {
+		resp.Diagnostics.AddError(
+			"Activity Still in Progress",
+			fmt.Sprintf("Activity %s has not finished. Retry its failed devices once it completes.", activityID),
+		)
+		return
+	}
+	if activity.Attributes.DownloadURL == "" {
+		resp.Diagnostics.AddError(
+			"Activity Log Not Available",
+			fmt.Sprintf("Activity %s (status %s) did not provide a download URL, so its failed devices cannot be determined.", activityID, activity.Attributes.Status),
+		)
+		return
+	}
+
+	logData, err := client.DownloadActivityLog(invokeCtx, activity.Attributes.DownloadURL)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to download activity log", fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err))
+		return
+	}
+	rows, err := client.ParseActivityLogRows(logData)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse activity log", fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err))
+		return
+	}
+
+	serialNumbers := device_management_service.FailedSerialNumbers(rows)
+	if len(serialNumbers) == 0 {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Activity %s has no failed devices to retry.", activityID),
+		})
+		return
+	}
+
+	tflog.Debug(ctx, "Retrying failed devices of activity", map[string]any{
+		"activity_id":   activityID,
+		"activity_type": activityType,
+		"server_id":     serverID,
+		"device_count":  len(serialNumbers),
+	})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Resubmitting %d failed device(s) from activity %s to device management service %s.", len(serialNumbers), activityID, serverID),
+	})
+
+	assign := activityType == client.ActivityTypeAssignDevices
+	if _, err := device_management_service.RunDeviceActivity(invokeCtx, a.client, serverID, serialNumbers, assign, &resp.Diagnostics); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Retry Failed Devices",
+			fmt.Sprintf("Activity ID: %s\n\n%v", activityID, err),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Retried %d failed device(s) from activity %s.", len(serialNumbers), activityID),
+	})
+}
+
+// auditedActivityTarget fills an unset activity type or server ID from the audit record of
+// activityID, leaving them empty when the activity was not audited.
+func auditedActivityTarget(audited []client.AuditRecord, activityID, activityType, serverID string) (string, string) {
+	for _, record := range audited {
+		if record.ActivityID != activityID {
+			continue
+		}
+		if activityType == "" {
+			activityType = record.ActivityType
+		}
+		if serverID == "" {
+			serverID = record.ServerID
+		}
+		break
+	}
+	return activityType, serverID
+}
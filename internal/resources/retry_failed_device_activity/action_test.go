@@ -0,0 +1,55 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package retry_failed_device_activity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/retry_failed_device_activity"
+)
+
+func TestActionMetadata(t *testing.T) {
+	a := retry_failed_device_activity.NewRetryFailedDeviceActivityAction()
+	resp := action.MetadataResponse{}
+	a.Metadata(context.Background(), action.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_retry_failed_device_activity" {
+		t.Errorf("expected TypeName %q, got %q", "axm_retry_failed_device_activity", resp.TypeName)
+	}
+}
+
+func TestActionSchema(t *testing.T) {
+	a := retry_failed_device_activity.NewRetryFailedDeviceActivityAction()
+	resp := action.SchemaResponse{}
+	a.Schema(context.Background(), action.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	tests := []struct {
+		name     string
+		required bool
+	}{
+		{"activity_id", true},
+		{"activity_type", false},
+		{"server_id", false},
+		{"timeouts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, ok := resp.Schema.Attributes[tt.name]
+			if !ok {
+				t.Fatalf("attribute %q not found in schema", tt.name)
+			}
+			if attr.IsRequired() != tt.required {
+				t.Errorf("expected attribute %q Required=%v, got %v", tt.name, tt.required, attr.IsRequired())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package retry_failed_device_activity
+
+import (
+	"testing"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestAuditedActivityTarget(t *testing.T) {
+	audited := []client.AuditRecord{
+		{ActivityID: "act-1", ActivityType: client.ActivityTypeAssignDevices, ServerID: "srv-1"},
+		{ActivityID: "act-2", ActivityType: client.ActivityTypeUnassignDevices, ServerID: "srv-2"},
+	}
+
+	tests := []struct {
+		name         string
+		activityID   string
+		activityType string
+		serverID     string
+		wantType     string
+		wantServer   string
+	}{
+		{"from_audit_log", "act-2", "", "", client.ActivityTypeUnassignDevices, "srv-2"},
+		{"configured_values_win", "act-1", client.ActivityTypeUnassignDevices, "srv-9", client.ActivityTypeUnassignDevices, "srv-9"},
+		{"partially_configured", "act-1", "", "srv-9", client.ActivityTypeAssignDevices, "srv-9"},
+		{"not_audited", "act-3", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotServer := auditedActivityTarget(audited, tt.activityID, tt.activityType, tt.serverID)
+			if gotType != tt.wantType || gotServer != tt.wantServer {
+				t.Errorf("expected (%q, %q), got (%q, %q)", tt.wantType, tt.wantServer, gotType, gotServer)
+			}
+		})
+	}
+}
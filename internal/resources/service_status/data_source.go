@@ -0,0 +1,141 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package service_status
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &ServiceStatusDataSource{}
+
+// NewServiceStatusDataSource returns a new data source reporting whether the organization can use the API.
+func NewServiceStatusDataSource() datasource.DataSource {
+	return &ServiceStatusDataSource{}
+}
+
+// ServiceStatusDataSource defines the data source implementation.
+type ServiceStatusDataSource struct {
+	client *client.Client
+}
+
+// ServiceStatusDataSourceModel describes the data source data model.
+type ServiceStatusDataSourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	FailOnProblem types.Bool     `tfsdk:"fail_on_problem"`
+	Status        types.String   `tfsdk:"status"`
+	Healthy       types.Bool     `tfsdk:"healthy"`
+	HTTPStatus    types.Int64    `tfsdk:"http_status"`
+	ErrorCode     types.String   `tfsdk:"error_code"`
+	ErrorDetail   types.String   `tfsdk:"error_detail"`
+	Message       types.String   `tfsdk:"message"`
+}
+
+func (d *ServiceStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_status"
+}
+
+func (d *ServiceStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports whether the organization can use the Apple Business or School Manager API, detected from the response to a minimal probe request. " +
+			"Terms and conditions that have not been accepted, API accounts that are not entitled to the API, and rejected credentials are reported with a " +
+			"message naming what to fix, so that pipelines can stop before touching resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source.",
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"fail_on_problem": schema.BoolAttribute{
+				Description: "When true, the read fails with message as its error when status is not OK. Defaults to false.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "The outcome of the probe. Possible values: 'OK', 'TERMS_NOT_ACCEPTED', 'NOT_ENTITLED', 'UNAUTHORIZED', 'UNAVAILABLE'.",
+				Computed:    true,
+			},
+			"healthy": schema.BoolAttribute{
+				Description: "Whether status is OK.",
+				Computed:    true,
+			},
+			"http_status": schema.Int64Attribute{
+				Description: "The HTTP status of the probe response. Null when no response was received.",
+				Computed:    true,
+			},
+			"error_code": schema.StringAttribute{
+				Description: "The API error code of an unsuccessful probe, if any.",
+				Computed:    true,
+			},
+			"error_detail": schema.StringAttribute{
+				Description: "The API error detail of an unsuccessful probe, or the error that prevented a response.",
+				Computed:    true,
+			},
+			"message": schema.StringAttribute{
+				Description: "A human-readable explanation of status and what to do about it.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ServiceStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *ServiceStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServiceStatusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+
+	probe, err := d.client.ProbeService(readCtx)
+	result := classifyProbe(probe, err)
+
+	data.ID = types.StringValue("service_status")
+	data.Status = types.StringValue(result.status)
+	data.Healthy = types.BoolValue(result.status == statusOK)
+	data.HTTPStatus = types.Int64Null()
+	if probe != nil {
+		data.HTTPStatus = types.Int64Value(int64(probe.StatusCode))
+	}
+	data.ErrorCode = common.OptionalString(result.code)
+	data.ErrorDetail = common.OptionalString(result.detail)
+	data.Message = types.StringValue(result.message)
+
+	tflog.Debug(ctx, "Read service status", map[string]any{
+		"status":     result.status,
+		"error_code": result.code,
+	})
+
+	if data.FailOnProblem.ValueBool() && result.status != statusOK {
+		resp.Diagnostics.AddError("Service Status Check Failed", result.message)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,93 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package service_status
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+const (
+	statusOK               = "OK"
+	statusTermsNotAccepted = "TERMS_NOT_ACCEPTED"
+	statusNotEntitled      = "NOT_ENTITLED"
+	statusUnauthorized     = "UNAUTHORIZED"
+	statusUnavailable      = "UNAVAILABLE"
+)
+
+// probeResult is the classified outcome of a service probe.
+type probeResult struct {
+	status  string
+	code    string
+	detail  string
+	message string
+}
+
+// classifyProbe classifies the outcome of a service probe. err is the error returned when no
+// response was received, in which case probe is nil.
+func classifyProbe(probe *client.ServiceProbe, err error) probeResult {
+	if err != nil {
+		if strings.Contains(err.Error(), "token request failed") {
+			return probeResult{
+				status:  statusUnauthorized,
+				detail:  err.Error(),
+				message: "An access token could not be obtained. Check client_id, key_id and the private key, and that the API key has not been revoked in Apple Business or School Manager.\n\n" + err.Error(),
+			}
+		}
+		return probeResult{
+			status:  statusUnavailable,
+			detail:  err.Error(),
+			message: "The API could not be reached: " + err.Error(),
+		}
+	}
+
+	if probe.StatusCode >= 200 && probe.StatusCode <= 299 {
+		return probeResult{status: statusOK, message: "The organization can use the API."}
+	}
+
+	result := probeResult{status: statusUnavailable}
+	if len(probe.Errors) > 0 {
+		result.code = probe.Errors[0].Code
+		result.detail = probe.Errors[0].Detail
+	}
+
+	switch {
+	case isTermsError(probe.Errors):
+		result.status = statusTermsNotAccepted
+		result.message = "The organization has not accepted the latest Apple Business or School Manager terms and conditions. " +
+			"An administrator must sign in to Apple Business or School Manager and accept them before the API can be used."
+	case probe.StatusCode == http.StatusUnauthorized:
+		result.status = statusUnauthorized
+		result.message = "The API rejected the provider's access token. Check that the API account and its key still exist in Apple Business or School Manager and match the provider configuration."
+	case probe.StatusCode == http.StatusForbidden || hasErrorCode(probe.Errors, "ENTITLEMENT"):
+		result.status = statusNotEntitled
+		result.message = "The API account is not entitled to use the API for this organization. Check that the organization is enrolled for API access, " +
+			"that the API account has not been disabled, and that the configured scope matches the organization type."
+	default:
+		result.message = fmt.Sprintf("The API returned HTTP %d to the probe request.", probe.StatusCode)
+	}
+	if result.detail != "" {
+		result.message += "\n\nAPI detail: " + result.detail
+	}
+	return result
+}
+
+// isTermsError reports whether any of errs reports terms and conditions or an agreement that
+// has not been accepted.
+func isTermsError(errs []client.Error) bool {
+	return hasErrorCode(errs, "AGREEMENT") || hasErrorCode(errs, "TERMS")
+}
+
+// hasErrorCode reports whether the code of any of errs contains fragment.
+func hasErrorCode(errs []client.Error, fragment string) bool {
+	for _, e := range errs {
+		if strings.Contains(strings.ToUpper(e.Code), fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package service_status
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestClassifyProbe(t *testing.T) {
+	tests := []struct {
+		name       string
+		probe      *client.ServiceProbe
+		err        error
+		wantStatus string
+		wantCode   string
+	}{
+		{
+			name:       "ok",
+			probe:      &client.ServiceProbe{StatusCode: http.StatusOK},
+			wantStatus: statusOK,
+		},
+		{
+			name: "terms not accepted",
+			probe: &client.ServiceProbe{StatusCode: http.StatusForbidden, Errors: []client.Error{
+				{Code: "FORBIDDEN.REQUIRED_AGREEMENTS_MISSING_OR_EXPIRED", Detail: "Accept the latest terms."},
+			}},
+			wantStatus: statusTermsNotAccepted,
+			wantCode:   "FORBIDDEN.REQUIRED_AGREEMENTS_MISSING_OR_EXPIRED",
+		},
+		{
+			name: "forbidden",
+			probe: &client.ServiceProbe{StatusCode: http.StatusForbidden, Errors: []client.Error{
+				{Code: "FORBIDDEN_ERROR"},
+			}},
+			wantStatus: statusNotEntitled,
+			wantCode:   "FORBIDDEN_ERROR",
+		},
+		{
+			name:       "unauthorized",
+			probe:      &client.ServiceProbe{StatusCode: http.StatusUnauthorized},
+			wantStatus: statusUnauthorized,
+		},
+		{
+			name:       "token failure",
+			err:        errors.New("token request failed: invalid_client - client assertion rejected"),
+			wantStatus: statusUnauthorized,
+		},
+		{
+			name:       "transport failure",
+			err:        errors.New("dial tcp: connection refused"),
+			wantStatus: statusUnavailable,
+		},
+		{
+			name:       "server error",
+			probe:      &client.ServiceProbe{StatusCode: http.StatusInternalServerError},
+			wantStatus: statusUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyProbe(tt.probe, tt.err)
+			if got.status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, got.status)
+			}
+			if got.code != tt.wantCode {
+				t.Errorf("expected code %q, got %q", tt.wantCode, got.code)
+			}
+			if got.message == "" {
+				t.Error("expected a message")
+			}
+		})
+	}
+}
+
+func TestClassifyProbe_IncludesDetail(t *testing.T) {
+	got := classifyProbe(&client.ServiceProbe{StatusCode: http.StatusForbidden, Errors: []client.Error{
+		{Code: "FORBIDDEN_ERROR", Detail: "This account cannot use the API."},
+	}}, nil)
+	if !strings.Contains(got.message, "This account cannot use the API.") {
+		t.Errorf("expected the API detail in the message, got %q", got.message)
+	}
+}
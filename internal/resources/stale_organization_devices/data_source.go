@@ -0,0 +1,198 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package stale_organization_devices
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+	"github.com/neilmartin83/terraform-provider-axm/internal/common"
+)
+
+var _ datasource.DataSource = &StaleOrganizationDevicesDataSource{}
+
+// NewStaleOrganizationDevicesDataSource returns a new data source for organization devices not updated recently.
+func NewStaleOrganizationDevicesDataSource() datasource.DataSource {
+	return &StaleOrganizationDevicesDataSource{}
+}
+
+// StaleOrganizationDevicesDataSource defines the data source implementation.
+type StaleOrganizationDevicesDataSource struct {
+	client *client.Client
+}
+
+// StaleOrganizationDevicesDataSourceModel describes the data source data model.
+type StaleOrganizationDevicesDataSourceModel struct {
+	ID        types.String              `tfsdk:"id"`
+	Timeouts  timeouts.Value            `tfsdk:"timeouts"`
+	OlderThan types.String              `tfsdk:"older_than"`
+	Cutoff    types.String              `tfsdk:"cutoff"`
+	Devices   []OrganizationDeviceModel `tfsdk:"devices"`
+}
+
+// OrganizationDeviceModel describes a stale organization device.
+type OrganizationDeviceModel struct {
+	ID types.String `tfsdk:"id"`
+	common.OrgDeviceModel
+}
+
+func (d *StaleOrganizationDevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stale_organization_devices"
+}
+
+func (d *StaleOrganizationDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	deviceAttributes := common.OrgDeviceSchemaAttributes()
+	deviceAttributes["id"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The opaque resource ID that uniquely identifies the resource.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Fetches the organization devices whose record has not changed in Apple Business or School Manager for longer than a given duration, " +
+			"such as hardware that has not been reassigned or updated in months, for audit and disposal workflows.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the data source.",
+				Computed:    true,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+			"older_than": schema.StringAttribute{
+				Description: `Only include devices last updated longer ago than this duration, such as "2160h" for 90 days. ` +
+					"A device's update time is its updated_date_time, or its added_to_org_date_time when the API reports no update time.",
+				Required: true,
+				Validators: []validator.String{
+					common.Duration(),
+				},
+			},
+			"cutoff": schema.StringAttribute{
+				Description: "The RFC 3339 time before which a device must have last been updated to be included.",
+				Computed:    true,
+			},
+			"devices": schema.ListNestedAttribute{
+				Description: "List of stale organization devices, least recently updated first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: deviceAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *StaleOrganizationDevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	c, diags := common.ConfigureClient(req.ProviderData, "Data Source")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.client = c
+}
+
+func (d *StaleOrganizationDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer common.RedactDiagnostics(d.client, &resp.Diagnostics)
+
+	var data StaleOrganizationDevicesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx, cancel, timeoutDiags := common.ResolveReadTimeout(ctx, data.Timeouts, common.DefaultReadTimeout)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+	readCtx, reportSkipped := common.CollectSkippedRecords(readCtx)
+
+	devices, err := d.client.GetOrgDevices(readCtx, nil)
+	if cached, ok := common.OfflineOrgDevices(d.client, err, &resp.Diagnostics); ok {
+		devices, err = cached, nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Organization Devices",
+			err.Error(),
+		)
+		return
+	}
+	reportSkipped(&resp.Diagnostics)
+
+	now := d.client.Clock().Now().UTC()
+	cutoff := now.Add(-common.DurationValue(data.OlderThan, 0))
+	stale := staleDevices(devices, cutoff)
+
+	data.Devices = make([]OrganizationDeviceModel, 0, len(stale))
+	for _, device := range stale {
+		data.Devices = append(data.Devices, OrganizationDeviceModel{
+			ID:             types.StringValue(device.ID),
+			OrgDeviceModel: common.NewOrgDeviceModel(device, &resp.Diagnostics),
+		})
+	}
+
+	data.Cutoff = types.StringValue(cutoff.Format(time.RFC3339))
+	data.ID = types.StringValue(now.String())
+
+	tflog.Debug(ctx, "Read stale organization devices", map[string]any{
+		"cutoff":       data.Cutoff.ValueString(),
+		"total_count":  len(devices),
+		"device_count": len(data.Devices),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// lastUpdated returns the time the device record last changed, falling back to the time it
+// was added to the organization, and whether either could be parsed.
+func lastUpdated(device client.OrgDevice) (time.Time, bool) {
+	for _, value := range []string{device.Attributes.UpdatedDateTime, device.Attributes.AddedToOrgDateTime} {
+		if value == "" {
+			continue
+		}
+		normalized, err := common.NormalizeTimestamp(value)
+		if err != nil {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, normalized); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// staleDevices returns the devices last updated before cutoff, least recently updated first.
+// Devices without a parsable update or added time are excluded.
+func staleDevices(devices []client.OrgDevice, cutoff time.Time) []client.OrgDevice {
+	type staleDevice struct {
+		device  client.OrgDevice
+		updated time.Time
+	}
+
+	var stale []staleDevice
+	for _, device := range devices {
+		if updated, ok := lastUpdated(device); ok && updated.Before(cutoff) {
+			stale = append(stale, staleDevice{device: device, updated: updated})
+		}
+	}
+	slices.SortStableFunc(stale, func(a, b staleDevice) int {
+		return a.updated.Compare(b.updated)
+	})
+
+	result := make([]client.OrgDevice, len(stale))
+	for i, s := range stale {
+		result[i] = s.device
+	}
+	return result
+}
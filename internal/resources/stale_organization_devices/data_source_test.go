@@ -0,0 +1,105 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package stale_organization_devices_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
+	"github.com/neilmartin83/terraform-provider-axm/internal/resources/stale_organization_devices"
+)
+
+func testAccProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"axm": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set; skipping acceptance test")
+	}
+	for _, envVar := range []string{"AXM_CLIENT_ID", "AXM_KEY_ID", "AXM_PRIVATE_KEY", "AXM_SCOPE"} {
+		if os.Getenv(envVar) == "" {
+			t.Skipf("%s must be set for acceptance tests", envVar)
+		}
+	}
+}
+
+func TestStaleOrganizationDevicesDataSourceMetadata(t *testing.T) {
+	ds := stale_organization_devices.NewStaleOrganizationDevicesDataSource()
+	resp := datasource.MetadataResponse{}
+	ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "axm"}, &resp)
+
+	if resp.TypeName != "axm_stale_organization_devices" {
+		t.Errorf("expected TypeName %q, got %q", "axm_stale_organization_devices", resp.TypeName)
+	}
+}
+
+func TestStaleOrganizationDevicesDataSourceSchema(t *testing.T) {
+	ds := stale_organization_devices.NewStaleOrganizationDevicesDataSource()
+	resp := datasource.SchemaResponse{}
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+	if resp.Schema.Description == "" {
+		t.Error("expected non-empty schema Description")
+	}
+
+	olderThanAttr, ok := resp.Schema.Attributes["older_than"]
+	if !ok {
+		t.Fatal("attribute 'older_than' not found")
+	}
+	if !olderThanAttr.IsRequired() {
+		t.Error("expected 'older_than' to be Required")
+	}
+
+	for _, name := range []string{"id", "cutoff", "devices"} {
+		attr, ok := resp.Schema.Attributes[name]
+		if !ok {
+			t.Fatalf("attribute %q not found", name)
+		}
+		if !attr.IsComputed() {
+			t.Errorf("expected %q to be Computed", name)
+		}
+	}
+
+	devicesAttr, ok := resp.Schema.Attributes["devices"].(dsschema.ListNestedAttribute)
+	if !ok {
+		t.Fatal("expected 'devices' to be a ListNestedAttribute")
+	}
+	for _, name := range []string{"id", "serial_number", "updated_date_time"} {
+		if _, ok := devicesAttr.NestedObject.Attributes[name]; !ok {
+			t.Errorf("nested attribute %q not found in devices", name)
+		}
+	}
+}
+
+func TestAccStaleOrganizationDevicesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "axm_stale_organization_devices" "test" {
+						older_than = "4320h"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.axm_stale_organization_devices.test", "cutoff"),
+					resource.TestCheckResourceAttrSet("data.axm_stale_organization_devices.test", "devices.#"),
+				),
+			},
+		},
+	})
+}
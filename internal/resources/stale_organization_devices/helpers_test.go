@@ -0,0 +1,50 @@
+// Copyright Neil Martin 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package stale_organization_devices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neilmartin83/terraform-provider-axm/internal/client"
+)
+
+func TestStaleDevices(t *testing.T) {
+	devices := []client.OrgDevice{
+		{ID: "recent", Attributes: client.DeviceAttribute{UpdatedDateTime: "2026-06-01T00:00:00Z"}},
+		{ID: "old", Attributes: client.DeviceAttribute{UpdatedDateTime: "2025-09-01T12:00:00.000Z"}},
+		{ID: "oldest", Attributes: client.DeviceAttribute{UpdatedDateTime: "2024-01-15T08:30:00Z"}},
+		{ID: "added-only", Attributes: client.DeviceAttribute{AddedToOrgDateTime: "2025-01-01T00:00:00Z"}},
+		{ID: "unparsable", Attributes: client.DeviceAttribute{UpdatedDateTime: "yesterday"}},
+		{ID: "no-times"},
+	}
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stale := staleDevices(devices, cutoff)
+
+	want := []string{"oldest", "added-only", "old"}
+	if len(stale) != len(want) {
+		t.Fatalf("expected %d stale devices, got %d: %+v", len(want), len(stale), stale)
+	}
+	for i, id := range want {
+		if stale[i].ID != id {
+			t.Errorf("device[%d]: expected ID %s, got %s", i, id, stale[i].ID)
+		}
+	}
+}
+
+func TestLastUpdated_PrefersUpdatedDateTime(t *testing.T) {
+	device := client.OrgDevice{Attributes: client.DeviceAttribute{
+		AddedToOrgDateTime: "2020-01-01T00:00:00Z",
+		UpdatedDateTime:    "2026-02-03T04:05:06+01:00",
+	}}
+
+	got, ok := lastUpdated(device)
+	if !ok {
+		t.Fatal("expected a last updated time")
+	}
+	if want := time.Date(2026, 2, 3, 3, 5, 6, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -9,27 +9,63 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-mux/tf6to5server"
 	"github.com/neilmartin83/terraform-provider-axm/internal/provider"
 )
 
+const providerAddress = "registry.terraform.io/neilmartin83/axm"
+
 var (
 	version string = "dev"
+
+	// protocol is the plugin protocol version served when -protocol5 is not given. Builds for
+	// Terraform CLI versions before 1.0 set it to "5" with -ldflags "-X main.protocol=5".
+	protocol string = "6"
 )
 
 func main() {
-	var debug bool
+	var debug, protocol5 bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&protocol5, "protocol5", protocol == "5", "set to true to serve plugin protocol version 5 for Terraform CLI versions before 1.0")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/neilmartin83/axm",
-		Debug:   debug,
+	var err error
+	if protocol5 {
+		err = serveProtocol5(context.Background(), debug)
+	} else {
+		err = providerserver.Serve(context.Background(), provider.New(version), providerserver.ServeOpts{
+			Address: providerAddress,
+			Debug:   debug,
+		})
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
-
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// serveProtocol5 serves the provider over plugin protocol version 5, downgrading its protocol 6
+// server and serving it through terraform-plugin-mux.
+func serveProtocol5(ctx context.Context, debug bool) error {
+	downgraded, err := tf6to5server.DowngradeServer(ctx, provider.NewProtocol5Compatible(version))
+	if err != nil {
+		return err
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, func() tfprotov5.ProviderServer {
+		return downgraded
+	})
+	if err != nil {
+		return err
+	}
+
+	var opts []tf5server.ServeOpt
+	if debug {
+		opts = append(opts, tf5server.WithManagedDebug())
+	}
+	return tf5server.Serve(providerAddress, muxServer.ProviderServer, opts...)
+}